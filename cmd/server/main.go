@@ -177,6 +177,10 @@ func main() {
 	consumerSrv, err := consumer.New(consumer.Config{
 		Logger:        logger,
 		KafkaConfig:   cfg.Kafka,
+		QdrantConfig:  cfg.Qdrant,
+		Indexing:      cfg.Indexing,
+		Lifecycle:     cfg.Lifecycle,
+		MinIOBucket:   cfg.MinIO.Bucket,
 		RedisClient:   redisClient,
 		QdrantClient:  qdrantClient,
 		PostgresDB:    postgresDB,
@@ -228,7 +232,9 @@ func main() {
 	}
 
 	httpErr := make(chan error, 1)
+	httpDone := make(chan struct{})
 	go func() {
+		defer close(httpDone)
 		if err := httpServer.Run(ctx); err != nil {
 			httpErr <- err
 		}
@@ -248,5 +254,7 @@ func main() {
 		stop()
 	}
 
+	// Let the HTTP server drain requests and flush the search query log
+	<-httpDone
 	logger.Info(ctx, "Knowledge Service stopped gracefully")
 }
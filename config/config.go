@@ -40,6 +40,24 @@ type Config struct {
 	// Analysis API - campaign analytics fallback for assistant/report context
 	Analysis AnalysisConfig
 
+	// Search - Retrieval ranking knobs
+	Search SearchConfig
+
+	// Indexing - Ingestion behaviour (near-duplicate handling)
+	Indexing IndexingConfig
+
+	// Backup - Scheduled Qdrant snapshots to MinIO
+	Backup BackupConfig
+
+	// Lifecycle - Purge of deleted projects/campaigns
+	Lifecycle LifecycleConfig
+
+	// Chat - Assistant query understanding
+	Chat ChatConfig
+
+	// FactCheck - Numeric verification of chat answers and reports
+	FactCheck FactCheckConfig
+
 	// MinIO - Storage
 	MinIO MinIOConfig
 
@@ -90,11 +108,49 @@ type MinIOConfig struct {
 
 // QdrantConfig is the configuration for Qdrant
 type QdrantConfig struct {
-	Host    string
-	Port    int
-	APIKey  string
-	UseTLS  bool
-	Timeout int // in seconds
+	Mode     string // grpc | memory
+	Host     string
+	Port     int
+	RESTPort int // HTTP API port, used for snapshot download/upload
+	APIKey   string
+	UseTLS   bool
+	Timeout  int // in seconds
+
+	Layout   string // per_project | shared
+	Profiles QdrantProfilesConfig
+	Tiers    QdrantTiersConfig
+	Search   QdrantSearchConfig
+}
+
+// QdrantProfilesConfig holds the collection profile of each project size tier.
+type QdrantProfilesConfig struct {
+	Small  QdrantProfileConfig
+	Medium QdrantProfileConfig
+	Large  QdrantProfileConfig
+}
+
+// QdrantProfileConfig configures quantization, on-disk storage and HNSW for a collection.
+type QdrantProfileConfig struct {
+	Quantization          string // none | scalar | binary
+	QuantizationAlwaysRAM bool
+	OnDiskVectors         bool
+	OnDiskPayload         bool
+	HnswM                 int
+	HnswEfConstruct       int
+	IndexingThreshold     int // KB of vectors before a segment is HNSW-indexed
+}
+
+// QdrantTiersConfig maps collection size (points) to a profile; larger collections use "large".
+type QdrantTiersConfig struct {
+	SmallMaxPoints  int
+	MediumMaxPoints int
+}
+
+// QdrantSearchConfig holds default search tuning.
+type QdrantSearchConfig struct {
+	HnswEf       int
+	Oversampling float64
+	Rescore      bool
 }
 
 // VoyageConfig is the configuration for Voyage AI (embedding). Same shape as pkg/voyage.VoyageConfig.
@@ -108,11 +164,47 @@ type LLMProviderConfig struct {
 	APIKey  string
 	Model   string // optional, defaults per provider
 	BaseURL string // optional, defaults per provider
+	// ContextTokens is the model's context window; 0 uses the provider default.
+	ContextTokens int
 }
 
 // LLMConfig holds multi-provider LLM configuration.
 type LLMConfig struct {
 	Providers []LLMProviderConfig
+	// MaxPromptTokens caps a chat prompt even when every model's window is larger:
+	// longer prompts cost more and answer slower.
+	MaxPromptTokens int
+	// ReservedOutputTokens is the room kept in the window for the answer.
+	ReservedOutputTokens int
+}
+
+// defaultContextTokens - Context windows of the providers' default models.
+var defaultContextTokens = map[string]int{
+	"gemini":   1048576,
+	"openai":   128000,
+	"deepseek": 64000,
+	"qwen":     131072,
+}
+
+// WindowTokens returns the context window of the provider's model.
+func (p LLMProviderConfig) WindowTokens() int {
+	if p.ContextTokens > 0 {
+		return p.ContextTokens
+	}
+	return defaultContextTokens[p.Name]
+}
+
+// PromptTokenBudget returns what a prompt may spend. Providers take turns, so any
+// of them may get the prompt: the budget follows the smallest window, less the
+// reserved output, capped at MaxPromptTokens.
+func (c LLMConfig) PromptTokenBudget() int {
+	budget := c.MaxPromptTokens
+	for _, p := range c.Providers {
+		if window := p.WindowTokens(); window > 0 {
+			budget = min(budget, window-c.ReservedOutputTokens)
+		}
+	}
+	return budget
 }
 
 // GeminiConfig is the legacy configuration for Google Gemini (LLM).
@@ -135,6 +227,96 @@ type AnalysisConfig struct {
 	Timeout int // in seconds
 }
 
+// SearchConfig is the configuration for search ranking.
+type SearchConfig struct {
+	Recency   RecencyConfig
+	Diversity DiversityConfig
+}
+
+// RecencyConfig configures time-decay ranking. Half-lives are in hours.
+type RecencyConfig struct {
+	DefaultHalfLifeHours  float64
+	CampaignHalfLifeHours map[string]float64 // campaign_id → half-life override
+}
+
+// DiversityConfig configures MMR result diversification defaults.
+type DiversityConfig struct {
+	Lambda              float64 // relevance vs. novelty trade-off, (0, 1]
+	RedundancyThreshold float64 // cosine similarity at which a candidate is suppressed
+}
+
+// IndexingConfig is the configuration for the indexing pipeline.
+type IndexingConfig struct {
+	NearDuplicate NearDuplicateConfig
+}
+
+// NearDuplicateConfig configures SimHash near-duplicate handling at index time.
+type NearDuplicateConfig struct {
+	Mode        string // "annotate" keeps duplicates with duplicate_of; "skip" drops them
+	MaxDistance int    // Hamming distance threshold, 1..3
+}
+
+// BackupConfig configures scheduled Qdrant snapshot backups to MinIO.
+type BackupConfig struct {
+	Enabled       bool   // run the scheduled backup job
+	IntervalHours int    // hours between scheduled runs
+	Prefix        string // object prefix inside minio.bucket
+	KeepLast      int    // newest successful backups kept per collection
+	RetentionDays int    // successful backups older than this are deleted (the newest one is always kept)
+}
+
+// LifecycleConfig configures the purge workflow for deleted projects and campaigns.
+type LifecycleConfig struct {
+	GraceHours          int // soft-delete grace period before data is purged
+	PollIntervalSeconds int // how often the worker looks for due purges
+	MaxAttempts         int // failed purges are retried until this many attempts
+}
+
+// ChatConfig configures the chat assistant.
+type ChatConfig struct {
+	Understanding UnderstandingConfig
+	Agent         AgentConfig
+	Memory        MemoryConfig
+	AnswerCache   AnswerCacheConfig
+}
+
+// AnswerCacheConfig configures the cache of answers to repeated opening questions.
+type AnswerCacheConfig struct {
+	Enabled             bool
+	SimilarityThreshold float64 // cosine similarity a new question needs to reuse an answer
+	TTLHours            int     // answers are also evicted when their campaign is re-indexed
+	MaxEntries          int     // answers kept per campaign and filter set
+}
+
+// MemoryConfig configures the rolling summary of long conversations.
+type MemoryConfig struct {
+	Enabled            bool
+	EveryTurns         int // turns summarized per refresh
+	RecentMessages     int // newest messages always kept verbatim
+	HistoryTokenBudget int // summary + recent messages in a prompt
+}
+
+// AgentConfig configures the tool-calling loop used for structured chat questions.
+type AgentConfig struct {
+	Enabled  bool
+	MaxSteps int // tool calls per answer before the model must reply
+}
+
+// FactCheckConfig configures the verifier that checks figures in generated text
+// against the data given to the model.
+type FactCheckConfig struct {
+	Mode             string  // "off" | "flag" | "correct" | "regenerate"
+	PercentTolerance float64 // percentage points
+	CountTolerance   float64 // relative difference, 0.02 = 2%
+}
+
+// UnderstandingConfig configures intent/filter extraction from chat messages.
+type UnderstandingConfig struct {
+	Mode          string // "llm" (keyword router as fallback) | "keyword"
+	TimeoutMs     int    // LLM call budget before falling back to the keyword router
+	CacheTTLHours int    // LLM results are cached per normalized query
+}
+
 // CookieConfig is the configuration for HttpOnly cookie authentication
 // Note: Secure and SameSite are now dynamically determined by auth.Middleware
 // based on the request Origin header. Bearer token acceptance is controlled by ENVIRONMENT_NAME.
@@ -223,8 +405,10 @@ func Load() (*Config, error) {
 	_ = viper.BindEnv("kafka.topic", "KAFKA_TOPIC")
 	_ = viper.BindEnv("kafka.group_id", "KAFKA_GROUP_ID")
 	// Qdrant
+	_ = viper.BindEnv("qdrant.mode", "QDRANT_MODE")
 	_ = viper.BindEnv("qdrant.host", "QDRANT_HOST")
 	_ = viper.BindEnv("qdrant.port", "QDRANT_PORT")
+	_ = viper.BindEnv("qdrant.rest_port", "QDRANT_REST_PORT")
 	_ = viper.BindEnv("qdrant.api_key", "QDRANT_API_KEY")
 	_ = viper.BindEnv("qdrant.use_tls", "QDRANT_USE_TLS")
 	_ = viper.BindEnv("qdrant.timeout", "QDRANT_TIMEOUT")
@@ -291,11 +475,22 @@ func Load() (*Config, error) {
 	cfg.Logger.ColorEnabled = viper.GetBool("logger.color_enabled")
 
 	// Qdrant
+	cfg.Qdrant.Mode = viper.GetString("qdrant.mode")
 	cfg.Qdrant.Host = viper.GetString("qdrant.host")
 	cfg.Qdrant.Port = viper.GetInt("qdrant.port")
+	cfg.Qdrant.RESTPort = viper.GetInt("qdrant.rest_port")
 	cfg.Qdrant.APIKey = viper.GetString("qdrant.api_key")
 	cfg.Qdrant.UseTLS = viper.GetBool("qdrant.use_tls")
 	cfg.Qdrant.Timeout = viper.GetInt("qdrant.timeout")
+	cfg.Qdrant.Layout = viper.GetString("qdrant.layout")
+	cfg.Qdrant.Profiles.Small = loadQdrantProfile("qdrant.profiles.small")
+	cfg.Qdrant.Profiles.Medium = loadQdrantProfile("qdrant.profiles.medium")
+	cfg.Qdrant.Profiles.Large = loadQdrantProfile("qdrant.profiles.large")
+	cfg.Qdrant.Tiers.SmallMaxPoints = viper.GetInt("qdrant.tiers.small_max_points")
+	cfg.Qdrant.Tiers.MediumMaxPoints = viper.GetInt("qdrant.tiers.medium_max_points")
+	cfg.Qdrant.Search.HnswEf = viper.GetInt("qdrant.search.hnsw_ef")
+	cfg.Qdrant.Search.Oversampling = viper.GetFloat64("qdrant.search.oversampling")
+	cfg.Qdrant.Search.Rescore = viper.GetBool("qdrant.search.rescore")
 
 	// Voyage - Embedding
 	cfg.Voyage.APIKey = viper.GetString("voyage.api_key")
@@ -316,6 +511,8 @@ func Load() (*Config, error) {
 	// LLM multi-provider config: build from explicit per-provider env vars + legacy gemini config.
 	// Order: gemini, openai, deepseek, qwen (all equal, round-robin)
 	cfg.LLM.Providers = buildLLMProviders(cfg.Gemini, viper.GetViper())
+	cfg.LLM.MaxPromptTokens = viper.GetInt("llm.max_prompt_tokens")
+	cfg.LLM.ReservedOutputTokens = viper.GetInt("llm.reserved_output_tokens")
 
 	// PostgreSQL - Metadata, conversation history
 	cfg.Postgres.Host = viper.GetString("postgres.host")
@@ -340,6 +537,53 @@ func Load() (*Config, error) {
 	cfg.Analysis.URL = viper.GetString("analysis.url")
 	cfg.Analysis.Timeout = viper.GetInt("analysis.timeout")
 
+	// Search - Recency decay (per-campaign half-life overrides)
+	cfg.Search.Recency.DefaultHalfLifeHours = viper.GetFloat64("search.recency.default_half_life_hours")
+	cfg.Search.Recency.CampaignHalfLifeHours = make(map[string]float64)
+	for campaignID := range viper.GetStringMap("search.recency.campaign_half_life_hours") {
+		cfg.Search.Recency.CampaignHalfLifeHours[campaignID] = viper.GetFloat64("search.recency.campaign_half_life_hours." + campaignID)
+	}
+
+	// Search - MMR diversification defaults
+	cfg.Search.Diversity.Lambda = viper.GetFloat64("search.diversity.lambda")
+	cfg.Search.Diversity.RedundancyThreshold = viper.GetFloat64("search.diversity.redundancy_threshold")
+
+	// Indexing - Near-duplicate handling
+	cfg.Indexing.NearDuplicate.Mode = viper.GetString("indexing.near_duplicate.mode")
+	cfg.Indexing.NearDuplicate.MaxDistance = viper.GetInt("indexing.near_duplicate.max_distance")
+
+	// Backup - Qdrant snapshots
+	cfg.Backup.Enabled = viper.GetBool("backup.enabled")
+	cfg.Backup.IntervalHours = viper.GetInt("backup.interval_hours")
+	cfg.Backup.Prefix = viper.GetString("backup.prefix")
+	cfg.Backup.KeepLast = viper.GetInt("backup.keep_last")
+	cfg.Backup.RetentionDays = viper.GetInt("backup.retention_days")
+
+	// Lifecycle - Purge of deleted projects/campaigns
+	cfg.Lifecycle.GraceHours = viper.GetInt("lifecycle.grace_hours")
+	cfg.Lifecycle.PollIntervalSeconds = viper.GetInt("lifecycle.poll_interval_seconds")
+	cfg.Lifecycle.MaxAttempts = viper.GetInt("lifecycle.max_attempts")
+
+	// Chat - Query understanding
+	cfg.Chat.Understanding.Mode = viper.GetString("chat.understanding.mode")
+	cfg.Chat.Understanding.TimeoutMs = viper.GetInt("chat.understanding.timeout_ms")
+	cfg.Chat.Understanding.CacheTTLHours = viper.GetInt("chat.understanding.cache_ttl_hours")
+	cfg.Chat.Agent.Enabled = viper.GetBool("chat.agent.enabled")
+	cfg.Chat.Agent.MaxSteps = viper.GetInt("chat.agent.max_steps")
+	cfg.Chat.Memory.Enabled = viper.GetBool("chat.memory.enabled")
+	cfg.Chat.Memory.EveryTurns = viper.GetInt("chat.memory.every_turns")
+	cfg.Chat.Memory.RecentMessages = viper.GetInt("chat.memory.recent_messages")
+	cfg.Chat.Memory.HistoryTokenBudget = viper.GetInt("chat.memory.history_token_budget")
+	cfg.Chat.AnswerCache.Enabled = viper.GetBool("chat.answer_cache.enabled")
+	cfg.Chat.AnswerCache.SimilarityThreshold = viper.GetFloat64("chat.answer_cache.similarity_threshold")
+	cfg.Chat.AnswerCache.TTLHours = viper.GetInt("chat.answer_cache.ttl_hours")
+	cfg.Chat.AnswerCache.MaxEntries = viper.GetInt("chat.answer_cache.max_entries")
+
+	// FactCheck - Numeric verification
+	cfg.FactCheck.Mode = viper.GetString("fact_check.mode")
+	cfg.FactCheck.PercentTolerance = viper.GetFloat64("fact_check.percent_tolerance")
+	cfg.FactCheck.CountTolerance = viper.GetFloat64("fact_check.count_tolerance")
+
 	// MinIO - Report storage (PDF/DOCX)
 	cfg.MinIO.Endpoint = viper.GetString("minio.endpoint")
 	cfg.MinIO.AccessKey = viper.GetString("minio.access_key")
@@ -395,13 +639,41 @@ func setDefaults() {
 	viper.SetDefault("logger.color_enabled", true)
 
 	// 1. Qdrant
+	viper.SetDefault("qdrant.mode", "grpc")
 	viper.SetDefault("qdrant.host", "localhost")
 	viper.SetDefault("qdrant.port", 6334)
+	viper.SetDefault("qdrant.rest_port", 6333)
 	viper.SetDefault("qdrant.use_tls", false)
 	viper.SetDefault("qdrant.timeout", 30)
+	viper.SetDefault("qdrant.layout", "per_project")
+	viper.SetDefault("qdrant.profiles.small.quantization", "none")
+	viper.SetDefault("qdrant.profiles.small.hnsw_m", 16)
+	viper.SetDefault("qdrant.profiles.small.hnsw_ef_construct", 100)
+	viper.SetDefault("qdrant.profiles.small.indexing_threshold", 20000)
+	viper.SetDefault("qdrant.profiles.medium.quantization", "scalar")
+	viper.SetDefault("qdrant.profiles.medium.quantization_always_ram", true)
+	viper.SetDefault("qdrant.profiles.medium.on_disk_vectors", true)
+	viper.SetDefault("qdrant.profiles.medium.on_disk_payload", true)
+	viper.SetDefault("qdrant.profiles.medium.hnsw_m", 16)
+	viper.SetDefault("qdrant.profiles.medium.hnsw_ef_construct", 100)
+	viper.SetDefault("qdrant.profiles.medium.indexing_threshold", 20000)
+	viper.SetDefault("qdrant.profiles.large.quantization", "binary")
+	viper.SetDefault("qdrant.profiles.large.quantization_always_ram", true)
+	viper.SetDefault("qdrant.profiles.large.on_disk_vectors", true)
+	viper.SetDefault("qdrant.profiles.large.on_disk_payload", true)
+	viper.SetDefault("qdrant.profiles.large.hnsw_m", 32)
+	viper.SetDefault("qdrant.profiles.large.hnsw_ef_construct", 200)
+	viper.SetDefault("qdrant.profiles.large.indexing_threshold", 50000)
+	viper.SetDefault("qdrant.tiers.small_max_points", 10000)
+	viper.SetDefault("qdrant.tiers.medium_max_points", 200000)
+	viper.SetDefault("qdrant.search.hnsw_ef", 128)
+	viper.SetDefault("qdrant.search.oversampling", 2.0)
+	viper.SetDefault("qdrant.search.rescore", true)
 
 	// 2. AI (Voyage + Gemini)
 	viper.SetDefault("gemini.model", "gemini-1.5-pro")
+	viper.SetDefault("llm.max_prompt_tokens", 28000)
+	viper.SetDefault("llm.reserved_output_tokens", 4096)
 
 	// 3. PostgreSQL (schema per specs: knowledge)
 	viper.SetDefault("postgres.host", "localhost")
@@ -426,6 +698,47 @@ func setDefaults() {
 	viper.SetDefault("analysis.url", "http://analysis-api.smap.svc.cluster.local")
 	viper.SetDefault("analysis.timeout", 12)
 
+	// 5c. Search
+	viper.SetDefault("search.recency.default_half_life_hours", 72)
+	viper.SetDefault("search.diversity.lambda", 0.7)
+	viper.SetDefault("search.diversity.redundancy_threshold", 0.92)
+
+	// 5d. Indexing
+	viper.SetDefault("indexing.near_duplicate.mode", "annotate")
+	viper.SetDefault("indexing.near_duplicate.max_distance", 3)
+
+	// 5e. Backup
+	viper.SetDefault("backup.enabled", false)
+	viper.SetDefault("backup.interval_hours", 24)
+	viper.SetDefault("backup.prefix", "qdrant-backups")
+	viper.SetDefault("backup.keep_last", 7)
+	viper.SetDefault("backup.retention_days", 30)
+
+	// 5f. Lifecycle
+	viper.SetDefault("lifecycle.grace_hours", 72)
+	viper.SetDefault("lifecycle.poll_interval_seconds", 60)
+	viper.SetDefault("lifecycle.max_attempts", 5)
+
+	// 5g. Chat
+	viper.SetDefault("chat.understanding.mode", "llm")
+	viper.SetDefault("chat.understanding.timeout_ms", 4000)
+	viper.SetDefault("chat.understanding.cache_ttl_hours", 6)
+	viper.SetDefault("chat.agent.enabled", true)
+	viper.SetDefault("chat.agent.max_steps", 4)
+	viper.SetDefault("chat.memory.enabled", true)
+	viper.SetDefault("chat.memory.every_turns", 4)
+	viper.SetDefault("chat.memory.recent_messages", 6)
+	viper.SetDefault("chat.memory.history_token_budget", 2000)
+	viper.SetDefault("chat.answer_cache.enabled", true)
+	viper.SetDefault("chat.answer_cache.similarity_threshold", 0.95)
+	viper.SetDefault("chat.answer_cache.ttl_hours", 6)
+	viper.SetDefault("chat.answer_cache.max_entries", 200)
+
+	// 5h. Fact check
+	viper.SetDefault("fact_check.mode", "flag")
+	viper.SetDefault("fact_check.percent_tolerance", 1.0)
+	viper.SetDefault("fact_check.count_tolerance", 0.02)
+
 	// 6. MinIO (bucket per specs: smap-reports)
 	viper.SetDefault("minio.endpoint", "localhost:9000")
 	viper.SetDefault("minio.access_key", "minioadmin")
@@ -500,12 +813,112 @@ func validate(cfg *Config) error {
 	}
 
 	// Validate Qdrant Configuration
-	if cfg.Qdrant.Host == "" {
+	if cfg.Qdrant.Mode != "grpc" && cfg.Qdrant.Mode != "memory" {
+		return fmt.Errorf("qdrant.mode must be grpc or memory")
+	}
+	if cfg.Qdrant.Mode == "grpc" && cfg.Qdrant.Host == "" {
 		return fmt.Errorf("qdrant.host is required")
 	}
-	if cfg.Qdrant.Port == 0 {
+	if cfg.Qdrant.Mode == "grpc" && cfg.Qdrant.Port == 0 {
 		return fmt.Errorf("qdrant.port is required")
 	}
+	if cfg.Qdrant.Layout != "per_project" && cfg.Qdrant.Layout != "shared" {
+		return fmt.Errorf("qdrant.layout must be per_project or shared")
+	}
+	for name, profile := range map[string]QdrantProfileConfig{
+		"small":  cfg.Qdrant.Profiles.Small,
+		"medium": cfg.Qdrant.Profiles.Medium,
+		"large":  cfg.Qdrant.Profiles.Large,
+	} {
+		switch profile.Quantization {
+		case "", "none", "scalar", "binary":
+		default:
+			return fmt.Errorf("qdrant.profiles.%s.quantization must be none, scalar or binary", name)
+		}
+	}
+	if cfg.Qdrant.Tiers.SmallMaxPoints <= 0 || cfg.Qdrant.Tiers.MediumMaxPoints <= cfg.Qdrant.Tiers.SmallMaxPoints {
+		return fmt.Errorf("qdrant.tiers: require 0 < small_max_points < medium_max_points")
+	}
+
+	// Validate Backup Configuration
+	if cfg.Backup.IntervalHours <= 0 {
+		return fmt.Errorf("backup.interval_hours must be positive")
+	}
+	if cfg.Backup.KeepLast <= 0 {
+		return fmt.Errorf("backup.keep_last must be positive")
+	}
+	if cfg.Backup.RetentionDays <= 0 {
+		return fmt.Errorf("backup.retention_days must be positive")
+	}
+	if strings.Trim(cfg.Backup.Prefix, "/") == "" {
+		return fmt.Errorf("backup.prefix is required")
+	}
+
+	// Validate Lifecycle Configuration
+	if cfg.Lifecycle.GraceHours < 0 {
+		return fmt.Errorf("lifecycle.grace_hours must not be negative")
+	}
+	if cfg.Lifecycle.PollIntervalSeconds <= 0 {
+		return fmt.Errorf("lifecycle.poll_interval_seconds must be positive")
+	}
+	if cfg.Lifecycle.MaxAttempts <= 0 {
+		return fmt.Errorf("lifecycle.max_attempts must be positive")
+	}
+
+	// Validate LLM Configuration
+	if cfg.LLM.MaxPromptTokens <= 0 {
+		return fmt.Errorf("llm.max_prompt_tokens must be positive")
+	}
+	if cfg.LLM.ReservedOutputTokens < 0 {
+		return fmt.Errorf("llm.reserved_output_tokens must not be negative")
+	}
+	for _, p := range cfg.LLM.Providers {
+		if p.ContextTokens < 0 {
+			return fmt.Errorf("%s context_tokens must not be negative", p.Name)
+		}
+	}
+	if cfg.LLM.PromptTokenBudget() < 2000 {
+		return fmt.Errorf("llm context windows leave less than 2000 tokens for a prompt after reserved_output_tokens")
+	}
+
+	// Validate Chat Configuration
+	if m := cfg.Chat.Understanding.Mode; m != "llm" && m != "keyword" {
+		return fmt.Errorf("chat.understanding.mode must be one of: llm, keyword")
+	}
+	if cfg.Chat.Understanding.TimeoutMs <= 0 {
+		return fmt.Errorf("chat.understanding.timeout_ms must be positive")
+	}
+	if cfg.Chat.Agent.MaxSteps <= 0 || cfg.Chat.Agent.MaxSteps > 8 {
+		return fmt.Errorf("chat.agent.max_steps must be between 1 and 8")
+	}
+	if cfg.Chat.Memory.EveryTurns <= 0 {
+		return fmt.Errorf("chat.memory.every_turns must be positive")
+	}
+	if cfg.Chat.Memory.RecentMessages < 2 || cfg.Chat.Memory.RecentMessages > 20 {
+		return fmt.Errorf("chat.memory.recent_messages must be between 2 and 20")
+	}
+	if cfg.Chat.Memory.HistoryTokenBudget <= 0 {
+		return fmt.Errorf("chat.memory.history_token_budget must be positive")
+	}
+	if t := cfg.Chat.AnswerCache.SimilarityThreshold; t <= 0 || t > 1 {
+		return fmt.Errorf("chat.answer_cache.similarity_threshold must be in (0, 1]")
+	}
+	if cfg.Chat.AnswerCache.TTLHours <= 0 {
+		return fmt.Errorf("chat.answer_cache.ttl_hours must be positive")
+	}
+	if cfg.Chat.AnswerCache.MaxEntries <= 0 {
+		return fmt.Errorf("chat.answer_cache.max_entries must be positive")
+	}
+
+	// Validate Fact Check Configuration
+	switch cfg.FactCheck.Mode {
+	case "off", "flag", "correct", "regenerate":
+	default:
+		return fmt.Errorf("fact_check.mode must be one of: off, flag, correct, regenerate")
+	}
+	if cfg.FactCheck.PercentTolerance < 0 || cfg.FactCheck.CountTolerance < 0 {
+		return fmt.Errorf("fact_check tolerances must not be negative")
+	}
 
 	// Validate Project Service Configuration
 	if cfg.Project.URL == "" {
@@ -537,6 +950,19 @@ func validate(cfg *Config) error {
 	return nil
 }
 
+// loadQdrantProfile reads one collection profile section (e.g. "qdrant.profiles.small").
+func loadQdrantProfile(key string) QdrantProfileConfig {
+	return QdrantProfileConfig{
+		Quantization:          viper.GetString(key + ".quantization"),
+		QuantizationAlwaysRAM: viper.GetBool(key + ".quantization_always_ram"),
+		OnDiskVectors:         viper.GetBool(key + ".on_disk_vectors"),
+		OnDiskPayload:         viper.GetBool(key + ".on_disk_payload"),
+		HnswM:                 viper.GetInt(key + ".hnsw_m"),
+		HnswEfConstruct:       viper.GetInt(key + ".hnsw_ef_construct"),
+		IndexingThreshold:     viper.GetInt(key + ".indexing_threshold"),
+	}
+}
+
 // buildLLMProviders assembles multi-provider config from env vars and legacy gemini config.
 func buildLLMProviders(geminiCfg GeminiConfig, v *viper.Viper) []LLMProviderConfig {
 	var providers []LLMProviderConfig
@@ -544,36 +970,40 @@ func buildLLMProviders(geminiCfg GeminiConfig, v *viper.Viper) []LLMProviderConf
 	// Gemini (from legacy config or env)
 	if geminiCfg.APIKey != "" {
 		providers = append(providers, LLMProviderConfig{
-			Name:   "gemini",
-			APIKey: geminiCfg.APIKey,
-			Model:  geminiCfg.Model,
+			Name:          "gemini",
+			APIKey:        geminiCfg.APIKey,
+			Model:         geminiCfg.Model,
+			ContextTokens: v.GetInt("gemini.context_tokens"),
 		})
 	}
 
 	// OpenAI
 	if key := v.GetString("llm.openai_api_key"); key != "" {
 		providers = append(providers, LLMProviderConfig{
-			Name:   "openai",
-			APIKey: key,
-			Model:  v.GetString("llm.openai_model"),
+			Name:          "openai",
+			APIKey:        key,
+			Model:         v.GetString("llm.openai_model"),
+			ContextTokens: v.GetInt("llm.openai_context_tokens"),
 		})
 	}
 
 	// DeepSeek
 	if key := v.GetString("llm.deepseek_api_key"); key != "" {
 		providers = append(providers, LLMProviderConfig{
-			Name:   "deepseek",
-			APIKey: key,
-			Model:  v.GetString("llm.deepseek_model"),
+			Name:          "deepseek",
+			APIKey:        key,
+			Model:         v.GetString("llm.deepseek_model"),
+			ContextTokens: v.GetInt("llm.deepseek_context_tokens"),
 		})
 	}
 
 	// Qwen
 	if key := v.GetString("llm.qwen_api_key"); key != "" {
 		providers = append(providers, LLMProviderConfig{
-			Name:   "qwen",
-			APIKey: key,
-			Model:  v.GetString("llm.qwen_model"),
+			Name:          "qwen",
+			APIKey:        key,
+			Model:         v.GetString("llm.qwen_model"),
+			ContextTokens: v.GetInt("llm.qwen_context_tokens"),
 		})
 	}
 
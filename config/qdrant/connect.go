@@ -18,6 +18,7 @@ var (
 )
 
 // Connect initializes and connects to Qdrant using singleton pattern.
+// In memory mode it returns an in-process store instead of dialing a server.
 func Connect(ctx context.Context, cfg config.QdrantConfig) (qdrant.IQdrant, error) {
 	mu.Lock()
 	defer mu.Unlock()
@@ -33,12 +34,18 @@ func Connect(ctx context.Context, cfg config.QdrantConfig) (qdrant.IQdrant, erro
 
 	var err error
 	once.Do(func() {
+		if cfg.Mode == "memory" {
+			instance = qdrant.NewMemory()
+			return
+		}
+
 		clientCfg := qdrant.Config{
-			Host:    cfg.Host,
-			Port:    cfg.Port,
-			APIKey:  cfg.APIKey,
-			UseTLS:  cfg.UseTLS,
-			Timeout: time.Duration(cfg.Timeout) * time.Second,
+			Host:     cfg.Host,
+			Port:     cfg.Port,
+			RESTPort: cfg.RESTPort,
+			APIKey:   cfg.APIKey,
+			UseTLS:   cfg.UseTLS,
+			Timeout:  time.Duration(cfg.Timeout) * time.Second,
 		}
 
 		client, e := qdrant.NewQdrant(clientCfg)
@@ -0,0 +1,24 @@
+package backup
+
+// Backup statuses (knowledge.qdrant_backups.status).
+const (
+	StatusRunning   = "RUNNING"
+	StatusSucceeded = "SUCCEEDED"
+	StatusFailed    = "FAILED"
+	StatusExpired   = "EXPIRED" // object removed from MinIO by retention
+)
+
+// Backup triggers.
+const (
+	TriggerScheduled = "SCHEDULED"
+	TriggerManual    = "MANUAL"
+)
+
+// IsValidStatus reports whether s is a known backup status.
+func IsValidStatus(s string) bool {
+	switch s {
+	case StatusRunning, StatusSucceeded, StatusFailed, StatusExpired:
+		return true
+	}
+	return false
+}
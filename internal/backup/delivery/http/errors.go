@@ -0,0 +1,48 @@
+package http
+
+import (
+	"errors"
+	"knowledge-srv/internal/backup"
+
+	pkgErrors "github.com/smap-hcmut/shared-libs/go/errors"
+)
+
+var (
+	errBackupRunning   = pkgErrors.NewHTTPError(409, "A backup run is already in progress")
+	errNotManaged      = pkgErrors.NewHTTPError(400, "Collection is not a managed collection")
+	errInvalidStatus   = pkgErrors.NewHTTPError(400, "Invalid backup status")
+	errProjectRequired = pkgErrors.NewHTTPError(400, "Project ID is required")
+	errSharedLayout    = pkgErrors.NewHTTPError(400, "Project posts are stored in the shared collection and cannot be restored per project")
+	errBackupNotFound  = pkgErrors.NewHTTPError(404, "No restorable backup found")
+	errBackupMismatch  = pkgErrors.NewHTTPError(400, "Backup does not belong to the project collection")
+	errTriggerBackup   = pkgErrors.NewHTTPError(500, "Failed to start backup")
+	errListBackups     = pkgErrors.NewHTTPError(500, "Failed to list backups")
+	errRestore         = pkgErrors.NewHTTPError(500, "Failed to restore backup")
+)
+
+func (h *handler) mapError(err error) error {
+	switch {
+	case errors.Is(err, backup.ErrBackupRunning):
+		return errBackupRunning
+	case errors.Is(err, backup.ErrNotManaged):
+		return errNotManaged
+	case errors.Is(err, backup.ErrInvalidStatus):
+		return errInvalidStatus
+	case errors.Is(err, backup.ErrProjectRequired):
+		return errProjectRequired
+	case errors.Is(err, backup.ErrSharedLayout):
+		return errSharedLayout
+	case errors.Is(err, backup.ErrBackupNotFound):
+		return errBackupNotFound
+	case errors.Is(err, backup.ErrBackupMismatch):
+		return errBackupMismatch
+	case errors.Is(err, backup.ErrTriggerBackup):
+		return errTriggerBackup
+	case errors.Is(err, backup.ErrListBackups):
+		return errListBackups
+	case errors.Is(err, backup.ErrRestore):
+		return errRestore
+	default:
+		return pkgErrors.NewHTTPError(500, "Internal server error")
+	}
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/response"
+)
+
+// TriggerBackup - Handler cho POST /internal/backups
+// @Summary Trigger a Qdrant backup
+// @Description Snapshot the given managed collections (empty body = every proj_*, macro_insights and knowledge_posts collection) and upload the snapshots to MinIO in the background. Retention runs after the backup. Returns 409 while another run holds the backup lock.
+// @Tags Backups (Internal)
+// @Accept json
+// @Produce json
+// @Param body body triggerBackupReq false "Collections to back up (empty = all)"
+// @Success 200 {object} triggerBackupResp
+// @Failure 400 {object} response.Resp
+// @Failure 409 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/backups [post]
+func (h *handler) TriggerBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processTriggerBackupRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "backup.delivery.http.TriggerBackup: processTriggerBackupRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.Trigger(ctx, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "backup.delivery.http.TriggerBackup: usecase Trigger failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newTriggerBackupResp(output))
+}
+
+// ListBackups - Handler cho GET /internal/backups
+// @Summary List Qdrant backups
+// @Description List backup records, newest first
+// @Tags Backups (Internal)
+// @Produce json
+// @Param project_id query string false "Project ID"
+// @Param collection query string false "Collection name"
+// @Param status query string false "RUNNING | SUCCEEDED | FAILED | EXPIRED"
+// @Param limit query int false "Max records (default 50, max 200)"
+// @Success 200 {object} listBackupsResp
+// @Failure 400 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/backups [get]
+func (h *handler) ListBackups(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processListBackupsRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "backup.delivery.http.ListBackups: processListBackupsRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.List(ctx, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "backup.delivery.http.ListBackups: usecase List failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newListBackupsResp(output))
+}
+
+// RestoreProject - Handler cho POST /internal/backups/restore
+// @Summary Restore a project collection
+// @Description Replace a project's proj_* collection with a backup from MinIO (backup_id, or the latest successful backup) and evict its cached search results. Not available in the shared collection layout.
+// @Tags Backups (Internal)
+// @Accept json
+// @Produce json
+// @Param body body restoreProjectReq true "Project and optional backup"
+// @Success 200 {object} restoreProjectResp
+// @Failure 400 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/backups/restore [post]
+func (h *handler) RestoreProject(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processRestoreProjectRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "backup.delivery.http.RestoreProject: processRestoreProjectRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.RestoreProject(ctx, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "backup.delivery.http.RestoreProject: usecase RestoreProject failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newRestoreProjectResp(output))
+}
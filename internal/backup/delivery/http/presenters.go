@@ -0,0 +1,111 @@
+package http
+
+import (
+	"time"
+
+	"knowledge-srv/internal/backup"
+	"knowledge-srv/internal/model"
+)
+
+type triggerBackupReq struct {
+	Collections []string `json:"collections"` // empty = every managed collection
+}
+
+func (r triggerBackupReq) toInput() backup.TriggerInput {
+	return backup.TriggerInput{Collections: r.Collections}
+}
+
+type triggerBackupResp struct {
+	RunID       string   `json:"run_id"`
+	Collections []string `json:"collections"`
+}
+
+type listBackupsReq struct {
+	ProjectID  string `form:"project_id"`
+	Collection string `form:"collection"`
+	Status     string `form:"status"`
+	Limit      int    `form:"limit"`
+}
+
+func (r listBackupsReq) toInput() backup.ListInput {
+	return backup.ListInput{
+		ProjectID:  r.ProjectID,
+		Collection: r.Collection,
+		Status:     r.Status,
+		Limit:      r.Limit,
+	}
+}
+
+type backupResp struct {
+	ID           string     `json:"id"`
+	RunID        string     `json:"run_id"`
+	Collection   string     `json:"collection"`
+	ProjectID    string     `json:"project_id,omitempty"`
+	ObjectName   string     `json:"object_name,omitempty"`
+	SizeBytes    int64      `json:"size_bytes"`
+	Checksum     string     `json:"checksum,omitempty"`
+	Trigger      string     `json:"trigger"`
+	Status       string     `json:"status"`
+	ErrorMessage string     `json:"error_message,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	ExpiredAt    *time.Time `json:"expired_at,omitempty"`
+	RestoredAt   *time.Time `json:"restored_at,omitempty"`
+}
+
+type listBackupsResp struct {
+	Backups []backupResp `json:"backups"`
+}
+
+type restoreProjectReq struct {
+	ProjectID string `json:"project_id" binding:"required,uuid"`
+	BackupID  string `json:"backup_id" binding:"omitempty,uuid"` // empty = latest successful backup
+}
+
+func (r restoreProjectReq) toInput() backup.RestoreInput {
+	return backup.RestoreInput{ProjectID: r.ProjectID, BackupID: r.BackupID}
+}
+
+type restoreProjectResp struct {
+	Collection string     `json:"collection"`
+	Backup     backupResp `json:"backup"`
+}
+
+func (h *handler) newTriggerBackupResp(o backup.TriggerOutput) triggerBackupResp {
+	resp := triggerBackupResp{RunID: o.RunID, Collections: o.Collections}
+	if resp.Collections == nil {
+		resp.Collections = []string{}
+	}
+	return resp
+}
+
+func (h *handler) newListBackupsResp(o backup.ListOutput) listBackupsResp {
+	resp := listBackupsResp{Backups: make([]backupResp, len(o.Backups))}
+	for i, b := range o.Backups {
+		resp.Backups[i] = newBackupResp(b)
+	}
+	return resp
+}
+
+func (h *handler) newRestoreProjectResp(o backup.RestoreOutput) restoreProjectResp {
+	return restoreProjectResp{Collection: o.Collection, Backup: newBackupResp(o.Backup)}
+}
+
+func newBackupResp(b model.QdrantBackup) backupResp {
+	return backupResp{
+		ID:           b.ID,
+		RunID:        b.RunID,
+		Collection:   b.CollectionName,
+		ProjectID:    b.ProjectID,
+		ObjectName:   b.ObjectName,
+		SizeBytes:    b.SizeBytes,
+		Checksum:     b.Checksum,
+		Trigger:      b.Trigger,
+		Status:       b.Status,
+		ErrorMessage: b.ErrorMessage,
+		CreatedAt:    b.CreatedAt,
+		CompletedAt:  b.CompletedAt,
+		ExpiredAt:    b.ExpiredAt,
+		RestoredAt:   b.RestoredAt,
+	}
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func (h *handler) processTriggerBackupRequest(c *gin.Context) (triggerBackupReq, error) {
+	var req triggerBackupReq
+
+	// Empty body = back up every managed collection
+	if c.Request.ContentLength == 0 {
+		return req, nil
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (h *handler) processListBackupsRequest(c *gin.Context) (listBackupsReq, error) {
+	var req listBackupsReq
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (h *handler) processRestoreProjectRequest(c *gin.Context) (restoreProjectReq, error) {
+	var req restoreProjectReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
@@ -0,0 +1,17 @@
+package backup
+
+import "errors"
+
+var (
+	ErrBackupRunning   = errors.New("backup: a backup run is already in progress")
+	ErrNotManaged      = errors.New("backup: collection is not managed")
+	ErrInvalidStatus   = errors.New("backup: invalid status filter")
+	ErrProjectRequired = errors.New("backup: project_id is required")
+	ErrSharedLayout    = errors.New("backup: project posts live in the shared collection")
+	ErrBackupNotFound  = errors.New("backup: no restorable backup found")
+	ErrBackupMismatch  = errors.New("backup: backup does not belong to the project collection")
+	ErrTriggerBackup   = errors.New("backup: failed to start backup")
+	ErrListBackups     = errors.New("backup: failed to list backups")
+	ErrRestore         = errors.New("backup: restore failed")
+	ErrPurge           = errors.New("backup: project purge failed")
+)
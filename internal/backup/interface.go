@@ -0,0 +1,20 @@
+package backup
+
+import "context"
+
+//go:generate mockery --name UseCase
+type UseCase interface {
+	// Trigger starts a manual backup run in the background; fails with ErrBackupRunning
+	// while another run (on any replica) holds the backup lock.
+	Trigger(ctx context.Context, input TriggerInput) (TriggerOutput, error)
+	// List returns backup records, newest first.
+	List(ctx context.Context, input ListInput) (ListOutput, error)
+	// RestoreProject replaces a project's collection with a backed-up snapshot.
+	RestoreProject(ctx context.Context, input RestoreInput) (RestoreOutput, error)
+	// PurgeProject deletes a deleted project's backup objects and records. Safe to re-run.
+	PurgeProject(ctx context.Context, projectID string) (PurgeProjectOutput, error)
+
+	// Close stops the scheduler and cancels a scheduled run in progress; ctx bounds
+	// the wait for it to stop. Manual runs are not affected.
+	Close(ctx context.Context) error
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"knowledge-srv/internal/model"
+	pkgQdrant "knowledge-srv/pkg/qdrant"
+)
+
+//go:generate mockery --name PostgresRepository
+type PostgresRepository interface {
+	// Create inserts a RUNNING backup record.
+	Create(ctx context.Context, opt CreateOptions) (model.QdrantBackup, error)
+	MarkSucceeded(ctx context.Context, opt MarkSucceededOptions) error
+	MarkFailed(ctx context.Context, id string, errorMessage string) error
+	// MarkExpired records that retention removed the backup object from MinIO.
+	MarkExpired(ctx context.Context, id string) error
+	MarkRestored(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (model.QdrantBackup, error)
+	List(ctx context.Context, opt ListOptions) ([]model.QdrantBackup, error)
+	// ListSucceeded returns the SUCCEEDED backups of a collection, newest first.
+	ListSucceeded(ctx context.Context, collection string) ([]model.QdrantBackup, error)
+	// ListByProject returns every backup record of a project.
+	ListByProject(ctx context.Context, projectID string) ([]model.QdrantBackup, error)
+	// DeleteByProject deletes every backup record of a project, returning the rows removed.
+	DeleteByProject(ctx context.Context, projectID string) (int64, error)
+}
+
+//go:generate mockery --name SnapshotRepository
+type SnapshotRepository interface {
+	ListCollections(ctx context.Context) ([]string, error)
+	CreateSnapshot(ctx context.Context, collection string) (pkgQdrant.SnapshotInfo, error)
+	DownloadSnapshot(ctx context.Context, collection, name string) (io.ReadCloser, error)
+	DeleteSnapshot(ctx context.Context, collection, name string) error
+	// RestoreSnapshot replaces the collection with the snapshot (created when missing).
+	RestoreSnapshot(ctx context.Context, collection string, snapshot io.Reader, checksum string) error
+}
+
+//go:generate mockery --name LockRepository
+type LockRepository interface {
+	// TryLock claims key for ttl; returns false when another holder has it.
+	TryLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	// Unlock releases key only when it is still held by token.
+	Unlock(ctx context.Context, key, token string) error
+}
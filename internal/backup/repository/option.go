@@ -0,0 +1,26 @@
+package repository
+
+// CreateOptions - Options for Create
+type CreateOptions struct {
+	RunID          string
+	CollectionName string
+	ProjectID      string // empty for non-project collections
+	Trigger        string
+}
+
+// MarkSucceededOptions - Options for MarkSucceeded
+type MarkSucceededOptions struct {
+	ID           string
+	SnapshotName string
+	ObjectName   string
+	SizeBytes    int64
+	Checksum     string
+}
+
+// ListOptions - Filters for List (empty = any)
+type ListOptions struct {
+	ProjectID  string
+	Collection string
+	Status     string
+	Limit      int
+}
@@ -0,0 +1,222 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	repo "knowledge-srv/internal/backup/repository"
+	"knowledge-srv/internal/model"
+)
+
+const backupColumns = `id, run_id, collection_name, project_id, snapshot_name, object_name, size_bytes, checksum,
+		trigger, status, error_message, created_at, completed_at, expired_at, restored_at`
+
+// Create - Insert a RUNNING backup record
+func (r *implPostgresRepository) Create(ctx context.Context, opt repo.CreateOptions) (model.QdrantBackup, error) {
+	query := `
+		INSERT INTO knowledge.qdrant_backups (run_id, collection_name, project_id, trigger, status)
+		VALUES ($1, $2, $3, $4, 'RUNNING')
+		RETURNING ` + backupColumns
+
+	b, err := scanBackup(r.db.QueryRowContext(ctx, query, opt.RunID, opt.CollectionName, nullString(opt.ProjectID), opt.Trigger))
+	if err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.Create: Failed to insert backup: %v", err)
+		return model.QdrantBackup{}, repo.ErrFailedToInsert
+	}
+	return b, nil
+}
+
+// MarkSucceeded - Record the uploaded snapshot object
+func (r *implPostgresRepository) MarkSucceeded(ctx context.Context, opt repo.MarkSucceededOptions) error {
+	const query = `
+		UPDATE knowledge.qdrant_backups
+		SET status = 'SUCCEEDED', snapshot_name = $2, object_name = $3, size_bytes = $4, checksum = $5, completed_at = NOW()
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, opt.ID, opt.SnapshotName, opt.ObjectName, opt.SizeBytes, opt.Checksum); err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.MarkSucceeded: Failed to update backup %s: %v", opt.ID, err)
+		return repo.ErrFailedToUpdate
+	}
+	return nil
+}
+
+// MarkFailed - Record a failed backup
+func (r *implPostgresRepository) MarkFailed(ctx context.Context, id string, errorMessage string) error {
+	const query = `
+		UPDATE knowledge.qdrant_backups
+		SET status = 'FAILED', error_message = $2, completed_at = NOW()
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, errorMessage); err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.MarkFailed: Failed to update backup %s: %v", id, err)
+		return repo.ErrFailedToUpdate
+	}
+	return nil
+}
+
+// MarkExpired - Record that retention removed the backup object
+func (r *implPostgresRepository) MarkExpired(ctx context.Context, id string) error {
+	const query = `
+		UPDATE knowledge.qdrant_backups
+		SET status = 'EXPIRED', expired_at = NOW()
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.MarkExpired: Failed to update backup %s: %v", id, err)
+		return repo.ErrFailedToUpdate
+	}
+	return nil
+}
+
+// MarkRestored - Record the last restore from a backup
+func (r *implPostgresRepository) MarkRestored(ctx context.Context, id string) error {
+	const query = `UPDATE knowledge.qdrant_backups SET restored_at = NOW() WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.MarkRestored: Failed to update backup %s: %v", id, err)
+		return repo.ErrFailedToUpdate
+	}
+	return nil
+}
+
+// Get - Backup record by ID
+func (r *implPostgresRepository) Get(ctx context.Context, id string) (model.QdrantBackup, error) {
+	query := `SELECT ` + backupColumns + ` FROM knowledge.qdrant_backups WHERE id = $1`
+
+	b, err := scanBackup(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.QdrantBackup{}, repo.ErrNotFound
+		}
+		r.l.Errorf(ctx, "backup.repository.postgre.Get: Failed to get backup %s: %v", id, err)
+		return model.QdrantBackup{}, repo.ErrFailedToGet
+	}
+	return b, nil
+}
+
+// List - Backup records matching the filters, newest first
+func (r *implPostgresRepository) List(ctx context.Context, opt repo.ListOptions) ([]model.QdrantBackup, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if opt.ProjectID != "" {
+		args = append(args, opt.ProjectID)
+		where = append(where, fmt.Sprintf("project_id = $%d", len(args)))
+	}
+	if opt.Collection != "" {
+		args = append(args, opt.Collection)
+		where = append(where, fmt.Sprintf("collection_name = $%d", len(args)))
+	}
+	if opt.Status != "" {
+		args = append(args, opt.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + backupColumns + ` FROM knowledge.qdrant_backups`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, opt.Limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
+
+	return r.queryBackups(ctx, "List", query, args...)
+}
+
+// ListSucceeded - SUCCEEDED backups of a collection, newest first
+func (r *implPostgresRepository) ListSucceeded(ctx context.Context, collection string) ([]model.QdrantBackup, error) {
+	query := `
+		SELECT ` + backupColumns + `
+		FROM knowledge.qdrant_backups
+		WHERE collection_name = $1 AND status = 'SUCCEEDED'
+		ORDER BY created_at DESC`
+
+	return r.queryBackups(ctx, "ListSucceeded", query, collection)
+}
+
+// ListByProject - Every backup record of a project
+func (r *implPostgresRepository) ListByProject(ctx context.Context, projectID string) ([]model.QdrantBackup, error) {
+	query := `SELECT ` + backupColumns + ` FROM knowledge.qdrant_backups WHERE project_id = $1`
+
+	return r.queryBackups(ctx, "ListByProject", query, projectID)
+}
+
+// DeleteByProject - Delete every backup record of a project
+func (r *implPostgresRepository) DeleteByProject(ctx context.Context, projectID string) (int64, error) {
+	const query = `DELETE FROM knowledge.qdrant_backups WHERE project_id = $1`
+
+	res, err := r.db.ExecContext(ctx, query, projectID)
+	if err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.DeleteByProject: Failed to delete backups of project %s: %v", projectID, err)
+		return 0, repo.ErrFailedToDelete
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.DeleteByProject: Failed to count deleted backups: %v", err)
+		return 0, repo.ErrFailedToDelete
+	}
+	return n, nil
+}
+
+func (r *implPostgresRepository) queryBackups(ctx context.Context, fn string, query string, args ...interface{}) ([]model.QdrantBackup, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.%s: Failed to query backups: %v", fn, err)
+		return nil, repo.ErrFailedToList
+	}
+	defer rows.Close()
+
+	var backups []model.QdrantBackup
+	for rows.Next() {
+		b, err := scanBackup(rows)
+		if err != nil {
+			r.l.Errorf(ctx, "backup.repository.postgre.%s: Failed to scan backup: %v", fn, err)
+			return nil, repo.ErrFailedToList
+		}
+		backups = append(backups, b)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "backup.repository.postgre.%s: Failed to iterate backups: %v", fn, err)
+		return nil, repo.ErrFailedToList
+	}
+	return backups, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBackup(s scanner) (model.QdrantBackup, error) {
+	var (
+		b                                                     model.QdrantBackup
+		projectID, snapshotName, objectName, checksum, errMsg sql.NullString
+		completedAt, expiredAt, restoredAt                    sql.NullTime
+	)
+	err := s.Scan(&b.ID, &b.RunID, &b.CollectionName, &projectID, &snapshotName, &objectName, &b.SizeBytes, &checksum,
+		&b.Trigger, &b.Status, &errMsg, &b.CreatedAt, &completedAt, &expiredAt, &restoredAt)
+	if err != nil {
+		return model.QdrantBackup{}, err
+	}
+	b.ProjectID = projectID.String
+	b.SnapshotName = snapshotName.String
+	b.ObjectName = objectName.String
+	b.Checksum = checksum.String
+	b.ErrorMessage = errMsg.String
+	if completedAt.Valid {
+		b.CompletedAt = &completedAt.Time
+	}
+	if expiredAt.Valid {
+		b.ExpiredAt = &expiredAt.Time
+	}
+	if restoredAt.Valid {
+		b.RestoredAt = &restoredAt.Time
+	}
+	return b, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
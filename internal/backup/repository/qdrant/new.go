@@ -0,0 +1,20 @@
+package qdrant
+
+import (
+	"knowledge-srv/internal/backup/repository"
+	pkgQdrant "knowledge-srv/pkg/qdrant"
+
+	"github.com/smap-hcmut/shared-libs/go/log"
+)
+
+type implRepository struct {
+	client pkgQdrant.IQdrant
+	l      log.Logger
+}
+
+func New(client pkgQdrant.IQdrant, l log.Logger) repository.SnapshotRepository {
+	return &implRepository{
+		client: client,
+		l:      l,
+	}
+}
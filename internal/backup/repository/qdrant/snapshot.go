@@ -0,0 +1,51 @@
+package qdrant
+
+import (
+	"context"
+	"io"
+
+	pkgQdrant "knowledge-srv/pkg/qdrant"
+)
+
+func (r *implRepository) ListCollections(ctx context.Context) ([]string, error) {
+	names, err := r.client.ListCollections(ctx)
+	if err != nil {
+		r.l.Errorf(ctx, "backup.repository.qdrant.ListCollections: failed to list collections: %v", err)
+		return nil, err
+	}
+	return names, nil
+}
+
+func (r *implRepository) CreateSnapshot(ctx context.Context, collection string) (pkgQdrant.SnapshotInfo, error) {
+	info, err := r.client.CreateSnapshot(ctx, collection)
+	if err != nil {
+		r.l.Errorf(ctx, "backup.repository.qdrant.CreateSnapshot: failed to snapshot %s: %v", collection, err)
+		return pkgQdrant.SnapshotInfo{}, err
+	}
+	return info, nil
+}
+
+func (r *implRepository) DownloadSnapshot(ctx context.Context, collection, name string) (io.ReadCloser, error) {
+	body, err := r.client.DownloadSnapshot(ctx, collection, name)
+	if err != nil {
+		r.l.Errorf(ctx, "backup.repository.qdrant.DownloadSnapshot: failed to download %s/%s: %v", collection, name, err)
+		return nil, err
+	}
+	return body, nil
+}
+
+func (r *implRepository) DeleteSnapshot(ctx context.Context, collection, name string) error {
+	if err := r.client.DeleteSnapshot(ctx, collection, name); err != nil {
+		r.l.Warnf(ctx, "backup.repository.qdrant.DeleteSnapshot: failed to delete %s/%s: %v", collection, name, err)
+		return err
+	}
+	return nil
+}
+
+func (r *implRepository) RestoreSnapshot(ctx context.Context, collection string, snapshot io.Reader, checksum string) error {
+	if err := r.client.RestoreSnapshot(ctx, collection, snapshot, checksum); err != nil {
+		r.l.Errorf(ctx, "backup.repository.qdrant.RestoreSnapshot: failed to restore %s: %v", collection, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// unlockScript deletes the lock only when it still holds the caller's token, so a
+// run that outlived its TTL cannot release a lock taken over by another replica.
+var unlockScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+func (r *implLockRepository) TryLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	ok, err := r.redis.GetClient().SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		r.l.Errorf(ctx, "backup.repository.redis.TryLock: failed to lock %s: %v", key, err)
+		return false, err
+	}
+	return ok, nil
+}
+
+func (r *implLockRepository) Unlock(ctx context.Context, key, token string) error {
+	if err := unlockScript.Run(ctx, r.redis.GetClient(), []string{key}, token).Err(); err != nil && err != goredis.Nil {
+		r.l.Warnf(ctx, "backup.repository.redis.Unlock: failed to unlock %s: %v", key, err)
+		return err
+	}
+	return nil
+}
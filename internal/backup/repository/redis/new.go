@@ -0,0 +1,21 @@
+package redis
+
+import (
+	repo "knowledge-srv/internal/backup/repository"
+
+	"github.com/smap-hcmut/shared-libs/go/log"
+	"github.com/smap-hcmut/shared-libs/go/redis"
+)
+
+type implLockRepository struct {
+	redis redis.IRedis
+	l     log.Logger
+}
+
+// New creates a new LockRepository backed by Redis.
+func New(redis redis.IRedis, l log.Logger) repo.LockRepository {
+	return &implLockRepository{
+		redis: redis,
+		l:     l,
+	}
+}
@@ -0,0 +1,53 @@
+package backup
+
+import "knowledge-srv/internal/model"
+
+// TriggerInput - Collections to back up (empty = every managed collection)
+type TriggerInput struct {
+	Collections []string
+}
+
+// TriggerOutput - A backup run started in the background
+type TriggerOutput struct {
+	RunID       string
+	Collections []string
+}
+
+// ListInput - Filters for backup records (empty = any)
+type ListInput struct {
+	ProjectID  string
+	Collection string
+	Status     string
+	Limit      int
+}
+
+// ListOutput - Backup records, newest first
+type ListOutput struct {
+	Backups []model.QdrantBackup
+}
+
+// RestoreInput - Restore a project's collection from BackupID, or from its latest successful backup
+type RestoreInput struct {
+	ProjectID string
+	BackupID  string
+}
+
+// RestoreOutput - The backup the collection was restored from
+type RestoreOutput struct {
+	Collection string
+	Backup     model.QdrantBackup
+}
+
+// RunOutput - Result of one backup run
+type RunOutput struct {
+	RunID     string
+	Succeeded int
+	Failed    int
+	Expired   int
+}
+
+// PurgeProjectOutput - What PurgeProject removed
+type PurgeProjectOutput struct {
+	Objects int64 // snapshot objects deleted from MinIO
+	Backups int64 // backup records deleted
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"knowledge-srv/internal/backup"
+	"knowledge-srv/internal/backup/repository"
+	"knowledge-srv/internal/point"
+
+	"github.com/google/uuid"
+	"github.com/smap-hcmut/shared-libs/go/minio"
+)
+
+// Trigger claims the run lock and backs up the collections in the background.
+func (uc *implUseCase) Trigger(ctx context.Context, input backup.TriggerInput) (backup.TriggerOutput, error) {
+	collections, err := uc.resolveCollections(ctx, input.Collections)
+	if err != nil {
+		return backup.TriggerOutput{}, err
+	}
+
+	runID := uuid.New().String()
+	locked, err := uc.locks.TryLock(ctx, runLockKey, runID, runLockTTL)
+	if err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.Trigger: TryLock failed: %v", err)
+		return backup.TriggerOutput{}, fmt.Errorf("%w: %v", backup.ErrTriggerBackup, err)
+	}
+	if !locked {
+		return backup.TriggerOutput{}, backup.ErrBackupRunning
+	}
+
+	go func() {
+		runCtx := context.Background()
+		defer func() { _ = uc.locks.Unlock(runCtx, runLockKey, runID) }()
+		uc.run(runCtx, runID, backup.TriggerManual, collections)
+	}()
+
+	return backup.TriggerOutput{RunID: runID, Collections: collections}, nil
+}
+
+// resolveCollections returns the requested collections, or every managed collection
+// (proj_*, macro_insights, knowledge_posts) currently in Qdrant.
+func (uc *implUseCase) resolveCollections(ctx context.Context, requested []string) ([]string, error) {
+	if len(requested) > 0 {
+		seen := make(map[string]bool, len(requested))
+		var collections []string
+		for _, name := range requested {
+			name = strings.TrimSpace(name)
+			if !point.IsManagedCollection(name) {
+				return nil, fmt.Errorf("%w: %q", backup.ErrNotManaged, name)
+			}
+			if !seen[name] {
+				seen[name] = true
+				collections = append(collections, name)
+			}
+		}
+		return collections, nil
+	}
+
+	names, err := uc.snapshots.ListCollections(ctx)
+	if err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.resolveCollections: ListCollections failed: %v", err)
+		return nil, fmt.Errorf("%w: %v", backup.ErrTriggerBackup, err)
+	}
+	var collections []string
+	for _, name := range names {
+		if point.IsManagedCollection(name) {
+			collections = append(collections, name)
+		}
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+// run backs up each collection, then applies retention to them. Callers must hold runLockKey.
+func (uc *implUseCase) run(ctx context.Context, runID, trigger string, collections []string) backup.RunOutput {
+	out := backup.RunOutput{RunID: runID}
+	uc.l.Infof(ctx, "backup.usecase.run: run=%s trigger=%s collections=%d", runID, trigger, len(collections))
+
+	if err := uc.ensureBucket(ctx); err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.run: Storage setup failed for bucket %q: %v", uc.config.Bucket, err)
+		out.Failed = len(collections)
+		return out
+	}
+
+	for _, name := range collections {
+		if err := uc.backupCollection(ctx, runID, trigger, name); err != nil {
+			out.Failed++
+			continue
+		}
+		out.Succeeded++
+	}
+	for _, name := range collections {
+		out.Expired += uc.applyRetention(ctx, name)
+	}
+
+	uc.l.Infof(ctx, "backup.usecase.run: run=%s succeeded=%d failed=%d expired=%d", runID, out.Succeeded, out.Failed, out.Expired)
+	return out
+}
+
+// backupCollection snapshots one collection, streams the snapshot to MinIO and
+// removes it from the Qdrant node. The outcome is recorded on the backup row.
+func (uc *implUseCase) backupCollection(ctx context.Context, runID, trigger, collection string) error {
+	projectID, _ := point.ProjectIDFromCollection(collection)
+	rec, err := uc.repo.Create(ctx, repository.CreateOptions{
+		RunID:          runID,
+		CollectionName: collection,
+		ProjectID:      projectID,
+		Trigger:        trigger,
+	})
+	if err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.backupCollection: Create record for %s failed: %v", collection, err)
+		return err
+	}
+
+	fail := func(step string, err error) error {
+		uc.l.Errorf(ctx, "backup.usecase.backupCollection: %s %s failed: %v", step, collection, err)
+		// Recorded even when a shutdown cancelled the run
+		_ = uc.repo.MarkFailed(context.WithoutCancel(ctx), rec.ID, fmt.Sprintf("%s: %v", step, err))
+		return err
+	}
+
+	info, err := uc.snapshots.CreateSnapshot(ctx, collection)
+	if err != nil {
+		return fail("create snapshot", err)
+	}
+	// Snapshots are only kept in MinIO; drop the node copy whatever happens next.
+	defer func() { _ = uc.snapshots.DeleteSnapshot(context.WithoutCancel(ctx), collection, info.Name) }()
+
+	body, err := uc.snapshots.DownloadSnapshot(ctx, collection, info.Name)
+	if err != nil {
+		return fail("download snapshot", err)
+	}
+	defer body.Close()
+
+	objectName := path.Join(uc.config.Prefix, collection, info.Name)
+	_, err = uc.minio.UploadFile(ctx, &minio.UploadRequest{
+		BucketName:  uc.config.Bucket,
+		ObjectName:  objectName,
+		Reader:      body,
+		Size:        info.Size,
+		ContentType: "application/octet-stream",
+		Metadata: map[string]string{
+			"backup_id":  rec.ID,
+			"run_id":     runID,
+			"collection": collection,
+			"checksum":   info.Checksum,
+		},
+	})
+	if err != nil {
+		return fail("upload snapshot", err)
+	}
+
+	if err := uc.repo.MarkSucceeded(ctx, repository.MarkSucceededOptions{
+		ID:           rec.ID,
+		SnapshotName: info.Name,
+		ObjectName:   objectName,
+		SizeBytes:    info.Size,
+		Checksum:     info.Checksum,
+	}); err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.backupCollection: MarkSucceeded %s failed: %v", collection, err)
+		return err
+	}
+	return nil
+}
+
+func (uc *implUseCase) ensureBucket(ctx context.Context) error {
+	bucket := strings.TrimSpace(uc.config.Bucket)
+	if bucket == "" {
+		return fmt.Errorf("backup bucket is empty")
+	}
+
+	exists, err := uc.minio.BucketExists(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("check backup bucket %q: %w", bucket, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err := uc.minio.CreateBucket(ctx, bucket); err != nil {
+		existsAfterCreate, checkErr := uc.minio.BucketExists(ctx, bucket)
+		if checkErr == nil && existsAfterCreate {
+			return nil
+		}
+		return fmt.Errorf("create backup bucket %q: %w", bucket, err)
+	}
+	return nil
+}
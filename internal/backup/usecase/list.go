@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"knowledge-srv/internal/backup"
+	"knowledge-srv/internal/backup/repository"
+)
+
+// List returns backup records matching the filters, newest first.
+func (uc *implUseCase) List(ctx context.Context, input backup.ListInput) (backup.ListOutput, error) {
+	status := strings.ToUpper(strings.TrimSpace(input.Status))
+	if status != "" && !backup.IsValidStatus(status) {
+		return backup.ListOutput{}, backup.ErrInvalidStatus
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	backups, err := uc.repo.List(ctx, repository.ListOptions{
+		ProjectID:  strings.TrimSpace(input.ProjectID),
+		Collection: strings.TrimSpace(input.Collection),
+		Status:     status,
+		Limit:      limit,
+	})
+	if err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.List: repo List failed: %v", err)
+		return backup.ListOutput{}, fmt.Errorf("%w: %v", backup.ErrListBackups, err)
+	}
+	return backup.ListOutput{Backups: backups}, nil
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"knowledge-srv/internal/backup"
+	"knowledge-srv/internal/backup/repository"
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
+
+	"github.com/smap-hcmut/shared-libs/go/log"
+	"github.com/smap-hcmut/shared-libs/go/minio"
+)
+
+const (
+	defaultInterval  = 24 * time.Hour
+	defaultPrefix    = "qdrant-backups"
+	defaultKeepLast  = 7
+	defaultRetention = 30 * 24 * time.Hour
+	defaultListLimit = 50
+	maxListLimit     = 200
+
+	// runLockKey is held for the duration of a backup run (any replica, any trigger).
+	runLockKey = "backup:qdrant:running"
+	runLockTTL = 6 * time.Hour
+	// scheduleLockKey claims a scheduled slot so only one replica runs per interval.
+	scheduleLockKey = "backup:qdrant:schedule"
+)
+
+// Config - Backup schedule, destination and retention
+type Config struct {
+	Enabled   bool          // start the scheduler in New
+	Interval  time.Duration // between scheduled runs
+	Bucket    string        // MinIO bucket
+	Prefix    string        // object prefix: {prefix}/{collection}/{snapshot}
+	KeepLast  int           // newest successful backups kept per collection
+	Retention time.Duration // successful backups older than this expire (the newest one is always kept)
+}
+
+type implUseCase struct {
+	repo      repository.PostgresRepository
+	snapshots repository.SnapshotRepository
+	locks     repository.LockRepository
+	pointUC   point.UseCase
+	searchUC  search.UseCase
+	minio     minio.MinIO
+	l         log.Logger
+	config    Config
+
+	stopScheduler context.CancelFunc // nil when the scheduler is disabled
+	schedulerDone chan struct{}      // closed when the scheduler has exited
+	closeOnce     sync.Once
+}
+
+func New(
+	repo repository.PostgresRepository,
+	snapshots repository.SnapshotRepository,
+	locks repository.LockRepository,
+	pointUC point.UseCase,
+	searchUC search.UseCase,
+	minioClient minio.MinIO,
+	l log.Logger,
+	cfg Config,
+) backup.UseCase {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+	if cfg.Prefix == "" {
+		cfg.Prefix = defaultPrefix
+	}
+	if cfg.KeepLast <= 0 {
+		cfg.KeepLast = defaultKeepLast
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultRetention
+	}
+
+	uc := &implUseCase{
+		repo:      repo,
+		snapshots: snapshots,
+		locks:     locks,
+		pointUC:   pointUC,
+		searchUC:  searchUC,
+		minio:     minioClient,
+		l:         l,
+		config:    cfg,
+	}
+	if cfg.Enabled {
+		ctx, cancel := context.WithCancel(context.Background())
+		uc.stopScheduler = cancel
+		uc.schedulerDone = make(chan struct{})
+		go uc.runScheduler(ctx)
+	}
+	return uc
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"knowledge-srv/internal/backup"
+)
+
+// PurgeProject deletes the snapshot objects of a project's backups before their records,
+// so a failed attempt can still find the objects on retry. Expired backups have no object left.
+func (uc *implUseCase) PurgeProject(ctx context.Context, projectID string) (backup.PurgeProjectOutput, error) {
+	var out backup.PurgeProjectOutput
+
+	backups, err := uc.repo.ListByProject(ctx, projectID)
+	if err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.PurgeProject: ListByProject %s failed: %v", projectID, err)
+		return out, fmt.Errorf("%w: %v", backup.ErrPurge, err)
+	}
+	for _, b := range backups {
+		if b.ObjectName == "" || b.ExpiredAt != nil {
+			continue
+		}
+		if err := uc.minio.DeleteFile(ctx, uc.config.Bucket, b.ObjectName); err != nil {
+			uc.l.Errorf(ctx, "backup.usecase.PurgeProject: DeleteFile %s failed: %v", b.ObjectName, err)
+			return out, fmt.Errorf("%w: %v", backup.ErrPurge, err)
+		}
+		out.Objects++
+	}
+
+	if out.Backups, err = uc.repo.DeleteByProject(ctx, projectID); err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.PurgeProject: DeleteByProject %s failed: %v", projectID, err)
+		return out, fmt.Errorf("%w: %v", backup.ErrPurge, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"knowledge-srv/internal/backup"
+	"knowledge-srv/internal/backup/repository"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+
+	"github.com/smap-hcmut/shared-libs/go/minio"
+)
+
+// RestoreProject replaces a project's collection with a backup from MinIO (the
+// given one, or the latest successful backup of the collection) and evicts the
+// project's cached search results.
+func (uc *implUseCase) RestoreProject(ctx context.Context, input backup.RestoreInput) (backup.RestoreOutput, error) {
+	projectID := strings.TrimSpace(input.ProjectID)
+	if projectID == "" {
+		return backup.RestoreOutput{}, backup.ErrProjectRequired
+	}
+	// A shared-collection snapshot holds every project; restoring it would roll back all of them.
+	collection := uc.pointUC.CollectionForProject(projectID)
+	if collection == point.CollectionSharedPosts {
+		return backup.RestoreOutput{}, backup.ErrSharedLayout
+	}
+
+	b, err := uc.findRestorable(ctx, collection, strings.TrimSpace(input.BackupID))
+	if err != nil {
+		return backup.RestoreOutput{}, err
+	}
+
+	reader, _, err := uc.minio.DownloadFile(ctx, &minio.DownloadRequest{
+		BucketName: uc.config.Bucket,
+		ObjectName: b.ObjectName,
+	})
+	if err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.RestoreProject: DownloadFile %s failed: %v", b.ObjectName, err)
+		return backup.RestoreOutput{}, fmt.Errorf("%w: %v", backup.ErrRestore, err)
+	}
+	defer reader.Close()
+
+	if err := uc.snapshots.RestoreSnapshot(ctx, collection, reader, b.Checksum); err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.RestoreProject: RestoreSnapshot %s from %s failed: %v", collection, b.ID, err)
+		return backup.RestoreOutput{}, fmt.Errorf("%w: %v", backup.ErrRestore, err)
+	}
+
+	_ = uc.repo.MarkRestored(ctx, b.ID)
+	if err := uc.searchUC.InvalidateProject(ctx, projectID); err != nil {
+		uc.l.Warnf(ctx, "backup.usecase.RestoreProject: InvalidateProject %s failed: %v", projectID, err)
+	}
+	uc.l.Infof(ctx, "backup.usecase.RestoreProject: restored %s from backup %s", collection, b.ID)
+
+	return backup.RestoreOutput{Collection: collection, Backup: b}, nil
+}
+
+func (uc *implUseCase) findRestorable(ctx context.Context, collection, backupID string) (model.QdrantBackup, error) {
+	if backupID == "" {
+		backups, err := uc.repo.ListSucceeded(ctx, collection)
+		if err != nil {
+			return model.QdrantBackup{}, fmt.Errorf("%w: %v", backup.ErrRestore, err)
+		}
+		if len(backups) == 0 {
+			return model.QdrantBackup{}, backup.ErrBackupNotFound
+		}
+		return backups[0], nil
+	}
+
+	b, err := uc.repo.Get(ctx, backupID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.QdrantBackup{}, backup.ErrBackupNotFound
+		}
+		return model.QdrantBackup{}, fmt.Errorf("%w: %v", backup.ErrRestore, err)
+	}
+	if b.CollectionName != collection {
+		return model.QdrantBackup{}, backup.ErrBackupMismatch
+	}
+	if b.Status != backup.StatusSucceeded {
+		return model.QdrantBackup{}, backup.ErrBackupNotFound
+	}
+	return b, nil
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// applyRetention expires successful backups of a collection that rank beyond
+// KeepLast or are older than Retention. The newest backup is always kept so a
+// collection that stopped changing can still be restored. Returns backups expired.
+func (uc *implUseCase) applyRetention(ctx context.Context, collection string) int {
+	backups, err := uc.repo.ListSucceeded(ctx, collection)
+	if err != nil {
+		uc.l.Warnf(ctx, "backup.usecase.applyRetention: ListSucceeded %s failed: %v", collection, err)
+		return 0
+	}
+
+	cutoff := time.Now().Add(-uc.config.Retention)
+	expired := 0
+	for i, b := range backups {
+		if i == 0 || (i < uc.config.KeepLast && !b.CreatedAt.Before(cutoff)) {
+			continue
+		}
+		if err := uc.minio.DeleteFile(ctx, uc.config.Bucket, b.ObjectName); err != nil {
+			uc.l.Warnf(ctx, "backup.usecase.applyRetention: DeleteFile %s failed: %v", b.ObjectName, err)
+			continue
+		}
+		if err := uc.repo.MarkExpired(ctx, b.ID); err != nil {
+			continue
+		}
+		expired++
+	}
+	return expired
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"knowledge-srv/internal/backup"
+
+	"github.com/google/uuid"
+)
+
+// runScheduler runs a scheduled backup every Interval. Every replica ticks, but
+// the schedule lock (held for just under one interval and never released) lets
+// only one of them run per interval, and the run lock keeps it from overlapping a
+// manual run. It returns once ctx is cancelled by Close.
+func (uc *implUseCase) runScheduler(ctx context.Context) {
+	defer close(uc.schedulerDone)
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			uc.runScheduled(ctx)
+		}
+	}
+}
+
+// Close - Stop the scheduler and wait for it to exit (bounded by ctx)
+func (uc *implUseCase) Close(ctx context.Context) error {
+	if uc.stopScheduler == nil {
+		return nil
+	}
+	uc.closeOnce.Do(uc.stopScheduler)
+
+	select {
+	case <-uc.schedulerDone:
+		return nil
+	case <-ctx.Done():
+		uc.l.Warnf(ctx, "backup.usecase.Close: scheduler did not stop in time: %v", ctx.Err())
+		return ctx.Err()
+	}
+}
+
+func (uc *implUseCase) runScheduled(ctx context.Context) {
+	runID := uuid.New().String()
+
+	slotTTL := uc.config.Interval - time.Minute
+	if slotTTL <= 0 {
+		slotTTL = uc.config.Interval
+	}
+	claimed, err := uc.locks.TryLock(ctx, scheduleLockKey, runID, slotTTL)
+	if err != nil || !claimed {
+		return
+	}
+
+	locked, err := uc.locks.TryLock(ctx, runLockKey, runID, runLockTTL)
+	if err != nil || !locked {
+		uc.l.Warnf(ctx, "backup.usecase.runScheduled: another backup run is in progress, skipping")
+		return
+	}
+	defer func() { _ = uc.locks.Unlock(context.WithoutCancel(ctx), runLockKey, runID) }()
+
+	collections, err := uc.resolveCollections(ctx, nil)
+	if err != nil {
+		uc.l.Errorf(ctx, "backup.usecase.runScheduled: resolveCollections failed: %v", err)
+		return
+	}
+	uc.run(ctx, runID, backup.TriggerScheduled, collections)
+}
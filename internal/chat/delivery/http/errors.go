@@ -16,6 +16,12 @@ var (
 	errLLMFailed            = pkgErrors.NewHTTPError(500, "AI generation failed")
 	errSearchFailed         = pkgErrors.NewHTTPError(500, "Search failed")
 	errConversationArchived = pkgErrors.NewHTTPError(400, "Conversation is archived")
+	errMessageNotFound      = pkgErrors.NewHTTPError(404, "Message not found")
+	errNotUserMessage       = pkgErrors.NewHTTPError(400, "Only questions can be edited")
+	errForbidden            = pkgErrors.NewHTTPError(403, "Forbidden")
+	errAnswerCacheStats     = pkgErrors.NewHTTPError(500, "Failed to load answer cache stats")
+	errTooManyCampaigns     = pkgErrors.NewHTTPError(400, "Too many campaigns to compare (max 4)")
+	errCampaignAccess       = pkgErrors.NewHTTPError(403, "No access to one of the campaigns")
 )
 
 func (h *handler) mapError(err error) error {
@@ -39,6 +45,18 @@ func (h *handler) mapError(err error) error {
 		return errSearchFailed
 	case errors.Is(err, chat.ErrConversationArchived):
 		return errConversationArchived
+	case errors.Is(err, chat.ErrMessageNotFound):
+		return errMessageNotFound
+	case errors.Is(err, chat.ErrNotUserMessage):
+		return errNotUserMessage
+	case errors.Is(err, chat.ErrForbidden):
+		return errForbidden
+	case errors.Is(err, chat.ErrAnswerCacheStats):
+		return errAnswerCacheStats
+	case errors.Is(err, chat.ErrTooManyCampaigns):
+		return errTooManyCampaigns
+	case errors.Is(err, chat.ErrCampaignAccess):
+		return errCampaignAccess
 	default:
 		return pkgErrors.NewHTTPError(500, "Internal server error")
 	}
@@ -9,13 +9,14 @@ import (
 )
 
 // @Summary Chat with knowledge service
-// @Description Send a message and receive an answer with citations
+// @Description Send a message and receive an answer with citations. campaign_ids compares up to 4 campaigns side by side; citations then come grouped by campaign
 // @Tags Chat
 // @Accept json
 // @Produce json
 // @Param body body chatReq true "Chat request"
 // @Success 200 {object} chatResp
 // @Failure 400 {object} response.Resp
+// @Failure 403 {object} response.Resp
 // @Failure 500 {object} response.Resp
 // @Router /chat [post]
 func (h *handler) Chat(c *gin.Context) {
@@ -49,12 +50,14 @@ func (h *handler) respondChatError(c *gin.Context, logPrefix string, err error)
 }
 
 // @Summary Get conversation detail
-// @Description Return full conversation info and messages
+// @Description Return full conversation info and messages. Readable by the owner, admins and whoever the conversation is shared with.
 // @Tags Chat
 // @Produce json
 // @Param conversation_id path string true "Conversation ID"
+// @Param share_token query string false "Share link token"
 // @Success 200 {object} conversationResp
 // @Failure 400 {object} response.Resp
+// @Failure 404 {object} response.Resp
 // @Failure 500 {object} response.Resp
 // @Router /conversations/{conversation_id} [get]
 func (h *handler) GetConversation(c *gin.Context) {
@@ -136,3 +139,179 @@ func (h *handler) GetSuggestions(c *gin.Context) {
 
 	response.OK(c, h.newSuggestionsResp(o))
 }
+
+// @Summary Get answer cache stats
+// @Description Return the answer cache hits, misses and hit rate of a campaign (admin only)
+// @Tags Chat
+// @Produce json
+// @Param campaign_id path string true "Campaign ID"
+// @Success 200 {object} answerCacheStatsResp
+// @Failure 400 {object} response.Resp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /campaigns/{campaign_id}/answer-cache/stats [get]
+func (h *handler) AnswerCacheStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processAnswerCacheStatsRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "chat.delivery.http.AnswerCacheStats: processAnswerCacheStatsRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	o, err := h.uc.AnswerCacheStats(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "chat.delivery.http.AnswerCacheStats: usecase AnswerCacheStats failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newAnswerCacheStatsResp(o))
+}
+
+// @Summary Regenerate an answer
+// @Description Answer a question again; the new answer is a sibling of the old one and becomes the active branch
+// @Tags Chat
+// @Produce json
+// @Param conversation_id path string true "Conversation ID"
+// @Param message_id path string true "Answer (or question) to regenerate"
+// @Success 200 {object} chatResp
+// @Failure 400 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /conversations/{conversation_id}/messages/{message_id}/regenerate [post]
+func (h *handler) Regenerate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processRegenerateRequest(c)
+	if err != nil {
+		h.respondChatError(c, "chat.delivery.http.Regenerate: processRegenerateRequest failed", err)
+		return
+	}
+
+	o, err := h.uc.Regenerate(ctx, sc, req.toInput())
+	if err != nil {
+		h.respondChatError(c, "chat.delivery.http.Regenerate: usecase Regenerate failed", err)
+		return
+	}
+
+	response.OK(c, h.newChatResp(o))
+}
+
+// @Summary Edit a question and resend it
+// @Description Ask an edited question beside the original; the original exchange stays on its own branch
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Param conversation_id path string true "Conversation ID"
+// @Param message_id path string true "Question to edit"
+// @Param body body editMessageReq true "Edited question"
+// @Success 200 {object} chatResp
+// @Failure 400 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /conversations/{conversation_id}/messages/{message_id}/edit [post]
+func (h *handler) EditMessage(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processEditMessageRequest(c)
+	if err != nil {
+		h.respondChatError(c, "chat.delivery.http.EditMessage: processEditMessageRequest failed", err)
+		return
+	}
+
+	o, err := h.uc.EditMessage(ctx, sc, req.toInput())
+	if err != nil {
+		h.respondChatError(c, "chat.delivery.http.EditMessage: usecase EditMessage failed", err)
+		return
+	}
+
+	response.OK(c, h.newChatResp(o))
+}
+
+// @Summary Switch conversation branch
+// @Description Show the branch through a message, continued along its newest replies; new questions follow it
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Param conversation_id path string true "Conversation ID"
+// @Param body body switchBranchReq true "Message on the branch to show"
+// @Success 200 {object} conversationResp
+// @Failure 400 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /conversations/{conversation_id}/branch [put]
+func (h *handler) SwitchBranch(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processSwitchBranchRequest(c)
+	if err != nil {
+		h.respondChatError(c, "chat.delivery.http.SwitchBranch: processSwitchBranchRequest failed", err)
+		return
+	}
+
+	o, err := h.uc.SwitchBranch(ctx, sc, req.toInput())
+	if err != nil {
+		h.respondChatError(c, "chat.delivery.http.SwitchBranch: usecase SwitchBranch failed", err)
+		return
+	}
+
+	response.OK(c, h.newConversationResp(o))
+}
+
+// @Summary Get conversation memory
+// @Description Return the rolling summary, key facts and active filters kept for older turns
+// @Tags Chat
+// @Produce json
+// @Param conversation_id path string true "Conversation ID"
+// @Success 200 {object} memoryResp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /conversations/{conversation_id}/memory [get]
+func (h *handler) GetMemory(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processMemoryRequest(c)
+	if err != nil {
+		h.l.Warnf(ctx, "chat.delivery.http.GetMemory: processMemoryRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	o, err := h.uc.GetMemory(ctx, sc, req.toGetInput())
+	if err != nil {
+		h.respondChatError(c, "chat.delivery.http.GetMemory: usecase GetMemory failed", err)
+		return
+	}
+
+	response.OK(c, h.newMemoryResp(o))
+}
+
+// @Summary Reset conversation memory
+// @Description Clear the summary and forget earlier turns; later answers only use messages sent after the reset
+// @Tags Chat
+// @Produce json
+// @Param conversation_id path string true "Conversation ID"
+// @Success 200 {object} memoryResp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /conversations/{conversation_id}/memory [delete]
+func (h *handler) ResetMemory(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processMemoryRequest(c)
+	if err != nil {
+		h.l.Warnf(ctx, "chat.delivery.http.ResetMemory: processMemoryRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	o, err := h.uc.ResetMemory(ctx, sc, req.toResetInput())
+	if err != nil {
+		h.respondChatError(c, "chat.delivery.http.ResetMemory: usecase ResetMemory failed", err)
+		return
+	}
+
+	response.OK(c, h.newMemoryResp(o))
+}
@@ -1,16 +1,21 @@
 package http
 
 import (
+	"encoding/json"
 	"time"
 
 	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/factcheck"
+	"knowledge-srv/internal/tokenbudget"
 )
 
 type chatReq struct {
-	CampaignID     string         `json:"campaign_id" binding:"required"`
+	CampaignID     string         `json:"campaign_id" binding:"required_without=CampaignIDs"`
+	CampaignIDs    []string       `json:"campaign_ids,omitempty" binding:"omitempty,max=4,dive,required"`
 	ConversationID string         `json:"conversation_id,omitempty"`
 	Message        string         `json:"message" binding:"required,max=2000"`
 	Filters        *chatFilterReq `json:"filters,omitempty"`
+	SkipCache      bool           `json:"skip_cache,omitempty"`
 }
 
 type chatFilterReq struct {
@@ -25,29 +30,80 @@ type chatFilterReq struct {
 func (r chatReq) toInput() chat.ChatInput {
 	input := chat.ChatInput{
 		CampaignID:     r.CampaignID,
+		CampaignIDs:    r.CampaignIDs,
 		ConversationID: r.ConversationID,
 		Message:        r.Message,
+		SkipCache:      r.SkipCache,
 	}
 	if r.Filters != nil {
-		input.Filters = chat.ChatFilters{
-			Sentiments: r.Filters.Sentiments,
-			Aspects:    r.Filters.Aspects,
-			Platforms:  r.Filters.Platforms,
-			DateFrom:   r.Filters.DateFrom,
-			DateTo:     r.Filters.DateTo,
-			RiskLevels: r.Filters.RiskLevels,
-		}
+		input.Filters = r.Filters.toFilters()
 	}
 	return input
 }
 
+func (f chatFilterReq) toFilters() chat.ChatFilters {
+	return chat.ChatFilters{
+		Sentiments: f.Sentiments,
+		Aspects:    f.Aspects,
+		Platforms:  f.Platforms,
+		DateFrom:   f.DateFrom,
+		DateTo:     f.DateTo,
+		RiskLevels: f.RiskLevels,
+	}
+}
+
+type regenerateReq struct {
+	ConversationID string
+	MessageID      string
+}
+
+func (r regenerateReq) toInput() chat.RegenerateInput {
+	return chat.RegenerateInput{
+		ConversationID: r.ConversationID,
+		MessageID:      r.MessageID,
+	}
+}
+
+type editMessageReq struct {
+	ConversationID string         `json:"-"`
+	MessageID      string         `json:"-"`
+	Message        string         `json:"message" binding:"required,max=2000"`
+	Filters        *chatFilterReq `json:"filters,omitempty"`
+}
+
+func (r editMessageReq) toInput() chat.EditMessageInput {
+	input := chat.EditMessageInput{
+		ConversationID: r.ConversationID,
+		MessageID:      r.MessageID,
+		Message:        r.Message,
+	}
+	if r.Filters != nil {
+		input.Filters = r.Filters.toFilters()
+	}
+	return input
+}
+
+type switchBranchReq struct {
+	ConversationID string `json:"-"`
+	MessageID      string `json:"message_id" binding:"required"`
+}
+
+func (r switchBranchReq) toInput() chat.SwitchBranchInput {
+	return chat.SwitchBranchInput{
+		ConversationID: r.ConversationID,
+		MessageID:      r.MessageID,
+	}
+}
+
 type getConversationReq struct {
 	ConversationID string
+	ShareToken     string
 }
 
 func (r getConversationReq) toInput() chat.GetConversationInput {
 	return chat.GetConversationInput{
 		ConversationID: r.ConversationID,
+		ShareToken:     r.ShareToken,
 	}
 }
 
@@ -75,12 +131,65 @@ func (r getSuggestionsReq) toInput() chat.GetSuggestionsInput {
 	}
 }
 
+type answerCacheStatsReq struct {
+	CampaignID string
+}
+
+func (r answerCacheStatsReq) toInput() chat.AnswerCacheStatsInput {
+	return chat.AnswerCacheStatsInput{
+		CampaignID: r.CampaignID,
+	}
+}
+
+type memoryReq struct {
+	ConversationID string
+}
+
+func (r memoryReq) toGetInput() chat.GetMemoryInput {
+	return chat.GetMemoryInput{
+		ConversationID: r.ConversationID,
+	}
+}
+
+func (r memoryReq) toResetInput() chat.ResetMemoryInput {
+	return chat.ResetMemoryInput{
+		ConversationID: r.ConversationID,
+	}
+}
+
 type chatResp struct {
-	ConversationID string         `json:"conversation_id"`
-	Answer         string         `json:"answer"`
-	Citations      []citationResp `json:"citations"`
-	Suggestions    []string       `json:"suggestions"`
-	SearchMetadata searchMetaResp `json:"search_metadata"`
+	ConversationID string              `json:"conversation_id"`
+	MessageID      string              `json:"message_id,omitempty"`
+	QuestionID     string              `json:"question_id,omitempty"`
+	Answer         string              `json:"answer"`
+	Citations      []citationResp      `json:"citations"`
+	Suggestions    []string            `json:"suggestions"`
+	SearchMetadata searchMetaResp      `json:"search_metadata"`
+	Understanding  understandingResp   `json:"query_understanding"`
+	CacheHit       bool                `json:"cache_hit"`
+	CampaignIDs    []string            `json:"campaign_ids,omitempty"`
+	CitationGroups []citationGroupResp `json:"citation_groups,omitempty"`
+}
+
+type citationGroupResp struct {
+	CampaignID   string         `json:"campaign_id"`
+	CampaignName string         `json:"campaign_name,omitempty"`
+	Label        string         `json:"label"`
+	Citations    []citationResp `json:"citations"`
+}
+
+type understandingResp struct {
+	Intent           string   `json:"intent"`
+	Platforms        []string `json:"platforms,omitempty"`
+	Sentiments       []string `json:"sentiments,omitempty"`
+	Aspects          []string `json:"aspects,omitempty"`
+	DateFrom         *int64   `json:"date_from,omitempty"`
+	DateTo           *int64   `json:"date_to,omitempty"`
+	DateLabel        string   `json:"date_label,omitempty"`
+	RiskLevels       []string `json:"risk_levels,omitempty"`
+	ComparisonTarget string   `json:"comparison_target,omitempty"`
+	Source           string   `json:"source"`
+	CacheHit         bool     `json:"cache_hit"`
 }
 
 type citationResp struct {
@@ -90,41 +199,97 @@ type citationResp struct {
 	Platform       string  `json:"platform"`
 	Sentiment      string  `json:"sentiment"`
 	URL            string  `json:"url,omitempty"`
+	RecencyFactor  float64 `json:"recency_factor,omitempty"`
+	Layer          string  `json:"layer,omitempty"`
+	CampaignID     string  `json:"campaign_id,omitempty"`
 }
 
 type searchMetaResp struct {
-	TotalDocsSearched int    `json:"total_docs_searched"`
-	DocsUsed          int    `json:"docs_used"`
-	ProcessingTimeMs  int64  `json:"processing_time_ms"`
-	ModelUsed         string `json:"model_used"`
+	TotalDocsSearched int     `json:"total_docs_searched"`
+	SuppressedDocs    int     `json:"suppressed_docs,omitempty"`
+	DocsUsed          int     `json:"docs_used"`
+	ProcessingTimeMs  int64   `json:"processing_time_ms"`
+	ModelUsed         string  `json:"model_used"`
+	Groundedness      float64 `json:"groundedness"`
+	CacheHit          bool    `json:"cache_hit,omitempty"`
+
+	ToolCalls    []toolCallResp     `json:"tool_calls,omitempty"`
+	NumericCheck *factcheck.Result  `json:"numeric_check,omitempty"`
+	TokenUsage   *tokenbudget.Usage `json:"token_usage,omitempty"`
+	Campaigns    []campaignMetaResp `json:"campaigns,omitempty"`
+}
+
+type campaignMetaResp struct {
+	CampaignID   string `json:"campaign_id"`
+	CampaignName string `json:"campaign_name,omitempty"`
+	Label        string `json:"label"`
+	DocsSearched int    `json:"docs_searched"`
+	DocsUsed     int    `json:"docs_used"`
+	TotalDocs    uint64 `json:"total_docs"`
+}
+
+type toolCallResp struct {
+	ID         string          `json:"id"`
+	Tool       string          `json:"tool"`
+	Arguments  json.RawMessage `json:"arguments,omitempty"`
+	Result     string          `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	DurationMs int64           `json:"duration_ms"`
 }
 
 type conversationResp struct {
 	ID            string        `json:"id"`
 	CampaignID    string        `json:"campaign_id"`
+	CampaignIDs   []string      `json:"campaign_ids,omitempty"`
 	UserID        string        `json:"user_id"`
 	Title         string        `json:"title"`
 	Status        string        `json:"status"`
 	MessageCount  int           `json:"message_count"`
 	Messages      []messageResp `json:"messages,omitempty"`
+	ActiveLeafID  string        `json:"active_leaf_id,omitempty"`
 	LastMessageAt *time.Time    `json:"last_message_at,omitempty"`
 	CreatedAt     time.Time     `json:"created_at"`
 }
 
 type messageResp struct {
-	ID             string          `json:"id"`
-	Role           string          `json:"role"`
-	Content        string          `json:"content"`
-	Citations      []citationResp  `json:"citations,omitempty"`
-	SearchMetadata *searchMetaResp `json:"search_metadata,omitempty"`
-	Suggestions    []string        `json:"suggestions,omitempty"`
-	CreatedAt      time.Time       `json:"created_at"`
+	ID             string              `json:"id"`
+	ParentID       string              `json:"parent_id,omitempty"`
+	Role           string              `json:"role"`
+	Content        string              `json:"content"`
+	Citations      []citationResp      `json:"citations,omitempty"`
+	CitationGroups []citationGroupResp `json:"citation_groups,omitempty"`
+	SearchMetadata *searchMetaResp     `json:"search_metadata,omitempty"`
+	Suggestions    []string            `json:"suggestions,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	SiblingIDs     []string            `json:"sibling_ids,omitempty"`
+	SiblingCount   int                 `json:"sibling_count"`
+	SiblingIndex   int                 `json:"sibling_index"`
 }
 
 type suggestionsResp struct {
 	Suggestions []smartSuggestionResp `json:"suggestions"`
 }
 
+type answerCacheStatsResp struct {
+	CampaignID string  `json:"campaign_id"`
+	Enabled    bool    `json:"enabled"`
+	Hits       int64   `json:"hits"`
+	Misses     int64   `json:"misses"`
+	HitRate    float64 `json:"hit_rate"`
+	Stores     int64   `json:"stores"`
+	OptOuts    int64   `json:"opt_outs"`
+}
+
+type memoryResp struct {
+	ConversationID  string         `json:"conversation_id"`
+	Summary         string         `json:"summary"`
+	KeyFacts        []string       `json:"key_facts"`
+	ActiveFilters   *chatFilterReq `json:"active_filters,omitempty"`
+	CoveredMessages int            `json:"covered_messages"`
+	MessageCount    int            `json:"message_count"`
+	UpdatedAt       *time.Time     `json:"updated_at,omitempty"`
+}
+
 type smartSuggestionResp struct {
 	Query       string `json:"query"`
 	Category    string `json:"category"`
@@ -134,65 +299,135 @@ type smartSuggestionResp struct {
 func (h *handler) newChatResp(o chat.ChatOutput) chatResp {
 	resp := chatResp{
 		ConversationID: o.ConversationID,
+		MessageID:      o.MessageID,
+		QuestionID:     o.QuestionID,
 		Answer:         o.Answer,
 		Suggestions:    o.Suggestions,
-		SearchMetadata: searchMetaResp{
-			TotalDocsSearched: o.SearchMetadata.TotalDocsSearched,
-			DocsUsed:          o.SearchMetadata.DocsUsed,
-			ProcessingTimeMs:  o.SearchMetadata.ProcessingTimeMs,
-			ModelUsed:         o.SearchMetadata.ModelUsed,
+		SearchMetadata: newSearchMetaResp(o.SearchMetadata),
+		CacheHit:       o.CacheHit,
+		Understanding: understandingResp{
+			Intent:           o.Understanding.Intent,
+			Platforms:        o.Understanding.Platforms,
+			Sentiments:       o.Understanding.Sentiments,
+			Aspects:          o.Understanding.Aspects,
+			DateFrom:         o.Understanding.DateFrom,
+			DateTo:           o.Understanding.DateTo,
+			DateLabel:        o.Understanding.DateLabel,
+			RiskLevels:       o.Understanding.RiskLevels,
+			ComparisonTarget: o.Understanding.ComparisonTarget,
+			Source:           o.Understanding.Source,
+			CacheHit:         o.Understanding.CacheHit,
 		},
 	}
 	resp.Citations = make([]citationResp, len(o.Citations))
 	for i, c := range o.Citations {
-		resp.Citations[i] = citationResp{
-			ID:             c.ID,
-			Content:        c.Content,
-			RelevanceScore: c.RelevanceScore,
-			Platform:       c.Platform,
-			Sentiment:      c.Sentiment,
-			URL:            c.URL,
+		resp.Citations[i] = newCitationResp(c)
+	}
+	resp.CampaignIDs = o.CampaignIDs
+	resp.CitationGroups = newCitationGroupsResp(o.CitationGroups)
+	return resp
+}
+
+func newCitationResp(c chat.Citation) citationResp {
+	return citationResp{
+		ID:             c.ID,
+		Content:        c.Content,
+		RelevanceScore: c.RelevanceScore,
+		Platform:       c.Platform,
+		Sentiment:      c.Sentiment,
+		URL:            c.URL,
+		RecencyFactor:  c.RecencyFactor,
+		Layer:          c.Layer,
+		CampaignID:     c.CampaignID,
+	}
+}
+
+func newCitationGroupsResp(groups []chat.CitationGroup) []citationGroupResp {
+	if len(groups) == 0 {
+		return nil
+	}
+	resp := make([]citationGroupResp, len(groups))
+	for i, g := range groups {
+		resp[i] = citationGroupResp{
+			CampaignID:   g.CampaignID,
+			CampaignName: g.CampaignName,
+			Label:        g.Label,
+			Citations:    make([]citationResp, len(g.Citations)),
+		}
+		for j, c := range g.Citations {
+			resp[i].Citations[j] = newCitationResp(c)
 		}
 	}
 	return resp
 }
 
+func newSearchMetaResp(m chat.SearchMeta) searchMetaResp {
+	resp := searchMetaResp{
+		TotalDocsSearched: m.TotalDocsSearched,
+		SuppressedDocs:    m.SuppressedDocs,
+		DocsUsed:          m.DocsUsed,
+		ProcessingTimeMs:  m.ProcessingTimeMs,
+		ModelUsed:         m.ModelUsed,
+		Groundedness:      m.Groundedness,
+		CacheHit:          m.CacheHit,
+		NumericCheck:      m.NumericCheck,
+		TokenUsage:        m.TokenUsage,
+	}
+	for _, c := range m.ToolCalls {
+		resp.ToolCalls = append(resp.ToolCalls, toolCallResp{
+			ID:         c.ID,
+			Tool:       c.Tool,
+			Arguments:  c.Arguments,
+			Result:     c.Result,
+			Error:      c.Error,
+			DurationMs: c.DurationMs,
+		})
+	}
+	for _, c := range m.Campaigns {
+		resp.Campaigns = append(resp.Campaigns, campaignMetaResp{
+			CampaignID:   c.CampaignID,
+			CampaignName: c.CampaignName,
+			Label:        c.Label,
+			DocsSearched: c.DocsSearched,
+			DocsUsed:     c.DocsUsed,
+			TotalDocs:    c.TotalDocs,
+		})
+	}
+	return resp
+}
+
 func (h *handler) newConversationResp(o chat.ConversationOutput) conversationResp {
 	resp := conversationResp{
 		ID:            o.ID,
 		CampaignID:    o.CampaignID,
+		CampaignIDs:   o.CampaignIDs,
 		UserID:        o.UserID,
 		Title:         o.Title,
 		Status:        o.Status,
 		MessageCount:  o.MessageCount,
+		ActiveLeafID:  o.ActiveLeafID,
 		LastMessageAt: o.LastMessageAt,
 		CreatedAt:     o.CreatedAt,
 	}
 	for _, m := range o.Messages {
 		msgResp := messageResp{
-			ID:          m.ID,
-			Role:        m.Role,
-			Content:     m.Content,
-			Suggestions: m.Suggestions,
-			CreatedAt:   m.CreatedAt,
+			ID:           m.ID,
+			ParentID:     m.ParentID,
+			Role:         m.Role,
+			Content:      m.Content,
+			Suggestions:  m.Suggestions,
+			CreatedAt:    m.CreatedAt,
+			SiblingIDs:   m.SiblingIDs,
+			SiblingCount: m.SiblingCount,
+			SiblingIndex: m.SiblingIndex,
 		}
 		for _, c := range m.Citations {
-			msgResp.Citations = append(msgResp.Citations, citationResp{
-				ID:             c.ID,
-				Content:        c.Content,
-				RelevanceScore: c.RelevanceScore,
-				Platform:       c.Platform,
-				Sentiment:      c.Sentiment,
-				URL:            c.URL,
-			})
+			msgResp.Citations = append(msgResp.Citations, newCitationResp(c))
 		}
+		msgResp.CitationGroups = newCitationGroupsResp(m.CitationGroups)
 		if m.SearchMetadata != nil {
-			msgResp.SearchMetadata = &searchMetaResp{
-				TotalDocsSearched: m.SearchMetadata.TotalDocsSearched,
-				DocsUsed:          m.SearchMetadata.DocsUsed,
-				ProcessingTimeMs:  m.SearchMetadata.ProcessingTimeMs,
-				ModelUsed:         m.SearchMetadata.ModelUsed,
-			}
+			meta := newSearchMetaResp(*m.SearchMetadata)
+			msgResp.SearchMetadata = &meta
 		}
 		resp.Messages = append(resp.Messages, msgResp)
 	}
@@ -219,3 +454,40 @@ func (h *handler) newSuggestionsResp(o chat.SuggestionOutput) suggestionsResp {
 	}
 	return resp
 }
+
+func (h *handler) newAnswerCacheStatsResp(o chat.AnswerCacheStatsOutput) answerCacheStatsResp {
+	return answerCacheStatsResp{
+		CampaignID: o.CampaignID,
+		Enabled:    o.Enabled,
+		Hits:       o.Hits,
+		Misses:     o.Misses,
+		HitRate:    o.HitRate,
+		Stores:     o.Stores,
+		OptOuts:    o.OptOuts,
+	}
+}
+
+func (h *handler) newMemoryResp(o chat.MemoryOutput) memoryResp {
+	resp := memoryResp{
+		ConversationID:  o.ConversationID,
+		Summary:         o.Summary,
+		KeyFacts:        o.KeyFacts,
+		CoveredMessages: o.CoveredMessages,
+		MessageCount:    o.MessageCount,
+		UpdatedAt:       o.UpdatedAt,
+	}
+	if resp.KeyFacts == nil {
+		resp.KeyFacts = []string{}
+	}
+	if f := o.ActiveFilters; f != nil {
+		resp.ActiveFilters = &chatFilterReq{
+			Sentiments: f.Sentiments,
+			Aspects:    f.Aspects,
+			Platforms:  f.Platforms,
+			DateFrom:   f.DateFrom,
+			DateTo:     f.DateTo,
+			RiskLevels: f.RiskLevels,
+		}
+	}
+	return resp
+}
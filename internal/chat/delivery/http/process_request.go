@@ -33,6 +33,72 @@ func (h *handler) processChatRequest(c *gin.Context) (chatReq, model.Scope, erro
 func (h *handler) processGetConversationRequest(c *gin.Context) (getConversationReq, model.Scope, error) {
 	req := getConversationReq{
 		ConversationID: c.Param("conversation_id"),
+		ShareToken:     strings.TrimSpace(c.Query("share_token")),
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processMemoryRequest(c *gin.Context) (memoryReq, model.Scope, error) {
+	req := memoryReq{
+		ConversationID: strings.TrimSpace(c.Param("conversation_id")),
+	}
+	if req.ConversationID == "" {
+		return req, model.Scope{}, errConversationNotFound
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processRegenerateRequest(c *gin.Context) (regenerateReq, model.Scope, error) {
+	req := regenerateReq{
+		ConversationID: strings.TrimSpace(c.Param("conversation_id")),
+		MessageID:      strings.TrimSpace(c.Param("message_id")),
+	}
+	if req.ConversationID == "" {
+		return req, model.Scope{}, errConversationNotFound
+	}
+	if req.MessageID == "" {
+		return req, model.Scope{}, errMessageNotFound
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processEditMessageRequest(c *gin.Context) (editMessageReq, model.Scope, error) {
+	var req editMessageReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if strings.TrimSpace(req.Message) == "" {
+			return req, model.Scope{}, errMessageTooShort
+		}
+		return req, model.Scope{}, pkgErrors.NewHTTPError(400, "Invalid edit request")
+	}
+	req.ConversationID = strings.TrimSpace(c.Param("conversation_id"))
+	req.MessageID = strings.TrimSpace(c.Param("message_id"))
+	req.Message = strings.TrimSpace(req.Message)
+	if req.ConversationID == "" {
+		return req, model.Scope{}, errConversationNotFound
+	}
+	if req.MessageID == "" {
+		return req, model.Scope{}, errMessageNotFound
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processSwitchBranchRequest(c *gin.Context) (switchBranchReq, model.Scope, error) {
+	var req switchBranchReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, model.Scope{}, pkgErrors.NewHTTPError(400, "message_id is required")
+	}
+	req.ConversationID = strings.TrimSpace(c.Param("conversation_id"))
+	req.MessageID = strings.TrimSpace(req.MessageID)
+	if req.ConversationID == "" {
+		return req, model.Scope{}, errConversationNotFound
 	}
 
 	sc := auth.GetScopeFromContext(c.Request.Context())
@@ -53,6 +119,15 @@ func (h *handler) processListConversationsRequest(c *gin.Context) (listConversat
 	return req, model.ToScope(sc), nil
 }
 
+func (h *handler) processAnswerCacheStatsRequest(c *gin.Context) (answerCacheStatsReq, model.Scope, error) {
+	req := answerCacheStatsReq{
+		CampaignID: c.Param("campaign_id"),
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
 func (h *handler) processGetSuggestionsRequest(c *gin.Context) (getSuggestionsReq, model.Scope, error) {
 	req := getSuggestionsReq{
 		CampaignID: c.Param("campaign_id"),
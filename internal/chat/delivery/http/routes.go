@@ -10,7 +10,13 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup, mw *middleware.Middleware)
 	{
 		r.POST("/chat", h.Chat)
 		r.GET("/conversations/:conversation_id", h.GetConversation)
+		r.PUT("/conversations/:conversation_id/branch", h.SwitchBranch)
+		r.POST("/conversations/:conversation_id/messages/:message_id/regenerate", h.Regenerate)
+		r.POST("/conversations/:conversation_id/messages/:message_id/edit", h.EditMessage)
+		r.GET("/conversations/:conversation_id/memory", h.GetMemory)
+		r.DELETE("/conversations/:conversation_id/memory", h.ResetMemory)
 		r.GET("/campaigns/:campaign_id/conversations", h.ListConversations)
 		r.GET("/campaigns/:campaign_id/suggestions", h.GetSuggestions)
+		r.GET("/campaigns/:campaign_id/answer-cache/stats", h.AnswerCacheStats)
 	}
 }
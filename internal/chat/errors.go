@@ -10,4 +10,11 @@ var (
 	ErrLLMFailed            = errors.New("chat: LLM generation failed")
 	ErrSearchFailed         = errors.New("chat: search failed")
 	ErrConversationArchived = errors.New("chat: conversation is archived")
+	ErrMessageNotFound      = errors.New("chat: message not found")
+	ErrNotUserMessage       = errors.New("chat: only questions can be edited")
+	ErrForbidden            = errors.New("chat: forbidden")
+	ErrAnswerCacheStats     = errors.New("chat: failed to load answer cache stats")
+	ErrTooManyCampaigns     = errors.New("chat: too many campaigns to compare")
+	ErrCampaignAccess       = errors.New("chat: campaign not accessible")
+	ErrPurgeFailed          = errors.New("chat: campaign purge failed")
 )
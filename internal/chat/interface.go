@@ -11,4 +11,13 @@ type UseCase interface {
 	GetConversation(ctx context.Context, sc model.Scope, input GetConversationInput) (ConversationOutput, error)
 	ListConversations(ctx context.Context, sc model.Scope, input ListConversationsInput) ([]ConversationOutput, error)
 	GetSuggestions(ctx context.Context, sc model.Scope, input GetSuggestionsInput) (SuggestionOutput, error)
+	GetMemory(ctx context.Context, sc model.Scope, input GetMemoryInput) (MemoryOutput, error)
+	ResetMemory(ctx context.Context, sc model.Scope, input ResetMemoryInput) (MemoryOutput, error)
+	Regenerate(ctx context.Context, sc model.Scope, input RegenerateInput) (ChatOutput, error)
+	EditMessage(ctx context.Context, sc model.Scope, input EditMessageInput) (ChatOutput, error)
+	SwitchBranch(ctx context.Context, sc model.Scope, input SwitchBranchInput) (ConversationOutput, error)
+	// AnswerCacheStats returns the answer cache counters of a campaign (admin only)
+	AnswerCacheStats(ctx context.Context, sc model.Scope, input AnswerCacheStatsInput) (AnswerCacheStatsOutput, error)
+	// PurgeCampaign deletes a deleted campaign's conversations and their messages. Safe to re-run.
+	PurgeCampaign(ctx context.Context, campaignID string) (PurgeCampaignOutput, error)
 }
@@ -7,4 +7,5 @@ var (
 	ErrFailedToGet    = errors.New("failed to get")
 	ErrFailedToList   = errors.New("failed to list")
 	ErrFailedToUpdate = errors.New("failed to update")
+	ErrFailedToDelete = errors.New("failed to delete")
 )
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"knowledge-srv/internal/model"
+	"time"
 )
 
 //go:generate mockery --name PostgresRepository
@@ -18,10 +19,58 @@ type ConversationRepository interface {
 	ListConversations(ctx context.Context, opt ListConversationsOptions) ([]model.Conversation, error)
 	UpdateConversationLastMessage(ctx context.Context, opt UpdateLastMessageOptions) error
 	ArchiveConversation(ctx context.Context, id string) error
+	// UpdateConversationMemory stores a new summary unless the memory changed since it
+	// was read (ExpectedMessageCount); a stale write is skipped without error.
+	UpdateConversationMemory(ctx context.Context, opt UpdateConversationMemoryOptions) error
+	// ResetConversationMemory clears the summary and marks the first MessageCount
+	// messages of the active branch as forgotten.
+	ResetConversationMemory(ctx context.Context, opt ResetConversationMemoryOptions) error
+	// SetActiveLeaf switches the branch a conversation shows and continues.
+	SetActiveLeaf(ctx context.Context, opt SetActiveLeafOptions) error
+	// DeleteCampaignConversations deletes every conversation of a campaign (messages cascade),
+	// returning the conversations removed.
+	DeleteCampaignConversations(ctx context.Context, campaignID string) (int64, error)
 }
 
 // MessageRepository - Interface cho message CRUD
 type MessageRepository interface {
 	CreateMessage(ctx context.Context, opt CreateMessageOptions) (model.Message, error)
+	// GetMessage returns a message of the conversation (zero value when not found).
+	GetMessage(ctx context.Context, opt GetMessageOptions) (model.Message, error)
 	ListMessages(ctx context.Context, opt ListMessagesOptions) ([]model.Message, error)
 }
+
+//go:generate mockery --name CacheRepository
+type CacheRepository interface {
+	// GetUnderstanding returns the cached understanding of a normalized query (redis.Nil on miss).
+	GetUnderstanding(ctx context.Context, queryKey string) ([]byte, error)
+	SaveUnderstanding(ctx context.Context, queryKey string, data []byte, ttl time.Duration) error
+
+	// ListCachedAnswers returns the answers cached for a campaign and filter set,
+	// pruning index entries whose answer expired or was evicted.
+	ListCachedAnswers(ctx context.Context, opt AnswerScopeOptions) ([][]byte, error)
+	// SaveCachedAnswer stores an answer and returns its cache key; the oldest answers
+	// beyond MaxEntries are dropped.
+	SaveCachedAnswer(ctx context.Context, opt SaveCachedAnswerOptions) (string, error)
+	// DeleteCachedAnswer removes an answer saved under key from the campaign and filter set.
+	DeleteCachedAnswer(ctx context.Context, opt AnswerScopeOptions, key string) error
+	// IncrAnswerCacheStat bumps a per-campaign counter (best-effort, never fails the caller).
+	IncrAnswerCacheStat(ctx context.Context, campaignID, field string)
+	GetAnswerCacheStats(ctx context.Context, campaignID string) (AnswerCacheStats, error)
+}
+
+// Answer cache counters, per campaign.
+const (
+	AnswerCacheStatHit    = "hit"
+	AnswerCacheStatMiss   = "miss"
+	AnswerCacheStatStore  = "store"
+	AnswerCacheStatOptOut = "opt_out" // requests that skipped the cache
+)
+
+// AnswerCacheStats - Cumulative answer cache counters of a campaign
+type AnswerCacheStats struct {
+	Hits    int64
+	Misses  int64
+	Stores  int64
+	OptOuts int64
+}
@@ -1,11 +1,15 @@
 package repository
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 type CreateConversationOptions struct {
-	CampaignID string
-	UserID     string
-	Title      string
+	CampaignID  string
+	CampaignIDs []string // every campaign of the first turn, CampaignID first
+	UserID      string
+	Title       string
 }
 
 type ListConversationsOptions struct {
@@ -19,10 +23,32 @@ type ListConversationsOptions struct {
 type UpdateLastMessageOptions struct {
 	ConversationID string
 	MessageCount   int
+	ActiveLeafID   string   // new last message of the active branch
+	CampaignIDs    []string // campaigns involved so far, replaces the stored list when set
+}
+
+type SetActiveLeafOptions struct {
+	ConversationID string
+	LeafID         string
+}
+
+type ResetConversationMemoryOptions struct {
+	ConversationID string
+	MessageCount   int // leading branch messages to mark as covered (forgotten)
+}
+
+type UpdateConversationMemoryOptions struct {
+	ConversationID       string
+	Summary              string
+	KeyFacts             []string
+	ActiveFilters        json.RawMessage
+	MessageCount         int // messages covered by the new summary
+	ExpectedMessageCount int // memory_message_count the summary was built on
 }
 
 type CreateMessageOptions struct {
 	ConversationID string
+	ParentID       string // empty for the first question of a conversation
 	Role           string
 	Content        string
 	Citations      json.RawMessage
@@ -31,8 +57,28 @@ type CreateMessageOptions struct {
 	FiltersUsed    json.RawMessage
 }
 
+type GetMessageOptions struct {
+	ConversationID string
+	ID             string
+}
+
 type ListMessagesOptions struct {
 	ConversationID string
 	Limit          int
 	OrderASC       bool
 }
+
+// AnswerScopeOptions - Cached answers are shared by a campaign and filter set
+type AnswerScopeOptions struct {
+	CampaignID string
+	FiltersKey string // hash of the filters the answer was retrieved with
+}
+
+type SaveCachedAnswerOptions struct {
+	CampaignID  string
+	FiltersKey  string
+	QuestionKey string // hash of the normalized question; the same question overwrites
+	Data        []byte
+	TTL         time.Duration
+	MaxEntries  int // per campaign and filter set
+}
@@ -68,6 +68,12 @@ func (r *implRepository) UpdateConversationLastMessage(ctx context.Context, opt
 
 	now := time.Now()
 	dbConv.MessageCount = opt.MessageCount
+	if opt.ActiveLeafID != "" {
+		dbConv.ActiveLeafID = null.StringFrom(opt.ActiveLeafID)
+	}
+	if len(opt.CampaignIDs) > 0 {
+		dbConv.CampaignIds = opt.CampaignIDs
+	}
 	dbConv.LastMessageAt = null.TimeFrom(now)
 	dbConv.UpdatedAt = null.TimeFrom(now)
 
@@ -99,3 +105,77 @@ func (r *implRepository) ArchiveConversation(ctx context.Context, id string) err
 
 	return nil
 }
+
+// DeleteCampaignConversations - Delete every conversation of a campaign (messages cascade)
+func (r *implRepository) DeleteCampaignConversations(ctx context.Context, campaignID string) (int64, error) {
+	n, err := sqlboiler.Conversations(sqlboiler.ConversationWhere.CampaignID.EQ(campaignID)).DeleteAll(ctx, r.db)
+	if err != nil {
+		r.l.Errorf(ctx, "chat.repository.postgre.DeleteCampaignConversations: Failed to delete conversations: %v", err)
+		return 0, repository.ErrFailedToDelete
+	}
+	return n, nil
+}
+
+// UpdateConversationMemory - Store the rolling summary if nobody changed it meanwhile
+func (r *implRepository) UpdateConversationMemory(ctx context.Context, opt repository.UpdateConversationMemoryOptions) error {
+	cols, err := buildConversationMemoryCols(opt)
+	if err != nil {
+		r.l.Errorf(ctx, "chat.repository.postgre.UpdateConversationMemory: Failed to encode memory: %v", err)
+		return repository.ErrFailedToUpdate
+	}
+
+	n, err := sqlboiler.Conversations(
+		sqlboiler.ConversationWhere.ID.EQ(opt.ConversationID),
+		sqlboiler.ConversationWhere.MemoryMessageCount.EQ(opt.ExpectedMessageCount),
+	).UpdateAll(ctx, r.db, cols)
+	if err != nil {
+		r.l.Errorf(ctx, "chat.repository.postgre.UpdateConversationMemory: Failed to update memory: %v", err)
+		return repository.ErrFailedToUpdate
+	}
+	if n == 0 {
+		r.l.Infof(ctx, "chat.repository.postgre.UpdateConversationMemory: memory of %s changed meanwhile, skipping", opt.ConversationID)
+	}
+
+	return nil
+}
+
+// ResetConversationMemory - Clear the summary and forget the leading branch messages
+func (r *implRepository) ResetConversationMemory(ctx context.Context, opt repository.ResetConversationMemoryOptions) error {
+	dbConv, err := sqlboiler.FindConversation(ctx, r.db, opt.ConversationID)
+	if err != nil {
+		r.l.Errorf(ctx, "chat.repository.postgre.ResetConversationMemory: Failed to find conversation: %v", err)
+		return repository.ErrFailedToUpdate
+	}
+
+	now := time.Now()
+	dbConv.MemorySummary = null.String{}
+	dbConv.MemoryKeyFacts = null.JSON{}
+	dbConv.MemoryActiveFilters = null.JSON{}
+	dbConv.MemoryMessageCount = opt.MessageCount
+	dbConv.MemoryUpdatedAt = null.TimeFrom(now)
+	dbConv.UpdatedAt = null.TimeFrom(now)
+
+	_, err = dbConv.Update(ctx, r.db, boil.Infer())
+	if err != nil {
+		r.l.Errorf(ctx, "chat.repository.postgre.ResetConversationMemory: Failed to reset memory: %v", err)
+		return repository.ErrFailedToUpdate
+	}
+
+	return nil
+}
+
+// SetActiveLeaf - Point the conversation at another branch
+func (r *implRepository) SetActiveLeaf(ctx context.Context, opt repository.SetActiveLeafOptions) error {
+	_, err := sqlboiler.Conversations(
+		sqlboiler.ConversationWhere.ID.EQ(opt.ConversationID),
+	).UpdateAll(ctx, r.db, sqlboiler.M{
+		sqlboiler.ConversationColumns.ActiveLeafID: null.StringFrom(opt.LeafID),
+		sqlboiler.ConversationColumns.UpdatedAt:    null.TimeFrom(time.Now()),
+	})
+	if err != nil {
+		r.l.Errorf(ctx, "chat.repository.postgre.SetActiveLeaf: Failed to update conversation: %v", err)
+		return repository.ErrFailedToUpdate
+	}
+
+	return nil
+}
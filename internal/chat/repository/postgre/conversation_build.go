@@ -1,6 +1,7 @@
 package postgre
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/aarondl/null/v8"
@@ -13,8 +14,14 @@ import (
 func buildCreateConversation(opt repository.CreateConversationOptions) *sqlboiler.Conversation {
 	now := time.Now()
 
+	campaignIDs := opt.CampaignIDs
+	if len(campaignIDs) == 0 {
+		campaignIDs = []string{opt.CampaignID}
+	}
+
 	return &sqlboiler.Conversation{
 		CampaignID:   opt.CampaignID,
+		CampaignIds:  campaignIDs,
 		UserID:       opt.UserID,
 		Title:        opt.Title,
 		Status:       "ACTIVE",
@@ -23,3 +30,27 @@ func buildCreateConversation(opt repository.CreateConversationOptions) *sqlboile
 		UpdatedAt:    null.TimeFrom(now),
 	}
 }
+
+// buildConversationMemoryCols - Columns written by UpdateConversationMemory
+func buildConversationMemoryCols(opt repository.UpdateConversationMemoryOptions) (sqlboiler.M, error) {
+	keyFacts := null.JSON{}
+	if len(opt.KeyFacts) > 0 {
+		data, err := json.Marshal(opt.KeyFacts)
+		if err != nil {
+			return nil, err
+		}
+		keyFacts = null.JSONFrom(data)
+	}
+	filters := null.JSON{}
+	if len(opt.ActiveFilters) > 0 && string(opt.ActiveFilters) != "null" {
+		filters = null.JSONFrom(opt.ActiveFilters)
+	}
+
+	return sqlboiler.M{
+		sqlboiler.ConversationColumns.MemorySummary:       null.NewString(opt.Summary, opt.Summary != ""),
+		sqlboiler.ConversationColumns.MemoryKeyFacts:      keyFacts,
+		sqlboiler.ConversationColumns.MemoryActiveFilters: filters,
+		sqlboiler.ConversationColumns.MemoryMessageCount:  opt.MessageCount,
+		sqlboiler.ConversationColumns.MemoryUpdatedAt:     null.TimeFrom(time.Now()),
+	}, nil
+}
@@ -10,9 +10,9 @@ import (
 func (r *implRepository) buildListConversationsQuery(opt repository.ListConversationsOptions) []qm.QueryMod {
 	mods := []qm.QueryMod{}
 
-	// Required filters
+	// Required filters: the campaign's own conversations and those that compared it
 	if opt.CampaignID != "" {
-		mods = append(mods, qm.Where("campaign_id = ?", opt.CampaignID))
+		mods = append(mods, qm.Where("(campaign_id = ? OR campaign_ids @> ARRAY[?]::text[])", opt.CampaignID, opt.CampaignID))
 	}
 	if opt.UserID != "" {
 		mods = append(mods, qm.Where("user_id = ?", opt.UserID))
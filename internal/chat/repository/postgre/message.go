@@ -2,6 +2,7 @@ package postgre
 
 import (
 	"context"
+	"database/sql"
 	"knowledge-srv/internal/chat/repository"
 	"knowledge-srv/internal/model"
 	"knowledge-srv/internal/sqlboiler"
@@ -25,6 +26,26 @@ func (r *implRepository) CreateMessage(ctx context.Context, opt repository.Creat
 	return model.Message{}, nil
 }
 
+// GetMessage - Get a message of a conversation by primary key
+func (r *implRepository) GetMessage(ctx context.Context, opt repository.GetMessageOptions) (model.Message, error) {
+	dbMsg, err := sqlboiler.Messages(
+		sqlboiler.MessageWhere.ID.EQ(opt.ID),
+		sqlboiler.MessageWhere.ConversationID.EQ(opt.ConversationID),
+	).One(ctx, r.db)
+	if err == sql.ErrNoRows {
+		return model.Message{}, nil // Not found
+	}
+	if err != nil {
+		r.l.Errorf(ctx, "chat.repository.postgre.GetMessage: Failed to get message: %v", err)
+		return model.Message{}, repository.ErrFailedToGet
+	}
+
+	if msg := model.NewMessageFromDB(dbMsg); msg != nil {
+		return *msg, nil
+	}
+	return model.Message{}, nil
+}
+
 // ListMessages - List messages by conversation
 func (r *implRepository) ListMessages(ctx context.Context, opt repository.ListMessagesOptions) ([]model.Message, error) {
 	mods := r.buildListMessagesQuery(opt)
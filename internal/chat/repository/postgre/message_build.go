@@ -14,6 +14,9 @@ func buildCreateMessage(opt repository.CreateMessageOptions) *sqlboiler.Message
 		Role:           opt.Role,
 		Content:        opt.Content,
 	}
+	if opt.ParentID != "" {
+		dbMsg.ParentID = null.StringFrom(opt.ParentID)
+	}
 
 	// Handle nullable JSON fields
 	if len(opt.Citations) > 0 && string(opt.Citations) != "null" {
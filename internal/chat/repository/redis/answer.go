@@ -0,0 +1,128 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"knowledge-srv/internal/chat/repository"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Answer cache layout: one key per answer, a sorted set per campaign and filter set
+// indexing them by age, and a counter hash per campaign. The usecase has the search
+// domain tag each answer with its projects, so re-indexing them evicts it.
+const (
+	answerKeyPrefix      = "chat:answer:"
+	answerIndexKeyPrefix = "chat:answer_idx:"
+	answerStatsKeyPrefix = "chat:answer_stats:"
+)
+
+func answerIndexKey(campaignID, filtersKey string) string {
+	return fmt.Sprintf("%s%s:%s", answerIndexKeyPrefix, campaignID, filtersKey)
+}
+
+func answerKey(campaignID, filtersKey, questionKey string) string {
+	return fmt.Sprintf("%s%s:%s:%s", answerKeyPrefix, campaignID, filtersKey, questionKey)
+}
+
+func (r *implCacheRepository) ListCachedAnswers(ctx context.Context, opt repository.AnswerScopeOptions) ([][]byte, error) {
+	client := r.redis.GetClient()
+	indexKey := answerIndexKey(opt.CampaignID, opt.FiltersKey)
+
+	keys, err := client.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	values, err := client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make([][]byte, 0, len(values))
+	var stale []interface{}
+	for i, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			stale = append(stale, keys[i])
+			continue
+		}
+		answers = append(answers, []byte(data))
+	}
+	if len(stale) > 0 {
+		if err := client.ZRem(ctx, indexKey, stale...).Err(); err != nil {
+			r.l.Debugf(ctx, "chat.repository.redis.ListCachedAnswers: Failed to prune index: %v", err)
+		}
+	}
+	return answers, nil
+}
+
+func (r *implCacheRepository) SaveCachedAnswer(ctx context.Context, opt repository.SaveCachedAnswerOptions) (string, error) {
+	client := r.redis.GetClient()
+	indexKey := answerIndexKey(opt.CampaignID, opt.FiltersKey)
+	key := answerKey(opt.CampaignID, opt.FiltersKey, opt.QuestionKey)
+
+	pipe := client.TxPipeline()
+	pipe.Set(ctx, key, opt.Data, opt.TTL)
+	pipe.ZAdd(ctx, indexKey, goredis.Z{Score: float64(time.Now().Unix()), Member: key})
+	pipe.Expire(ctx, indexKey, opt.TTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.l.Warnf(ctx, "chat.repository.redis.SaveCachedAnswer: Failed to save to cache: %v", err)
+		return "", err
+	}
+
+	// Keep the newest MaxEntries answers of the index
+	if opt.MaxEntries <= 0 {
+		return key, nil
+	}
+	oldest, err := client.ZRange(ctx, indexKey, 0, int64(-opt.MaxEntries-1)).Result()
+	if err != nil || len(oldest) == 0 {
+		return key, nil
+	}
+	pipe = client.TxPipeline()
+	pipe.Del(ctx, oldest...)
+	pipe.ZRemRangeByRank(ctx, indexKey, 0, int64(len(oldest)-1))
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.l.Debugf(ctx, "chat.repository.redis.SaveCachedAnswer: Failed to trim index: %v", err)
+	}
+	return key, nil
+}
+
+func (r *implCacheRepository) DeleteCachedAnswer(ctx context.Context, opt repository.AnswerScopeOptions, key string) error {
+	pipe := r.redis.GetClient().TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.ZRem(ctx, answerIndexKey(opt.CampaignID, opt.FiltersKey), key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.l.Warnf(ctx, "chat.repository.redis.DeleteCachedAnswer: cache unavailable: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *implCacheRepository) IncrAnswerCacheStat(ctx context.Context, campaignID, field string) {
+	if err := r.redis.GetClient().HIncrBy(ctx, answerStatsKeyPrefix+campaignID, field, 1).Err(); err != nil {
+		r.l.Debugf(ctx, "chat.repository.redis.IncrAnswerCacheStat: Failed to bump %s: %v", field, err)
+	}
+}
+
+func (r *implCacheRepository) GetAnswerCacheStats(ctx context.Context, campaignID string) (repository.AnswerCacheStats, error) {
+	values, err := r.redis.GetClient().HGetAll(ctx, answerStatsKeyPrefix+campaignID).Result()
+	if err != nil && err != goredis.Nil {
+		return repository.AnswerCacheStats{}, err
+	}
+	counter := func(field string) int64 {
+		n, _ := strconv.ParseInt(values[field], 10, 64)
+		return n
+	}
+	return repository.AnswerCacheStats{
+		Hits:    counter(repository.AnswerCacheStatHit),
+		Misses:  counter(repository.AnswerCacheStatMiss),
+		Stores:  counter(repository.AnswerCacheStatStore),
+		OptOuts: counter(repository.AnswerCacheStatOptOut),
+	}, nil
+}
@@ -0,0 +1,24 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+const understandingKeyPrefix = "chat:understanding:"
+
+func (r *implCacheRepository) GetUnderstanding(ctx context.Context, queryKey string) ([]byte, error) {
+	data, err := r.redis.GetClient().Get(ctx, understandingKeyPrefix+queryKey).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *implCacheRepository) SaveUnderstanding(ctx context.Context, queryKey string, data []byte, ttl time.Duration) error {
+	if err := r.redis.GetClient().Set(ctx, understandingKeyPrefix+queryKey, data, ttl).Err(); err != nil {
+		r.l.Warnf(ctx, "chat.repository.redis.SaveUnderstanding: Failed to save to cache: %v", err)
+		return err
+	}
+	return nil
+}
@@ -1,21 +1,37 @@
 package chat
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"knowledge-srv/internal/factcheck"
+	"knowledge-srv/internal/tokenbudget"
+)
 
 const (
-	MaxHistoryMessages = 20
-	MaxSearchDocs      = 10
-	MaxDocContentLen   = 500
-	MinMessageLength   = 3
-	MaxMessageLength   = 2000
-	MaxTokenWindow     = 28000
+	MaxHistoryMessages  = 20
+	MaxSearchDocs       = 10
+	MaxDocContentTokens = 250 // evidence snippets are cut to this
+	MinMessageLength    = 3
+	MaxMessageLength    = 2000
+	MaxTokenWindow      = 28000 // default prompt token budget
+	MaxCompareCampaigns = 4     // campaigns one comparative turn may cover
+
+	// Query understanding sources
+	UnderstandingSourceLLM     = "LLM"
+	UnderstandingSourceKeyword = "KEYWORD"
 )
 
 type ChatInput struct {
-	CampaignID     string
+	CampaignID string
+	// CampaignIDs compares several campaigns in one turn, CampaignID included. Empty
+	// keeps the campaigns of the conversation; a single campaign is a plain turn.
+	CampaignIDs    []string
 	ConversationID string
 	Message        string
 	Filters        ChatFilters
+	// SkipCache always answers afresh, neither reading nor filling the answer cache.
+	SkipCache bool
 }
 
 type ChatFilters struct {
@@ -29,6 +45,7 @@ type ChatFilters struct {
 
 type GetConversationInput struct {
 	ConversationID string
+	ShareToken     string // link share token, for readers other than the owner
 }
 
 type ListConversationsInput struct {
@@ -41,14 +58,78 @@ type GetSuggestionsInput struct {
 	CampaignID string
 }
 
+type GetMemoryInput struct {
+	ConversationID string
+}
+
+type ResetMemoryInput struct {
+	ConversationID string
+}
+
+// RegenerateInput - Answer a question again. MessageID is the answer to replace
+// (or its question); the new answer becomes its sibling.
+type RegenerateInput struct {
+	ConversationID string
+	MessageID      string
+}
+
+// EditMessageInput - Ask an edited question in place of MessageID, a user message.
+// The edited question starts a new branch beside the original one.
+type EditMessageInput struct {
+	ConversationID string
+	MessageID      string
+	Message        string
+	Filters        ChatFilters
+}
+
+// SwitchBranchInput - Show the branch through MessageID, continued to its latest message.
+type SwitchBranchInput struct {
+	ConversationID string
+	MessageID      string
+}
+
 type ChatOutput struct {
 	ConversationID string
+	MessageID      string // the answer, empty when it could not be stored
+	QuestionID     string
 	Answer         string
 	Citations      []Citation
 	Suggestions    []string
 	SearchMetadata SearchMeta
 	Backend        string
 	QueryIntent    string
+	Understanding  QueryUnderstanding
+	// CacheHit marks an answer served from the answer cache: a near-identical
+	// question asked on the campaign with the same filters.
+	CacheHit bool
+	// CampaignIDs are the campaigns a comparative turn covered and CitationGroups
+	// its citations split by campaign, in that order. Both are nil on one campaign.
+	CampaignIDs    []string
+	CitationGroups []CitationGroup
+}
+
+// CitationGroup - The citations of one campaign in a comparative answer.
+type CitationGroup struct {
+	CampaignID   string
+	CampaignName string
+	Label        string // "A", "B", ... as the answer refers to it
+	Citations    []Citation
+}
+
+// QueryUnderstanding - Intent and filters extracted from a chat message.
+// Explicit ChatInput.Filters always win over extracted ones.
+type QueryUnderstanding struct {
+	Intent           string
+	Platforms        []string
+	Sentiments       []string
+	Aspects          []string
+	DateFrom         *int64 // unix seconds
+	DateTo           *int64
+	DateLabel        string // e.g. "previous month", "last 7 days"
+	RiskLevels       []string
+	ComparisonTarget string // what the user compares against (competitor, period...), empty if none
+	Source           string // UnderstandingSourceLLM | UnderstandingSourceKeyword
+	CacheHit         bool
 }
 
 type Citation struct {
@@ -58,36 +139,96 @@ type Citation struct {
 	Platform       string
 	Sentiment      string
 	URL            string
+	RecencyFactor  float64 // time-decay multiplier applied to RelevanceScore (0 when not ranked by recency)
+	Layer          string  // search.LayerPost | LayerInsight | LayerDigest
+	CampaignID     string  // set in comparative answers only
 }
 
 type SearchMeta struct {
 	TotalDocsSearched int
+	SuppressedDocs    int // candidates dropped as near-duplicates by diversity selection
 	DocsUsed          int
 	ProcessingTimeMs  int64
 	ModelUsed         string
+	ToolCalls         []ToolCall // agent tool trace, empty for plain retrieval answers
+	// Groundedness is the share of answer sentences carrying a valid citation marker (0-1).
+	Groundedness float64
+	// NumericCheck is the verification of the answer's figures, nil when it had none.
+	NumericCheck *factcheck.Result
+	// TokenUsage is the estimated size of the retrieval prompt by section, nil for
+	// agent and fallback answers.
+	TokenUsage *tokenbudget.Usage
+	// CacheHit - The answer was copied from the answer cache; the other fields
+	// describe the run that produced it, except ProcessingTimeMs.
+	CacheHit bool
+	// Campaigns is the retrieval per campaign of a comparative answer, nil otherwise.
+	Campaigns []CampaignMeta
+}
+
+// CampaignMeta - Retrieval for one campaign of a comparative answer.
+type CampaignMeta struct {
+	CampaignID   string
+	CampaignName string
+	Label        string
+	DocsSearched int
+	DocsUsed     int
+	TotalDocs    uint64 // documents matching the filters, from the side-by-side aggregates
+}
+
+// ToolCall - One tool invocation of the agent loop. The answer cites its output as [ID].
+type ToolCall struct {
+	ID         string // "T1", "T2", ...
+	Tool       string
+	Arguments  json.RawMessage
+	Result     string // tool output as shown to the model
+	Error      string
+	DurationMs int64
 }
 
 type ConversationOutput struct {
-	ID            string
-	CampaignID    string
+	ID          string
+	CampaignID  string
+	CampaignIDs []string // every campaign the conversation involved
+
 	UserID        string
 	Title         string
 	Status        string
-	MessageCount  int
-	Messages      []MessageOutput
+	MessageCount  int             // messages on every branch
+	Messages      []MessageOutput // the active branch, oldest first
+	ActiveLeafID  string
 	LastMessageAt *time.Time
 	CreatedAt     time.Time
 }
 
 type MessageOutput struct {
 	ID             string
+	ParentID       string
 	Role           string
 	Content        string
 	Citations      []Citation
+	CitationGroups []CitationGroup // comparative answers only
 	SearchMetadata *SearchMeta
 	Suggestions    []string
 	FiltersUsed    *ChatFilters
 	CreatedAt      time.Time
+
+	// Siblings are the alternatives to this message: regenerated answers or edited
+	// questions sharing its parent, oldest first, the message itself included.
+	SiblingIDs   []string
+	SiblingCount int
+	SiblingIndex int // position among the siblings, from 0
+}
+
+// MemoryOutput - Rolling memory of a conversation: the summary of its older turns,
+// the facts kept from them and the filters in effect when it was written.
+type MemoryOutput struct {
+	ConversationID  string
+	Summary         string
+	KeyFacts        []string
+	ActiveFilters   *ChatFilters
+	CoveredMessages int // leading messages summarized, or forgotten by a reset
+	MessageCount    int
+	UpdatedAt       *time.Time
 }
 
 type SuggestionOutput struct {
@@ -99,3 +240,25 @@ type SmartSuggestion struct {
 	Category    string
 	Description string
 }
+
+// AnswerCacheStatsInput - Answer cache counters of one campaign
+type AnswerCacheStatsInput struct {
+	CampaignID string
+}
+
+// AnswerCacheStatsOutput - Cumulative answer cache counters of a campaign. Requests
+// that skipped the cache count in OptOuts only.
+type AnswerCacheStatsOutput struct {
+	CampaignID string
+	Enabled    bool
+	Hits       int64
+	Misses     int64
+	HitRate    float64 // Hits / (Hits + Misses)
+	Stores     int64
+	OptOuts    int64
+}
+
+// PurgeCampaignOutput - What PurgeCampaign removed
+type PurgeCampaignOutput struct {
+	Conversations int64
+}
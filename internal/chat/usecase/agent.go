@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
+)
+
+const agentToolsPrompt = `Bạn có các công cụ truy vấn dữ liệu campaign. Mỗi lượt trả lời đúng MỘT JSON object, không kèm chữ nào khác:
+- Gọi công cụ: {"tool": "<tên>", "arguments": {...}}
+- Trả lời cuối: {"final_answer": "<câu trả lời>"}
+Công cụ:
+- search_posts {"query": string, "limit": 1-10, "filters": Filters}: tìm bài viết/bình luận liên quan, kết quả đánh số [n]
+- aggregate {"filters": Filters}: tổng số documents, phân bổ sentiment và nền tảng, aspect tiêu cực nhiều nhất
+- time_series {"interval": "day"|"week"|"month", "filters": Filters}: số documents và sentiment theo thời gian (mặc định 30 ngày gần nhất)
+- analytics_snapshot {}: KPI dashboard (mentions, engagement, sentiment, nền tảng, chủ đề)
+- macro_insights {"query": string, "limit": 1-5}: digest và insight card cấp campaign, đánh số [n]
+Filters = {"platforms": ["TIKTOK"|"FACEBOOK"|"YOUTUBE"], "sentiments": ["POSITIVE"|"NEGATIVE"|"NEUTRAL"|"MIXED"], "aspects": ["UPPER_SNAKE_CASE"], "risk_levels": ["LOW"|"MEDIUM"|"HIGH"|"CRITICAL"], "date_range": null | {"kind": "last_n", "unit": "day|week|month|year", "amount": 7} | {"kind": "current"|"previous", "unit": "day|week|month|year"} | {"kind": "absolute", "from": "YYYY-MM-DD", "to": "YYYY-MM-DD"}}
+Quy tắc:
+- Số liệu, tỷ lệ, so sánh phải lấy từ kết quả công cụ; không tự ước lượng
+- Trong final_answer, trích dẫn kết quả công cụ bằng [T1], [T2], ... và documents bằng [1], [2], ...
+- Không gọi lại công cụ với cùng tham số; khi đã đủ dữ liệu thì trả lời ngay`
+
+const (
+	// agentTimeout bounds the whole loop, tool calls included.
+	agentTimeout = 90 * time.Second
+	// maxToolArgumentBytes bounds the arguments kept in the tool trace.
+	maxToolArgumentBytes = 1024
+)
+
+var (
+	errAgentNoAnswer     = errors.New("agent did not answer within the step budget")
+	errAgentInvalidReply = errors.New("invalid agent reply")
+)
+
+// agentReply - JSON contract of one agent turn: a tool call or the final answer.
+type agentReply struct {
+	Tool        string          `json:"tool"`
+	Arguments   json.RawMessage `json:"arguments"`
+	FinalAnswer string          `json:"final_answer"`
+}
+
+// useAgent - Counting, ranking and comparison questions go through the tool loop;
+// narrative ones stay on plain retrieval.
+func (uc *implUseCase) useAgent(u chat.QueryUnderstanding) bool {
+	return uc.config.AgentEnabled && uc.llm != nil &&
+		(u.Intent == string(IntentStructured) || u.ComparisonTarget != "")
+}
+
+// agentChat answers with the tool loop and persists the exchange, the tool trace
+// included. Returns false when the loop fails so the caller can fall back to retrieval.
+func (uc *implUseCase) agentChat(
+	ctx context.Context,
+	sc model.Scope,
+	conversation model.Conversation,
+	history chatHistory,
+	input chat.ChatInput,
+	explicit search.SearchFilters,
+	u chat.QueryUnderstanding,
+	startTime time.Time,
+) (chat.ChatOutput, bool) {
+	run := newAgentRun(sc, input, explicit)
+	answer, err := uc.runAgent(ctx, run, history, u)
+	if err != nil {
+		uc.l.Warnf(ctx, "chat.usecase.agentChat: falling back to retrieval after %d tool calls: %v", len(run.calls), err)
+		return chat.ChatOutput{}, false
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	answer, numericCheck := uc.checkFigures(checkCtx, answer, agentFacts(run),
+		func(ctx context.Context, draft, feedback string) (string, error) {
+			prompt := rewritePrompt(uc.buildAgentPrompt(run, history, u, true), "JSON:", draft, feedback)
+			raw, err := uc.llm.Generate(ctx, prompt)
+			if err != nil {
+				return "", err
+			}
+			reply, err := parseAgentReply(raw)
+			if err != nil {
+				return "", err
+			}
+			return reply.FinalAnswer, nil
+		})
+	grounded := groundAnswer(answer, run.docs, run.calls)
+	answer = grounded.Text
+	citations := uc.extractCitations(grounded.Cited)
+	suggestions := uc.generateSuggestions(input.Message, search.SearchOutput{Results: run.docs})
+	searchMeta := chat.SearchMeta{
+		TotalDocsSearched: run.totalDocs,
+		SuppressedDocs:    run.suppressed,
+		DocsUsed:          len(citations),
+		ProcessingTimeMs:  time.Since(startTime).Milliseconds(),
+		ModelUsed:         uc.llm.Name(),
+		ToolCalls:         run.calls,
+		Groundedness:      grounded.Groundedness,
+		NumericCheck:      numericCheck,
+	}
+	active, _ := mergeUnderstoodFilters(explicit, u)
+	questionID, answerID := uc.persistChatExchange(ctx, conversation, history, input, active, answer, citations, suggestions, searchMeta)
+
+	return chat.ChatOutput{
+		ConversationID: conversation.ID,
+		MessageID:      answerID,
+		QuestionID:     questionID,
+		Answer:         answer,
+		Citations:      citations,
+		Suggestions:    suggestions,
+		SearchMetadata: searchMeta,
+		QueryIntent:    u.Intent,
+		Understanding:  u,
+		Backend:        "Agent",
+	}, true
+}
+
+// runAgent runs up to AgentMaxSteps tool calls, feeding every result back to the
+// model, then requires a final answer.
+func (uc *implUseCase) runAgent(ctx context.Context, run *agentRun, history chatHistory, u chat.QueryUnderstanding) (string, error) {
+	agentCtx, cancel := context.WithTimeout(ctx, agentTimeout)
+	defer cancel()
+
+	tools := uc.tools()
+	for step := 0; ; step++ {
+		final := step >= uc.config.AgentMaxSteps
+		raw, err := uc.llm.Generate(agentCtx, uc.buildAgentPrompt(run, history, u, final))
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", chat.ErrLLMFailed, err)
+		}
+
+		reply, err := parseAgentReply(raw)
+		if err != nil {
+			return "", err
+		}
+		if reply.FinalAnswer != "" {
+			return reply.FinalAnswer, nil
+		}
+		if final {
+			return "", errAgentNoAnswer
+		}
+		run.call(agentCtx, tools, reply)
+	}
+}
+
+// call executes one tool and records it in the trace. Failures are recorded and
+// shown to the model rather than aborting the loop.
+func (run *agentRun) call(ctx context.Context, tools map[string]toolFunc, reply agentReply) {
+	start := time.Now()
+	call := chat.ToolCall{
+		ID:        fmt.Sprintf("T%d", len(run.calls)+1),
+		Tool:      reply.Tool,
+		Arguments: compactArguments(reply.Arguments),
+	}
+
+	var args toolArgs
+	fn, ok := tools[reply.Tool]
+	switch {
+	case !ok:
+		call.Error = errUnknownTool.Error()
+	case len(call.Arguments) > 0 && json.Unmarshal(call.Arguments, &args) != nil:
+		call.Error = "invalid arguments"
+	default:
+		result, err := fn(ctx, run, args)
+		if err != nil {
+			call.Error = err.Error()
+		} else {
+			call.Result = trimRunes(strings.TrimSpace(result), maxToolResultRunes)
+		}
+	}
+	call.DurationMs = time.Since(start).Milliseconds()
+	run.calls = append(run.calls, call)
+}
+
+func (uc *implUseCase) buildAgentPrompt(run *agentRun, history chatHistory, u chat.QueryUnderstanding, final bool) string {
+	var b strings.Builder
+	b.WriteString(systemPrompt)
+	b.WriteString("\n\n")
+	b.WriteString(agentToolsPrompt)
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("Hôm nay là %s.\n", run.now.In(vietnamTZ).Format(dateLayout)))
+	if hints := understandingHints(u); hints != "" {
+		b.WriteString(fmt.Sprintf("Bộ lọc suy ra từ câu hỏi (dùng khi phù hợp): %s\n", hints))
+	}
+	b.WriteString("\n")
+
+	if !history.empty() {
+		b.WriteString(uc.buildConversationBlock(history))
+	}
+	b.WriteString(fmt.Sprintf("User: %s\n\n", run.input.Message))
+
+	if len(run.calls) > 0 {
+		b.WriteString("Kết quả công cụ:\n")
+		for _, c := range run.calls {
+			b.WriteString(fmt.Sprintf("[%s] %s %s\n", c.ID, c.Tool, string(c.Arguments)))
+			if c.Error != "" {
+				b.WriteString(fmt.Sprintf("Lỗi: %s\n", c.Error))
+			} else {
+				b.WriteString(c.Result)
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+	if final {
+		b.WriteString("Đã hết lượt gọi công cụ. Trả lời ngay bằng {\"final_answer\": ...} dựa trên kết quả hiện có, nói rõ nếu dữ liệu chưa đủ.\n")
+	}
+	b.WriteString("JSON:")
+	return b.String()
+}
+
+// understandingHints - Understood filters as a compact line for the agent prompt.
+func understandingHints(u chat.QueryUnderstanding) string {
+	var parts []string
+	if len(u.Platforms) > 0 {
+		parts = append(parts, "platforms="+strings.Join(u.Platforms, ","))
+	}
+	if len(u.Sentiments) > 0 {
+		parts = append(parts, "sentiments="+strings.Join(u.Sentiments, ","))
+	}
+	if len(u.Aspects) > 0 {
+		parts = append(parts, "aspects="+strings.Join(u.Aspects, ","))
+	}
+	if len(u.RiskLevels) > 0 {
+		parts = append(parts, "risk_levels="+strings.Join(u.RiskLevels, ","))
+	}
+	if u.DateLabel != "" {
+		parts = append(parts, "date="+u.DateLabel)
+	}
+	if u.ComparisonTarget != "" {
+		parts = append(parts, "so sánh với="+u.ComparisonTarget)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// parseAgentReply decodes one agent turn. A reply without any JSON object is taken
+// as the final answer, since models sometimes skip the wrapper when done.
+func parseAgentReply(raw string) (agentReply, error) {
+	start := strings.Index(raw, "{")
+	end := strings.LastIndex(raw, "}")
+	if start < 0 || end <= start {
+		if answer := strings.TrimSpace(raw); answer != "" {
+			return agentReply{FinalAnswer: answer}, nil
+		}
+		return agentReply{}, errAgentInvalidReply
+	}
+	var reply agentReply
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &reply); err != nil {
+		return agentReply{}, fmt.Errorf("%w: %v", errAgentInvalidReply, err)
+	}
+	reply.Tool = strings.ToLower(strings.TrimSpace(reply.Tool))
+	reply.FinalAnswer = strings.TrimSpace(reply.FinalAnswer)
+	if reply.Tool == "" && reply.FinalAnswer == "" {
+		return agentReply{}, errAgentInvalidReply
+	}
+	return reply, nil
+}
+
+// compactArguments normalizes tool arguments for the prompt and the trace.
+func compactArguments(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 || string(raw) == "null" {
+		return json.RawMessage("{}")
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return raw
+	}
+	out, err := json.Marshal(v)
+	if err != nil || len(out) > maxToolArgumentBytes {
+		return json.RawMessage(`{"truncated":true}`)
+	}
+	return out
+}
@@ -12,13 +12,16 @@ import (
 	"knowledge-srv/internal/chat/repository"
 	"knowledge-srv/internal/contentquality"
 	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
 	analyticspkg "knowledge-srv/pkg/analytics"
 )
 
 func (uc *implUseCase) tryAnalyticsFallback(
 	ctx context.Context,
 	conversation model.Conversation,
+	history chatHistory,
 	input chat.ChatInput,
+	filters search.SearchFilters,
 	startTime time.Time,
 	intent QueryIntent,
 	snapshot *analyticspkg.Snapshot,
@@ -35,10 +38,12 @@ func (uc *implUseCase) tryAnalyticsFallback(
 		ModelUsed:         "analysis-api",
 	}
 
-	uc.persistChatExchange(ctx, conversation, input, answer, citations, suggestions, searchMeta)
+	questionID, answerID := uc.persistChatExchange(ctx, conversation, history, input, filters, answer, citations, suggestions, searchMeta)
 
 	return chat.ChatOutput{
 		ConversationID: conversation.ID,
+		MessageID:      answerID,
+		QuestionID:     questionID,
 		Answer:         answer,
 		Citations:      citations,
 		Suggestions:    suggestions,
@@ -69,39 +74,64 @@ func (uc *implUseCase) loadAnalyticsSnapshot(ctx context.Context, campaignID str
 	return &snapshot, true
 }
 
+// persistChatExchange stores the question (unless it is being answered again) and
+// the answer at the end of the branch history was loaded from, and makes the answer
+// the conversation's active leaf. Returns the stored IDs, empty on failure.
 func (uc *implUseCase) persistChatExchange(
 	ctx context.Context,
 	conversation model.Conversation,
+	history chatHistory,
 	input chat.ChatInput,
+	filters search.SearchFilters,
 	answer string,
 	citations []chat.Citation,
 	suggestions []string,
 	searchMeta chat.SearchMeta,
-) {
-	filtersJSON, _ := json.Marshal(input.Filters)
-	_, _ = uc.repo.CreateMessage(ctx, repository.CreateMessageOptions{
-		ConversationID: conversation.ID,
-		Role:           "user",
-		Content:        input.Message,
-		FiltersUsed:    filtersJSON,
-	})
+) (questionID, answerID string) {
+	added := 1
+	questionID = history.questionID
+	if questionID == "" {
+		filtersJSON, _ := json.Marshal(input.Filters)
+		question, err := uc.repo.CreateMessage(ctx, repository.CreateMessageOptions{
+			ConversationID: conversation.ID,
+			ParentID:       history.parentID,
+			Role:           "user",
+			Content:        input.Message,
+			FiltersUsed:    filtersJSON,
+		})
+		if err != nil {
+			uc.l.Warnf(ctx, "chat.usecase.persistChatExchange: CreateMessage failed: %v", err)
+			return "", ""
+		}
+		questionID = question.ID
+		added++
+	}
 
 	citationsJSON, _ := json.Marshal(citations)
 	suggestionsJSON, _ := json.Marshal(suggestions)
 	searchMetaJSON, _ := json.Marshal(searchMeta)
-	_, _ = uc.repo.CreateMessage(ctx, repository.CreateMessageOptions{
+	reply, err := uc.repo.CreateMessage(ctx, repository.CreateMessageOptions{
 		ConversationID: conversation.ID,
+		ParentID:       questionID,
 		Role:           "assistant",
 		Content:        answer,
 		Citations:      citationsJSON,
 		SearchMetadata: searchMetaJSON,
 		Suggestions:    suggestionsJSON,
 	})
+	if err != nil {
+		uc.l.Warnf(ctx, "chat.usecase.persistChatExchange: CreateMessage failed: %v", err)
+		return questionID, ""
+	}
 
 	_ = uc.repo.UpdateConversationLastMessage(ctx, repository.UpdateLastMessageOptions{
 		ConversationID: conversation.ID,
-		MessageCount:   conversation.MessageCount + 2,
+		MessageCount:   conversation.MessageCount + added,
+		ActiveLeafID:   reply.ID,
+		CampaignIDs:    conversation.CampaignIDs,
 	})
+	uc.maybeRefreshMemory(ctx, conversation, reply.ID, history.depth+2, filters)
+	return questionID, reply.ID
 }
 
 func buildAnalyticsAnswer(question string, snapshot analyticspkg.Snapshot) (string, []chat.Citation, []string, int) {
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/chat/repository"
+	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
+)
+
+// answerCacheVersion is bumped whenever cachedAnswer or the answer pipeline changes
+// in a way that makes earlier answers unfit to serve.
+const answerCacheVersion = "v1"
+
+// answerCacheKey - Where the answer to a question is looked up and stored: its
+// campaign, the intent and filters it is retrieved with, and the embedding of the
+// normalized question.
+type answerCacheKey struct {
+	campaignID  string
+	filtersKey  string
+	questionKey string
+	question    string
+	vector      []float32
+}
+
+// cachedAnswer - What the answer cache stores, as JSON.
+type cachedAnswer struct {
+	Question    string
+	Vector      []float32
+	Answer      string
+	Citations   []chat.Citation
+	Suggestions []string
+	SearchMeta  chat.SearchMeta
+	Backend     string
+	QueryIntent string
+	CachedAt    time.Time
+}
+
+// answerCacheFor returns the cache key of input, nil when it must be answered
+// afresh: the cache is off or skipped, the question follows earlier turns (its
+// answer depends on them), or a stored question is being answered again.
+func (uc *implUseCase) answerCacheFor(ctx context.Context, input chat.ChatInput, history chatHistory, intent string, filters search.SearchFilters) *answerCacheKey {
+	if !uc.config.AnswerCacheEnabled || uc.cache == nil || uc.embedUC == nil {
+		return nil
+	}
+	if history.questionID != "" || history.depth > 0 || !history.empty() {
+		return nil
+	}
+	if input.SkipCache {
+		uc.cache.IncrAnswerCacheStat(ctx, input.CampaignID, repository.AnswerCacheStatOptOut)
+		return nil
+	}
+
+	filtersJSON, err := json.Marshal(filters)
+	if err != nil {
+		return nil
+	}
+	question := normalizeQuestion(input.Message)
+	out, err := uc.embedUC.Generate(ctx, embedding.GenerateInput{Text: question})
+	if err != nil || len(out.Vector) == 0 {
+		uc.l.Warnf(ctx, "chat.usecase.answerCacheFor: embedding failed, answer cache skipped: %v", err)
+		return nil
+	}
+	return &answerCacheKey{
+		campaignID:  input.CampaignID,
+		filtersKey:  shortHash(answerCacheVersion + ":" + intent + ":" + string(filtersJSON)),
+		questionKey: shortHash(question),
+		question:    question,
+		vector:      out.Vector,
+	}
+}
+
+// lookupAnswer returns the cached answer most similar to the question, when it
+// reaches the similarity threshold.
+func (uc *implUseCase) lookupAnswer(ctx context.Context, key *answerCacheKey) (cachedAnswer, bool) {
+	entries, err := uc.cache.ListCachedAnswers(ctx, repository.AnswerScopeOptions{
+		CampaignID: key.campaignID,
+		FiltersKey: key.filtersKey,
+	})
+	if err != nil {
+		uc.l.Warnf(ctx, "chat.usecase.lookupAnswer: ListCachedAnswers failed: %v", err)
+		return cachedAnswer{}, false
+	}
+
+	var best cachedAnswer
+	bestSim := 0.0
+	for _, data := range entries {
+		var entry cachedAnswer
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+		if sim := vectorSimilarity(key.vector, entry.Vector); sim >= uc.config.AnswerCacheThreshold && sim > bestSim {
+			best, bestSim = entry, sim
+		}
+	}
+	if bestSim == 0 {
+		uc.cache.IncrAnswerCacheStat(ctx, key.campaignID, repository.AnswerCacheStatMiss)
+		return cachedAnswer{}, false
+	}
+
+	uc.cache.IncrAnswerCacheStat(ctx, key.campaignID, repository.AnswerCacheStatHit)
+	uc.l.Infof(ctx, "chat.usecase.lookupAnswer: cache hit for campaign %s (similarity=%.3f, cached %q)",
+		key.campaignID, bestSim, best.Question)
+	return best, true
+}
+
+// serveCachedAnswer stores the exchange with the cached answer, its citations and
+// suggestions, as if it had just been generated.
+func (uc *implUseCase) serveCachedAnswer(
+	ctx context.Context,
+	conversation model.Conversation,
+	history chatHistory,
+	input chat.ChatInput,
+	filters search.SearchFilters,
+	entry cachedAnswer,
+	understanding chat.QueryUnderstanding,
+	startTime time.Time,
+) chat.ChatOutput {
+	searchMeta := entry.SearchMeta
+	searchMeta.CacheHit = true
+	searchMeta.ProcessingTimeMs = time.Since(startTime).Milliseconds()
+
+	questionID, answerID := uc.persistChatExchange(ctx, conversation, history, input, filters, entry.Answer, entry.Citations, entry.Suggestions, searchMeta)
+	return chat.ChatOutput{
+		ConversationID: conversation.ID,
+		MessageID:      answerID,
+		QuestionID:     questionID,
+		Answer:         entry.Answer,
+		Citations:      entry.Citations,
+		Suggestions:    entry.Suggestions,
+		SearchMetadata: searchMeta,
+		Backend:        entry.Backend,
+		QueryIntent:    entry.QueryIntent,
+		Understanding:  understanding,
+		CacheHit:       true,
+	}
+}
+
+// storeAnswer caches a generated answer, tagged with every project of the campaign
+// so that new indexed data on any of them evicts it. Without the projects the
+// answer could not be evicted, so it is not cached.
+func (uc *implUseCase) storeAnswer(ctx context.Context, key *answerCacheKey, output chat.ChatOutput) {
+	if key == nil || output.Answer == "" {
+		return
+	}
+	projectIDs, err := uc.searchUC.CampaignProjects(ctx, key.campaignID)
+	if err != nil || len(projectIDs) == 0 {
+		uc.l.Warnf(ctx, "chat.usecase.storeAnswer: campaign projects unavailable, answer not cached: %v", err)
+		return
+	}
+
+	data, err := json.Marshal(cachedAnswer{
+		Question:    key.question,
+		Vector:      key.vector,
+		Answer:      output.Answer,
+		Citations:   output.Citations,
+		Suggestions: output.Suggestions,
+		SearchMeta:  output.SearchMetadata,
+		Backend:     output.Backend,
+		QueryIntent: output.QueryIntent,
+		CachedAt:    time.Now(),
+	})
+	if err != nil {
+		return
+	}
+	cacheKey, err := uc.cache.SaveCachedAnswer(ctx, repository.SaveCachedAnswerOptions{
+		CampaignID:  key.campaignID,
+		FiltersKey:  key.filtersKey,
+		QuestionKey: key.questionKey,
+		Data:        data,
+		TTL:         uc.config.AnswerCacheTTL,
+		MaxEntries:  uc.config.AnswerCacheMaxEntries,
+	})
+	if err != nil {
+		return
+	}
+
+	// An answer that new data cannot evict would be served stale for hours, so drop it
+	// when it cannot be tagged.
+	if err := uc.searchUC.TagCachedAnswer(ctx, search.TagCachedAnswerInput{
+		CacheKey:   cacheKey,
+		ProjectIDs: projectIDs,
+		TTL:        uc.config.AnswerCacheTTL,
+	}); err != nil {
+		uc.l.Warnf(ctx, "chat.usecase.storeAnswer: tagging failed, answer not cached: %v", err)
+		_ = uc.cache.DeleteCachedAnswer(ctx, repository.AnswerScopeOptions{
+			CampaignID: key.campaignID,
+			FiltersKey: key.filtersKey,
+		}, cacheKey)
+		return
+	}
+	uc.cache.IncrAnswerCacheStat(ctx, key.campaignID, repository.AnswerCacheStatStore)
+}
+
+// AnswerCacheStats - Hit/miss counters of a campaign's answer cache
+func (uc *implUseCase) AnswerCacheStats(ctx context.Context, sc model.Scope, input chat.AnswerCacheStatsInput) (chat.AnswerCacheStatsOutput, error) {
+	if !sc.IsAdmin() {
+		return chat.AnswerCacheStatsOutput{}, chat.ErrForbidden
+	}
+	if input.CampaignID == "" {
+		return chat.AnswerCacheStatsOutput{}, chat.ErrCampaignRequired
+	}
+	output := chat.AnswerCacheStatsOutput{
+		CampaignID: input.CampaignID,
+		Enabled:    uc.config.AnswerCacheEnabled && uc.embedUC != nil,
+	}
+	if uc.cache == nil {
+		return output, nil
+	}
+
+	stats, err := uc.cache.GetAnswerCacheStats(ctx, input.CampaignID)
+	if err != nil {
+		uc.l.Errorf(ctx, "chat.usecase.AnswerCacheStats: Failed to load stats: %v", err)
+		return chat.AnswerCacheStatsOutput{}, fmt.Errorf("%w: %v", chat.ErrAnswerCacheStats, err)
+	}
+	output.Hits = stats.Hits
+	output.Misses = stats.Misses
+	output.Stores = stats.Stores
+	output.OptOuts = stats.OptOuts
+	if stats.Hits+stats.Misses > 0 {
+		output.HitRate = float64(stats.Hits) / float64(stats.Hits+stats.Misses)
+	}
+	return output, nil
+}
+
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:16])
+}
+
+// vectorSimilarity - Cosine similarity, 0 when either vector is missing or mismatched.
+func vectorSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/chat/repository"
+	"knowledge-srv/internal/model"
+)
+
+// messageTree - Messages of a conversation linked by parent. Every regenerated
+// answer and edited question is a sibling of the message it replaces; a branch is
+// the path from a leaf back to a first question.
+type messageTree struct {
+	byID     map[string]model.Message
+	children map[string][]model.Message // parent ID ("" for first questions) -> children, oldest first
+	newest   string
+}
+
+// newMessageTree indexes msgs, which are ordered oldest first.
+func newMessageTree(msgs []model.Message) messageTree {
+	t := messageTree{
+		byID:     make(map[string]model.Message, len(msgs)),
+		children: make(map[string][]model.Message),
+	}
+	for _, m := range msgs {
+		t.byID[m.ID] = m
+		t.children[m.ParentID] = append(t.children[m.ParentID], m)
+		t.newest = m.ID
+	}
+	return t
+}
+
+func (uc *implUseCase) loadMessageTree(ctx context.Context, conversationID string) (messageTree, error) {
+	msgs, err := uc.repo.ListMessages(ctx, repository.ListMessagesOptions{
+		ConversationID: conversationID,
+		OrderASC:       true,
+	})
+	if err != nil {
+		return messageTree{}, err
+	}
+	return newMessageTree(msgs), nil
+}
+
+// leaf returns the active leaf, or the newest message when it is unknown (a
+// conversation whose last update failed).
+func (t messageTree) leaf(activeID string) string {
+	if _, ok := t.byID[activeID]; ok {
+		return activeID
+	}
+	return t.newest
+}
+
+// path returns the branch ending at leafID, oldest first.
+func (t messageTree) path(leafID string) []model.Message {
+	var out []model.Message
+	for id := leafID; id != "" && len(out) < len(t.byID); {
+		m, ok := t.byID[id]
+		if !ok {
+			break
+		}
+		out = append(out, m)
+		id = m.ParentID
+	}
+	slices.Reverse(out)
+	return out
+}
+
+// latestLeaf follows the newest reply from id down to the end of its branch.
+func (t messageTree) latestLeaf(id string) string {
+	for range len(t.byID) {
+		children := t.children[id]
+		if len(children) == 0 {
+			break
+		}
+		id = children[len(children)-1].ID
+	}
+	return id
+}
+
+// commonPrefix - Number of leading messages two branches share.
+func commonPrefix(a, b []model.Message) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n].ID == b[n].ID {
+		n++
+	}
+	return n
+}
+
+// Regenerate answers a question again. The new answer is a sibling of the old one
+// and becomes the end of the active branch.
+func (uc *implUseCase) Regenerate(ctx context.Context, sc model.Scope, input chat.RegenerateInput) (chat.ChatOutput, error) {
+	startTime := time.Now()
+
+	conv, tree, err := uc.openBranchTarget(ctx, sc, input.ConversationID)
+	if err != nil {
+		return chat.ChatOutput{}, err
+	}
+	question, ok := tree.byID[input.MessageID]
+	if ok && question.Role != "user" {
+		question, ok = tree.byID[question.ParentID]
+	}
+	if !ok || question.Role != "user" {
+		return chat.ChatOutput{}, chat.ErrMessageNotFound
+	}
+
+	chatInput := chat.ChatInput{
+		CampaignID:     conv.CampaignID,
+		CampaignIDs:    questionCampaigns(conv, tree, question.ID),
+		ConversationID: conv.ID,
+		Message:        question.Content,
+	}
+	if filters := decodeChatFilters(question.FiltersUsed); filters != nil {
+		chatInput.Filters = *filters
+	}
+	campaigns, err := uc.turnCampaigns(ctx, sc, chatInput.CampaignID, chatInput.CampaignIDs)
+	if err != nil {
+		return chat.ChatOutput{}, err
+	}
+
+	conv = uc.forkMemory(ctx, conv, len(tree.path(question.ID)))
+	history := uc.branchHistory(conv, tree, question.ParentID)
+	history.questionID = question.ID
+
+	understanding := uc.understandQuery(ctx, question.Content)
+	return uc.answer(ctx, sc, conv, history, chatInput, campaigns, understanding, startTime)
+}
+
+// EditMessage asks an edited question beside the original one; the original
+// question and everything after it stay on their own branch.
+func (uc *implUseCase) EditMessage(ctx context.Context, sc model.Scope, input chat.EditMessageInput) (chat.ChatOutput, error) {
+	startTime := time.Now()
+
+	conv, tree, err := uc.openBranchTarget(ctx, sc, input.ConversationID)
+	if err != nil {
+		return chat.ChatOutput{}, err
+	}
+	original, ok := tree.byID[input.MessageID]
+	if !ok {
+		return chat.ChatOutput{}, chat.ErrMessageNotFound
+	}
+	if original.Role != "user" {
+		return chat.ChatOutput{}, chat.ErrNotUserMessage
+	}
+
+	chatInput := chat.ChatInput{
+		CampaignID:     conv.CampaignID,
+		CampaignIDs:    questionCampaigns(conv, tree, original.ID),
+		ConversationID: conv.ID,
+		Message:        input.Message,
+		Filters:        input.Filters,
+	}
+	if err := uc.validateChatInput(chatInput); err != nil {
+		uc.l.Warnf(ctx, "chat.usecase.EditMessage: validateChatInput failed: %v", err)
+		return chat.ChatOutput{}, err
+	}
+	campaigns, err := uc.turnCampaigns(ctx, sc, chatInput.CampaignID, chatInput.CampaignIDs)
+	if err != nil {
+		return chat.ChatOutput{}, err
+	}
+
+	conv = uc.forkMemory(ctx, conv, len(tree.path(original.ParentID)))
+	history := uc.branchHistory(conv, tree, original.ParentID)
+
+	understanding := uc.understandQuery(ctx, input.Message)
+	return uc.answer(ctx, sc, conv, history, chatInput, campaigns, understanding, startTime)
+}
+
+// SwitchBranch shows the branch through a message, continued along the newest
+// replies, and makes it the one new questions follow.
+func (uc *implUseCase) SwitchBranch(ctx context.Context, sc model.Scope, input chat.SwitchBranchInput) (chat.ConversationOutput, error) {
+	conv, err := uc.ownConversation(ctx, sc, input.ConversationID)
+	if err != nil {
+		return chat.ConversationOutput{}, err
+	}
+	tree, err := uc.loadMessageTree(ctx, conv.ID)
+	if err != nil {
+		uc.l.Errorf(ctx, "chat.usecase.SwitchBranch: ListMessages failed: %v", err)
+		return chat.ConversationOutput{}, err
+	}
+	if _, ok := tree.byID[input.MessageID]; !ok {
+		return chat.ConversationOutput{}, chat.ErrMessageNotFound
+	}
+
+	leafID := tree.latestLeaf(input.MessageID)
+	if current := tree.leaf(conv.ActiveLeafID); leafID != current {
+		conv = uc.forkMemory(ctx, conv, commonPrefix(tree.path(current), tree.path(leafID)))
+		if err := uc.repo.SetActiveLeaf(ctx, repository.SetActiveLeafOptions{
+			ConversationID: conv.ID,
+			LeafID:         leafID,
+		}); err != nil {
+			uc.l.Errorf(ctx, "chat.usecase.SwitchBranch: SetActiveLeaf failed: %v", err)
+			return chat.ConversationOutput{}, err
+		}
+		conv.ActiveLeafID = leafID
+	}
+
+	return uc.toBranchOutput(conv, tree), nil
+}
+
+// openBranchTarget loads a conversation of the caller that can take a new turn, and its messages.
+func (uc *implUseCase) openBranchTarget(ctx context.Context, sc model.Scope, conversationID string) (model.Conversation, messageTree, error) {
+	conv, err := uc.ownConversation(ctx, sc, conversationID)
+	if err != nil {
+		return model.Conversation{}, messageTree{}, err
+	}
+	if conv.Status == "ARCHIVED" {
+		return model.Conversation{}, messageTree{}, chat.ErrConversationArchived
+	}
+	tree, err := uc.loadMessageTree(ctx, conv.ID)
+	if err != nil {
+		uc.l.Errorf(ctx, "chat.usecase.openBranchTarget: ListMessages failed: %v", err)
+		return model.Conversation{}, messageTree{}, err
+	}
+	return conv, tree, nil
+}
+
+// forkMemory drops the rolling memory when it covers messages beyond the first
+// shared ones, which the new branch does not have. It is rebuilt from that branch.
+func (uc *implUseCase) forkMemory(ctx context.Context, conv model.Conversation, shared int) model.Conversation {
+	if conv.MemoryMessageCount <= shared {
+		return conv
+	}
+	if err := uc.repo.ResetConversationMemory(ctx, repository.ResetConversationMemoryOptions{
+		ConversationID: conv.ID,
+	}); err != nil {
+		uc.l.Warnf(ctx, "chat.usecase.forkMemory: ResetConversationMemory failed: %v", err)
+	}
+	conv.MemorySummary = ""
+	conv.MemoryKeyFacts = nil
+	conv.MemoryActiveFilters = nil
+	conv.MemoryMessageCount = 0
+	return conv
+}
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -16,23 +15,32 @@ import (
 func (uc *implUseCase) Chat(ctx context.Context, sc model.Scope, input chat.ChatInput) (chat.ChatOutput, error) {
 	startTime := time.Now()
 
-	intent := ClassifyIntent(input.Message)
-
+	if input.CampaignID == "" && len(input.CampaignIDs) > 0 {
+		input.CampaignID = input.CampaignIDs[0]
+	}
 	if err := uc.validateChatInput(input); err != nil {
 		uc.l.Warnf(ctx, "chat.usecase.Chat: validateChatInput failed: %v", err)
 		return chat.ChatOutput{}, err
 	}
 
+	understanding := uc.understandQuery(ctx, input.Message)
+
 	var conversation model.Conversation
-	var history []model.Message
+	var history chatHistory
+	var campaigns []campaignRef
 	isNewConversation := input.ConversationID == ""
 
 	if isNewConversation {
+		var err error
+		if campaigns, err = uc.turnCampaigns(ctx, sc, input.CampaignID, input.CampaignIDs); err != nil {
+			return chat.ChatOutput{}, err
+		}
 		title := uc.generateTitle(input.Message)
 		conv, err := uc.repo.CreateConversation(ctx, repository.CreateConversationOptions{
-			CampaignID: input.CampaignID,
-			UserID:     sc.UserID,
-			Title:      title,
+			CampaignID:  input.CampaignID,
+			CampaignIDs: campaignList(input.CampaignID, input.CampaignIDs),
+			UserID:      sc.UserID,
+			Title:       title,
 		})
 		if err != nil {
 			uc.l.Errorf(ctx, "chat.usecase.Chat: CreateConversation failed: %v", err)
@@ -45,42 +53,42 @@ func (uc *implUseCase) Chat(ctx context.Context, sc model.Scope, input chat.Chat
 			uc.l.Errorf(ctx, "chat.usecase.Chat: GetConversationByID failed: %v", err)
 			return chat.ChatOutput{}, chat.ErrConversationNotFound
 		}
+		// Shares are read-only: only the owner continues a conversation
+		if sc.UserID != "" && conv.UserID != sc.UserID {
+			return chat.ChatOutput{}, chat.ErrConversationNotFound
+		}
 		if conv.Status == "ARCHIVED" {
 			uc.l.Warnf(ctx, "chat.usecase.Chat: conversation is archived")
 			return chat.ChatOutput{}, chat.ErrConversationArchived
 		}
 		conversation = conv
-
-		msgs, err := uc.repo.ListMessages(ctx, repository.ListMessagesOptions{
-			ConversationID: conversation.ID,
-			Limit:          chat.MaxHistoryMessages,
-			OrderASC:       true,
-		})
-		if err != nil {
-			uc.l.Warnf(ctx, "chat.usecase.Chat: ListMessages failed: %v", err)
+		history = uc.loadHistory(ctx, conversation)
+		ids := input.CampaignIDs
+		if len(ids) == 0 {
+			ids = followUpCampaigns(conversation, history)
+		}
+		if campaigns, err = uc.turnCampaigns(ctx, sc, conversation.CampaignID, ids); err != nil {
+			return chat.ChatOutput{}, err
 		}
-		history = msgs
 	}
 
-	// Build search input — tune params based on query intent
-	var searchLimit int
-	var searchMinScore float64
-	switch intent {
-	case IntentNarrative:
-		searchLimit = 15
-		searchMinScore = 0.58
-	default: // IntentStructured
-		searchLimit = chat.MaxSearchDocs
-		searchMinScore = 0.52
-	}
+	return uc.answer(ctx, sc, conversation, history, input, campaigns, understanding, startTime)
+}
 
-	searchInput := search.SearchInput{
-		CampaignID: input.CampaignID,
-		Query:      input.Message,
-		Limit:      searchLimit,
-		MinScore:   searchMinScore,
-	}
-	searchFilters := search.SearchFilters{
+// answer runs retrieval (or the agent) for input and stores the exchange on the
+// branch history was loaded from. Several campaigns make it a comparative answer.
+func (uc *implUseCase) answer(
+	ctx context.Context,
+	sc model.Scope,
+	conversation model.Conversation,
+	history chatHistory,
+	input chat.ChatInput,
+	campaigns []campaignRef,
+	understanding chat.QueryUnderstanding,
+	startTime time.Time,
+) (chat.ChatOutput, error) {
+	intent := QueryIntent(understanding.Intent)
+	explicitFilters := search.SearchFilters{
 		Sentiments: input.Filters.Sentiments,
 		Aspects:    input.Filters.Aspects,
 		Platforms:  input.Filters.Platforms,
@@ -88,23 +96,64 @@ func (uc *implUseCase) Chat(ctx context.Context, sc model.Scope, input chat.Chat
 		DateTo:     input.Filters.DateTo,
 		RiskLevels: input.Filters.RiskLevels,
 	}
-	if len(searchFilters.Platforms) == 0 {
-		searchFilters.Platforms = InferPlatforms(input.Message)
+
+	if len(campaigns) > 1 {
+		conversation.CampaignIDs = mergeCampaignIDs(conversation.CampaignIDs, campaigns)
+		return uc.compareAnswer(ctx, sc, conversation, history, input, campaigns, explicitFilters, understanding, startTime)
 	}
-	if len(searchFilters.Sentiments) > 0 || len(searchFilters.Aspects) > 0 ||
-		len(searchFilters.Platforms) > 0 || len(searchFilters.RiskLevels) > 0 ||
-		searchFilters.DateFrom != nil || searchFilters.DateTo != nil {
-		searchInput.Filters = searchFilters
+
+	// Opening questions repeat across the users of a campaign: serve an earlier
+	// answer to a near-identical one instead of searching and generating again
+	cacheFilters, _ := mergeUnderstoodFilters(explicitFilters, understanding)
+	cacheKey := uc.answerCacheFor(ctx, input, history, string(intent), cacheFilters)
+	if cacheKey != nil {
+		if entry, ok := uc.lookupAnswer(ctx, cacheKey); ok {
+			return uc.serveCachedAnswer(ctx, conversation, history, input, cacheFilters, entry, understanding, startTime), nil
+		}
 	}
 
+	// Structured questions: let the model query aggregates and analytics itself
+	if uc.useAgent(understanding) {
+		if output, ok := uc.agentChat(ctx, sc, conversation, history, input, explicitFilters, understanding, startTime); ok {
+			uc.storeAnswer(ctx, cacheKey, output)
+			return output, nil
+		}
+	}
+
+	// Build search input — tune params based on query intent
+	searchLimit, searchMinScore := retrievalParams(intent)
+	searchInput := search.SearchInput{
+		CampaignID: input.CampaignID,
+		Query:      input.Message,
+		Limit:      searchLimit,
+		MinScore:   searchMinScore,
+		Intent:     string(intent),
+		Recency:    InferRecency(input.Message),
+		Layers:     &search.LayerQuotas{},
+		Diversity:  &search.DiversityOptions{},
+	}
+	searchFilters, inferred := mergeUnderstoodFilters(explicitFilters, understanding)
+	searchInput.Filters = searchFilters
+
 	searchOutput, err := uc.searchUC.Search(ctx, sc, searchInput)
 	if err != nil {
-		uc.l.Errorf(ctx, "chat.usecase.Chat: Search failed: %v", err)
+		uc.l.Errorf(ctx, "chat.usecase.answer: Search failed: %v", err)
 		return chat.ChatOutput{}, fmt.Errorf("%w: %v", chat.ErrSearchFailed, err)
 	}
+	// Inferred filters may be wrong: when they leave nothing, retry with the user's own filters.
+	if inferred && (searchOutput.NoRelevantContext || len(searchOutput.Results) == 0) {
+		searchInput.Filters = explicitFilters
+		relaxed, err := uc.searchUC.Search(ctx, sc, searchInput)
+		if err != nil {
+			uc.l.Warnf(ctx, "chat.usecase.answer: relaxed Search failed: %v", err)
+		} else {
+			searchOutput = relaxed
+		}
+	}
 	if searchOutput.NoRelevantContext || len(searchOutput.Results) == 0 {
 		analyticsSnapshot, _ := uc.loadAnalyticsSnapshot(ctx, input.CampaignID, 8*time.Second)
-		if output, ok := uc.tryAnalyticsFallback(ctx, conversation, input, startTime, intent, analyticsSnapshot); ok {
+		if output, ok := uc.tryAnalyticsFallback(ctx, conversation, history, input, searchInput.Filters, startTime, intent, analyticsSnapshot); ok {
+			output.Understanding = understanding
 			return output, nil
 		}
 
@@ -112,92 +161,114 @@ func (uc *implUseCase) Chat(ctx context.Context, sc model.Scope, input chat.Chat
 		suggestions := uc.generateSuggestions(input.Message, searchOutput)
 		searchMeta := chat.SearchMeta{
 			TotalDocsSearched: searchOutput.TotalFound,
+			SuppressedDocs:    searchOutput.SuppressedRedundant,
 			DocsUsed:          0,
 			ProcessingTimeMs:  time.Since(startTime).Milliseconds(),
 			ModelUsed:         uc.llm.Name(),
 		}
-		uc.persistChatExchange(ctx, conversation, input, answer, nil, suggestions, searchMeta)
+		questionID, answerID := uc.persistChatExchange(ctx, conversation, history, input, searchInput.Filters, answer, nil, suggestions, searchMeta)
 		return chat.ChatOutput{
 			ConversationID: conversation.ID,
+			MessageID:      answerID,
+			QuestionID:     questionID,
 			Answer:         answer,
 			Citations:      nil,
 			Suggestions:    suggestions,
 			SearchMetadata: searchMeta,
 			QueryIntent:    string(intent),
+			Understanding:  understanding,
 			Backend:        "Qdrant",
 		}, nil
 	}
 
 	analyticsSnapshot, _ := uc.loadAnalyticsSnapshot(ctx, input.CampaignID, 8*time.Second)
-	prompt := uc.buildPrompt(input.Message, searchOutput.Results, history, analyticsSnapshot)
+	prompt, tokenUsage := uc.buildPrompt(input.Message, searchOutput.Results, history, analyticsSnapshot)
 
 	llmCtx, llmCancel := context.WithTimeout(ctx, 60*time.Second)
 	defer llmCancel()
 	answer, err := uc.llm.Generate(llmCtx, prompt)
 	if err != nil {
-		uc.l.Errorf(ctx, "chat.usecase.Chat: LLM failed: %v", err)
+		uc.l.Errorf(ctx, "chat.usecase.answer: LLM failed: %v", err)
 		return chat.ChatOutput{}, fmt.Errorf("%w: %v", chat.ErrLLMFailed, err)
 	}
 
-	citations := uc.extractCitations(searchOutput.Results)
+	answer, numericCheck := uc.checkFigures(llmCtx, answer, uc.ragFacts(input.Message, searchOutput.Results, analyticsSnapshot),
+		func(ctx context.Context, draft, feedback string) (string, error) {
+			return uc.llm.Generate(ctx, rewritePrompt(prompt, "Assistant:", draft, feedback))
+		})
+	grounded := groundAnswer(answer, searchOutput.Results, nil)
+	answer = grounded.Text
+	citations := uc.extractCitations(grounded.Cited)
 	suggestions := uc.generateSuggestions(input.Message, searchOutput)
 
-	// Persist user message
-	filtersJSON, _ := json.Marshal(input.Filters)
-	_, err = uc.repo.CreateMessage(ctx, repository.CreateMessageOptions{
-		ConversationID: conversation.ID,
-		Role:           "user",
-		Content:        input.Message,
-		FiltersUsed:    filtersJSON,
-	})
-	if err != nil {
-		uc.l.Warnf(ctx, "chat.usecase.Chat: CreateMessage failed: %v", err)
-	}
-
-	// Persist assistant message
 	searchMeta := chat.SearchMeta{
 		TotalDocsSearched: searchOutput.TotalFound,
+		SuppressedDocs:    searchOutput.SuppressedRedundant,
 		DocsUsed:          len(citations),
 		ProcessingTimeMs:  time.Since(startTime).Milliseconds(),
 		ModelUsed:         uc.llm.Name(),
+		Groundedness:      grounded.Groundedness,
+		NumericCheck:      numericCheck,
+		TokenUsage:        &tokenUsage,
 	}
-	citationsJSON, _ := json.Marshal(citations)
-	suggestionsJSON, _ := json.Marshal(suggestions)
-	searchMetaJSON, _ := json.Marshal(searchMeta)
-
-	_, err = uc.repo.CreateMessage(ctx, repository.CreateMessageOptions{
-		ConversationID: conversation.ID,
-		Role:           "assistant",
-		Content:        answer,
-		Citations:      citationsJSON,
-		SearchMetadata: searchMetaJSON,
-		Suggestions:    suggestionsJSON,
-	})
-	if err != nil {
-		uc.l.Warnf(ctx, "chat.usecase.Chat: CreateMessage failed: %v", err)
-	}
+	questionID, answerID := uc.persistChatExchange(ctx, conversation, history, input, searchInput.Filters, answer, citations, suggestions, searchMeta)
 
-	newCount := conversation.MessageCount + 2
-	_ = uc.repo.UpdateConversationLastMessage(ctx, repository.UpdateLastMessageOptions{
-		ConversationID: conversation.ID,
-		MessageCount:   newCount,
-	})
-
-	return chat.ChatOutput{
+	output := chat.ChatOutput{
 		ConversationID: conversation.ID,
+		MessageID:      answerID,
+		QuestionID:     questionID,
 		Answer:         answer,
 		Citations:      citations,
 		Suggestions:    suggestions,
 		SearchMetadata: searchMeta,
 		QueryIntent:    string(intent),
+		Understanding:  understanding,
 		Backend:        "Qdrant",
-	}, nil
+	}
+	uc.storeAnswer(ctx, cacheKey, output)
+	return output, nil
+}
+
+// retrievalParams - Result limit and minimum score of the retrieval for an intent.
+func retrievalParams(intent QueryIntent) (limit int, minScore float64) {
+	switch intent {
+	case IntentNarrative:
+		return 15, 0.58
+	default: // IntentStructured
+		return chat.MaxSearchDocs, 0.52
+	}
+}
+
+// mergeUnderstoodFilters fills every filter the user left empty from the understanding.
+// inferred reports whether any understood value was added.
+func mergeUnderstoodFilters(explicit search.SearchFilters, u chat.QueryUnderstanding) (search.SearchFilters, bool) {
+	merged := explicit
+	inferred := false
+	if len(merged.Platforms) == 0 && len(u.Platforms) > 0 {
+		merged.Platforms, inferred = u.Platforms, true
+	}
+	if len(merged.Sentiments) == 0 && len(u.Sentiments) > 0 {
+		merged.Sentiments, inferred = u.Sentiments, true
+	}
+	if len(merged.Aspects) == 0 && len(u.Aspects) > 0 {
+		merged.Aspects, inferred = u.Aspects, true
+	}
+	if len(merged.RiskLevels) == 0 && len(u.RiskLevels) > 0 {
+		merged.RiskLevels, inferred = u.RiskLevels, true
+	}
+	if merged.DateFrom == nil && merged.DateTo == nil && (u.DateFrom != nil || u.DateTo != nil) {
+		merged.DateFrom, merged.DateTo, inferred = u.DateFrom, u.DateTo, true
+	}
+	return merged, inferred
 }
 
 func (uc *implUseCase) validateChatInput(input chat.ChatInput) error {
 	if input.CampaignID == "" {
 		return chat.ErrCampaignRequired
 	}
+	if len(campaignList(input.CampaignID, input.CampaignIDs)) > chat.MaxCompareCampaigns {
+		return chat.ErrTooManyCampaigns
+	}
 	if len(input.Message) < chat.MinMessageLength {
 		return chat.ErrMessageTooShort
 	}
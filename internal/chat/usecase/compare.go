@@ -0,0 +1,499 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/factcheck"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/tokenbudget"
+)
+
+const comparePrompt = `Câu hỏi so sánh nhiều campaign. Evidence được chia theo từng campaign (Campaign A, B, ...):
+- So sánh song song các campaign theo cùng tiêu chí; dùng Side-by-side Aggregates cho số liệu và tỷ trọng
+- Mỗi nhận định phải nói rõ thuộc campaign nào; không gán evidence của campaign này cho campaign khác
+- Nếu một campaign thiếu dữ liệu cho tiêu chí được hỏi, nói rõ thay vì suy đoán`
+
+const (
+	aggregatesHeader = "Side-by-side Aggregates (toàn bộ documents khớp bộ lọc của từng campaign):\n"
+
+	promptSectionAggregates = "aggregates"
+	// promptSectionCampaign prefixes the evidence section of each campaign: "context:A".
+	promptSectionCampaign = "context:"
+
+	// compareEvidenceReserve is split evenly between the campaigns so none of them
+	// crowds the others out of the prompt.
+	compareEvidenceReserve = 0.45
+
+	// minCompareDocs - Floor of each campaign's retrieval quota.
+	minCompareDocs = 4
+)
+
+// campaignRef - A campaign of a comparative turn and the label the answer knows it by.
+type campaignRef struct {
+	id    string
+	name  string
+	label string // "A", "B", ...
+}
+
+func (c campaignRef) title() string {
+	if c.name == "" || c.name == c.id {
+		return fmt.Sprintf("Campaign %s (%s)", c.label, c.id)
+	}
+	return fmt.Sprintf("Campaign %s (%s)", c.label, c.name)
+}
+
+// campaignEvidence - What retrieval found for one campaign.
+type campaignEvidence struct {
+	campaign  campaignRef
+	output    search.SearchOutput
+	aggregate *search.AggregateOutput // nil when the aggregation failed
+}
+
+// campaignList - primary followed by the other campaigns, without duplicates.
+func campaignList(primary string, ids []string) []string {
+	list := make([]string, 0, len(ids)+1)
+	if primary != "" {
+		list = append(list, primary)
+	}
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id != "" && !slices.Contains(list, id) {
+			list = append(list, id)
+		}
+	}
+	return list
+}
+
+// turnCampaigns resolves the campaigns a turn compares: primary plus ids. A single
+// campaign is a plain turn and returns nil. A comparative turn reaches campaigns
+// beyond the conversation's own, so sc must be able to read every one of them.
+func (uc *implUseCase) turnCampaigns(ctx context.Context, sc model.Scope, primary string, ids []string) ([]campaignRef, error) {
+	list := campaignList(primary, ids)
+	if len(list) <= 1 {
+		return nil, nil
+	}
+	if len(list) > chat.MaxCompareCampaigns {
+		return nil, chat.ErrTooManyCampaigns
+	}
+
+	campaigns := make([]campaignRef, 0, len(list))
+	for i, id := range list {
+		access, err := uc.searchUC.CampaignAccess(ctx, sc, id)
+		if err != nil {
+			uc.l.Warnf(ctx, "chat.usecase.turnCampaigns: CampaignAccess %s failed: %v", id, err)
+			if errors.Is(err, search.ErrForbidden) || errors.Is(err, search.ErrCampaignNotFound) || errors.Is(err, search.ErrCampaignNoProjects) {
+				return nil, fmt.Errorf("%w: %s", chat.ErrCampaignAccess, id)
+			}
+			return nil, fmt.Errorf("%w: %v", chat.ErrSearchFailed, err)
+		}
+		campaigns = append(campaigns, campaignRef{
+			id:    id,
+			name:  access.Name,
+			label: string(rune('A' + i)),
+		})
+	}
+	return campaigns, nil
+}
+
+// questionCampaigns - The campaigns a stored question was answered on.
+func questionCampaigns(conv model.Conversation, tree messageTree, questionID string) []string {
+	if ids, ok := answerCampaigns(tree.children[questionID]); ok {
+		return ids
+	}
+	return conversationCampaigns(conv)
+}
+
+// followUpCampaigns - The campaigns of the latest answer on the branch: a follow-up
+// naming no campaigns keeps comparing what the previous turn compared.
+func followUpCampaigns(conv model.Conversation, history chatHistory) []string {
+	msgs := slices.Clone(history.recent)
+	slices.Reverse(msgs)
+	if ids, ok := answerCampaigns(msgs); ok {
+		return ids
+	}
+	return conversationCampaigns(conv)
+}
+
+// answerCampaigns reads the campaigns of the first answer among msgs from its
+// retrieval metadata; empty for an answer on the conversation's campaign alone.
+func answerCampaigns(msgs []model.Message) ([]string, bool) {
+	for _, m := range msgs {
+		if m.Role != "assistant" || len(m.SearchMetadata) == 0 {
+			continue
+		}
+		var meta chat.SearchMeta
+		if err := json.Unmarshal(m.SearchMetadata, &meta); err != nil {
+			continue
+		}
+		ids := make([]string, 0, len(meta.Campaigns))
+		for _, c := range meta.Campaigns {
+			ids = append(ids, c.CampaignID)
+		}
+		return ids, true
+	}
+	return nil, false
+}
+
+// conversationCampaigns - The campaigns the conversation started with, when no
+// answer tells which ones a turn compared.
+func conversationCampaigns(conv model.Conversation) []string {
+	return conv.CampaignIDs[:min(len(conv.CampaignIDs), chat.MaxCompareCampaigns)]
+}
+
+// mergeCampaignIDs adds the campaigns of a turn to those the conversation involved.
+func mergeCampaignIDs(current []string, campaigns []campaignRef) []string {
+	ids := slices.Clone(current)
+	for _, c := range campaigns {
+		if !slices.Contains(ids, c.id) {
+			ids = append(ids, c.id)
+		}
+	}
+	return ids
+}
+
+// compareAnswer answers a question across several campaigns. Each campaign is
+// searched on its own quota so the largest one cannot fill the evidence alone, and
+// its aggregates are laid side by side with the others'. The agent and the answer
+// cache work on one campaign and are not used.
+func (uc *implUseCase) compareAnswer(
+	ctx context.Context,
+	sc model.Scope,
+	conversation model.Conversation,
+	history chatHistory,
+	input chat.ChatInput,
+	campaigns []campaignRef,
+	explicitFilters search.SearchFilters,
+	understanding chat.QueryUnderstanding,
+	startTime time.Time,
+) (chat.ChatOutput, error) {
+	intent := QueryIntent(understanding.Intent)
+	limit, minScore := retrievalParams(intent)
+	quota := max((limit+len(campaigns)-1)/len(campaigns), minCompareDocs)
+	filters, inferred := mergeUnderstoodFilters(explicitFilters, understanding)
+
+	evidence, err := uc.compareSearch(ctx, sc, input, campaigns, intent, filters, quota, minScore)
+	if err != nil {
+		uc.l.Errorf(ctx, "chat.usecase.compareAnswer: Search failed: %v", err)
+		return chat.ChatOutput{}, fmt.Errorf("%w: %v", chat.ErrSearchFailed, err)
+	}
+	// Campaigns are compared under the same filters: relax them for all or none
+	if inferred && !hasEvidence(evidence) {
+		relaxed, err := uc.compareSearch(ctx, sc, input, campaigns, intent, explicitFilters, quota, minScore)
+		if err != nil {
+			uc.l.Warnf(ctx, "chat.usecase.compareAnswer: relaxed Search failed: %v", err)
+		} else {
+			evidence, filters = relaxed, explicitFilters
+		}
+	}
+	uc.compareAggregates(ctx, sc, evidence, filters)
+
+	var docs []search.SearchResult
+	docCampaigns := make(map[string]string)
+	for _, e := range evidence {
+		for _, doc := range e.output.Results {
+			if _, ok := docCampaigns[doc.ID]; !ok {
+				docCampaigns[doc.ID] = e.campaign.id
+			}
+		}
+		docs = append(docs, e.output.Results...)
+	}
+
+	output := chat.ChatOutput{
+		ConversationID: conversation.ID,
+		QueryIntent:    string(intent),
+		Understanding:  understanding,
+		Backend:        "Qdrant",
+		CampaignIDs:    make([]string, len(campaigns)),
+	}
+	for i, c := range campaigns {
+		output.CampaignIDs[i] = c.id
+	}
+
+	if len(docs) == 0 && !hasAggregates(evidence) {
+		output.Answer = "Mình chưa tìm thấy đủ dữ liệu liên quan trong các campaign đã chọn để so sánh. Bạn có thể hỏi hẹp hơn theo nền tảng, khoảng thời gian, hoặc chủ đề cụ thể như giá, giao hàng, hỗ trợ."
+		output.Suggestions = compareSuggestions()
+		output.SearchMetadata = compareSearchMeta(evidence, nil, startTime, uc.llm.Name())
+		output.CitationGroups = groupCitations(nil, output.SearchMetadata.Campaigns)
+		output.QuestionID, output.MessageID = uc.persistChatExchange(ctx, conversation, history, input, filters, output.Answer, nil, output.Suggestions, output.SearchMetadata)
+		return output, nil
+	}
+
+	prompt, tokenUsage := uc.buildComparePrompt(input.Message, evidence, history)
+
+	llmCtx, llmCancel := context.WithTimeout(ctx, 60*time.Second)
+	defer llmCancel()
+	answer, err := uc.llm.Generate(llmCtx, prompt)
+	if err != nil {
+		uc.l.Errorf(ctx, "chat.usecase.compareAnswer: LLM failed: %v", err)
+		return chat.ChatOutput{}, fmt.Errorf("%w: %v", chat.ErrLLMFailed, err)
+	}
+
+	answer, numericCheck := uc.checkFigures(llmCtx, answer, uc.compareFacts(input.Message, evidence, docs),
+		func(ctx context.Context, draft, feedback string) (string, error) {
+			return uc.llm.Generate(ctx, rewritePrompt(prompt, "Assistant:", draft, feedback))
+		})
+	grounded := groundAnswer(answer, docs, nil)
+	citations := uc.extractCitations(grounded.Cited)
+	for i := range citations {
+		citations[i].CampaignID = docCampaigns[citations[i].ID]
+	}
+
+	output.Answer = grounded.Text
+	output.Citations = citations
+	output.Suggestions = compareSuggestions()
+	output.SearchMetadata = compareSearchMeta(evidence, citations, startTime, uc.llm.Name())
+	output.SearchMetadata.Groundedness = grounded.Groundedness
+	output.SearchMetadata.NumericCheck = numericCheck
+	output.SearchMetadata.TokenUsage = &tokenUsage
+	output.CitationGroups = groupCitations(citations, output.SearchMetadata.Campaigns)
+	output.QuestionID, output.MessageID = uc.persistChatExchange(ctx, conversation, history, input, filters, output.Answer, citations, output.Suggestions, output.SearchMetadata)
+	return output, nil
+}
+
+// compareSearch searches every campaign in parallel, each up to quota results.
+func (uc *implUseCase) compareSearch(
+	ctx context.Context,
+	sc model.Scope,
+	input chat.ChatInput,
+	campaigns []campaignRef,
+	intent QueryIntent,
+	filters search.SearchFilters,
+	quota int,
+	minScore float64,
+) ([]campaignEvidence, error) {
+	evidence := make([]campaignEvidence, len(campaigns))
+	g, gCtx := errgroup.WithContext(ctx)
+	for i, c := range campaigns {
+		evidence[i].campaign = c
+		g.Go(func() error {
+			out, err := uc.searchUC.Search(gCtx, sc, search.SearchInput{
+				CampaignID: c.id,
+				Query:      input.Message,
+				Filters:    filters,
+				Limit:      quota,
+				MinScore:   minScore,
+				Intent:     string(intent),
+				Recency:    InferRecency(input.Message),
+				Layers:     &search.LayerQuotas{},
+				Diversity:  &search.DiversityOptions{},
+			})
+			if err != nil {
+				return fmt.Errorf("campaign %s: %w", c.id, err)
+			}
+			if !out.NoRelevantContext {
+				evidence[i].output = out
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+// compareAggregates fills the side-by-side aggregates. A campaign whose
+// aggregation fails is shown without them rather than failing the turn.
+func (uc *implUseCase) compareAggregates(ctx context.Context, sc model.Scope, evidence []campaignEvidence, filters search.SearchFilters) {
+	var g errgroup.Group
+	for i := range evidence {
+		g.Go(func() error {
+			out, err := uc.searchUC.Aggregate(ctx, sc, search.AggregateInput{
+				CampaignID: evidence[i].campaign.id,
+				Filters:    filters,
+			})
+			if err != nil {
+				uc.l.Warnf(ctx, "chat.usecase.compareAggregates: Aggregate %s failed: %v", evidence[i].campaign.id, err)
+				return nil
+			}
+			evidence[i].aggregate = &out
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
+
+func hasEvidence(evidence []campaignEvidence) bool {
+	return slices.ContainsFunc(evidence, func(e campaignEvidence) bool { return len(e.output.Results) > 0 })
+}
+
+func hasAggregates(evidence []campaignEvidence) bool {
+	return slices.ContainsFunc(evidence, func(e campaignEvidence) bool { return e.aggregate != nil && e.aggregate.TotalDocs > 0 })
+}
+
+// buildComparePrompt packs a comparative prompt: the side-by-side aggregates, then
+// the evidence of each campaign under its own label. Documents are numbered across
+// campaigns, so [n] is still docs[n-1].
+func (uc *implUseCase) buildComparePrompt(question string, evidence []campaignEvidence, history chatHistory) (string, tokenbudget.Usage) {
+	questionLine := fmt.Sprintf("User: %s\nAssistant:", question)
+	fixed := systemPrompt + "\n\n" + comparePrompt + "\n\n" + questionLine
+
+	sections := make([]tokenbudget.Section, 0, len(evidence)+3)
+	n := 0
+	for _, e := range evidence {
+		items := make([]string, 0, len(e.output.Results))
+		for _, doc := range e.output.Results {
+			n++
+			if isMacroLayer(doc) {
+				items = append(items, analysisLine(n, doc))
+			} else {
+				items = append(items, contextLine(n, doc))
+			}
+		}
+		sections = append(sections, tokenbudget.Section{
+			Name:     promptSectionCampaign + e.campaign.label,
+			Header:   e.campaign.title() + ":\n",
+			Items:    items,
+			Footer:   "\n",
+			Priority: 1,
+			Reserve:  compareEvidenceReserve / float64(len(evidence)),
+		})
+	}
+	sections = append(sections, tokenbudget.Section{
+		Name:     promptSectionAggregates,
+		Header:   aggregatesHeader,
+		Items:    aggregateLines(evidence),
+		Footer:   "\n",
+		Priority: 2,
+		Reserve:  0.15,
+	})
+	if memory := uc.buildMemoryBlock(history); memory != "" {
+		sections = append(sections, tokenbudget.Section{Name: promptSectionMemory, Items: []string{memory}, Priority: 3, Reserve: 0.05})
+	}
+	historyItems := make([]string, 0, len(history.recent))
+	for _, msg := range history.recent {
+		historyItems = append(historyItems, historyLine(msg))
+	}
+	sections = append(sections, tokenbudget.Section{
+		Name:     promptSectionHistory,
+		Header:   historyHeader,
+		Items:    historyItems,
+		Footer:   "\n",
+		Priority: 4,
+		Reserve:  0.15,
+		Newest:   true,
+	})
+	packed := tokenbudget.Pack(uc.config.PromptTokenBudget, fixed, sections)
+
+	var b strings.Builder
+	b.WriteString(systemPrompt)
+	b.WriteString("\n\n")
+	b.WriteString(comparePrompt)
+	b.WriteString("\n\n")
+	b.WriteString(packed.Text(promptSectionAggregates))
+	for _, e := range evidence {
+		if block := packed.Text(promptSectionCampaign + e.campaign.label); block != "" {
+			b.WriteString(block)
+		} else {
+			b.WriteString(e.campaign.title() + ": Không có documents liên quan.\n\n")
+		}
+	}
+	b.WriteString(packed.Text(promptSectionMemory))
+	b.WriteString(packed.Text(promptSectionHistory))
+	b.WriteString(questionLine)
+	return b.String(), packed.Usage
+}
+
+// aggregateLines - One line per campaign, in the format of the aggregate tool.
+func aggregateLines(evidence []campaignEvidence) []string {
+	lines := make([]string, 0, len(evidence))
+	for _, e := range evidence {
+		out := e.aggregate
+		if out == nil {
+			lines = append(lines, fmt.Sprintf("- %s: không có dữ liệu tổng hợp\n", e.campaign.title()))
+			continue
+		}
+		line := fmt.Sprintf("- %s: %d documents; Sentiment: %s; Nền tảng: %s",
+			e.campaign.title(), out.TotalDocs,
+			formatBreakdown(out.SentimentBreakdown, out.TotalDocs),
+			formatBreakdown(out.PlatformBreakdown, out.TotalDocs))
+		if len(out.TopNegativeAspects) > 0 {
+			aspects := make(map[string]uint64, len(out.TopNegativeAspects))
+			for _, a := range out.TopNegativeAspects {
+				aspects[a.Aspect] = a.Count
+			}
+			line += fmt.Sprintf("; Aspect tiêu cực nhiều nhất: %s", formatBreakdown(aspects, 0))
+		}
+		lines = append(lines, line+"\n")
+	}
+	return lines
+}
+
+// compareFacts - Figures the comparative prompt gave the model.
+func (uc *implUseCase) compareFacts(question string, evidence []campaignEvidence, docs []search.SearchResult) []factcheck.Fact {
+	facts := factcheck.FactsFromText(question, factSourceQuestion)
+	facts = append(facts, factcheck.FactsFromText(strings.Join(aggregateLines(evidence), ""), factSourceAnalytics)...)
+	facts = append(facts, factcheck.FactsFromText(uc.buildAnalysisBlock(docs), factSourceDocuments)...)
+	return append(facts, factcheck.FactsFromText(uc.buildContextBlock(docs), factSourceDocuments)...)
+}
+
+// compareSearchMeta - Retrieval totals and the per-campaign breakdown.
+func compareSearchMeta(evidence []campaignEvidence, citations []chat.Citation, startTime time.Time, modelName string) chat.SearchMeta {
+	meta := chat.SearchMeta{
+		DocsUsed:         len(citations),
+		ProcessingTimeMs: time.Since(startTime).Milliseconds(),
+		ModelUsed:        modelName,
+		Campaigns:        make([]chat.CampaignMeta, 0, len(evidence)),
+	}
+	for _, e := range evidence {
+		cm := chat.CampaignMeta{
+			CampaignID:   e.campaign.id,
+			CampaignName: e.campaign.name,
+			Label:        e.campaign.label,
+			DocsSearched: e.output.TotalFound,
+		}
+		if e.aggregate != nil {
+			cm.TotalDocs = e.aggregate.TotalDocs
+		}
+		for _, c := range citations {
+			if c.CampaignID == e.campaign.id {
+				cm.DocsUsed++
+			}
+		}
+		meta.TotalDocsSearched += e.output.TotalFound
+		meta.SuppressedDocs += e.output.SuppressedRedundant
+		meta.Campaigns = append(meta.Campaigns, cm)
+	}
+	return meta
+}
+
+// groupCitations splits citations by campaign, one group per campaign in order,
+// empty groups included so every compared campaign is listed.
+func groupCitations(citations []chat.Citation, campaigns []chat.CampaignMeta) []chat.CitationGroup {
+	if len(campaigns) == 0 {
+		return nil
+	}
+	groups := make([]chat.CitationGroup, len(campaigns))
+	for i, c := range campaigns {
+		groups[i] = chat.CitationGroup{
+			CampaignID:   c.CampaignID,
+			CampaignName: c.CampaignName,
+			Label:        c.Label,
+			Citations:    []chat.Citation{},
+		}
+		for _, citation := range citations {
+			if citation.CampaignID == c.CampaignID {
+				groups[i].Citations = append(groups[i].Citations, citation)
+			}
+		}
+	}
+	return groups
+}
+
+func compareSuggestions() []string {
+	return []string{
+		"Khác biệt lớn nhất giữa các campaign là gì?",
+		"So sánh theo từng nền tảng?",
+		"Xu hướng theo thời gian của từng campaign?",
+	}
+}
@@ -3,10 +3,12 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"knowledge-srv/internal/chat"
 	"knowledge-srv/internal/chat/repository"
 	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/share"
 )
 
 func (uc *implUseCase) GetConversation(ctx context.Context, sc model.Scope, input chat.GetConversationInput) (chat.ConversationOutput, error) {
@@ -14,16 +16,33 @@ func (uc *implUseCase) GetConversation(ctx context.Context, sc model.Scope, inpu
 	if err != nil {
 		return chat.ConversationOutput{}, chat.ErrConversationNotFound
 	}
+	if !uc.canReadConversation(ctx, sc, conv, input.ShareToken) {
+		return chat.ConversationOutput{}, chat.ErrConversationNotFound
+	}
 
-	msgs, err := uc.repo.ListMessages(ctx, repository.ListMessagesOptions{
-		ConversationID: conv.ID,
-		OrderASC:       true,
-	})
+	tree, err := uc.loadMessageTree(ctx, conv.ID)
 	if err != nil {
 		uc.l.Warnf(ctx, "chat.usecase.GetConversation: ListMessages failed: %v", err)
+		tree = newMessageTree(nil)
 	}
 
-	return uc.toConversationOutput(conv, msgs), nil
+	return uc.toBranchOutput(conv, tree), nil
+}
+
+// canReadConversation - The owner, admins and whoever the conversation is shared with.
+func (uc *implUseCase) canReadConversation(ctx context.Context, sc model.Scope, conv model.Conversation, token string) bool {
+	if sc.UserID == "" || sc.UserID == conv.UserID || sc.IsAdmin() {
+		return true
+	}
+	if uc.shareUC == nil {
+		return false
+	}
+	return uc.shareUC.CanRead(ctx, sc, share.Resource{
+		Type:       share.ResourceConversation,
+		ID:         conv.ID,
+		CampaignID: conv.CampaignID,
+		OwnerID:    conv.UserID,
+	}, token)
 }
 
 func (uc *implUseCase) ListConversations(ctx context.Context, sc model.Scope, input chat.ListConversationsInput) ([]chat.ConversationOutput, error) {
@@ -49,10 +68,31 @@ func (uc *implUseCase) ListConversations(ctx context.Context, sc model.Scope, in
 	return results, nil
 }
 
+// toBranchOutput - The conversation with the messages of its active branch, each
+// with its siblings.
+func (uc *implUseCase) toBranchOutput(conv model.Conversation, tree messageTree) chat.ConversationOutput {
+	leafID := tree.leaf(conv.ActiveLeafID)
+	output := uc.toConversationOutput(conv, nil)
+	output.ActiveLeafID = leafID
+	for _, m := range tree.path(leafID) {
+		msg := uc.toMessageOutput(m)
+		for i, sibling := range tree.children[m.ParentID] {
+			msg.SiblingIDs = append(msg.SiblingIDs, sibling.ID)
+			if sibling.ID == m.ID {
+				msg.SiblingIndex = i
+			}
+		}
+		msg.SiblingCount = len(msg.SiblingIDs)
+		output.Messages = append(output.Messages, msg)
+	}
+	return output
+}
+
 func (uc *implUseCase) toConversationOutput(conv model.Conversation, msgs []model.Message) chat.ConversationOutput {
 	output := chat.ConversationOutput{
 		ID:            conv.ID,
 		CampaignID:    conv.CampaignID,
+		CampaignIDs:   conv.CampaignIDs,
 		UserID:        conv.UserID,
 		Title:         conv.Title,
 		Status:        conv.Status,
@@ -69,6 +109,7 @@ func (uc *implUseCase) toConversationOutput(conv model.Conversation, msgs []mode
 func (uc *implUseCase) toMessageOutput(m model.Message) chat.MessageOutput {
 	output := chat.MessageOutput{
 		ID:        m.ID,
+		ParentID:  m.ParentID,
 		Role:      m.Role,
 		Content:   m.Content,
 		CreatedAt: m.CreatedAt,
@@ -86,6 +127,9 @@ func (uc *implUseCase) toMessageOutput(m model.Message) chat.MessageOutput {
 			output.SearchMetadata = &meta
 		}
 	}
+	if output.SearchMetadata != nil {
+		output.CitationGroups = groupCitations(output.Citations, output.SearchMetadata.Campaigns)
+	}
 	if len(m.Suggestions) > 0 && string(m.Suggestions) != "null" {
 		var suggestions []string
 		if err := json.Unmarshal(m.Suggestions, &suggestions); err == nil {
@@ -101,3 +145,13 @@ func (uc *implUseCase) toMessageOutput(m model.Message) chat.MessageOutput {
 
 	return output
 }
+
+// PurgeCampaign deletes every conversation of a deleted campaign.
+func (uc *implUseCase) PurgeCampaign(ctx context.Context, campaignID string) (chat.PurgeCampaignOutput, error) {
+	n, err := uc.repo.DeleteCampaignConversations(ctx, campaignID)
+	if err != nil {
+		uc.l.Errorf(ctx, "chat.usecase.PurgeCampaign: DeleteCampaignConversations %s failed: %v", campaignID, err)
+		return chat.PurgeCampaignOutput{}, fmt.Errorf("%w: %v", chat.ErrPurgeFailed, err)
+	}
+	return chat.PurgeCampaignOutput{Conversations: n}, nil
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateSpec - A date range as the user phrased it. It is resolved against the
+// current time on every use, so a cached understanding never goes stale.
+type dateSpec struct {
+	Kind   string `json:"kind"`             // last_n | current | previous | absolute
+	Unit   string `json:"unit,omitempty"`   // day | week | month | year
+	Amount int    `json:"amount,omitempty"` // last_n only
+	From   string `json:"from,omitempty"`   // absolute only, YYYY-MM-DD
+	To     string `json:"to,omitempty"`     // absolute only, YYYY-MM-DD (inclusive)
+}
+
+const (
+	dateKindLastN    = "last_n"
+	dateKindCurrent  = "current"
+	dateKindPrevious = "previous"
+	dateKindAbsolute = "absolute"
+
+	dateLayout     = "2006-01-02"
+	maxDateAmount  = 3650
+	maxDateSpanDay = 3 * 366
+)
+
+var dateUnits = map[string]bool{"day": true, "week": true, "month": true, "year": true}
+
+// vietnamTZ - Calendar boundaries ("hôm nay", "tháng trước") follow the users' timezone.
+var vietnamTZ = time.FixedZone("ICT", 7*60*60)
+
+func (s dateSpec) valid() bool {
+	switch s.Kind {
+	case dateKindLastN:
+		return dateUnits[s.Unit] && s.Amount > 0 && s.Amount <= maxDateAmount
+	case dateKindCurrent, dateKindPrevious:
+		return dateUnits[s.Unit]
+	case dateKindAbsolute:
+		from, errFrom := time.ParseInLocation(dateLayout, s.From, vietnamTZ)
+		to, errTo := time.ParseInLocation(dateLayout, s.To, vietnamTZ)
+		return errFrom == nil && errTo == nil && !to.Before(from) && to.Sub(from) <= maxDateSpanDay*24*time.Hour
+	}
+	return false
+}
+
+// label describes the range for logs and the response, e.g. "previous month".
+func (s dateSpec) label() string {
+	switch s.Kind {
+	case dateKindLastN:
+		return fmt.Sprintf("last %d %ss", s.Amount, s.Unit)
+	case dateKindCurrent:
+		return "current " + s.Unit
+	case dateKindPrevious:
+		return "previous " + s.Unit
+	case dateKindAbsolute:
+		return s.From + ".." + s.To
+	}
+	return ""
+}
+
+// resolve returns the range as unix seconds [from, to], matching content_created_at.
+func (s dateSpec) resolve(now time.Time) (int64, int64, bool) {
+	if !s.valid() {
+		return 0, 0, false
+	}
+	now = now.In(vietnamTZ)
+
+	switch s.Kind {
+	case dateKindLastN:
+		return addUnits(now, s.Unit, -s.Amount).Unix(), now.Unix(), true
+	case dateKindCurrent:
+		return startOfUnit(now, s.Unit).Unix(), now.Unix(), true
+	case dateKindPrevious:
+		end := startOfUnit(now, s.Unit)
+		return addUnits(end, s.Unit, -1).Unix(), end.Unix() - 1, true
+	case dateKindAbsolute:
+		from, _ := time.ParseInLocation(dateLayout, s.From, vietnamTZ)
+		to, _ := time.ParseInLocation(dateLayout, s.To, vietnamTZ)
+		return from.Unix(), to.AddDate(0, 0, 1).Unix() - 1, true
+	}
+	return 0, 0, false
+}
+
+func addUnits(t time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "month":
+		return t.AddDate(0, n, 0)
+	case "year":
+		return t.AddDate(n, 0, 0)
+	}
+	return t.AddDate(0, 0, n)
+}
+
+// startOfUnit truncates t to the start of its day, ISO week (Monday), month or year.
+func startOfUnit(t time.Time, unit string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch unit {
+	case "week":
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case "year":
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
+
+// "7 ngày qua", "3 tháng gần đây" / "last 7 days", "past 2 weeks"
+var (
+	viLastNPattern = regexp.MustCompile(`(\d{1,4})\s*(ngày|tuần|tháng|năm)\s*(qua|gần đây|gần nhất|vừa qua|vừa rồi|trở lại đây|trước)`)
+	enLastNPattern = regexp.MustCompile(`\b(?:last|past|previous)\s+(\d{1,4})\s+(day|week|month|year)s?\b`)
+)
+
+var viUnits = map[string]string{"ngày": "day", "tuần": "week", "tháng": "month", "năm": "year"}
+
+// dateCues - Fixed phrases, checked in order after the "N units" patterns.
+var dateCues = []struct {
+	phrases []string
+	spec    dateSpec
+}{
+	{[]string{"hôm qua", "yesterday"}, dateSpec{Kind: dateKindPrevious, Unit: "day"}},
+	{[]string{"hôm nay", "today"}, dateSpec{Kind: dateKindCurrent, Unit: "day"}},
+	{[]string{"tuần trước", "last week", "previous week"}, dateSpec{Kind: dateKindPrevious, Unit: "week"}},
+	{[]string{"tuần qua", "past week"}, dateSpec{Kind: dateKindLastN, Unit: "day", Amount: 7}},
+	{[]string{"tuần này", "this week"}, dateSpec{Kind: dateKindCurrent, Unit: "week"}},
+	{[]string{"tháng trước", "tháng vừa rồi", "last month", "previous month"}, dateSpec{Kind: dateKindPrevious, Unit: "month"}},
+	{[]string{"tháng qua", "past month"}, dateSpec{Kind: dateKindLastN, Unit: "day", Amount: 30}},
+	{[]string{"tháng này", "this month"}, dateSpec{Kind: dateKindCurrent, Unit: "month"}},
+	{[]string{"năm ngoái", "năm trước", "last year", "previous year"}, dateSpec{Kind: dateKindPrevious, Unit: "year"}},
+	{[]string{"năm nay", "this year"}, dateSpec{Kind: dateKindCurrent, Unit: "year"}},
+}
+
+// parseDateRange extracts a date range from Vietnamese or English phrasing.
+// Returns false when the question names no period.
+func parseDateRange(query string) (dateSpec, bool) {
+	q := strings.ToLower(strings.TrimSpace(query))
+
+	if m := viLastNPattern.FindStringSubmatch(q); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			spec := dateSpec{Kind: dateKindLastN, Unit: viUnits[m[2]], Amount: n}
+			if spec.valid() {
+				return spec, true
+			}
+		}
+	}
+	if m := enLastNPattern.FindStringSubmatch(q); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			spec := dateSpec{Kind: dateKindLastN, Unit: m[2], Amount: n}
+			if spec.valid() {
+				return spec, true
+			}
+		}
+	}
+
+	for _, cue := range dateCues {
+		for _, phrase := range cue.phrases {
+			if hasPhrase(q, phrase) {
+				return cue.spec, true
+			}
+		}
+	}
+	return dateSpec{}, false
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"knowledge-srv/internal/factcheck"
+	"knowledge-srv/internal/search"
+	analyticspkg "knowledge-srv/pkg/analytics"
+)
+
+// Sources of the facts an answer is checked against
+const (
+	factSourceQuestion  = "question"
+	factSourceAnalytics = "analytics"
+	factSourceDocuments = "documents"
+)
+
+// ragFacts - Figures the retrieval prompt gave the model. History is left out:
+// figures from earlier answers are not a source.
+func (uc *implUseCase) ragFacts(question string, docs []search.SearchResult, snapshot *analyticspkg.Snapshot) []factcheck.Fact {
+	facts := factcheck.FactsFromText(question, factSourceQuestion)
+	if snapshot != nil && snapshot.HasData() {
+		facts = append(facts, factcheck.FactsFromText(uc.buildAnalyticsContextBlock(*snapshot), factSourceAnalytics)...)
+	}
+	facts = append(facts, factcheck.FactsFromText(uc.buildAnalysisBlock(docs), factSourceDocuments)...)
+	return append(facts, factcheck.FactsFromText(uc.buildContextBlock(docs), factSourceDocuments)...)
+}
+
+// agentFacts - Figures the agent saw: the question and every tool result, by tool call ID.
+func agentFacts(run *agentRun) []factcheck.Fact {
+	facts := factcheck.FactsFromText(run.input.Message, factSourceQuestion)
+	for _, c := range run.calls {
+		facts = append(facts, factcheck.FactsFromText(c.Result, c.ID)...)
+	}
+	return facts
+}
+
+// checkFigures verifies the figures of an answer. The result is nil when the check
+// is off or the answer states no figure.
+func (uc *implUseCase) checkFigures(ctx context.Context, answer string, facts []factcheck.Fact, regenerate factcheck.Regenerate) (string, *factcheck.Result) {
+	if !uc.config.FactCheck.Enabled() {
+		return answer, nil
+	}
+	checked, res := factcheck.Check(ctx, uc.config.FactCheck, answer, facts, regenerate)
+	if res.Checked == 0 {
+		return checked, nil
+	}
+	if len(res.Unsupported) > 0 {
+		uc.l.Warnf(ctx, "chat.usecase.checkFigures: %d/%d figures unsupported (mode=%s, regenerated=%v)",
+			len(res.Unsupported), res.Checked, res.Mode, res.Regenerated)
+	}
+	return checked, &res
+}
+
+// rewritePrompt asks for a rewrite of a draft on the prompt that produced it.
+// suffix is the prompt's answer cue ("Assistant:", "JSON:").
+func rewritePrompt(prompt, suffix, draft, feedback string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(prompt, suffix))
+	b.WriteString("Bản nháp trước:\n")
+	b.WriteString(draft)
+	b.WriteString("\n\n")
+	b.WriteString(feedback)
+	b.WriteString("\n")
+	b.WriteString(suffix)
+	return b.String()
+}
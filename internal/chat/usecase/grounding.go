@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/search"
+)
+
+// minClaimWords - Shorter sentences (headings, "Tóm lại:") are not counted as claims.
+const minClaimWords = 3
+
+var (
+	// "[1]", "[2, 5]", "[T1]", "[T2, 3]" with the spaces before it
+	citationMarkerPattern = regexp.MustCompile(`[ \t]*\[(T?\d{1,3}(?:\s*,\s*T?\d{1,3})*)\]`)
+	// Sentence end: punctuation, any markers right after it, then whitespace or end of line.
+	// A period inside a number ("40.5%") is not followed by whitespace and does not split.
+	sentenceEndPattern = regexp.MustCompile(`[.!?…]+(?:\s*\[[^\]]*\])*(?:\s+|$)`)
+	listPrefixPattern  = regexp.MustCompile(`^(?:[-*•]+|\d+[.)])\s*`)
+)
+
+// groundedAnswer - The answer with validated citation markers.
+type groundedAnswer struct {
+	Text         string
+	Cited        []search.SearchResult // in first-citation order; [n] in Text is Cited[n-1]
+	Groundedness float64
+}
+
+// groundAnswer validates the [n] markers of an answer against the documents it was
+// given and the [Tn] markers against the tool calls. Unknown markers are stripped,
+// cited documents are renumbered in the order they are first cited, and the
+// groundedness score is the share of claim sentences carrying a valid marker.
+func groundAnswer(answer string, docs []search.SearchResult, calls []chat.ToolCall) groundedAnswer {
+	toolIDs := make(map[string]bool, len(calls))
+	for _, c := range calls {
+		toolIDs[c.ID] = true
+	}
+
+	renumber := make(map[int]int)
+	var cited []search.SearchResult
+	text := citationMarkerPattern.ReplaceAllStringFunc(answer, func(marker string) string {
+		m := citationMarkerPattern.FindStringSubmatch(marker)
+		var refs []string
+		for _, part := range strings.Split(m[1], ",") {
+			part = strings.TrimSpace(part)
+			if strings.HasPrefix(part, "T") {
+				if toolIDs[part] && !slices.Contains(refs, part) {
+					refs = append(refs, part)
+				}
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 1 || n > len(docs) {
+				continue
+			}
+			k, ok := renumber[n]
+			if !ok {
+				cited = append(cited, docs[n-1])
+				k = len(cited)
+				renumber[n] = k
+			}
+			if ref := strconv.Itoa(k); !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+		if len(refs) == 0 {
+			return ""
+		}
+		return fmt.Sprintf(" [%s]", strings.Join(refs, ", "))
+	})
+
+	return groundedAnswer{
+		Text:         strings.TrimSpace(text),
+		Cited:        cited,
+		Groundedness: groundedness(text),
+	}
+}
+
+// groundedness - Share of claim sentences with at least one citation marker.
+// 0 when the answer has no claim sentence.
+func groundedness(text string) float64 {
+	claims, grounded := 0, 0
+	for _, sentence := range splitSentences(text) {
+		bare := citationMarkerPattern.ReplaceAllString(sentence, "")
+		bare = listPrefixPattern.ReplaceAllString(strings.TrimSpace(bare), "")
+		if len(strings.Fields(bare)) < minClaimWords {
+			continue
+		}
+		claims++
+		if citationMarkerPattern.MatchString(sentence) {
+			grounded++
+		}
+	}
+	if claims == 0 {
+		return 0
+	}
+	return float64(grounded) / float64(claims)
+}
+
+// splitSentences splits on line breaks and sentence punctuation, keeping markers
+// placed after the punctuation with the sentence they follow.
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, line := range strings.Split(text, "\n") {
+		start := 0
+		for _, loc := range sentenceEndPattern.FindAllStringIndex(line, -1) {
+			if s := strings.TrimSpace(line[start:loc[1]]); s != "" {
+				sentences = append(sentences, s)
+			}
+			start = loc[1]
+		}
+		if s := strings.TrimSpace(line[start:]); s != "" {
+			sentences = append(sentences, s)
+		}
+	}
+	return sentences
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/search"
+)
+
+func TestGroundAnswer(t *testing.T) {
+	docs := []search.SearchResult{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	calls := []chat.ToolCall{{ID: "T1"}}
+
+	cases := []struct {
+		name      string
+		answer    string
+		calls     []chat.ToolCall
+		want      string
+		wantCited []string
+		wantScore float64
+	}{
+		{
+			name:      "documents are renumbered in first-citation order",
+			answer:    "Khách phàn nàn giao hàng chậm [3]. Tài xế hủy đơn phút chót [1]. Giao hàng chậm lặp lại nhiều lần [3].",
+			want:      "Khách phàn nàn giao hàng chậm [1]. Tài xế hủy đơn phút chót [2]. Giao hàng chậm lặp lại nhiều lần [1].",
+			wantCited: []string{"c", "a"},
+			wantScore: 1,
+		},
+		{
+			name:      "repeated references in one marker collapse",
+			answer:    "Tổng đài không nghe máy [2, 2, 3].",
+			want:      "Tổng đài không nghe máy [1, 2].",
+			wantCited: []string{"b", "c"},
+			wantScore: 1,
+		},
+		{
+			name:      "dangling markers are stripped",
+			answer:    "Khách phàn nàn giao hàng chậm [7]. Tài xế hủy đơn phút chót [0, 2].",
+			want:      "Khách phàn nàn giao hàng chậm. Tài xế hủy đơn phút chót [1].",
+			wantCited: []string{"b"},
+			wantScore: 0.5,
+		},
+		{
+			name:      "tool marker with a matching call is kept",
+			answer:    "Tỷ lệ tiêu cực là 23% tuần này [T1]. Khách phàn nàn giao hàng chậm [1].",
+			calls:     calls,
+			want:      "Tỷ lệ tiêu cực là 23% tuần này [T1]. Khách phàn nàn giao hàng chậm [1].",
+			wantCited: []string{"a"},
+			wantScore: 1,
+		},
+		{
+			name:      "tool marker without a matching call is stripped",
+			answer:    "Tỷ lệ tiêu cực là 23% tuần này [T2]. Khách phàn nàn giao hàng chậm [T1, 1].",
+			calls:     calls,
+			want:      "Tỷ lệ tiêu cực là 23% tuần này. Khách phàn nàn giao hàng chậm [T1, 1].",
+			wantCited: []string{"a"},
+			wantScore: 0.5,
+		},
+		{
+			name:      "tool marker without any call is stripped",
+			answer:    "Tỷ lệ tiêu cực là 23% tuần này [T1].",
+			want:      "Tỷ lệ tiêu cực là 23% tuần này.",
+			wantScore: 0,
+		},
+		{
+			name:      "headings and list prefixes are not claims",
+			answer:    "Tóm lại:\n- Khách phàn nàn giao hàng chậm [1].\n- Tài xế hủy đơn phút chót.",
+			want:      "Tóm lại:\n- Khách phàn nàn giao hàng chậm [1].\n- Tài xế hủy đơn phút chót.",
+			wantCited: []string{"a"},
+			wantScore: 0.5,
+		},
+		{
+			name:      "decimal point does not split a sentence",
+			answer:    "Tiêu cực chiếm 40.5% thảo luận tuần này [2].",
+			want:      "Tiêu cực chiếm 40.5% thảo luận tuần này [1].",
+			wantCited: []string{"b"},
+			wantScore: 1,
+		},
+		{
+			name:      "answer without claims scores zero",
+			answer:    "Không có dữ liệu.",
+			want:      "Không có dữ liệu.",
+			wantScore: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := groundAnswer(tc.answer, docs, tc.calls)
+			if got.Text != tc.want {
+				t.Errorf("Text = %q, want %q", got.Text, tc.want)
+			}
+			var cited []string
+			for _, d := range got.Cited {
+				cited = append(cited, d.ID)
+			}
+			if !slices.Equal(cited, tc.wantCited) {
+				t.Errorf("Cited = %v, want %v", cited, tc.wantCited)
+			}
+			if math.Abs(got.Groundedness-tc.wantScore) > 1e-9 {
+				t.Errorf("Groundedness = %v, want %v", got.Groundedness, tc.wantScore)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ func (uc *implUseCase) extractCitations(results []search.SearchResult) []chat.Ci
 			Platform:       r.Platform,
 			Sentiment:      r.OverallSentiment,
 			URL:            sourceURLFromSearchMetadata(r.Metadata),
+			RecencyFactor:  r.RecencyFactor,
+			Layer:          r.Layer,
 		})
 	}
 	return citations
@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/chat/repository"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/tokenbudget"
+)
+
+const memoryPrompt = `Bạn tóm tắt hội thoại giữa người dùng và trợ lý phân tích dữ liệu SMAP để làm ngữ cảnh cho các lượt sau.
+Trả lời đúng MỘT JSON object, không kèm chữ nào khác: {"summary": "<tối đa 150 từ>", "key_facts": ["<dữ kiện>", ...]}
+- summary: người dùng quan tâm gì, đã hỏi gì, trợ lý đã kết luận gì
+- key_facts: tối đa 8 dữ kiện cụ thể cần nhớ chính xác (số liệu, tên, mốc thời gian); giữ nguyên số liệu như trong hội thoại
+- Gộp với tóm tắt trước nếu có, bỏ thông tin đã lỗi thời`
+
+const (
+	defaultMemoryEveryTurns     = 4
+	defaultMemoryRecentMessages = 6
+	defaultHistoryTokenBudget   = 2000
+
+	memoryTimeout       = 60 * time.Second
+	maxMemoryKeyFacts   = 8
+	maxMemoryFactRunes  = 200
+	maxHistoryMsgTokens = 250 // historyLine cuts longer messages
+)
+
+// chatHistory - What a prompt knows about earlier turns: the rolling memory and
+// the recent messages it does not cover. It also records where on the message tree
+// the new exchange goes.
+type chatHistory struct {
+	summary       string
+	keyFacts      []string
+	activeFilters *chat.ChatFilters
+	recent        []model.Message
+
+	parentID   string // branch leaf the question attaches to
+	depth      int    // messages on the branch up to parentID
+	questionID string // stored question answered again by a regenerate, empty for a new one
+}
+
+func (h chatHistory) empty() bool {
+	return h.summary == "" && len(h.keyFacts) == 0 && len(h.recent) == 0
+}
+
+// memoryReply - JSON contract of the summarizer.
+type memoryReply struct {
+	Summary  string   `json:"summary"`
+	KeyFacts []string `json:"key_facts"`
+}
+
+// loadHistory returns the history of the active branch for the next question.
+func (uc *implUseCase) loadHistory(ctx context.Context, conv model.Conversation) chatHistory {
+	tree, err := uc.loadMessageTree(ctx, conv.ID)
+	if err != nil {
+		uc.l.Warnf(ctx, "chat.usecase.loadHistory: ListMessages failed: %v", err)
+		// No history, but the question still goes on the active branch
+		return uc.branchHistory(conv, newMessageTree(nil), conv.ActiveLeafID)
+	}
+	return uc.branchHistory(conv, tree, tree.leaf(conv.ActiveLeafID))
+}
+
+// branchHistory returns the conversation memory and the newest messages of the
+// branch ending at leafID it does not cover, as many as fit the history token budget.
+func (uc *implUseCase) branchHistory(conv model.Conversation, tree messageTree, leafID string) chatHistory {
+	h := chatHistory{parentID: leafID}
+	if uc.config.MemoryEnabled {
+		h.summary = conv.MemorySummary
+		h.keyFacts = conv.MemoryKeyFacts
+		h.activeFilters = decodeChatFilters(conv.MemoryActiveFilters)
+	}
+	msgs := tree.path(leafID)
+	h.depth = len(msgs)
+
+	// Messages before MemoryMessageCount are summarized, or forgotten after a reset
+	msgs = msgs[min(conv.MemoryMessageCount, len(msgs)):]
+	msgs = msgs[max(len(msgs)-chat.MaxHistoryMessages, 0):]
+
+	budget := uc.config.HistoryTokenBudget - tokenbudget.Estimate(uc.buildMemoryBlock(h))
+	start := len(msgs)
+	for start > 0 {
+		cost := min(tokenbudget.Estimate(msgs[start-1].Content), maxHistoryMsgTokens)
+		if cost > budget {
+			break
+		}
+		budget -= cost
+		start--
+	}
+	h.recent = msgs[start:]
+	return h
+}
+
+// buildConversationBlock - Memory block, then the recent messages.
+func (uc *implUseCase) buildConversationBlock(h chatHistory) string {
+	var b strings.Builder
+	b.WriteString(uc.buildMemoryBlock(h))
+	if len(h.recent) > 0 {
+		b.WriteString(uc.buildHistoryBlock(h.recent))
+	}
+	return b.String()
+}
+
+func (uc *implUseCase) buildMemoryBlock(h chatHistory) string {
+	if h.summary == "" && len(h.keyFacts) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Tóm tắt hội thoại trước:\n")
+	if h.summary != "" {
+		b.WriteString(h.summary)
+		b.WriteString("\n")
+	}
+	if len(h.keyFacts) > 0 {
+		b.WriteString("Dữ kiện đã nêu:\n")
+		for _, f := range h.keyFacts {
+			b.WriteString(fmt.Sprintf("- %s\n", f))
+		}
+	}
+	if h.activeFilters != nil {
+		if filters := describeChatFilters(*h.activeFilters); filters != "" {
+			b.WriteString(fmt.Sprintf("Bộ lọc đang áp dụng: %s\n", filters))
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// maybeRefreshMemory summarizes in the background once MemoryEveryTurns turns have
+// piled up outside the recent window of the branch ending at leafID, depth messages
+// long. At most one refresh per conversation runs at a time in this process; the
+// conditional update covers other replicas.
+func (uc *implUseCase) maybeRefreshMemory(ctx context.Context, conv model.Conversation, leafID string, depth int, filters search.SearchFilters) {
+	if !uc.config.MemoryEnabled || uc.llm == nil {
+		return
+	}
+	target := depth - uc.config.MemoryRecentMessages
+	if target-conv.MemoryMessageCount < 2*uc.config.MemoryEveryTurns {
+		return
+	}
+	if _, running := uc.memoryJobs.LoadOrStore(conv.ID, struct{}{}); running {
+		return
+	}
+
+	active := chat.ChatFilters{
+		Sentiments: filters.Sentiments,
+		Aspects:    filters.Aspects,
+		Platforms:  filters.Platforms,
+		DateFrom:   filters.DateFrom,
+		DateTo:     filters.DateTo,
+		RiskLevels: filters.RiskLevels,
+	}
+	go func() {
+		defer uc.memoryJobs.Delete(conv.ID)
+		ctx, cancel := context.WithTimeout(context.Background(), memoryTimeout)
+		defer cancel()
+		if err := uc.refreshMemory(ctx, conv, leafID, target, active); err != nil {
+			uc.l.Warnf(ctx, "chat.usecase.maybeRefreshMemory: conversation %s: %v", conv.ID, err)
+		}
+	}()
+}
+
+// refreshMemory folds messages [MemoryMessageCount, target) of the branch ending at
+// leafID into the summary.
+func (uc *implUseCase) refreshMemory(ctx context.Context, conv model.Conversation, leafID string, target int, filters chat.ChatFilters) error {
+	tree, err := uc.loadMessageTree(ctx, conv.ID)
+	if err != nil {
+		return err
+	}
+	msgs := tree.path(leafID)
+	msgs = msgs[:min(target, len(msgs))]
+	if len(msgs) <= conv.MemoryMessageCount {
+		return nil
+	}
+
+	raw, err := uc.llm.Generate(ctx, uc.buildMemoryPrompt(conv, msgs[conv.MemoryMessageCount:]))
+	if err != nil {
+		return fmt.Errorf("%w: %v", chat.ErrLLMFailed, err)
+	}
+	reply, err := parseMemoryReply(raw)
+	if err != nil {
+		return err
+	}
+
+	filtersJSON, _ := json.Marshal(filters)
+	return uc.repo.UpdateConversationMemory(ctx, repository.UpdateConversationMemoryOptions{
+		ConversationID:       conv.ID,
+		Summary:              reply.Summary,
+		KeyFacts:             reply.KeyFacts,
+		ActiveFilters:        filtersJSON,
+		MessageCount:         len(msgs),
+		ExpectedMessageCount: conv.MemoryMessageCount,
+	})
+}
+
+func (uc *implUseCase) buildMemoryPrompt(conv model.Conversation, msgs []model.Message) string {
+	var b strings.Builder
+	b.WriteString(memoryPrompt)
+	b.WriteString("\n\n")
+	if conv.MemorySummary != "" {
+		b.WriteString(fmt.Sprintf("Tóm tắt trước:\n%s\n", conv.MemorySummary))
+	}
+	if len(conv.MemoryKeyFacts) > 0 {
+		b.WriteString("Dữ kiện trước:\n")
+		for _, f := range conv.MemoryKeyFacts {
+			b.WriteString(fmt.Sprintf("- %s\n", f))
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(uc.buildHistoryBlock(msgs))
+	b.WriteString("JSON:")
+	return b.String()
+}
+
+func parseMemoryReply(raw string) (memoryReply, error) {
+	start := strings.Index(raw, "{")
+	end := strings.LastIndex(raw, "}")
+	if start < 0 || end <= start {
+		return memoryReply{}, fmt.Errorf("invalid memory reply")
+	}
+	var reply memoryReply
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &reply); err != nil {
+		return memoryReply{}, fmt.Errorf("invalid memory reply: %v", err)
+	}
+	reply.Summary = strings.TrimSpace(reply.Summary)
+	if reply.Summary == "" {
+		return memoryReply{}, fmt.Errorf("empty memory summary")
+	}
+	facts := make([]string, 0, len(reply.KeyFacts))
+	for _, f := range reply.KeyFacts {
+		if f = strings.TrimSpace(f); f != "" && len(facts) < maxMemoryKeyFacts {
+			facts = append(facts, trimRunes(f, maxMemoryFactRunes))
+		}
+	}
+	reply.KeyFacts = facts
+	return reply, nil
+}
+
+// GetMemory returns the rolling memory of a conversation.
+func (uc *implUseCase) GetMemory(ctx context.Context, sc model.Scope, input chat.GetMemoryInput) (chat.MemoryOutput, error) {
+	conv, err := uc.ownConversation(ctx, sc, input.ConversationID)
+	if err != nil {
+		return chat.MemoryOutput{}, err
+	}
+	return toMemoryOutput(conv), nil
+}
+
+// ResetMemory clears the summary and forgets every turn of the active branch so far;
+// the conversation continues as if it started now. Messages stay visible in the
+// conversation.
+func (uc *implUseCase) ResetMemory(ctx context.Context, sc model.Scope, input chat.ResetMemoryInput) (chat.MemoryOutput, error) {
+	conv, err := uc.ownConversation(ctx, sc, input.ConversationID)
+	if err != nil {
+		return chat.MemoryOutput{}, err
+	}
+	tree, err := uc.loadMessageTree(ctx, conv.ID)
+	if err != nil {
+		uc.l.Errorf(ctx, "chat.usecase.ResetMemory: ListMessages failed: %v", err)
+		return chat.MemoryOutput{}, err
+	}
+	if err := uc.repo.ResetConversationMemory(ctx, repository.ResetConversationMemoryOptions{
+		ConversationID: conv.ID,
+		MessageCount:   len(tree.path(conv.ActiveLeafID)),
+	}); err != nil {
+		uc.l.Errorf(ctx, "chat.usecase.ResetMemory: ResetConversationMemory failed: %v", err)
+		return chat.MemoryOutput{}, err
+	}
+
+	conv, err = uc.repo.GetConversationByID(ctx, conv.ID)
+	if err != nil {
+		return chat.MemoryOutput{}, chat.ErrConversationNotFound
+	}
+	return toMemoryOutput(conv), nil
+}
+
+// ownConversation loads a conversation of the calling user.
+func (uc *implUseCase) ownConversation(ctx context.Context, sc model.Scope, id string) (model.Conversation, error) {
+	conv, err := uc.repo.GetConversationByID(ctx, id)
+	if err != nil || conv.ID == "" {
+		return model.Conversation{}, chat.ErrConversationNotFound
+	}
+	if sc.UserID != "" && conv.UserID != sc.UserID {
+		return model.Conversation{}, chat.ErrConversationNotFound
+	}
+	return conv, nil
+}
+
+func toMemoryOutput(conv model.Conversation) chat.MemoryOutput {
+	return chat.MemoryOutput{
+		ConversationID:  conv.ID,
+		Summary:         conv.MemorySummary,
+		KeyFacts:        conv.MemoryKeyFacts,
+		ActiveFilters:   decodeChatFilters(conv.MemoryActiveFilters),
+		CoveredMessages: conv.MemoryMessageCount,
+		MessageCount:    conv.MessageCount,
+		UpdatedAt:       conv.MemoryUpdatedAt,
+	}
+}
+
+func decodeChatFilters(raw json.RawMessage) *chat.ChatFilters {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var filters chat.ChatFilters
+	if err := json.Unmarshal(raw, &filters); err != nil {
+		return nil
+	}
+	return &filters
+}
+
+// describeChatFilters - "platforms=TIKTOK; date=01/09/2026..30/09/2026", empty without filters.
+func describeChatFilters(f chat.ChatFilters) string {
+	var parts []string
+	if len(f.Platforms) > 0 {
+		parts = append(parts, "platforms="+strings.Join(f.Platforms, ","))
+	}
+	if len(f.Sentiments) > 0 {
+		parts = append(parts, "sentiments="+strings.Join(f.Sentiments, ","))
+	}
+	if len(f.Aspects) > 0 {
+		parts = append(parts, "aspects="+strings.Join(f.Aspects, ","))
+	}
+	if len(f.RiskLevels) > 0 {
+		parts = append(parts, "risk_levels="+strings.Join(f.RiskLevels, ","))
+	}
+	if f.DateFrom != nil || f.DateTo != nil {
+		from, to := "", ""
+		if f.DateFrom != nil {
+			from = time.Unix(*f.DateFrom, 0).In(vietnamTZ).Format(dateLayout)
+		}
+		if f.DateTo != nil {
+			to = time.Unix(*f.DateTo, 0).In(vietnamTZ).Format(dateLayout)
+		}
+		parts = append(parts, fmt.Sprintf("date=%s..%s", from, to))
+	}
+	return strings.Join(parts, "; ")
+}
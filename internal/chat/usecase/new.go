@@ -1,35 +1,132 @@
 package usecase
 
 import (
+	"sync"
+	"time"
+
 	"knowledge-srv/internal/chat"
 	"knowledge-srv/internal/chat/repository"
+	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/factcheck"
 	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/share"
 	"knowledge-srv/pkg/analytics"
 
 	"github.com/smap-hcmut/shared-libs/go/llm"
 	"github.com/smap-hcmut/shared-libs/go/log"
 )
 
+const (
+	// Query understanding modes
+	UnderstandingModeLLM     = "llm"     // LLM extraction, keyword router on failure/timeout
+	UnderstandingModeKeyword = "keyword" // keyword router only
+
+	defaultUnderstandingTimeout = 4 * time.Second
+	defaultAgentMaxSteps        = 4
+
+	defaultAnswerCacheThreshold  = 0.95
+	defaultAnswerCacheTTL        = 6 * time.Hour
+	defaultAnswerCacheMaxEntries = 200
+)
+
+// Config - Query understanding and agent settings
+type Config struct {
+	UnderstandingMode     string
+	UnderstandingTimeout  time.Duration
+	UnderstandingCacheTTL time.Duration // 0 disables the cache
+
+	// AgentEnabled routes structured questions through the tool-calling loop.
+	AgentEnabled  bool
+	AgentMaxSteps int
+
+	// FactCheck verifies the figures of every answer against its sources.
+	FactCheck factcheck.Config
+
+	// MemoryEnabled keeps a rolling summary of older turns, refreshed every
+	// MemoryEveryTurns turns beyond the MemoryRecentMessages kept verbatim.
+	MemoryEnabled        bool
+	MemoryEveryTurns     int
+	MemoryRecentMessages int
+	// HistoryTokenBudget bounds memory plus recent messages in a prompt.
+	HistoryTokenBudget int
+
+	// PromptTokenBudget is what a retrieval prompt may spend: the smallest context
+	// window of the configured models, less the room kept for the answer.
+	PromptTokenBudget int
+
+	// AnswerCacheEnabled serves a new conversation's first question from an earlier
+	// answer on the campaign when the questions' embeddings are at least
+	// AnswerCacheThreshold similar under the same filters.
+	AnswerCacheEnabled    bool
+	AnswerCacheThreshold  float64
+	AnswerCacheTTL        time.Duration
+	AnswerCacheMaxEntries int // per campaign and filter set
+}
+
 type implUseCase struct {
 	repo      repository.PostgresRepository
+	cache     repository.CacheRepository
 	searchUC  search.UseCase
+	embedUC   embedding.UseCase // nil disables the answer cache
 	analytics analytics.Client
 	llm       llm.LLM
+	shareUC   share.UseCase // nil = conversations are readable by their owner only
 	l         log.Logger
+	config    Config
+
+	memoryJobs sync.Map // conversation ID -> in-flight memory refresh
 }
 
 func New(
 	repo repository.PostgresRepository,
+	cache repository.CacheRepository,
 	searchUC search.UseCase,
+	embedUC embedding.UseCase,
 	analyticsClient analytics.Client,
 	llmClient llm.LLM,
+	shareUC share.UseCase,
 	l log.Logger,
+	cfg Config,
 ) chat.UseCase {
+	if cfg.UnderstandingMode != UnderstandingModeKeyword {
+		cfg.UnderstandingMode = UnderstandingModeLLM
+	}
+	if cfg.UnderstandingTimeout <= 0 {
+		cfg.UnderstandingTimeout = defaultUnderstandingTimeout
+	}
+	if cfg.AgentMaxSteps <= 0 {
+		cfg.AgentMaxSteps = defaultAgentMaxSteps
+	}
+	if cfg.MemoryEveryTurns <= 0 {
+		cfg.MemoryEveryTurns = defaultMemoryEveryTurns
+	}
+	if cfg.MemoryRecentMessages <= 0 {
+		cfg.MemoryRecentMessages = defaultMemoryRecentMessages
+	}
+	if cfg.HistoryTokenBudget <= 0 {
+		cfg.HistoryTokenBudget = defaultHistoryTokenBudget
+	}
+	if cfg.PromptTokenBudget <= 0 {
+		cfg.PromptTokenBudget = chat.MaxTokenWindow
+	}
+	if cfg.AnswerCacheThreshold <= 0 || cfg.AnswerCacheThreshold > 1 {
+		cfg.AnswerCacheThreshold = defaultAnswerCacheThreshold
+	}
+	if cfg.AnswerCacheTTL <= 0 {
+		cfg.AnswerCacheTTL = defaultAnswerCacheTTL
+	}
+	if cfg.AnswerCacheMaxEntries <= 0 {
+		cfg.AnswerCacheMaxEntries = defaultAnswerCacheMaxEntries
+	}
 	return &implUseCase{
 		repo:      repo,
+		cache:     cache,
 		searchUC:  searchUC,
+		embedUC:   embedUC,
 		analytics: analyticsClient,
 		llm:       llmClient,
+		shareUC:   shareUC,
 		l:         l,
+		config:    cfg,
 	}
 }
@@ -3,75 +3,138 @@ package usecase
 import (
 	"fmt"
 	"strings"
-	"unicode/utf8"
 
 	"knowledge-srv/internal/chat"
 	"knowledge-srv/internal/model"
 	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/tokenbudget"
 	analyticspkg "knowledge-srv/pkg/analytics"
 )
 
 const systemPrompt = `Bạn là trợ lý phân tích dữ liệu SMAP. Nhiệm vụ:
 - Trả lời câu hỏi dựa trên context documents được cung cấp
 - Dùng Analytics Snapshot để trả lời số liệu/tỷ trọng/so sánh nền tảng; dùng documents để minh họa định tính
-- Trích dẫn nguồn bằng [1], [2], ... tương ứng với thứ tự documents
+- Trích dẫn nguồn bằng [1], [2], ... tương ứng với thứ tự documents, đặt ngay cuối mỗi câu nêu dữ kiện; chỉ dùng số có trong context, không tự đặt số mới
+- Analysis (report digest, insight card) là tổng hợp cấp campaign; dùng để định hướng nhận định và đối chiếu với documents cấp bài viết
 - Không suy diễn ngoài dữ liệu; nếu context yếu, nói rõ giới hạn mẫu dữ liệu
 - Nếu context không liên quan trực tiếp đến câu hỏi, nói "Không tìm thấy dữ liệu liên quan" thay vì cố bịa
 - Phân biệt rõ dữ liệu quan sát được, giả thuyết, và khuyến nghị hành động
 - Trả lời bằng tiếng Việt, ngắn gọn, chính xác
 - Phân tích sentiment và xu hướng nếu được hỏi`
 
-func (uc *implUseCase) buildPrompt(question string, docs []search.SearchResult, history []model.Message, snapshot *analyticspkg.Snapshot) string {
-	var b strings.Builder
+// Prompt sections, packed by priority into the prompt token budget. Evidence comes
+// first: it is what answers are cited against.
+var promptSections = []struct {
+	name     string
+	priority int
+	reserve  float64
+}{
+	{promptSectionContext, 1, 0.35},
+	{promptSectionAnalytics, 2, 0.15},
+	{promptSectionAnalysis, 3, 0.15},
+	{promptSectionMemory, 4, 0.05},
+	{promptSectionHistory, 5, 0.15},
+}
 
-	// System prompt
-	b.WriteString(systemPrompt)
-	b.WriteString("\n\n")
+const (
+	analyticsHeader = "Analytics Snapshot (dashboard-grade, đã qua quality gate):\n"
+	analysisHeader  = "Analysis (campaign-level digests & insights):\n"
+	historyHeader   = "Conversation History:\n"
 
-	if snapshot != nil && snapshot.HasData() {
-		b.WriteString(uc.buildAnalyticsContextBlock(*snapshot))
-	}
+	promptSectionContext   = "context"
+	promptSectionAnalytics = "analytics"
+	promptSectionAnalysis  = "analysis"
+	promptSectionMemory    = "memory"
+	promptSectionHistory   = "history"
 
-	// Context block
-	contextBlock := uc.buildContextBlock(docs)
-	b.WriteString(contextBlock)
+	noContextBlock = "Context: Không có documents liên quan.\n\n"
+)
 
-	// History block (if multi-turn)
-	if len(history) > 0 {
-		historyBlock := uc.buildHistoryBlock(history)
-		b.WriteString(historyBlock)
-	}
+// buildPrompt packs the retrieval prompt into the prompt token budget and reports
+// what each section cost. The system prompt and the question are never cut.
+func (uc *implUseCase) buildPrompt(question string, docs []search.SearchResult, history chatHistory, snapshot *analyticspkg.Snapshot) (string, tokenbudget.Usage) {
+	questionLine := fmt.Sprintf("User: %s\nAssistant:", question)
+	fixed := systemPrompt + "\n\n" + questionLine
 
-	// Current question
-	b.WriteString(fmt.Sprintf("User: %s\nAssistant:", question))
+	var analysisItems, contextItems []string
+	for i, doc := range docs {
+		if isMacroLayer(doc) {
+			analysisItems = append(analysisItems, analysisLine(i+1, doc))
+		} else {
+			contextItems = append(contextItems, contextLine(i+1, doc))
+		}
+	}
+	if len(contextItems) == 0 {
+		fixed += noContextBlock
+	}
+	var analyticsItems []string
+	if snapshot != nil && snapshot.HasData() {
+		analyticsItems = uc.analyticsLines(*snapshot)
+	}
+	var memoryItems []string
+	if memory := uc.buildMemoryBlock(history); memory != "" {
+		memoryItems = []string{memory}
+	}
+	historyItems := make([]string, 0, len(history.recent))
+	for _, msg := range history.recent {
+		historyItems = append(historyItems, historyLine(msg))
+	}
 
-	// Token window management
-	prompt := b.String()
-	estimatedTokens := utf8.RuneCountInString(prompt) / 2 // Vietnamese ~2 runes per token
-	if estimatedTokens > chat.MaxTokenWindow {
-		return uc.buildReducedPrompt(question, docs, history, snapshot)
+	content := map[string]tokenbudget.Section{
+		promptSectionContext:   {Header: "Context:\n", Items: contextItems, Footer: "\n"},
+		promptSectionAnalytics: {Header: analyticsHeader, Items: analyticsItems, Footer: "\n"},
+		promptSectionAnalysis:  {Header: analysisHeader, Items: analysisItems, Footer: "\n"},
+		promptSectionMemory:    {Items: memoryItems},
+		promptSectionHistory:   {Header: historyHeader, Items: historyItems, Footer: "\n", Newest: true},
 	}
+	sections := make([]tokenbudget.Section, 0, len(promptSections))
+	for _, s := range promptSections {
+		section := content[s.name]
+		section.Name, section.Priority, section.Reserve = s.name, s.priority, s.reserve
+		sections = append(sections, section)
+	}
+	packed := tokenbudget.Pack(uc.config.PromptTokenBudget, fixed, sections)
 
-	return prompt
+	var b strings.Builder
+	b.WriteString(systemPrompt)
+	b.WriteString("\n\n")
+	b.WriteString(packed.Text(promptSectionAnalytics))
+	// Analysis block (macro digests / insight cards), then post-level context block
+	b.WriteString(packed.Text(promptSectionAnalysis))
+	if contextBlock := packed.Text(promptSectionContext); contextBlock != "" {
+		b.WriteString(contextBlock)
+	} else if len(contextItems) == 0 {
+		b.WriteString(noContextBlock)
+	}
+	// Memory + history block (if multi-turn)
+	b.WriteString(packed.Text(promptSectionMemory))
+	b.WriteString(packed.Text(promptSectionHistory))
+	b.WriteString(questionLine)
+	return b.String(), packed.Usage
 }
 
 func (uc *implUseCase) buildAnalyticsContextBlock(snapshot analyticspkg.Snapshot) string {
-	var b strings.Builder
-	b.WriteString("Analytics Snapshot (dashboard-grade, đã qua quality gate):\n")
-	b.WriteString(fmt.Sprintf("- Total mentions: %s\n", metricFormatted(snapshot.KPIs.Metrics, "Total Mentions", totalDocsFromSnapshot(snapshot))))
+	return analyticsHeader + strings.Join(uc.analyticsLines(snapshot), "") + "\n"
+}
+
+// analyticsLines - One line per snapshot figure or breakdown row, so the packer can
+// drop the tail of the snapshot rather than all of it.
+func (uc *implUseCase) analyticsLines(snapshot analyticspkg.Snapshot) []string {
+	var lines []string
+	lines = append(lines, fmt.Sprintf("- Total mentions: %s\n", metricFormatted(snapshot.KPIs.Metrics, "Total Mentions", totalDocsFromSnapshot(snapshot))))
 	if sentimentScore, ok := metricFormattedIfPresent(snapshot.KPIs.Metrics, "Sentiment Score"); ok {
-		b.WriteString(fmt.Sprintf("- Sentiment score: %s\n", sentimentScore))
+		lines = append(lines, fmt.Sprintf("- Sentiment score: %s\n", sentimentScore))
 	}
 	engagementFallback := totalEngagement(sortedPlatformStats(snapshot.Platforms.Stats))
 	if engagementFallback == 0 {
 		engagementFallback = totalPostEngagement(snapshot.Posts.Posts)
 	}
-	b.WriteString(fmt.Sprintf("- Engagement: %s\n", metricFormatted(snapshot.KPIs.Metrics, "Engagement", engagementFallback)))
+	lines = append(lines, fmt.Sprintf("- Engagement: %s\n", metricFormatted(snapshot.KPIs.Metrics, "Engagement", engagementFallback)))
 	if len(snapshot.Errors) > 0 || !snapshot.HasCoreAnalytics() {
-		b.WriteString("- Snapshot note: partial analytics response; avoid treating missing metrics as zero.\n")
+		lines = append(lines, "- Snapshot note: partial analytics response; avoid treating missing metrics as zero.\n")
 	}
 	if snapshot.Sentiment.Total > 0 {
-		b.WriteString(fmt.Sprintf("- Sentiment share: positive %.1f%%, neutral %.1f%%, negative %.1f%%\n",
+		lines = append(lines, fmt.Sprintf("- Sentiment share: positive %.1f%%, neutral %.1f%%, negative %.1f%%\n",
 			sentimentShare(snapshot.Sentiment.Donut, "positive"),
 			sentimentShare(snapshot.Sentiment.Donut, "neutral"),
 			sentimentShare(snapshot.Sentiment.Donut, "negative"),
@@ -79,59 +142,113 @@ func (uc *implUseCase) buildAnalyticsContextBlock(snapshot analyticspkg.Snapshot
 	}
 	platforms := sortedPlatformStats(snapshot.Platforms.Stats)
 	if len(platforms) > 0 {
-		b.WriteString("- Platform breakdown:\n")
+		lines = append(lines, "- Platform breakdown:\n")
 		for _, p := range platforms {
-			b.WriteString(fmt.Sprintf("  - %s: mentions=%s, sentiment=%.1f%%, engagement=%s, reach=%s\n",
+			lines = append(lines, fmt.Sprintf("  - %s: mentions=%s, sentiment=%.1f%%, engagement=%s, reach=%s\n",
 				platformLabel(p), formatInt(p.Mentions), p.Sentiment, formatInt(p.EngagementRaw), formatInt(p.Reach)))
 		}
 	}
 	drivers := topKeywords(snapshot.Keywords.Keywords, 8)
 	if len(drivers) > 0 {
-		b.WriteString(fmt.Sprintf("- Top topics: %s\n", strings.Join(drivers, ", ")))
+		lines = append(lines, fmt.Sprintf("- Top topics: %s\n", strings.Join(drivers, ", ")))
 	}
 	examples := samplePosts(snapshot.Posts.Posts, "", 5)
 	if len(examples) > 0 {
-		b.WriteString("- High-engagement examples from analytics API:\n")
+		lines = append(lines, "- High-engagement examples from analytics API:\n")
 		for _, post := range examples {
 			source := "source unavailable"
 			if strings.TrimSpace(post.URL) != "" {
 				source = post.URL
 			}
-			b.WriteString(fmt.Sprintf("  - %s · %s · %s · engagement=%s · source=%s · %s\n",
+			lines = append(lines, fmt.Sprintf("  - %s · %s · %s · engagement=%s · source=%s · %s\n",
 				platformLabelName(post.Platform), post.Sentiment, authorLabel(post), formatInt(post.Engagement), source, trimRunes(post.Content, 150)))
 		}
 	}
+	return lines
+}
+
+// buildAnalysisBlock - Format macro-layer results (digests, insight cards) as the analysis section.
+// Numbering is shared with buildContextBlock so [n] always maps to docs[n-1].
+func (uc *implUseCase) buildAnalysisBlock(docs []search.SearchResult) string {
+	var b strings.Builder
+	for i, doc := range docs {
+		if !isMacroLayer(doc) {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString(analysisHeader)
+		}
+		b.WriteString(analysisLine(i+1, doc))
+	}
+	if b.Len() == 0 {
+		return ""
+	}
 	b.WriteString("\n")
 	return b.String()
 }
 
-// buildContextBlock - Format search results as numbered context
-func (uc *implUseCase) buildContextBlock(docs []search.SearchResult) string {
-	if len(docs) == 0 {
-		return "Context: Không có documents liên quan.\n\n"
+// analysisLine - One numbered digest or insight card line.
+func analysisLine(n int, doc search.SearchResult) string {
+	content := tokenbudget.Truncate(doc.Content, chat.MaxDocContentTokens)
+	label := "Insight"
+	if doc.Layer == search.LayerDigest {
+		label = "Digest"
+	}
+	line := fmt.Sprintf("[%d] %s: \"%s\" (Score: %.2f", n, label, content, doc.Score)
+	if window := analysisWindow(doc.Metadata); window != "" {
+		line += fmt.Sprintf(", Window: %s", window)
 	}
+	return line + ")\n"
+}
 
+func isMacroLayer(doc search.SearchResult) bool {
+	return doc.Layer == search.LayerDigest || doc.Layer == search.LayerInsight
+}
+
+func analysisWindow(metadata map[string]interface{}) string {
+	start := stringValuePath(metadata, "analysis_window_start")
+	end := stringValuePath(metadata, "analysis_window_end")
+	if start == "" && end == "" {
+		return ""
+	}
+	return start + " → " + end
+}
+
+// buildContextBlock - Format post-level search results as numbered context
+func (uc *implUseCase) buildContextBlock(docs []search.SearchResult) string {
 	var b strings.Builder
-	b.WriteString("Context:\n")
 	for i, doc := range docs {
-		content := doc.Content
-		if len(content) > chat.MaxDocContentLen {
-			content = content[:chat.MaxDocContentLen] + "..."
+		if isMacroLayer(doc) {
+			continue
 		}
-		b.WriteString(fmt.Sprintf("[%d] \"%s\" (Platform: %s, Sentiment: %s, Score: %.2f, Risk: %s, Engagement: %.2f",
-			i+1, content, doc.Platform, doc.OverallSentiment, doc.Score, doc.RiskLevel, doc.EngagementScore))
-		if len(doc.Keywords) > 0 {
-			b.WriteString(fmt.Sprintf(", Keywords: %s", joinLimited(doc.Keywords, 8)))
+		if b.Len() == 0 {
+			b.WriteString("Context:\n")
 		}
-		if len(doc.Aspects) > 0 {
-			b.WriteString(fmt.Sprintf(", Aspects: %s", formatAspects(doc.Aspects, 5)))
-		}
-		b.WriteString(")\n")
+		b.WriteString(contextLine(i+1, doc))
+	}
+	if b.Len() == 0 {
+		return "Context: Không có documents liên quan.\n\n"
 	}
 	b.WriteString("\n")
 	return b.String()
 }
 
+// contextLine - One numbered post-level document line.
+func contextLine(n int, doc search.SearchResult) string {
+	content := tokenbudget.Truncate(doc.Content, chat.MaxDocContentTokens)
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("[%d] \"%s\" (Platform: %s, Sentiment: %s, Score: %.2f, Risk: %s, Engagement: %.2f",
+		n, content, doc.Platform, doc.OverallSentiment, doc.Score, doc.RiskLevel, doc.EngagementScore))
+	if len(doc.Keywords) > 0 {
+		b.WriteString(fmt.Sprintf(", Keywords: %s", joinLimited(doc.Keywords, 8)))
+	}
+	if len(doc.Aspects) > 0 {
+		b.WriteString(fmt.Sprintf(", Aspects: %s", formatAspects(doc.Aspects, 5)))
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
 func joinLimited(values []string, limit int) string {
 	if len(values) == 0 {
 		return ""
@@ -166,55 +283,20 @@ func formatAspects(aspects []search.AspectResult, limit int) string {
 
 // buildHistoryBlock - Format conversation history with per-message truncation
 func (uc *implUseCase) buildHistoryBlock(msgs []model.Message) string {
-	const maxMsgLen = 500
 	var b strings.Builder
-	b.WriteString("Conversation History:\n")
+	b.WriteString(historyHeader)
 	for _, msg := range msgs {
-		role := msg.Role
-		if len(role) > 0 {
-			role = strings.ToUpper(role[:1]) + role[1:]
-		}
-		content := msg.Content
-		if utf8.RuneCountInString(content) > maxMsgLen {
-			// Truncate to maxMsgLen runes
-			runeCount := 0
-			for i := range content {
-				runeCount++
-				if runeCount == maxMsgLen {
-					content = content[:i] + "..."
-					break
-				}
-			}
-		}
-		b.WriteString(fmt.Sprintf("%s: %s\n", role, content))
+		b.WriteString(historyLine(msg))
 	}
 	b.WriteString("\n")
 	return b.String()
 }
 
-// buildReducedPrompt - Rebuild prompt with fewer docs and history to fit token window
-func (uc *implUseCase) buildReducedPrompt(question string, docs []search.SearchResult, history []model.Message, snapshot *analyticspkg.Snapshot) string {
-	// Reduce: fewer docs (max 5), fewer history (last 10)
-	reducedDocs := docs
-	if len(reducedDocs) > 5 {
-		reducedDocs = reducedDocs[:5]
-	}
-	reducedHistory := history
-	if len(reducedHistory) > 10 {
-		reducedHistory = reducedHistory[len(reducedHistory)-10:]
-	}
-
-	var b strings.Builder
-	b.WriteString(systemPrompt)
-	b.WriteString("\n\n")
-	if snapshot != nil && snapshot.HasData() {
-		b.WriteString(uc.buildAnalyticsContextBlock(*snapshot))
-	}
-	b.WriteString(uc.buildContextBlock(reducedDocs))
-	if len(reducedHistory) > 0 {
-		b.WriteString(uc.buildHistoryBlock(reducedHistory))
+// historyLine - One message, cut to maxHistoryMsgTokens.
+func historyLine(msg model.Message) string {
+	role := msg.Role
+	if len(role) > 0 {
+		role = strings.ToUpper(role[:1]) + role[1:]
 	}
-	b.WriteString(fmt.Sprintf("User: %s\nAssistant:", question))
-
-	return b.String()
+	return fmt.Sprintf("%s: %s\n", role, tokenbudget.Truncate(msg.Content, maxHistoryMsgTokens))
 }
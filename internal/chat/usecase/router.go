@@ -1,7 +1,14 @@
 package usecase
 
 import (
+	"regexp"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/search"
 )
 
 // QueryIntent represents the intent of a user's query.
@@ -30,8 +37,37 @@ var narrativeKeywords = []string{
 	"trend", "overview", "analysis", "analyze", "summary", "summarize", "predict", "sentiment",
 }
 
+// recencyCues map time-sensitive phrasing to a decay half-life (hours).
+// 0 means "use the campaign/default half-life". Checked in order; first match wins.
+var recencyCues = []struct {
+	keywords      []string
+	halfLifeHours float64
+}{
+	{[]string{"hôm nay", "hôm qua", "sáng nay", "tối nay", "24h", "today", "yesterday", "this morning"}, 12},
+	{[]string{"tuần này", "tuần qua", "7 ngày", "this week", "past week", "last 7 days"}, 48},
+	{[]string{"gần đây", "dạo này", "mới nhất", "vừa qua", "recent", "recently", "latest", "lately"}, 0},
+}
+
+// InferRecency enables time-decay ranking when the question carries a recency cue.
+// Returns nil when the question is not time-sensitive.
+func InferRecency(query string) *search.RecencyOptions {
+	q := strings.ToLower(strings.TrimSpace(query))
+	for _, cue := range recencyCues {
+		for _, kw := range cue.keywords {
+			if strings.Contains(q, kw) {
+				return &search.RecencyOptions{
+					Decay:         search.RecencyDecayExponential,
+					HalfLifeHours: cue.halfLifeHours,
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // ClassifyIntent uses multi-signal scoring: count keyword matches for each intent bucket,
-// return the bucket with the most matches.
+// return the bucket with the most matches. Keywords match whole words only, so "top"
+// does not fire inside "laptop".
 //
 // Default (no matches at all) is NARRATIVE — a query with no analytics keywords is
 // most likely a broad contextual search, so we use the lower minScore (0.55) to avoid
@@ -41,14 +77,14 @@ func ClassifyIntent(query string) QueryIntent {
 
 	structuredScore := 0
 	for _, kw := range structuredKeywords {
-		if strings.Contains(q, kw) {
+		if hasPhrase(q, kw) {
 			structuredScore++
 		}
 	}
 
 	narrativeScore := 0
 	for _, kw := range narrativeKeywords {
-		if strings.Contains(q, kw) {
+		if hasPhrase(q, kw) {
 			narrativeScore++
 		}
 	}
@@ -60,13 +96,14 @@ func ClassifyIntent(query string) QueryIntent {
 	return IntentNarrative
 }
 
+// InferPlatforms returns the platforms named in the query ("fb" no longer matches "feedback").
 func InferPlatforms(query string) []string {
 	q := strings.ToLower(strings.TrimSpace(query))
 	platforms := make([]string, 0, len(platformKeywords))
 
 	for platform, keywords := range platformKeywords {
 		for _, keyword := range keywords {
-			if strings.Contains(q, keyword) {
+			if hasPhrase(q, keyword) {
 				platforms = append(platforms, platform)
 				break
 			}
@@ -75,3 +112,75 @@ func InferPlatforms(query string) []string {
 
 	return platforms
 }
+
+// sentimentKeywords and riskKeywords map explicit wording to filter values.
+var sentimentKeywords = map[string][]string{
+	"NEGATIVE": {"tiêu cực", "chê", "phàn nàn", "negative", "complaint", "complaints"},
+	"POSITIVE": {"tích cực", "khen", "positive", "praise"},
+	"NEUTRAL":  {"trung lập", "neutral"},
+}
+
+var riskKeywords = map[string][]string{
+	"CRITICAL": {"nghiêm trọng", "khẩn cấp", "critical"},
+	"HIGH":     {"rủi ro cao", "high risk", "high-risk"},
+}
+
+// "so sánh với Grab", "compared to last month", "vs Shopee"
+var comparisonPattern = regexp.MustCompile(`(?:so sánh (?:với|cùng)|so với|compared? (?:to|with)|versus|\bvs\.?)\s+([^?.,!;]+)`)
+
+// keywordUnderstanding is the keyword router: the fallback when the LLM fails or
+// times out, and the baseline of the offline evaluation.
+func keywordUnderstanding(query string, now time.Time) chat.QueryUnderstanding {
+	q := strings.ToLower(strings.TrimSpace(query))
+	u := chat.QueryUnderstanding{
+		Intent:     string(ClassifyIntent(query)),
+		Platforms:  InferPlatforms(query),
+		Sentiments: matchKeywordValues(q, sentimentKeywords),
+		RiskLevels: matchKeywordValues(q, riskKeywords),
+		Source:     chat.UnderstandingSourceKeyword,
+	}
+	if spec, ok := parseDateRange(q); ok {
+		applyDateSpec(&u, spec, now)
+	}
+	if m := comparisonPattern.FindStringSubmatch(q); m != nil {
+		u.ComparisonTarget = strings.TrimSpace(m[1])
+	}
+	return u
+}
+
+func matchKeywordValues(q string, keywords map[string][]string) []string {
+	var values []string
+	for value, kws := range keywords {
+		for _, kw := range kws {
+			if hasPhrase(q, kw) {
+				values = append(values, value)
+				break
+			}
+		}
+	}
+	return values
+}
+
+// hasPhrase reports whether phrase occurs in q as whole words (q and phrase lower-cased).
+func hasPhrase(q, phrase string) bool {
+	for start := 0; start < len(q); {
+		i := strings.Index(q[start:], phrase)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(phrase)
+		before, _ := utf8.DecodeLastRuneInString(q[:i])
+		after, _ := utf8.DecodeRuneInString(q[end:])
+		if (i == 0 || !isWordRune(before)) && (end == len(q) || !isWordRune(after)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(q[i:])
+		start = i + size
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"knowledge-srv/internal/chat"
+
+	"github.com/smap-hcmut/shared-libs/go/llm"
+	"github.com/smap-hcmut/shared-libs/go/log"
+)
+
+// Offline evaluation of the query routers on testdata/router_eval.jsonl.
+//
+//	go test ./internal/chat/usecase -run TestRouterEval -v
+//
+// The keyword router always runs. Set CHAT_EVAL_LLM_PROVIDER (gemini, openai, ...),
+// CHAT_EVAL_LLM_API_KEY and optionally CHAT_EVAL_LLM_MODEL to score the LLM router
+// on the same set and print both side by side.
+
+const routerEvalFile = "testdata/router_eval.jsonl"
+
+// keywordEvalFloor - Per-field accuracy of the keyword router when the set was written;
+// a change to the keyword lists must not drop below it.
+var keywordEvalFloor = map[string]float64{
+	"intent":     0.80,
+	"platforms":  0.95,
+	"sentiments": 0.90,
+	"date":       0.90,
+	"risk":       0.95,
+	"comparison": 0.90,
+}
+
+var evalFields = []string{"intent", "platforms", "sentiments", "aspects", "date", "risk", "comparison"}
+
+type routerEvalCase struct {
+	Query         string   `json:"query"`
+	Intent        string   `json:"intent"`
+	Platforms     []string `json:"platforms"`
+	Sentiments    []string `json:"sentiments"`
+	Aspects       []string `json:"aspects"`
+	Date          string   `json:"date"` // dateSpec label, empty = no range
+	RiskLevels    []string `json:"risk_levels"`
+	HasComparison bool     `json:"has_comparison"`
+}
+
+type routerFunc func(ctx context.Context, query string) chat.QueryUnderstanding
+
+func TestRouterEvalKeyword(t *testing.T) {
+	cases := loadRouterEvalCases(t)
+	scores := scoreRouter(t, cases, func(_ context.Context, q string) chat.QueryUnderstanding {
+		return keywordUnderstanding(q, time.Now())
+	})
+
+	for _, field := range evalFields {
+		t.Logf("keyword %-10s %5.1f%%", field, 100*scores[field])
+		if floor, ok := keywordEvalFloor[field]; ok && scores[field] < floor {
+			t.Errorf("keyword router %s accuracy %.2f below floor %.2f", field, scores[field], floor)
+		}
+	}
+}
+
+func TestRouterEvalLLM(t *testing.T) {
+	provider, apiKey := os.Getenv("CHAT_EVAL_LLM_PROVIDER"), os.Getenv("CHAT_EVAL_LLM_API_KEY")
+	if provider == "" || apiKey == "" {
+		t.Skip("set CHAT_EVAL_LLM_PROVIDER and CHAT_EVAL_LLM_API_KEY to evaluate the LLM router")
+	}
+	client, err := llm.NewFromConfig(llm.MultiConfig{Providers: []llm.ProviderConfig{{
+		Name:   provider,
+		APIKey: apiKey,
+		Model:  os.Getenv("CHAT_EVAL_LLM_MODEL"),
+	}}})
+	if err != nil {
+		t.Fatalf("llm.NewFromConfig: %v", err)
+	}
+
+	uc := &implUseCase{
+		llm:    client,
+		l:      log.NewLogger(log.ZapConfig{Level: log.LevelError, Mode: log.ModeProduction, Encoding: log.EncodingJSON}),
+		config: Config{UnderstandingMode: UnderstandingModeLLM, UnderstandingTimeout: 20 * time.Second},
+	}
+	cases := loadRouterEvalCases(t)
+
+	fallbacks := 0
+	llmScores := scoreRouter(t, cases, func(ctx context.Context, q string) chat.QueryUnderstanding {
+		u := uc.understandQuery(ctx, q)
+		if u.Source != chat.UnderstandingSourceLLM {
+			fallbacks++
+		}
+		return u
+	})
+	keywordScores := scoreRouter(t, cases, func(_ context.Context, q string) chat.QueryUnderstanding {
+		return keywordUnderstanding(q, time.Now())
+	})
+
+	t.Logf("%-10s %8s %8s", "field", "keyword", client.Name())
+	for _, field := range evalFields {
+		t.Logf("%-10s %7.1f%% %7.1f%%", field, 100*keywordScores[field], 100*llmScores[field])
+	}
+	t.Logf("LLM fell back to the keyword router on %d/%d queries", fallbacks, len(cases))
+}
+
+// scoreRouter returns the share of cases each field is right for. List fields
+// must match as sets; aspects count as right when every expected one is found.
+func scoreRouter(t *testing.T, cases []routerEvalCase, route routerFunc) map[string]float64 {
+	t.Helper()
+	hits := make(map[string]int, len(evalFields))
+	for _, c := range cases {
+		u := route(context.Background(), c.Query)
+		got := map[string]bool{
+			"intent":     u.Intent == c.Intent,
+			"platforms":  sameSet(u.Platforms, c.Platforms),
+			"sentiments": sameSet(u.Sentiments, c.Sentiments),
+			"aspects":    coversAll(u.Aspects, c.Aspects),
+			"date":       u.DateLabel == c.Date,
+			"risk":       sameSet(u.RiskLevels, c.RiskLevels),
+			"comparison": (u.ComparisonTarget != "") == c.HasComparison,
+		}
+		for field, ok := range got {
+			if ok {
+				hits[field]++
+			} else if testing.Verbose() {
+				t.Logf("miss %-10s %q (%s)", field, c.Query, u.Source)
+			}
+		}
+	}
+	scores := make(map[string]float64, len(evalFields))
+	for _, field := range evalFields {
+		scores[field] = float64(hits[field]) / float64(len(cases))
+	}
+	return scores
+}
+
+func loadRouterEvalCases(t *testing.T) []routerEvalCase {
+	t.Helper()
+	f, err := os.Open(routerEvalFile)
+	if err != nil {
+		t.Fatalf("open %s: %v", routerEvalFile, err)
+	}
+	defer f.Close()
+
+	var cases []routerEvalCase
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var c routerEvalCase
+		if err := json.Unmarshal([]byte(line), &c); err != nil {
+			t.Fatalf("parse %s: %v", line, err)
+		}
+		cases = append(cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read %s: %v", routerEvalFile, err)
+	}
+	return cases
+}
+
+func sameSet(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	return coversAll(got, want)
+}
+
+func coversAll(got, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(got, w) {
+			return false
+		}
+	}
+	return true
+}
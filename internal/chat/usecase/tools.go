@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
+)
+
+// Agent tools
+const (
+	toolSearchPosts       = "search_posts"
+	toolAggregate         = "aggregate"
+	toolTimeSeries        = "time_series"
+	toolAnalyticsSnapshot = "analytics_snapshot"
+	toolMacroInsights     = "macro_insights"
+
+	defaultToolSearchLimit = 5
+	defaultToolMacroLimit  = 3
+	maxToolMacroLimit      = 5
+	maxToolResultRunes     = 2000
+	maxToolBreakdownItems  = 8
+	defaultTimeSeriesDays  = 30
+	toolSnapshotTimeout    = 8 * time.Second
+)
+
+var (
+	errUnknownTool         = errors.New("unknown tool")
+	errSnapshotUnavailable = errors.New("analytics snapshot unavailable")
+)
+
+// toolArgs - Arguments shared by every tool; each tool reads the fields it documents.
+type toolArgs struct {
+	Query    string      `json:"query,omitempty"`
+	Limit    int         `json:"limit,omitempty"`
+	Interval string      `json:"interval,omitempty"`
+	Filters  toolFilters `json:"filters"`
+}
+
+type toolFilters struct {
+	Platforms  []string  `json:"platforms,omitempty"`
+	Sentiments []string  `json:"sentiments,omitempty"`
+	Aspects    []string  `json:"aspects,omitempty"`
+	RiskLevels []string  `json:"risk_levels,omitempty"`
+	DateRange  *dateSpec `json:"date_range,omitempty"`
+}
+
+type toolFunc func(ctx context.Context, run *agentRun, args toolArgs) (string, error)
+
+// agentRun - State of one agent answer. Documents keep their [n] number for the
+// whole run, tool calls their [Tn] ID.
+type agentRun struct {
+	sc         model.Scope
+	input      chat.ChatInput
+	explicit   search.SearchFilters
+	now        time.Time
+	docs       []search.SearchResult
+	docIndex   map[string]int
+	calls      []chat.ToolCall
+	totalDocs  int
+	suppressed int
+}
+
+func newAgentRun(sc model.Scope, input chat.ChatInput, explicit search.SearchFilters) *agentRun {
+	return &agentRun{
+		sc:       sc,
+		input:    input,
+		explicit: explicit,
+		now:      time.Now(),
+		docIndex: make(map[string]int),
+	}
+}
+
+func (uc *implUseCase) tools() map[string]toolFunc {
+	return map[string]toolFunc{
+		toolSearchPosts:       uc.toolSearchPosts,
+		toolAggregate:         uc.toolAggregate,
+		toolTimeSeries:        uc.toolTimeSeries,
+		toolAnalyticsSnapshot: uc.toolAnalyticsSnapshot,
+		toolMacroInsights:     uc.toolMacroInsights,
+	}
+}
+
+// toolSearchPosts - Semantic search over posts and comments.
+func (uc *implUseCase) toolSearchPosts(ctx context.Context, run *agentRun, args toolArgs) (string, error) {
+	query := strings.TrimSpace(args.Query)
+	if query == "" {
+		query = run.input.Message
+	}
+	out, err := uc.searchUC.Search(ctx, run.sc, search.SearchInput{
+		CampaignID: run.input.CampaignID,
+		Query:      query,
+		Limit:      clampLimit(args.Limit, defaultToolSearchLimit, chat.MaxSearchDocs),
+		MinScore:   0.52,
+		Intent:     string(IntentStructured),
+		Filters:    run.filters(args.Filters),
+		Diversity:  &search.DiversityOptions{},
+	})
+	if err != nil {
+		return "", err
+	}
+	run.totalDocs += out.TotalFound
+	run.suppressed += out.SuppressedRedundant
+	if len(out.Results) == 0 {
+		return "Không có bài viết phù hợp.", nil
+	}
+	return run.addDocs(out.Results), nil
+}
+
+// toolMacroInsights - Campaign-level digests and insight cards.
+func (uc *implUseCase) toolMacroInsights(ctx context.Context, run *agentRun, args toolArgs) (string, error) {
+	query := strings.TrimSpace(args.Query)
+	if query == "" {
+		query = run.input.Message
+	}
+	limit := clampLimit(args.Limit, defaultToolMacroLimit, maxToolMacroLimit)
+	out, err := uc.searchUC.Search(ctx, run.sc, search.SearchInput{
+		CampaignID: run.input.CampaignID,
+		Query:      query,
+		// Search caps macro results at half the limit
+		Limit:  2 * (limit + 1),
+		Intent: string(IntentStructured),
+		Layers: &search.LayerQuotas{Digests: 1, Insights: limit},
+	})
+	if err != nil {
+		return "", err
+	}
+	macro := make([]search.SearchResult, 0, limit+1)
+	for _, r := range out.Results {
+		if isMacroLayer(r) {
+			macro = append(macro, r)
+		}
+	}
+	if len(macro) == 0 {
+		return "Không có digest hay insight card phù hợp.", nil
+	}
+	return run.addDocs(macro), nil
+}
+
+// toolAggregate - Totals, sentiment/platform breakdown and top negative aspects.
+func (uc *implUseCase) toolAggregate(ctx context.Context, run *agentRun, args toolArgs) (string, error) {
+	out, err := uc.searchUC.Aggregate(ctx, run.sc, search.AggregateInput{
+		CampaignID: run.input.CampaignID,
+		Filters:    run.filters(args.Filters),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Tổng số documents: %d\n", out.TotalDocs))
+	b.WriteString(fmt.Sprintf("Sentiment: %s\n", formatBreakdown(out.SentimentBreakdown, out.TotalDocs)))
+	b.WriteString(fmt.Sprintf("Nền tảng: %s\n", formatBreakdown(out.PlatformBreakdown, out.TotalDocs)))
+	if len(out.TopNegativeAspects) > 0 {
+		aspects := make(map[string]uint64, len(out.TopNegativeAspects))
+		for _, a := range out.TopNegativeAspects {
+			aspects[a.Aspect] = a.Count
+		}
+		b.WriteString(fmt.Sprintf("Aspect tiêu cực nhiều nhất: %s\n", formatBreakdown(aspects, 0)))
+	}
+	if len(out.TopEntities) > 0 {
+		entities := make(map[string]uint64, len(out.TopEntities))
+		for _, e := range out.TopEntities {
+			entities[e.Value] = e.Count
+		}
+		b.WriteString(fmt.Sprintf("Thực thể được nhắc nhiều nhất: %s\n", formatBreakdown(entities, 0)))
+	}
+	return b.String(), nil
+}
+
+// toolTimeSeries - Document counts per day, week or month with the sentiment mix.
+// Without a date range it covers the last defaultTimeSeriesDays whole days.
+func (uc *implUseCase) toolTimeSeries(ctx context.Context, run *agentRun, args toolArgs) (string, error) {
+	filters := run.filters(args.Filters)
+	from := startOfUnit(run.now.In(vietnamTZ).AddDate(0, 0, -defaultTimeSeriesDays), "day").Unix()
+	to := run.now.Unix()
+	if filters.DateFrom != nil {
+		from = *filters.DateFrom
+	}
+	if filters.DateTo != nil {
+		to = *filters.DateTo
+	}
+	filters.DateFrom, filters.DateTo = nil, nil
+
+	interval := strings.ToLower(strings.TrimSpace(args.Interval))
+	switch interval {
+	case search.IntervalDay, search.IntervalWeek, search.IntervalMonth:
+	default:
+		interval = defaultInterval(from, to)
+	}
+
+	out, err := uc.searchUC.TimeSeries(ctx, run.sc, search.TimeSeriesInput{
+		CampaignID: run.input.CampaignID,
+		From:       from,
+		To:         to,
+		Interval:   interval,
+		Filters:    filters,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Chuỗi thời gian theo %s, tổng %d documents:\n", out.Interval, out.TotalDocs))
+	for _, bucket := range out.Buckets {
+		start := time.Unix(bucket.Start, 0).In(vietnamTZ).Format(dateLayout)
+		label := start
+		if out.Interval != search.IntervalDay {
+			label = start + ".." + time.Unix(bucket.End, 0).In(vietnamTZ).Format(dateLayout)
+		}
+		b.WriteString(fmt.Sprintf("- %s: %d", label, bucket.Count))
+		if bucket.Count > 0 {
+			b.WriteString(fmt.Sprintf(" (%s)", formatBreakdown(bucket.SentimentBreakdown, 0)))
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// toolAnalyticsSnapshot - Dashboard KPIs from the analytics service.
+func (uc *implUseCase) toolAnalyticsSnapshot(ctx context.Context, run *agentRun, _ toolArgs) (string, error) {
+	snapshot, ok := uc.loadAnalyticsSnapshot(ctx, run.input.CampaignID, toolSnapshotTimeout)
+	if !ok {
+		return "", errSnapshotUnavailable
+	}
+	return uc.buildAnalyticsContextBlock(*snapshot), nil
+}
+
+// filters fills every filter the user left empty from the tool arguments.
+// The user's explicit filters always apply.
+func (run *agentRun) filters(f toolFilters) search.SearchFilters {
+	u := chat.QueryUnderstanding{
+		Platforms:  filterEnum(f.Platforms, func(v string) bool { _, ok := platformKeywords[v]; return ok }),
+		Sentiments: filterEnum(f.Sentiments, func(v string) bool { return validSentiments[v] }),
+		Aspects:    normalizeAspects(f.Aspects),
+		RiskLevels: filterEnum(f.RiskLevels, func(v string) bool { return validRiskLevels[v] }),
+	}
+	if f.DateRange != nil {
+		spec := *f.DateRange
+		spec.Kind = strings.ToLower(strings.TrimSpace(spec.Kind))
+		spec.Unit = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(spec.Unit)), "s")
+		applyDateSpec(&u, spec, run.now)
+	}
+	merged, _ := mergeUnderstoodFilters(run.explicit, u)
+	return merged
+}
+
+// addDocs numbers new documents after the ones already retrieved; a document seen
+// before keeps its number.
+func (run *agentRun) addDocs(results []search.SearchResult) string {
+	var b strings.Builder
+	for _, r := range results {
+		n, ok := run.docIndex[r.ID]
+		if !ok {
+			run.docs = append(run.docs, r)
+			n = len(run.docs)
+			run.docIndex[r.ID] = n
+		}
+		if isMacroLayer(r) {
+			b.WriteString(analysisLine(n, r))
+		} else {
+			b.WriteString(contextLine(n, r))
+		}
+	}
+	return b.String()
+}
+
+// defaultInterval keeps a series within a readable number of buckets.
+func defaultInterval(from, to int64) string {
+	days := (to - from) / 86400
+	switch {
+	case days <= 31:
+		return search.IntervalDay
+	case days <= 26*7:
+		return search.IntervalWeek
+	default:
+		return search.IntervalMonth
+	}
+}
+
+// formatBreakdown - "NEGATIVE 120 (40.0%), POSITIVE 90 (30.0%)" by count, largest first.
+// Shares are omitted when total is 0.
+func formatBreakdown(counts map[string]uint64, total uint64) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > maxToolBreakdownItems {
+		keys = keys[:maxToolBreakdownItems]
+	}
+	if len(keys) == 0 {
+		return "không có dữ liệu"
+	}
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if total > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d (%.1f%%)", k, counts[k], 100*float64(counts[k])/float64(total)))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %d", k, counts[k]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func clampLimit(limit, defaultLimit, maxLimit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	if limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"knowledge-srv/internal/chat"
+)
+
+const understandingPrompt = `You extract search intent and filters from a user question about social-media
+listening data (Vietnamese or English). Reply with ONE JSON object and nothing else:
+{
+  "intent": "NARRATIVE" | "STRUCTURED",
+  "platforms": ["TIKTOK" | "FACEBOOK" | "YOUTUBE"],
+  "sentiments": ["POSITIVE" | "NEGATIVE" | "NEUTRAL" | "MIXED"],
+  "aspects": ["UPPER_SNAKE_CASE business aspect, e.g. DELIVERY, PRICE, CUSTOMER_SERVICE"],
+  "date_range": null | {"kind": "last_n", "unit": "day|week|month|year", "amount": 7}
+                     | {"kind": "current" | "previous", "unit": "day|week|month|year"}
+                     | {"kind": "absolute", "from": "YYYY-MM-DD", "to": "YYYY-MM-DD"},
+  "risk_levels": ["LOW" | "MEDIUM" | "HIGH" | "CRITICAL"],
+  "comparison_target": "what the question compares against (brand, platform, period), or empty"
+}
+Rules:
+- STRUCTURED: counts, rankings, ratios, statistics, comparisons. NARRATIVE: summaries, opinions, trends, explanations.
+- Only fill a filter the question clearly asks for; otherwise leave it empty. Never guess.
+- "fb" means FACEBOOK only as a standalone word; "feedback" is not a platform.
+- "tháng trước" / "last month" = {"kind":"previous","unit":"month"}; "7 ngày qua" / "last 7 days" = {"kind":"last_n","unit":"day","amount":7}; "hôm nay" = {"kind":"current","unit":"day"}.
+Today is %s.
+Question: %s
+JSON:`
+
+const (
+	maxUnderstoodAspects = 5
+	maxAspectLength      = 40
+	maxComparisonRunes   = 100
+	// understandingCacheVersion is bumped whenever the prompt or its JSON contract changes.
+	understandingCacheVersion = "v1"
+)
+
+var (
+	validIntents    = map[string]bool{string(IntentNarrative): true, string(IntentStructured): true}
+	validSentiments = map[string]bool{"POSITIVE": true, "NEGATIVE": true, "NEUTRAL": true, "MIXED": true}
+	validRiskLevels = map[string]bool{"LOW": true, "MEDIUM": true, "HIGH": true, "CRITICAL": true}
+	aspectPattern   = regexp.MustCompile(`^[A-Z0-9_]+$`)
+	errNoJSONObject = errors.New("no JSON object in LLM response")
+)
+
+// llmUnderstanding - JSON contract of the understanding prompt, and the cached form
+// (the date range stays relative until resolved).
+type llmUnderstanding struct {
+	Intent           string    `json:"intent"`
+	Platforms        []string  `json:"platforms"`
+	Sentiments       []string  `json:"sentiments"`
+	Aspects          []string  `json:"aspects"`
+	DateRange        *dateSpec `json:"date_range"`
+	RiskLevels       []string  `json:"risk_levels"`
+	ComparisonTarget string    `json:"comparison_target"`
+}
+
+// understandQuery extracts intent and filters from a chat message with the LLM,
+// falling back to the keyword router when the LLM fails, times out or returns
+// invalid JSON. LLM results are cached per normalized query.
+func (uc *implUseCase) understandQuery(ctx context.Context, message string) chat.QueryUnderstanding {
+	now := time.Now()
+	if uc.config.UnderstandingMode == UnderstandingModeKeyword || uc.llm == nil {
+		return keywordUnderstanding(message, now)
+	}
+
+	key := understandingCacheKey(message)
+	if cached, ok := uc.getCachedUnderstanding(ctx, key); ok {
+		u := cached.toUnderstanding(now)
+		u.CacheHit = true
+		return u
+	}
+
+	parsed, err := uc.llmUnderstand(ctx, message, now)
+	if err != nil {
+		uc.l.Warnf(ctx, "chat.usecase.understandQuery: falling back to keyword router: %v", err)
+		return keywordUnderstanding(message, now)
+	}
+	uc.saveCachedUnderstanding(ctx, key, parsed)
+	return parsed.toUnderstanding(now)
+}
+
+func (uc *implUseCase) llmUnderstand(ctx context.Context, message string, now time.Time) (llmUnderstanding, error) {
+	llmCtx, cancel := context.WithTimeout(ctx, uc.config.UnderstandingTimeout)
+	defer cancel()
+
+	prompt := fmt.Sprintf(understandingPrompt, now.In(vietnamTZ).Format(dateLayout), strings.TrimSpace(message))
+	raw, err := uc.llm.Generate(llmCtx, prompt)
+	if err != nil {
+		return llmUnderstanding{}, err
+	}
+	return parseUnderstanding(raw)
+}
+
+func (uc *implUseCase) getCachedUnderstanding(ctx context.Context, key string) (llmUnderstanding, bool) {
+	if uc.cache == nil || uc.config.UnderstandingCacheTTL <= 0 {
+		return llmUnderstanding{}, false
+	}
+	data, err := uc.cache.GetUnderstanding(ctx, key)
+	if err != nil {
+		return llmUnderstanding{}, false
+	}
+	var cached llmUnderstanding
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return llmUnderstanding{}, false
+	}
+	return cached, true
+}
+
+func (uc *implUseCase) saveCachedUnderstanding(ctx context.Context, key string, u llmUnderstanding) {
+	if uc.cache == nil || uc.config.UnderstandingCacheTTL <= 0 {
+		return
+	}
+	data, err := json.Marshal(u)
+	if err != nil {
+		return
+	}
+	_ = uc.cache.SaveUnderstanding(ctx, key, data, uc.config.UnderstandingCacheTTL)
+}
+
+// parseUnderstanding decodes the LLM reply and validates every field: an unknown
+// intent rejects the reply, unknown filter values and malformed dates are dropped.
+func parseUnderstanding(raw string) (llmUnderstanding, error) {
+	start := strings.Index(raw, "{")
+	end := strings.LastIndex(raw, "}")
+	if start < 0 || end <= start {
+		return llmUnderstanding{}, errNoJSONObject
+	}
+	var u llmUnderstanding
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &u); err != nil {
+		return llmUnderstanding{}, fmt.Errorf("invalid understanding JSON: %w", err)
+	}
+
+	u.Intent = strings.ToUpper(strings.TrimSpace(u.Intent))
+	if !validIntents[u.Intent] {
+		return llmUnderstanding{}, fmt.Errorf("invalid intent %q", u.Intent)
+	}
+	u.Platforms = filterEnum(u.Platforms, func(v string) bool { _, ok := platformKeywords[v]; return ok })
+	u.Sentiments = filterEnum(u.Sentiments, func(v string) bool { return validSentiments[v] })
+	u.RiskLevels = filterEnum(u.RiskLevels, func(v string) bool { return validRiskLevels[v] })
+
+	u.Aspects = normalizeAspects(u.Aspects)
+
+	if u.DateRange != nil {
+		u.DateRange.Kind = strings.ToLower(strings.TrimSpace(u.DateRange.Kind))
+		u.DateRange.Unit = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(u.DateRange.Unit)), "s")
+		if !u.DateRange.valid() {
+			u.DateRange = nil
+		}
+	}
+
+	target := strings.TrimSpace(u.ComparisonTarget)
+	if utf8.RuneCountInString(target) > maxComparisonRunes {
+		target = string([]rune(target)[:maxComparisonRunes])
+	}
+	u.ComparisonTarget = target
+	return u, nil
+}
+
+func (u llmUnderstanding) toUnderstanding(now time.Time) chat.QueryUnderstanding {
+	out := chat.QueryUnderstanding{
+		Intent:           u.Intent,
+		Platforms:        u.Platforms,
+		Sentiments:       u.Sentiments,
+		Aspects:          u.Aspects,
+		RiskLevels:       u.RiskLevels,
+		ComparisonTarget: u.ComparisonTarget,
+		Source:           chat.UnderstandingSourceLLM,
+	}
+	if u.DateRange != nil {
+		applyDateSpec(&out, *u.DateRange, now)
+	}
+	return out
+}
+
+func applyDateSpec(u *chat.QueryUnderstanding, spec dateSpec, now time.Time) {
+	from, to, ok := spec.resolve(now)
+	if !ok {
+		return
+	}
+	u.DateFrom, u.DateTo = &from, &to
+	u.DateLabel = spec.label()
+}
+
+// understandingCacheKey - Queries differing only in case, spacing or trailing
+// punctuation share one cache entry.
+func understandingCacheKey(message string) string {
+	sum := sha256.Sum256([]byte(normalizeQuestion(message)))
+	return understandingCacheVersion + ":" + hex.EncodeToString(sum[:16])
+}
+
+// normalizeQuestion lowercases message, collapses its spacing and drops trailing
+// punctuation.
+func normalizeQuestion(message string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(message), " "))
+	return strings.TrimRight(normalized, "?!.。 ")
+}
+
+// normalizeAspects upper-snake-cases LLM aspects, dropping malformed ones.
+func normalizeAspects(values []string) []string {
+	aspects := make([]string, 0, len(values))
+	for _, a := range values {
+		a = strings.ToUpper(strings.Join(strings.Fields(a), "_"))
+		if a == "" || len(a) > maxAspectLength || !aspectPattern.MatchString(a) || slices.Contains(aspects, a) {
+			continue
+		}
+		aspects = append(aspects, a)
+		if len(aspects) == maxUnderstoodAspects {
+			break
+		}
+	}
+	return aspects
+}
+
+func filterEnum(values []string, valid func(string) bool) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.ToUpper(strings.TrimSpace(v))
+		if valid(v) && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
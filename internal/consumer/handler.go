@@ -3,20 +3,41 @@ package consumer
 import (
 	"context"
 	"fmt"
-
+	"time"
+
+	backupPostgre "knowledge-srv/internal/backup/repository/postgre"
+	backupQdrant "knowledge-srv/internal/backup/repository/qdrant"
+	backupRedis "knowledge-srv/internal/backup/repository/redis"
+	backupUsecase "knowledge-srv/internal/backup/usecase"
+	chatPostgre "knowledge-srv/internal/chat/repository/postgre"
+	chatRedis "knowledge-srv/internal/chat/repository/redis"
+	chatUsecase "knowledge-srv/internal/chat/usecase"
 	embeddingRepo "knowledge-srv/internal/embedding/repository/redis"
 	embeddingUsecase "knowledge-srv/internal/embedding/usecase"
+	erasurePostgre "knowledge-srv/internal/erasure/repository/postgre"
+	erasureRedis "knowledge-srv/internal/erasure/repository/redis"
+	erasureUsecase "knowledge-srv/internal/erasure/usecase"
 	indexingConsumer "knowledge-srv/internal/indexing/delivery/kafka/consumer"
 	indexingPostgre "knowledge-srv/internal/indexing/repository/postgre"
-	indexingRedis "knowledge-srv/internal/indexing/repository/redis"
 	indexingUsecase "knowledge-srv/internal/indexing/usecase"
+	lifecycleConsumer "knowledge-srv/internal/lifecycle/delivery/kafka/consumer"
+	lifecyclePostgre "knowledge-srv/internal/lifecycle/repository/postgre"
+	lifecycleUsecase "knowledge-srv/internal/lifecycle/usecase"
 	pointRepo "knowledge-srv/internal/point/repository/qdrant"
 	pointUsecase "knowledge-srv/internal/point/usecase"
+	reportPostgre "knowledge-srv/internal/report/repository/postgre"
+	reportUsecase "knowledge-srv/internal/report/usecase"
+	"knowledge-srv/internal/search"
+	searchPostgre "knowledge-srv/internal/search/repository/postgre"
+	searchRedis "knowledge-srv/internal/search/repository/redis"
+	searchUsecase "knowledge-srv/internal/search/usecase"
 )
 
 // domainConsumers holds references to all domain consumers for cleanup
 type domainConsumers struct {
-	indexingConsumer indexingConsumer.Consumer
+	indexingConsumer  indexingConsumer.Consumer
+	lifecycleConsumer lifecycleConsumer.Consumer
+	searchUC          search.UseCase
 }
 
 // setupDomains initializes all domain layers (repositories, usecases, consumers)
@@ -35,19 +56,45 @@ func (srv *ConsumerServer) setupDomains(ctx context.Context) (*domainConsumers,
 	pointUC := pointUsecase.New(
 		pointQdrantRepo,
 		srv.l,
+		pointUsecase.ConfigFromQdrant(srv.qdrantConfig),
+	)
+
+	// Search (only cache eviction and the campaign purge are used here: no project service)
+	searchUC := searchUsecase.New(
+		pointUC,
+		embeddingUC,
+		searchRedis.New(srv.redisClient, srv.l),
+		searchPostgre.New(srv.postgresDB, srv.l),
+		nil,
+		srv.l,
+		searchUsecase.Config{},
+	)
+
+	// Erasure (tombstones checked before indexing)
+	erasureUC := erasureUsecase.New(
+		erasurePostgre.New(srv.postgresDB, srv.l),
+		erasureRedis.New(srv.redisClient, srv.l),
+		pointUC,
+		embeddingUC,
+		searchUC,
+		srv.l,
 	)
 
 	// 2. Indexing Domain
 	postgreRepo := indexingPostgre.New(srv.postgresDB, srv.l)
-	cacheRepo := indexingRedis.New(srv.redisClient, srv.l)
 
 	indexingUC := indexingUsecase.New(
 		srv.l,
 		postgreRepo,
 		pointUC,
 		embeddingUC,
-		cacheRepo,
+		searchUC,
 		srv.minioClient,
+		erasureUC,
+		indexingUsecase.Config{
+			NearDuplicateMode:        srv.indexing.NearDuplicate.Mode,
+			NearDuplicateMaxDistance: srv.indexing.NearDuplicate.MaxDistance,
+		},
 	)
 
 	indexingCons, err := indexingConsumer.New(indexingConsumer.Config{
@@ -61,8 +108,73 @@ func (srv *ConsumerServer) setupDomains(ctx context.Context) (*domainConsumers,
 
 	srv.l.Infof(ctx, "Indexing domain initialized")
 
+	// 3. Lifecycle Domain (purge of deleted projects/campaigns; the purge worker runs here)
+	// Backup, report and chat are only used for their purge: no scheduler, analytics or sharing.
+	backupUC := backupUsecase.New(
+		backupPostgre.New(srv.postgresDB, srv.l),
+		backupQdrant.New(srv.qdrantClient, srv.l),
+		backupRedis.New(srv.redisClient, srv.l),
+		pointUC,
+		searchUC,
+		srv.minioClient,
+		srv.l,
+		backupUsecase.Config{Bucket: srv.minioBucket},
+	)
+	reportUC := reportUsecase.New(
+		reportPostgre.New(srv.postgresDB, srv.l),
+		searchUC,
+		indexingUC,
+		nil,
+		srv.llmClient,
+		srv.minioClient,
+		nil,
+		srv.l,
+		reportUsecase.Config{ReportBucket: srv.minioBucket},
+	)
+	chatUC := chatUsecase.New(
+		chatPostgre.New(srv.postgresDB, srv.l),
+		chatRedis.New(srv.redisClient, srv.l),
+		searchUC,
+		embeddingUC,
+		nil,
+		srv.llmClient,
+		nil,
+		srv.l,
+		chatUsecase.Config{},
+	)
+
+	lifecycleUC := lifecycleUsecase.New(
+		lifecyclePostgre.New(srv.postgresDB, srv.l),
+		pointUC,
+		indexingUC,
+		backupUC,
+		reportUC,
+		chatUC,
+		searchUC,
+		srv.l,
+		lifecycleUsecase.Config{
+			GracePeriod:  time.Duration(srv.lifecycle.GraceHours) * time.Hour,
+			PollInterval: time.Duration(srv.lifecycle.PollIntervalSeconds) * time.Second,
+			MaxAttempts:  srv.lifecycle.MaxAttempts,
+			RunWorker:    true,
+		},
+	)
+
+	lifecycleCons, err := lifecycleConsumer.New(lifecycleConsumer.Config{
+		Logger:      srv.l,
+		KafkaConfig: srv.kafkaConfig,
+		UseCase:     lifecycleUC,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lifecycle consumer: %w", err)
+	}
+
+	srv.l.Infof(ctx, "Lifecycle domain initialized")
+
 	return &domainConsumers{
-		indexingConsumer: indexingCons,
+		indexingConsumer:  indexingCons,
+		lifecycleConsumer: lifecycleCons,
+		searchUC:          searchUC,
 	}, nil
 }
 
@@ -81,6 +193,11 @@ func (srv *ConsumerServer) startConsumers(ctx context.Context, consumers *domain
 		return fmt.Errorf("failed to start digest consumer: %w", err)
 	}
 
+	// Start lifecycle consumer
+	if err := consumers.lifecycleConsumer.ConsumeProjectEvents(ctx); err != nil {
+		return fmt.Errorf("failed to start lifecycle consumer: %w", err)
+	}
+
 	srv.l.Infof(ctx, "All consumers started successfully")
 	return nil
 }
@@ -94,5 +211,19 @@ func (srv *ConsumerServer) stopConsumers(ctx context.Context, consumers *domainC
 		}
 	}
 
+	// Close lifecycle consumer
+	if consumers.lifecycleConsumer != nil {
+		if err := consumers.lifecycleConsumer.Close(); err != nil {
+			srv.l.Errorf(ctx, "Error closing lifecycle consumer: %v", err)
+		}
+	}
+
+	// Stop the search usecase once nothing indexes anymore
+	if consumers.searchUC != nil {
+		if err := consumers.searchUC.Close(ctx); err != nil {
+			srv.l.Errorf(ctx, "Error closing search usecase: %v", err)
+		}
+	}
+
 	srv.l.Infof(ctx, "All consumers stopped")
 }
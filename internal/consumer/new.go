@@ -9,6 +9,10 @@ func New(cfg Config) (*ConsumerServer, error) {
 	srv := &ConsumerServer{
 		l:             cfg.Logger,
 		kafkaConfig:   cfg.KafkaConfig,
+		qdrantConfig:  cfg.QdrantConfig,
+		indexing:      cfg.Indexing,
+		lifecycle:     cfg.Lifecycle,
+		minioBucket:   cfg.MinIOBucket,
 		redisClient:   cfg.RedisClient,
 		qdrantClient:  cfg.QdrantClient,
 		postgresDB:    cfg.PostgresDB,
@@ -18,8 +18,12 @@ import (
 // ConsumerServer is the Kafka consumer orchestrator
 type ConsumerServer struct {
 	// Core Configuration
-	l           log.Logger
-	kafkaConfig config.KafkaConfig
+	l            log.Logger
+	kafkaConfig  config.KafkaConfig
+	qdrantConfig config.QdrantConfig
+	indexing     config.IndexingConfig
+	lifecycle    config.LifecycleConfig
+	minioBucket  string
 
 	// Infrastructure clients
 	redisClient   redis.IRedis
@@ -39,8 +43,12 @@ type ConsumerServer struct {
 // Config holds all dependencies for the consumer server
 type Config struct {
 	// Core Configuration
-	Logger      log.Logger
-	KafkaConfig config.KafkaConfig
+	Logger       log.Logger
+	KafkaConfig  config.KafkaConfig
+	QdrantConfig config.QdrantConfig
+	Indexing     config.IndexingConfig
+	Lifecycle    config.LifecycleConfig
+	MinIOBucket  string // report and backup objects removed by purges
 
 	// Infrastructure clients
 	RedisClient   redis.IRedis
@@ -0,0 +1,4 @@
+// Package e2e holds end-to-end tests that run the real indexing, point and search
+// usecases against the in-memory Qdrant (pkg/qdrant.NewMemory). Postgres, Redis,
+// Voyage and Project Service are replaced by small in-process fakes.
+package e2e
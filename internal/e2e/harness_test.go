@@ -0,0 +1,350 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode"
+
+	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/indexing"
+	indexingRepo "knowledge-srv/internal/indexing/repository"
+	indexingUsecase "knowledge-srv/internal/indexing/usecase"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+	pointRepo "knowledge-srv/internal/point/repository/qdrant"
+	pointUsecase "knowledge-srv/internal/point/usecase"
+	"knowledge-srv/internal/search"
+	searchRepo "knowledge-srv/internal/search/repository"
+	searchUsecase "knowledge-srv/internal/search/usecase"
+	"knowledge-srv/pkg/projectsrv"
+	pkgQdrant "knowledge-srv/pkg/qdrant"
+
+	"github.com/smap-hcmut/shared-libs/go/log"
+)
+
+const vectorSize = 1024
+
+// env wires the real usecases on top of one in-memory Qdrant.
+type env struct {
+	qdrant   pkgQdrant.IQdrant
+	cache    *fakeCache
+	projects *fakeProjects
+	docs     *fakeIndexingPostgres
+	pointUC  point.UseCase
+	indexing indexing.UseCase
+	search   search.UseCase
+}
+
+func newEnv(t *testing.T, layout string) *env {
+	t.Helper()
+
+	l := log.NewLogger(log.ZapConfig{Level: log.LevelError, Mode: log.ModeProduction, Encoding: log.EncodingJSON})
+	client := pkgQdrant.NewMemory()
+	cache := newFakeCache()
+	projects := &fakeProjects{campaigns: map[string]*projectsrv.Campaign{}}
+	embedder := bagOfWordsEmbedder{}
+	docs := &fakeIndexingPostgres{docs: map[string]model.IndexedDocument{}}
+
+	pointUC := pointUsecase.New(pointRepo.New(client, l), l, pointUsecase.Config{Layout: layout})
+	searchUC := searchUsecase.New(pointUC, embedder, cache, nil, projects, l, searchUsecase.Config{})
+	return &env{
+		qdrant:   client,
+		cache:    cache,
+		projects: projects,
+		docs:     docs,
+		pointUC:  pointUC,
+		indexing: indexingUsecase.New(l, docs, pointUC, embedder, searchUC, nil, nil, indexingUsecase.Config{}),
+		search:   searchUC,
+	}
+}
+
+// =====================================================
+// Embedding: hashed bag of words
+// =====================================================
+
+// bagOfWordsEmbedder maps each lowercase token to a fixed dimension, so cosine
+// similarity tracks word overlap and results are deterministic.
+type bagOfWordsEmbedder struct{}
+
+func (bagOfWordsEmbedder) Generate(ctx context.Context, input embedding.GenerateInput) (embedding.GenerateOutput, error) {
+	return embedding.GenerateOutput{Vector: embed(input.Text)}, nil
+}
+
+func (bagOfWordsEmbedder) GenerateMany(ctx context.Context, input embedding.GenerateManyInput) (embedding.GenerateManyOutput, error) {
+	vectors := make([][]float32, 0, len(input.Texts))
+	for _, text := range input.Texts {
+		vectors = append(vectors, embed(text))
+	}
+	return embedding.GenerateManyOutput{Vectors: vectors}, nil
+}
+
+func (bagOfWordsEmbedder) DeleteCached(ctx context.Context, input embedding.DeleteCachedInput) (embedding.DeleteCachedOutput, error) {
+	return embedding.DeleteCachedOutput{}, nil
+}
+
+func embed(text string) []float32 {
+	vector := make([]float32, vectorSize)
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, token := range tokens {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(token))
+		vector[h.Sum32()%vectorSize]++
+	}
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		vector[0] = 1
+		return vector
+	}
+	for i := range vector {
+		vector[i] = float32(float64(vector[i]) / math.Sqrt(norm))
+	}
+	return vector
+}
+
+// =====================================================
+// Redis: search + indexing cache
+// =====================================================
+
+// fakeCache implements both search and indexing CacheRepository, so indexing
+// invalidations are visible to search like with the shared Redis.
+type fakeCache struct {
+	mu               sync.Mutex
+	campaignProjects map[string][]string
+	campaignNames    map[string]string
+	entries          map[string][]byte
+	tags             map[string][]string // project ID → cache keys
+	invalidations    int
+}
+
+var _ searchRepo.CacheRepository = (*fakeCache)(nil)
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{
+		campaignProjects: map[string][]string{},
+		campaignNames:    map[string]string{},
+		entries:          map[string][]byte{},
+		tags:             map[string][]string{},
+	}
+}
+
+func (c *fakeCache) GetCampaignProjects(ctx context.Context, campaignID string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.campaignProjects[campaignID], nil
+}
+
+func (c *fakeCache) SaveCampaignProjects(ctx context.Context, campaignID string, projectIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.campaignProjects[campaignID] = projectIDs
+	return nil
+}
+
+func (c *fakeCache) GetCampaignName(ctx context.Context, campaignID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.campaignNames[campaignID], nil
+}
+
+func (c *fakeCache) SaveCampaignName(ctx context.Context, campaignID string, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.campaignNames[campaignID] = name
+	return nil
+}
+
+func (c *fakeCache) GetSearchResults(ctx context.Context, cacheKey string) ([]byte, error) {
+	return c.get(cacheKey), nil
+}
+
+func (c *fakeCache) SaveSearchResults(ctx context.Context, cacheKey string, data []byte, projectIDs []string) error {
+	c.save(cacheKey, data, projectIDs)
+	return nil
+}
+
+func (c *fakeCache) GetAggregateResults(ctx context.Context, cacheKey string) ([]byte, error) {
+	return c.get(cacheKey), nil
+}
+
+func (c *fakeCache) SaveAggregateResults(ctx context.Context, cacheKey string, data []byte, projectIDs []string) error {
+	c.save(cacheKey, data, projectIDs)
+	return nil
+}
+
+func (c *fakeCache) TagAnswer(ctx context.Context, cacheKey string, projectIDs []string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, projectID := range projectIDs {
+		c.tags[projectID] = append(c.tags[projectID], cacheKey)
+	}
+	return nil
+}
+
+func (c *fakeCache) InvalidateSearchCache(ctx context.Context, projectID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range c.tags[projectID] {
+		delete(c.entries, key)
+	}
+	delete(c.tags, projectID)
+	c.invalidations++
+	return nil
+}
+
+func (c *fakeCache) GetCacheStats(ctx context.Context) (searchRepo.CacheStats, error) {
+	return searchRepo.CacheStats{}, nil
+}
+
+func (c *fakeCache) get(key string) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key]
+}
+
+func (c *fakeCache) save(key string, data []byte, projectIDs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = data
+	for _, projectID := range projectIDs {
+		c.tags[projectID] = append(c.tags[projectID], key)
+	}
+}
+
+// =====================================================
+// Project Service
+// =====================================================
+
+type fakeProjects struct {
+	campaigns map[string]*projectsrv.Campaign
+}
+
+func (p *fakeProjects) GetCampaign(ctx context.Context, campaignID string) (*projectsrv.Campaign, error) {
+	campaign, ok := p.campaigns[campaignID]
+	if !ok {
+		return nil, fmt.Errorf("campaign %s not found", campaignID)
+	}
+	return campaign, nil
+}
+
+func (p *fakeProjects) ValidateProjectAccess(ctx context.Context, userID, projectID string) (bool, error) {
+	return true, nil
+}
+
+// =====================================================
+// Postgres: tracking records and near-duplicate clusters
+// =====================================================
+
+// fakeIndexingPostgres implements only what IndexBatch touches; every document
+// opens its own near-duplicate cluster. Other methods panic via the nil embed.
+type fakeIndexingPostgres struct {
+	indexingRepo.PostgresRepository
+
+	mu       sync.Mutex
+	clusters int
+	docs     map[string]model.IndexedDocument // keyed by analytics_id
+}
+
+func (r *fakeIndexingPostgres) UpsertDocument(ctx context.Context, opt indexingRepo.UpsertDocumentOptions) (model.IndexedDocument, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	doc := model.IndexedDocument{
+		ID:             opt.AnalyticsID,
+		AnalyticsID:    opt.AnalyticsID,
+		ProjectID:      opt.ProjectID,
+		QdrantPointID:  opt.QdrantPointID,
+		CollectionName: opt.CollectionName,
+		ContentHash:    opt.ContentHash,
+		Status:         opt.Status,
+	}
+	if nd := opt.NearDuplicate; nd != nil {
+		doc.SimHash, doc.ClusterID = &nd.SimHash, &nd.ClusterID
+		if nd.DuplicateOf != "" {
+			doc.DuplicateOf = &nd.DuplicateOf
+		}
+	}
+	r.docs[opt.AnalyticsID] = doc
+	return doc, nil
+}
+
+func (r *fakeIndexingPostgres) UpdateDocumentStatus(ctx context.Context, opt indexingRepo.UpdateDocumentStatusOptions) (model.IndexedDocument, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	doc, ok := r.docs[opt.ID]
+	if !ok {
+		return model.IndexedDocument{}, indexingRepo.ErrNotFound
+	}
+	doc.Status = opt.Status
+	r.docs[opt.ID] = doc
+	return doc, nil
+}
+
+func (r *fakeIndexingPostgres) BeginClusterTx(ctx context.Context, projectID string) (indexingRepo.ClusterTx, error) {
+	return &fakeClusterTx{r: r}, nil
+}
+
+// fakeClusterTx never finds a nearby cluster, so every point becomes a representative.
+type fakeClusterTx struct {
+	r *fakeIndexingPostgres
+}
+
+func (tx *fakeClusterTx) NearestCluster(ctx context.Context, opt indexingRepo.NearestClusterOptions) (model.NearDuplicateCluster, error) {
+	return model.NearDuplicateCluster{}, indexingRepo.ErrNotFound
+}
+
+func (tx *fakeClusterTx) CreateCluster(ctx context.Context, opt indexingRepo.CreateClusterOptions) (model.NearDuplicateCluster, error) {
+	tx.r.mu.Lock()
+	defer tx.r.mu.Unlock()
+	tx.r.clusters++
+	return model.NearDuplicateCluster{
+		ID:                    fmt.Sprintf("cluster-%d", tx.r.clusters),
+		ProjectID:             opt.ProjectID,
+		RepresentativePointID: opt.PointID,
+		SimHash:               opt.SimHash,
+		MemberCount:           1,
+	}, nil
+}
+
+func (tx *fakeClusterTx) AddClusterMember(ctx context.Context, clusterID, pointID string) (int, error) {
+	return 0, fmt.Errorf("fakeClusterTx: unexpected AddClusterMember")
+}
+
+func (tx *fakeClusterTx) Commit() error   { return nil }
+func (tx *fakeClusterTx) Rollback() error { return nil }
+
+// =====================================================
+// Fixtures
+// =====================================================
+
+func insight(uapID, platform, sentiment, text string) indexing.InsightMessageInput {
+	return indexing.InsightMessageInput{
+		Identity: indexing.InsightIdentityInput{UapID: uapID, UapType: "post", Platform: platform, PublishedAt: "2026-10-01T08:00:00Z"},
+		Content:  indexing.InsightContentInput{CleanText: text},
+		NLP: indexing.InsightNLPInput{
+			Sentiment: indexing.InsightSentimentInput{Label: sentiment, Score: 0.8},
+			Aspects:   []indexing.InsightAspectInput{{Aspect: "DELIVERY", Polarity: sentiment}},
+		},
+		Business: indexing.InsightBusinessInput{RelevanceScore: 0.8},
+		Source:   indexing.InsightSourceInput{URL: "https://example.com/" + uapID},
+		RAG:      true,
+	}
+}
+
+func resultUapIDs(results []search.SearchResult) []string {
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		id, _ := r.Metadata["uap_id"].(string)
+		ids = append(ids, id)
+	}
+	return ids
+}
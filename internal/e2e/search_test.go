@@ -0,0 +1,314 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"knowledge-srv/internal/indexing"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
+	"knowledge-srv/pkg/projectsrv"
+)
+
+const campaignID = "campaign-1"
+
+var analyst = model.Scope{UserID: "user-1", Role: "ANALYST"}
+
+// seed indexes a fixed corpus across two projects of one campaign.
+func seed(t *testing.T, e *env) {
+	t.Helper()
+	ctx := context.Background()
+	e.projects.campaigns[campaignID] = &projectsrv.Campaign{ID: campaignID, Name: "Ahamove", ProjectIDs: []string{"p1", "p2"}}
+
+	batches := []indexing.IndexBatchInput{
+		{
+			ProjectID:  "p1",
+			CampaignID: campaignID,
+			Documents: []indexing.InsightMessageInput{
+				insight("uap-late-1", "tiktok", "NEGATIVE", "Ahamove giao hàng chậm quá, tài xế đến trễ hai tiếng"),
+				insight("uap-cod-1", "facebook", "NEUTRAL", "Ahamove thu hộ COD đối soát mất ba ngày mới nhận tiền"),
+				insight("uap-short", "facebook", "NEUTRAL", "ship ok"),
+				func() indexing.InsightMessageInput {
+					doc := insight("uap-norag", "facebook", "POSITIVE", "Ahamove tổng đài hỗ trợ nhiệt tình, giao hàng nhanh")
+					doc.RAG = false
+					return doc
+				}(),
+			},
+		},
+		{
+			ProjectID:  "p2",
+			CampaignID: campaignID,
+			Documents: []indexing.InsightMessageInput{
+				insight("uap-late-2", "facebook", "NEGATIVE", "Đơn hàng giao chậm, tài xế Ahamove hủy đơn không báo"),
+				insight("uap-support-2", "tiktok", "POSITIVE", "Tổng đài Ahamove hỗ trợ nhanh, shipper thân thiện"),
+			},
+		},
+	}
+
+	for _, batch := range batches {
+		out, err := e.indexing.IndexBatch(ctx, batch)
+		if err != nil {
+			t.Fatalf("IndexBatch(%s): %v", batch.ProjectID, err)
+		}
+		if out.Failed != 0 {
+			t.Fatalf("IndexBatch(%s): %d failed", batch.ProjectID, out.Failed)
+		}
+	}
+}
+
+func forEachLayout(t *testing.T, fn func(t *testing.T, e *env)) {
+	for _, layout := range []string{point.LayoutPerProject, point.LayoutShared} {
+		t.Run(layout, func(t *testing.T) {
+			e := newEnv(t, layout)
+			seed(t, e)
+			fn(t, e)
+		})
+	}
+}
+
+func TestIndexBatch(t *testing.T) {
+	forEachLayout(t, func(t *testing.T, e *env) {
+		ctx := context.Background()
+
+		var total uint64
+		for _, target := range e.pointUC.CollectionTargets([]string{"p1", "p2"}) {
+			n, err := e.pointUC.Count(ctx, point.CountInput{CollectionName: target.Collection, Filter: target.Scope(nil)})
+			if err != nil {
+				t.Fatalf("Count(%s): %v", target.Collection, err)
+			}
+			total += n
+		}
+		// uap-short is below the content threshold, uap-norag is not flagged for RAG.
+		if total != 4 {
+			t.Fatalf("indexed points = %d, want 4", total)
+		}
+		if e.cache.invalidations != 2 {
+			t.Fatalf("cache invalidations = %d, want 2", e.cache.invalidations)
+		}
+
+		// Every indexed insight is tracked with its fingerprint and cluster.
+		if len(e.docs.docs) != 4 {
+			t.Fatalf("tracking records = %d, want 4", len(e.docs.docs))
+		}
+		for _, doc := range e.docs.docs {
+			if doc.Status != indexing.STATUS_INDEXED {
+				t.Errorf("%s: status = %s, want %s", doc.QdrantPointID, doc.Status, indexing.STATUS_INDEXED)
+			}
+			if doc.SimHash == nil || doc.ClusterID == nil {
+				t.Errorf("%s: missing simhash or cluster", doc.QdrantPointID)
+			}
+		}
+	})
+}
+
+func TestSearch(t *testing.T) {
+	forEachLayout(t, func(t *testing.T, e *env) {
+		out, err := e.search.Search(context.Background(), analyst, search.SearchInput{
+			CampaignID: campaignID,
+			Query:      "giao hàng chậm tài xế",
+			MinScore:   0.1,
+		})
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if out.NoRelevantContext || len(out.Results) == 0 {
+			t.Fatalf("Search returned no results")
+		}
+
+		ids := resultUapIDs(out.Results)
+		if ids[0] != "uap-late-1" && ids[0] != "uap-late-2" {
+			t.Fatalf("top result = %s, want a late-delivery post (got %v)", ids[0], ids)
+		}
+		projects := map[string]bool{}
+		for _, r := range out.Results {
+			projects[r.ProjectID] = true
+			if r.Layer != search.LayerPost {
+				t.Fatalf("result %s layer = %q, want %q", r.ID, r.Layer, search.LayerPost)
+			}
+		}
+		if !projects["p1"] || !projects["p2"] {
+			t.Fatalf("results cover projects %v, want p1 and p2", projects)
+		}
+	})
+}
+
+func TestSearchOffsetMatchesCursor(t *testing.T) {
+	forEachLayout(t, func(t *testing.T, e *env) {
+		ctx := context.Background()
+		input := search.SearchInput{CampaignID: campaignID, Query: "giao hàng chậm tài xế", Limit: 1, MinScore: 0.1}
+
+		first, err := e.search.Search(ctx, analyst, input)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if !first.HasMore {
+			t.Fatalf("first page has no more results")
+		}
+		byCursor := input
+		byCursor.Cursor = first.NextCursor
+		next, err := e.search.Search(ctx, analyst, byCursor)
+		if err != nil {
+			t.Fatalf("Search(cursor): %v", err)
+		}
+		byOffset := input
+		byOffset.Offset = 1
+		second, err := e.search.Search(ctx, analyst, byOffset)
+		if err != nil {
+			t.Fatalf("Search(offset): %v", err)
+		}
+
+		got, want := resultUapIDs(second.Results), resultUapIDs(next.Results)
+		if len(got) != 1 || len(want) != 1 || got[0] != want[0] {
+			t.Fatalf("offset page = %v, cursor page = %v", got, want)
+		}
+
+		count, err := e.search.Count(ctx, analyst, search.CountInput{CampaignID: campaignID})
+		if err != nil {
+			t.Fatalf("Count: %v", err)
+		}
+		if count.Total != 4 {
+			t.Fatalf("Count = %d, want 4", count.Total)
+		}
+	})
+}
+
+func TestSearchFilters(t *testing.T) {
+	forEachLayout(t, func(t *testing.T, e *env) {
+		tests := []struct {
+			name    string
+			filters search.SearchFilters
+			want    map[string]bool
+		}{
+			{
+				name:    "platform",
+				filters: search.SearchFilters{Platforms: []string{"tiktok"}},
+				want:    map[string]bool{"uap-late-1": true, "uap-support-2": true},
+			},
+			{
+				name:    "sentiment",
+				filters: search.SearchFilters{Sentiments: []string{"NEGATIVE"}},
+				want:    map[string]bool{"uap-late-1": true, "uap-late-2": true},
+			},
+			{
+				name:    "platform and sentiment",
+				filters: search.SearchFilters{Platforms: []string{"facebook"}, Sentiments: []string{"NEGATIVE"}},
+				want:    map[string]bool{"uap-late-2": true},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				out, err := e.search.Search(context.Background(), analyst, search.SearchInput{
+					CampaignID: campaignID,
+					Query:      "Ahamove giao hàng tổng đài",
+					Filters:    tt.filters,
+					MinScore:   0.05,
+				})
+				if err != nil {
+					t.Fatalf("Search: %v", err)
+				}
+				got := resultUapIDs(out.Results)
+				if len(got) != len(tt.want) {
+					t.Fatalf("results = %v, want %v", got, tt.want)
+				}
+				for _, id := range got {
+					if !tt.want[id] {
+						t.Fatalf("unexpected result %s (got %v)", id, got)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestSearchCacheInvalidatedByIndexing(t *testing.T) {
+	forEachLayout(t, func(t *testing.T, e *env) {
+		ctx := context.Background()
+		input := search.SearchInput{CampaignID: campaignID, Query: "thu hộ COD đối soát", MinScore: 0.1}
+
+		first, err := e.search.Search(ctx, analyst, input)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if first.CacheHit {
+			t.Fatalf("first search hit the cache")
+		}
+		second, err := e.search.Search(ctx, analyst, input)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if !second.CacheHit || len(second.Results) != len(first.Results) {
+			t.Fatalf("second search: cache_hit=%v results=%d, want cached %d", second.CacheHit, len(second.Results), len(first.Results))
+		}
+
+		_, err = e.indexing.IndexBatch(ctx, indexing.IndexBatchInput{
+			ProjectID:  "p2",
+			CampaignID: campaignID,
+			Documents:  []indexing.InsightMessageInput{insight("uap-cod-2", "facebook", "NEGATIVE", "Tiền thu hộ COD Ahamove đối soát chậm, shop phải chờ")},
+		})
+		if err != nil {
+			t.Fatalf("IndexBatch: %v", err)
+		}
+
+		third, err := e.search.Search(ctx, analyst, input)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if third.CacheHit {
+			t.Fatalf("search after indexing was served from cache")
+		}
+		if len(third.Results) != len(first.Results)+1 {
+			t.Fatalf("results after indexing = %v, want the new post added to %v", resultUapIDs(third.Results), resultUapIDs(first.Results))
+		}
+	})
+}
+
+func TestIndexSamePostInTwoProjects(t *testing.T) {
+	forEachLayout(t, func(t *testing.T, e *env) {
+		ctx := context.Background()
+		doc := insight("uap-repost", "tiktok", "NEGATIVE", "Ahamove hủy đơn hàng phút chót, shipper không nghe máy")
+
+		for _, projectID := range []string{"p1", "p2"} {
+			out, err := e.indexing.IndexBatch(ctx, indexing.IndexBatchInput{
+				ProjectID:  projectID,
+				CampaignID: campaignID,
+				Documents:  []indexing.InsightMessageInput{doc},
+			})
+			if err != nil {
+				t.Fatalf("IndexBatch(%s): %v", projectID, err)
+			}
+			if out.Indexed != 1 {
+				t.Fatalf("IndexBatch(%s): indexed = %d, want 1", projectID, out.Indexed)
+			}
+		}
+
+		// Points are keyed by collection and ID; the source ID stays in the payload.
+		pointIDs := map[string]string{}
+		for _, projectID := range []string{"p1", "p2"} {
+			for _, target := range e.pointUC.CollectionTargets([]string{projectID}) {
+				points, err := e.pointUC.Scroll(ctx, point.ScrollInput{
+					CollectionName: target.Collection,
+					Filter:         target.Scope(nil),
+					Limit:          100,
+					WithPayload:    true,
+				})
+				if err != nil {
+					t.Fatalf("Scroll(%s): %v", target.Collection, err)
+				}
+				for _, p := range points {
+					if id, _ := p.Payload["uap_id"].(string); id == "uap-repost" {
+						pointIDs[projectID] = target.Collection + "/" + p.ID
+					}
+				}
+			}
+		}
+		// The second project must not overwrite the first project's point.
+		if pointIDs["p1"] == "" || pointIDs["p2"] == "" {
+			t.Fatalf("uap-repost found in projects %v, want p1 and p2", pointIDs)
+		}
+		if pointIDs["p1"] == pointIDs["p2"] {
+			t.Fatalf("both projects share point %s", pointIDs["p1"])
+		}
+	})
+}
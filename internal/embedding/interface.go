@@ -8,4 +8,6 @@ import (
 type UseCase interface {
 	Generate(ctx context.Context, input GenerateInput) (GenerateOutput, error)
 	GenerateMany(ctx context.Context, input GenerateManyInput) (GenerateManyOutput, error)
+	// DeleteCached removes cached embeddings of content that was purged or erased
+	DeleteCached(ctx context.Context, input DeleteCachedInput) (DeleteCachedOutput, error)
 }
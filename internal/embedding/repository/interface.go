@@ -6,4 +6,6 @@ import "context"
 type Repository interface {
 	Get(ctx context.Context, opt GetOptions) ([]float32, error)
 	Save(ctx context.Context, opt SaveOptions) error
+	// Delete removes cached vectors and returns the keys deleted, also when it fails part way.
+	Delete(ctx context.Context, opt DeleteOptions) (int64, error)
 }
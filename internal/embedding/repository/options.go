@@ -11,3 +11,7 @@ type SaveOptions struct {
 	Vector []float32
 	TTL    time.Duration
 }
+
+type DeleteOptions struct {
+	Keys []string
+}
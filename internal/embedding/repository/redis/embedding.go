@@ -12,6 +12,9 @@ import (
 
 const Prefix = "embedding:"
 
+// deleteChunkSize bounds the keys of one DEL.
+const deleteChunkSize = 500
+
 func (r *implRepository) Get(ctx context.Context, opt repository.GetOptions) ([]float32, error) {
 	key := fmt.Sprintf("%s%s", Prefix, opt.Key)
 	data, err := r.redis.GetClient().Get(ctx, key).Result()
@@ -52,3 +55,25 @@ func (r *implRepository) Save(ctx context.Context, opt repository.SaveOptions) e
 	}
 	return nil
 }
+
+// Delete removes cached embeddings in chunks and returns the keys deleted, also when
+// a later chunk fails.
+func (r *implRepository) Delete(ctx context.Context, opt repository.DeleteOptions) (int64, error) {
+	client := r.redis.GetClient()
+
+	var deleted int64
+	for start := 0; start < len(opt.Keys); start += deleteChunkSize {
+		end := min(start+deleteChunkSize, len(opt.Keys))
+		keys := make([]string, 0, end-start)
+		for _, key := range opt.Keys[start:end] {
+			keys = append(keys, Prefix+key)
+		}
+		n, err := client.Del(ctx, keys...).Result()
+		if err != nil && err != goredis.Nil {
+			r.l.Warnf(ctx, "embedding.repository.redis.Delete: cache unavailable: %v", err)
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
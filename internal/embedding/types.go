@@ -15,3 +15,11 @@ type GenerateManyInput struct {
 type GenerateManyOutput struct {
 	Vectors [][]float32
 }
+
+type DeleteCachedInput struct {
+	ContentHashes []string // SHA-256 of the embedded text, as cached by Generate
+}
+
+type DeleteCachedOutput struct {
+	Deleted int64 // cache keys removed
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/embedding/repository"
+)
+
+func (uc *implUseCase) DeleteCached(ctx context.Context, input embedding.DeleteCachedInput) (embedding.DeleteCachedOutput, error) {
+	if len(input.ContentHashes) == 0 {
+		return embedding.DeleteCachedOutput{}, nil
+	}
+
+	deleted, err := uc.repo.Delete(ctx, repository.DeleteOptions{Keys: input.ContentHashes})
+	if err != nil {
+		uc.l.Errorf(ctx, "embedding.usecase.DeleteCached: deleted %d of %d before failing: %v", deleted, len(input.ContentHashes), err)
+	}
+	return embedding.DeleteCachedOutput{Deleted: deleted}, err
+}
@@ -0,0 +1,59 @@
+package erasure
+
+// Erasure subject kinds.
+const (
+	KindAuthor   = "AUTHOR"   // author / author_display_name
+	KindUsername = "USERNAME" // author_username
+	KindURL      = "URL"      // any source URL field, including parent_post_url (comments under the post)
+)
+
+// EvidenceReferencesField - macro_insights payload listing the documents an insight cites
+const EvidenceReferencesField = "evidence_references"
+
+// authorFields, usernameFields and urlFields are the payload keys matched per kind.
+// Insight documents keep them at the top level, legacy analytics posts under metadata.
+var (
+	authorFields   = []string{"author", "author_display_name"}
+	usernameFields = []string{"author_username"}
+	urlFields      = []string{
+		"url", "post_url", "original_url", "permalink", "source_url",
+		"web_url", "comment_url", "parent_post_url",
+	}
+)
+
+// IsValidKind reports whether k is an erasure subject kind.
+func IsValidKind(k string) bool {
+	return k == KindAuthor || k == KindUsername || k == KindURL
+}
+
+// PayloadFields returns the Qdrant payload keys (dot paths) matched for kind.
+func PayloadFields(kind string) []string {
+	base := baseFields(kind)
+	fields := make([]string, 0, 2*len(base))
+	for _, f := range base {
+		fields = append(fields, f, "metadata."+f)
+	}
+	return fields
+}
+
+// DLQFields returns the raw_payload paths of indexing_dlq matched for kind.
+func DLQFields(kind string) []string {
+	base := baseFields(kind)
+	fields := make([]string, 0, 2*len(base))
+	for _, f := range base {
+		fields = append(fields, f, "uap_metadata."+f)
+	}
+	return fields
+}
+
+func baseFields(kind string) []string {
+	switch kind {
+	case KindAuthor:
+		return authorFields
+	case KindUsername:
+		return usernameFields
+	case KindURL:
+		return urlFields
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"errors"
+	"knowledge-srv/internal/erasure"
+
+	pkgErrors "github.com/smap-hcmut/shared-libs/go/errors"
+)
+
+var (
+	errInvalidKind      = pkgErrors.NewHTTPError(400, "Invalid erasure kind")
+	errValueRequired    = pkgErrors.NewHTTPError(400, "Erasure value is required")
+	errInvalidProjectID = pkgErrors.NewHTTPError(400, "Invalid project ID")
+	errErasureNotFound  = pkgErrors.NewHTTPError(404, "Erasure request not found")
+	errErase            = pkgErrors.NewHTTPError(500, "Failed to erase")
+	errListErasures     = pkgErrors.NewHTTPError(500, "Failed to list erasure requests")
+)
+
+func (h *handler) mapError(err error) error {
+	switch {
+	case errors.Is(err, erasure.ErrInvalidKind):
+		return errInvalidKind
+	case errors.Is(err, erasure.ErrValueRequired):
+		return errValueRequired
+	case errors.Is(err, erasure.ErrInvalidProjectID):
+		return errInvalidProjectID
+	case errors.Is(err, erasure.ErrErasureNotFound):
+		return errErasureNotFound
+	case errors.Is(err, erasure.ErrErase):
+		return errErase
+	case errors.Is(err, erasure.ErrListErasures):
+		return errListErasures
+	default:
+		return pkgErrors.NewHTTPError(500, "Internal server error")
+	}
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/response"
+)
+
+// Erase - Handler cho POST /internal/erasures
+// @Summary Erase an author, username or source URL (right to be forgotten)
+// @Description Delete every document of an author/username, or with a source URL, from all project collections (or only the given projects): Qdrant points, indexed documents, DLQ entries, cached embeddings and search results. Erased documents are removed from the evidence references of macro insights, and a tombstone blocks their re-ingestion. Returns a deletion receipt; dry_run only counts the matching points and evidence references. Qdrant snapshots already in MinIO keep the data until their retention expires.
+// @Tags Erasure (Internal)
+// @Accept json
+// @Produce json
+// @Param body body eraseReq true "Erasure subject"
+// @Success 200 {object} erasureResp
+// @Failure 400 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/erasures [post]
+func (h *handler) Erase(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processEraseRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "erasure.delivery.http.Erase: processEraseRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.Erase(ctx, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "erasure.delivery.http.Erase: usecase Erase failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newEraseResp(output))
+}
+
+// ListErasures - Handler cho GET /internal/erasures
+// @Summary List erasure receipts
+// @Description List erasure requests and what they removed, newest first
+// @Tags Erasure (Internal)
+// @Produce json
+// @Param kind query string false "AUTHOR | USERNAME | URL"
+// @Param value query string false "Subject (matched after normalization)"
+// @Param limit query int false "Max records (default 50, max 200)"
+// @Success 200 {object} listErasuresResp
+// @Failure 400 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/erasures [get]
+func (h *handler) ListErasures(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processListErasuresRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "erasure.delivery.http.ListErasures: processListErasuresRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.ListErasures(ctx, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "erasure.delivery.http.ListErasures: usecase ListErasures failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newListErasuresResp(output))
+}
+
+// GetErasure - Handler cho GET /internal/erasures/:id
+// @Summary Get an erasure receipt
+// @Description Get an erasure request with what it removed
+// @Tags Erasure (Internal)
+// @Produce json
+// @Param id path string true "Erasure request ID"
+// @Success 200 {object} erasureResp
+// @Failure 400 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/erasures/{id} [get]
+func (h *handler) GetErasure(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processErasureIDRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "erasure.delivery.http.GetErasure: processErasureIDRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.GetErasure(ctx, req.ID)
+	if err != nil {
+		h.l.Errorf(ctx, "erasure.delivery.http.GetErasure: usecase GetErasure failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, newErasureResp(output))
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"time"
+
+	"knowledge-srv/internal/erasure"
+	"knowledge-srv/internal/model"
+)
+
+type eraseReq struct {
+	Kind        string   `json:"kind" binding:"required,oneof=AUTHOR USERNAME URL"`
+	Value       string   `json:"value" binding:"required"`
+	ProjectIDs  []string `json:"project_ids" binding:"omitempty,dive,uuid"` // empty = every project
+	Reason      string   `json:"reason"`
+	RequestedBy string   `json:"requested_by"`
+	DryRun      bool     `json:"dry_run"` // count matches without deleting anything
+}
+
+func (r eraseReq) toInput() erasure.EraseInput {
+	return erasure.EraseInput{
+		Kind:        r.Kind,
+		Value:       r.Value,
+		ProjectIDs:  r.ProjectIDs,
+		Reason:      r.Reason,
+		RequestedBy: r.RequestedBy,
+		DryRun:      r.DryRun,
+	}
+}
+
+type listErasuresReq struct {
+	Kind  string `form:"kind"`
+	Value string `form:"value"`
+	Limit int    `form:"limit"`
+}
+
+func (r listErasuresReq) toInput() erasure.ListErasuresInput {
+	return erasure.ListErasuresInput{
+		Kind:  r.Kind,
+		Value: r.Value,
+		Limit: r.Limit,
+	}
+}
+
+type erasureIDReq struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type erasureCollectionResp struct {
+	Collection string `json:"collection"`
+	Points     uint64 `json:"points"`
+}
+
+type erasureRemovedResp struct {
+	Collections         []erasureCollectionResp `json:"collections"`
+	PointsDeleted       uint64                  `json:"points_deleted"`
+	InsightsUpdated     int64                   `json:"insights_updated"`
+	EvidenceReferences  int64                   `json:"evidence_references"`
+	IndexedDocuments    int64                   `json:"indexed_documents"`
+	DLQEntries          int64                   `json:"dlq_entries"`
+	EmbeddingKeys       int64                   `json:"embedding_keys"`
+	SearchCacheProjects []string                `json:"search_cache_projects"`
+	TombstoneCreated    bool                    `json:"tombstone_created"`
+}
+
+type erasureResp struct {
+	ID          string             `json:"id,omitempty"`
+	Kind        string             `json:"kind"`
+	Value       string             `json:"value"`
+	ValueNorm   string             `json:"value_norm"`
+	ProjectIDs  []string           `json:"project_ids"`
+	Reason      string             `json:"reason,omitempty"`
+	RequestedBy string             `json:"requested_by,omitempty"`
+	DryRun      bool               `json:"dry_run"`
+	Removed     erasureRemovedResp `json:"removed"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+}
+
+type listErasuresResp struct {
+	Erasures []erasureResp `json:"erasures"`
+}
+
+func (h *handler) newEraseResp(o erasure.EraseOutput) erasureResp {
+	resp := newErasureResp(o.Receipt)
+	resp.DryRun = o.DryRun
+	return resp
+}
+
+func (h *handler) newListErasuresResp(o erasure.ListErasuresOutput) listErasuresResp {
+	resp := listErasuresResp{Erasures: make([]erasureResp, len(o.Erasures))}
+	for i, e := range o.Erasures {
+		resp.Erasures[i] = newErasureResp(e)
+	}
+	return resp
+}
+
+func newErasureResp(e model.ErasureRequest) erasureResp {
+	collections := make([]erasureCollectionResp, len(e.Removed.Collections))
+	for i, c := range e.Removed.Collections {
+		collections[i] = erasureCollectionResp{Collection: c.Collection, Points: c.Points}
+	}
+	return erasureResp{
+		ID:          e.ID,
+		Kind:        e.Kind,
+		Value:       e.Value,
+		ValueNorm:   e.ValueNorm,
+		ProjectIDs:  nonNil(e.ProjectIDs),
+		Reason:      e.Reason,
+		RequestedBy: e.RequestedBy,
+		Removed: erasureRemovedResp{
+			Collections:         collections,
+			PointsDeleted:       e.Removed.PointsDeleted,
+			InsightsUpdated:     e.Removed.InsightsUpdated,
+			EvidenceReferences:  e.Removed.EvidenceReferences,
+			IndexedDocuments:    e.Removed.IndexedDocuments,
+			DLQEntries:          e.Removed.DLQEntries,
+			EmbeddingKeys:       e.Removed.EmbeddingKeys,
+			SearchCacheProjects: nonNil(e.Removed.SearchCacheProjects),
+			TombstoneCreated:    e.Removed.TombstoneCreated,
+		},
+		CreatedAt:   e.CreatedAt,
+		CompletedAt: e.CompletedAt,
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func (h *handler) processEraseRequest(c *gin.Context) (eraseReq, error) {
+	var req eraseReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (h *handler) processListErasuresRequest(c *gin.Context) (listErasuresReq, error) {
+	var req listErasuresReq
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (h *handler) processErasureIDRequest(c *gin.Context) (erasureIDReq, error) {
+	var req erasureIDReq
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
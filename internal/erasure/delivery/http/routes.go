@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup, mw *middleware.Middleware) {
+	internal := r.Group("/internal")
+	internal.Use(mw.InternalAuth())
+	{
+		internal.POST("/erasures", h.Erase)
+		internal.GET("/erasures", h.ListErasures)
+		internal.GET("/erasures/:id", h.GetErasure)
+	}
+}
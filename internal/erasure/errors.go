@@ -0,0 +1,12 @@
+package erasure
+
+import "errors"
+
+var (
+	ErrInvalidKind      = errors.New("erasure: invalid subject kind")
+	ErrValueRequired    = errors.New("erasure: value is required")
+	ErrInvalidProjectID = errors.New("erasure: invalid project id")
+	ErrErasureNotFound  = errors.New("erasure: request not found")
+	ErrErase            = errors.New("erasure: failed to erase")
+	ErrListErasures     = errors.New("erasure: failed to list erasure requests")
+)
@@ -0,0 +1,20 @@
+package erasure
+
+import (
+	"context"
+
+	"knowledge-srv/internal/model"
+)
+
+//go:generate mockery --name UseCase
+type UseCase interface {
+	// Erase records a tombstone for the subject, deletes its matching points from every
+	// post collection, strips them from macro_insights evidence, removes their indexed
+	// documents, DLQ entries and cached embeddings, evicts affected search caches and
+	// returns the receipt. Safe to re-run: a second run removes whatever is left.
+	Erase(ctx context.Context, input EraseInput) (EraseOutput, error)
+	GetErasure(ctx context.Context, id string) (model.ErasureRequest, error)
+	ListErasures(ctx context.Context, input ListErasuresInput) (ListErasuresOutput, error)
+	// IsErased reports whether a document matches a tombstone and must not be indexed.
+	IsErased(ctx context.Context, ref SourceRef) (bool, error)
+}
@@ -0,0 +1,69 @@
+package erasure
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Normalize returns the canonical form of an erasure subject, used for tombstones.
+// Authors are compared case-insensitively with collapsed whitespace, usernames
+// without a leading "@", URLs without fragment, "www." and trailing slash.
+func Normalize(kind, value string) string {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case KindAuthor:
+		return strings.ToLower(strings.Join(strings.Fields(value), " "))
+	case KindUsername:
+		return strings.ToLower(strings.TrimLeft(value, "@"))
+	case KindURL:
+		return normalizeURL(value)
+	}
+	return value
+}
+
+// Variants returns the raw spellings to match exactly in payloads (Qdrant keyword
+// matches are case-sensitive, so the value as sent and its normal form are both tried).
+func Variants(kind, value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	variants := []string{value, Normalize(kind, value)}
+	switch kind {
+	case KindAuthor:
+		variants = append(variants, strings.Join(strings.Fields(value), " "))
+	case KindUsername:
+		bare := strings.TrimLeft(value, "@")
+		variants = append(variants, bare, "@"+bare, "@"+Normalize(kind, value))
+	case KindURL:
+		trimmed := strings.TrimRight(value, "/")
+		variants = append(variants, trimmed, trimmed+"/")
+	}
+	return unique(variants)
+}
+
+func normalizeURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(strings.TrimRight(raw, "/"))
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	normalized := strings.ToLower(u.Scheme) + "://" + host + strings.TrimRight(u.EscapedPath(), "/")
+	if u.RawQuery != "" {
+		normalized += "?" + u.RawQuery
+	}
+	return normalized
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := values[:0]
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
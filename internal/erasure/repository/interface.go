@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"knowledge-srv/internal/model"
+)
+
+//go:generate mockery --name PostgresRepository
+type PostgresRepository interface {
+	ErasureRepository
+	TombstoneRepository
+	// DeleteDocuments deletes indexed_documents rows by analytics ID or Qdrant point ID.
+	DeleteDocuments(ctx context.Context, ids []string) (DeleteDocumentsResult, error)
+	DeleteDLQ(ctx context.Context, opt DeleteDLQOptions) (int64, error)
+}
+
+// ErasureRepository - Operations for the erasure_requests table (receipts)
+type ErasureRepository interface {
+	CreateErasure(ctx context.Context, opt CreateErasureOptions) (model.ErasureRequest, error)
+	CompleteErasure(ctx context.Context, id string, removed model.ErasureRemoved) (model.ErasureRequest, error)
+	GetErasure(ctx context.Context, id string) (model.ErasureRequest, error)
+	ListErasures(ctx context.Context, opt ListErasuresOptions) ([]model.ErasureRequest, error)
+}
+
+// TombstoneRepository - Operations for the erasure_tombstones table
+type TombstoneRepository interface {
+	// CreateTombstone inserts a tombstone; created is false when the subject already had one.
+	CreateTombstone(ctx context.Context, kind, valueNorm, requestID string) (created bool, err error)
+	ListTombstones(ctx context.Context) ([]model.ErasureTombstone, error)
+}
+
+//go:generate mockery --name CacheRepository
+type CacheRepository interface {
+	// TombstoneVersion changes whenever a tombstone is added, so every replica reloads its set.
+	TombstoneVersion(ctx context.Context) (int64, error)
+	BumpTombstoneVersion(ctx context.Context) error
+}
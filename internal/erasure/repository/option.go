@@ -0,0 +1,32 @@
+package repository
+
+// CreateErasureOptions - Options for CreateErasure
+type CreateErasureOptions struct {
+	Kind        string
+	Value       string
+	ValueNorm   string
+	ProjectIDs  []string
+	Reason      string
+	RequestedBy string
+}
+
+// ListErasuresOptions - Filters for ListErasures (empty = any)
+type ListErasuresOptions struct {
+	Kind      string
+	ValueNorm string
+	Limit     int
+}
+
+// DeleteDLQOptions - DLQ entries of the given records, or whose raw payload matches
+type DeleteDLQOptions struct {
+	AnalyticsIDs []string
+	Fields       []string // raw_payload dot paths
+	Values       []string // matched exactly against every path
+}
+
+// DeleteDocumentsResult - What DeleteDocuments removed
+type DeleteDocumentsResult struct {
+	Deleted       int64
+	ContentHashes []string // embedding cache keys of the removed documents
+	ProjectIDs    []string
+}
@@ -0,0 +1,82 @@
+package postgre
+
+import (
+	"context"
+
+	repo "knowledge-srv/internal/erasure/repository"
+
+	"github.com/lib/pq"
+)
+
+// DeleteDocuments - indexed_documents rows by analytics ID or Qdrant point ID
+func (r *implPostgresRepository) DeleteDocuments(ctx context.Context, ids []string) (repo.DeleteDocumentsResult, error) {
+	const query = `
+		DELETE FROM knowledge.indexed_documents
+		WHERE analytics_id::text = ANY($1) OR qdrant_point_id::text = ANY($1)
+		RETURNING content_hash, project_id::text`
+
+	var result repo.DeleteDocumentsResult
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.DeleteDocuments: Failed to delete documents: %v", err)
+		return result, repo.ErrFailedToDelete
+	}
+	defer rows.Close()
+
+	projects := make(map[string]bool)
+	for rows.Next() {
+		var hash, projectID string
+		if err := rows.Scan(&hash, &projectID); err != nil {
+			r.l.Errorf(ctx, "erasure.repository.postgre.DeleteDocuments: Failed to scan: %v", err)
+			return result, repo.ErrFailedToDelete
+		}
+		result.Deleted++
+		if hash != "" {
+			result.ContentHashes = append(result.ContentHashes, hash)
+		}
+		if !projects[projectID] {
+			projects[projectID] = true
+			result.ProjectIDs = append(result.ProjectIDs, projectID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.DeleteDocuments: Failed to iterate: %v", err)
+		return result, repo.ErrFailedToDelete
+	}
+	return result, nil
+}
+
+// DeleteDLQ - DLQ entries of the given records, or whose raw payload matches one of the values
+func (r *implPostgresRepository) DeleteDLQ(ctx context.Context, opt repo.DeleteDLQOptions) (int64, error) {
+	const query = `
+		DELETE FROM knowledge.indexing_dlq
+		WHERE analytics_id::text = ANY($1)
+		   OR EXISTS (
+				SELECT 1 FROM unnest($2::text[]) AS f(path)
+				WHERE raw_payload #>> string_to_array(f.path, '.') = ANY($3)
+		   )`
+
+	ids := opt.AnalyticsIDs
+	if ids == nil {
+		ids = []string{}
+	}
+	fields, values := opt.Fields, opt.Values
+	if len(values) == 0 {
+		fields, values = []string{}, []string{}
+	}
+
+	res, err := r.db.ExecContext(ctx, query, pq.Array(ids), pq.Array(fields), pq.Array(values))
+	if err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.DeleteDLQ: Failed to delete DLQ entries: %v", err)
+		return 0, repo.ErrFailedToDelete
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, repo.ErrFailedToDelete
+	}
+	return n, nil
+}
@@ -0,0 +1,146 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	repo "knowledge-srv/internal/erasure/repository"
+	"knowledge-srv/internal/model"
+
+	"github.com/lib/pq"
+)
+
+const erasureColumns = `id, kind, value, value_norm, project_ids, reason, requested_by, removed, created_at, completed_at`
+
+// CreateErasure - Open a receipt (completed by CompleteErasure)
+func (r *implPostgresRepository) CreateErasure(ctx context.Context, opt repo.CreateErasureOptions) (model.ErasureRequest, error) {
+	query := `
+		INSERT INTO knowledge.erasure_requests (kind, value, value_norm, project_ids, reason, requested_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + erasureColumns
+
+	projectIDs := opt.ProjectIDs
+	if projectIDs == nil {
+		projectIDs = []string{}
+	}
+	e, err := scanErasure(r.db.QueryRowContext(ctx, query,
+		opt.Kind, opt.Value, opt.ValueNorm, pq.Array(projectIDs), opt.Reason, opt.RequestedBy,
+	))
+	if err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.CreateErasure: Failed to insert erasure %s: %v", opt.Kind, err)
+		return model.ErasureRequest{}, repo.ErrFailedToInsert
+	}
+	return e, nil
+}
+
+// CompleteErasure - Record what the erasure removed
+func (r *implPostgresRepository) CompleteErasure(ctx context.Context, id string, removed model.ErasureRemoved) (model.ErasureRequest, error) {
+	query := `
+		UPDATE knowledge.erasure_requests
+		SET removed = $2, completed_at = NOW()
+		WHERE id = $1
+		RETURNING ` + erasureColumns
+
+	data, err := json.Marshal(removed)
+	if err != nil {
+		return model.ErasureRequest{}, err
+	}
+	e, err := scanErasure(r.db.QueryRowContext(ctx, query, id, data))
+	if err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.CompleteErasure: Failed to update erasure %s: %v", id, err)
+		return model.ErasureRequest{}, repo.ErrFailedToUpdate
+	}
+	return e, nil
+}
+
+func (r *implPostgresRepository) GetErasure(ctx context.Context, id string) (model.ErasureRequest, error) {
+	query := `SELECT ` + erasureColumns + ` FROM knowledge.erasure_requests WHERE id = $1`
+
+	e, err := scanErasure(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErasureRequest{}, repo.ErrNotFound
+		}
+		r.l.Errorf(ctx, "erasure.repository.postgre.GetErasure: Failed to get erasure %s: %v", id, err)
+		return model.ErasureRequest{}, repo.ErrFailedToGet
+	}
+	return e, nil
+}
+
+// ListErasures - Receipts matching the filters, newest first
+func (r *implPostgresRepository) ListErasures(ctx context.Context, opt repo.ListErasuresOptions) ([]model.ErasureRequest, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if opt.Kind != "" {
+		args = append(args, opt.Kind)
+		where = append(where, fmt.Sprintf("kind = $%d", len(args)))
+	}
+	if opt.ValueNorm != "" {
+		args = append(args, opt.ValueNorm)
+		where = append(where, fmt.Sprintf("value_norm = $%d", len(args)))
+	}
+
+	query := `SELECT ` + erasureColumns + ` FROM knowledge.erasure_requests`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, opt.Limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.ListErasures: Failed to query erasures: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	defer rows.Close()
+
+	var erasures []model.ErasureRequest
+	for rows.Next() {
+		e, err := scanErasure(rows)
+		if err != nil {
+			r.l.Errorf(ctx, "erasure.repository.postgre.ListErasures: Failed to scan erasure: %v", err)
+			return nil, repo.ErrFailedToList
+		}
+		erasures = append(erasures, e)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.ListErasures: Failed to iterate erasures: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	return erasures, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanErasure(s scanner) (model.ErasureRequest, error) {
+	var (
+		e                   model.ErasureRequest
+		reason, requestedBy sql.NullString
+		removed             []byte
+		completedAt         sql.NullTime
+	)
+	err := s.Scan(&e.ID, &e.Kind, &e.Value, &e.ValueNorm, pq.Array(&e.ProjectIDs), &reason, &requestedBy,
+		&removed, &e.CreatedAt, &completedAt)
+	if err != nil {
+		return model.ErasureRequest{}, err
+	}
+	e.Reason = reason.String
+	e.RequestedBy = requestedBy.String
+	if len(removed) > 0 {
+		if err := json.Unmarshal(removed, &e.Removed); err != nil {
+			return model.ErasureRequest{}, err
+		}
+	}
+	if completedAt.Valid {
+		e.CompletedAt = &completedAt.Time
+	}
+	return e, nil
+}
@@ -0,0 +1,61 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+
+	repo "knowledge-srv/internal/erasure/repository"
+	"knowledge-srv/internal/model"
+)
+
+// CreateTombstone - Insert a tombstone unless the subject already has one
+func (r *implPostgresRepository) CreateTombstone(ctx context.Context, kind, valueNorm, requestID string) (bool, error) {
+	const query = `
+		INSERT INTO knowledge.erasure_tombstones (kind, value_norm, request_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (kind, value_norm) DO NOTHING`
+
+	res, err := r.db.ExecContext(ctx, query, kind, valueNorm, requestID)
+	if err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.CreateTombstone: Failed to insert tombstone %s: %v", kind, err)
+		return false, repo.ErrFailedToInsert
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, repo.ErrFailedToInsert
+	}
+	return n > 0, nil
+}
+
+// ListTombstones - Every tombstone (loaded into the indexing-time blocklist)
+func (r *implPostgresRepository) ListTombstones(ctx context.Context) ([]model.ErasureTombstone, error) {
+	const query = `
+		SELECT id, kind, value_norm, request_id, created_at
+		FROM knowledge.erasure_tombstones`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.ListTombstones: Failed to query tombstones: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	defer rows.Close()
+
+	var tombstones []model.ErasureTombstone
+	for rows.Next() {
+		var (
+			t         model.ErasureTombstone
+			requestID sql.NullString
+		)
+		if err := rows.Scan(&t.ID, &t.Kind, &t.ValueNorm, &requestID, &t.CreatedAt); err != nil {
+			r.l.Errorf(ctx, "erasure.repository.postgre.ListTombstones: Failed to scan tombstone: %v", err)
+			return nil, repo.ErrFailedToList
+		}
+		t.RequestID = requestID.String
+		tombstones = append(tombstones, t)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "erasure.repository.postgre.ListTombstones: Failed to iterate tombstones: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	return tombstones, nil
+}
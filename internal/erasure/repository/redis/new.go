@@ -1,7 +1,7 @@
 package redis
 
 import (
-	repo "knowledge-srv/internal/indexing/repository"
+	repo "knowledge-srv/internal/erasure/repository"
 
 	"github.com/smap-hcmut/shared-libs/go/log"
 	"github.com/smap-hcmut/shared-libs/go/redis"
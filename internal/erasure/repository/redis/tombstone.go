@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// tombstoneVersionKey is bumped on every new tombstone; replicas compare it with the
+// version of their in-memory set before trusting it.
+const tombstoneVersionKey = "erasure:tombstones:version"
+
+func (r *implCacheRepository) TombstoneVersion(ctx context.Context) (int64, error) {
+	v, err := r.redis.GetClient().Get(ctx, tombstoneVersionKey).Int64()
+	if err == goredis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		r.l.Warnf(ctx, "erasure.repository.redis.TombstoneVersion: unavailable: %v", err)
+		return 0, err
+	}
+	return v, nil
+}
+
+func (r *implCacheRepository) BumpTombstoneVersion(ctx context.Context) error {
+	if err := r.redis.GetClient().Incr(ctx, tombstoneVersionKey).Err(); err != nil {
+		r.l.Warnf(ctx, "erasure.repository.redis.BumpTombstoneVersion: unavailable: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package erasure
+
+import "knowledge-srv/internal/model"
+
+// EraseInput - Delete every document of an author/username, or with a source URL
+type EraseInput struct {
+	Kind        string
+	Value       string
+	ProjectIDs  []string // restrict to these projects (empty = every project)
+	Reason      string
+	RequestedBy string
+	DryRun      bool // count matches only: nothing is deleted, tombstoned or recorded
+}
+
+// EraseOutput - Deletion receipt
+type EraseOutput struct {
+	Receipt model.ErasureRequest
+	DryRun  bool
+}
+
+// ListErasuresInput - Filters for erasure receipts (empty = any)
+type ListErasuresInput struct {
+	Kind  string
+	Value string // matched on the normalized value
+	Limit int
+}
+
+type ListErasuresOutput struct {
+	Erasures []model.ErasureRequest
+}
+
+// SourceRef - Author, username and URLs of a document about to be indexed
+type SourceRef struct {
+	Authors   []string
+	Usernames []string
+	URLs      []string
+}
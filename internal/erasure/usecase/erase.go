@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/erasure"
+	"knowledge-srv/internal/erasure/repository"
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+
+	"github.com/google/uuid"
+	pb "github.com/qdrant/go-client/qdrant"
+)
+
+// evidenceMatchChunk bounds the keywords of one evidence_references match condition.
+const evidenceMatchChunk = 512
+
+// matchedPoints - Points of one subject found in the post collections
+type matchedPoints struct {
+	ids        map[string]bool // point IDs, analytics IDs and UAP IDs (evidence and indexed_documents keys)
+	projectIDs map[string]bool
+}
+
+// Erase - Right-to-be-forgotten deletion of one author, username or source URL.
+// The tombstone is written first, so a run interrupted half-way still blocks
+// re-ingestion. Qdrant points are the source of the matched IDs and are deleted
+// last, so a second run finds them again and removes whatever is left.
+func (uc *implUseCase) Erase(ctx context.Context, input erasure.EraseInput) (erasure.EraseOutput, error) {
+	if !erasure.IsValidKind(input.Kind) {
+		return erasure.EraseOutput{}, erasure.ErrInvalidKind
+	}
+	value := strings.TrimSpace(input.Value)
+	if value == "" {
+		return erasure.EraseOutput{}, erasure.ErrValueRequired
+	}
+	for _, id := range input.ProjectIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return erasure.EraseOutput{}, erasure.ErrInvalidProjectID
+		}
+	}
+	norm := erasure.Normalize(input.Kind, value)
+	variants := erasure.Variants(input.Kind, value)
+
+	receipt := model.ErasureRequest{
+		Kind:        input.Kind,
+		Value:       value,
+		ValueNorm:   norm,
+		ProjectIDs:  input.ProjectIDs,
+		Reason:      input.Reason,
+		RequestedBy: input.RequestedBy,
+		CreatedAt:   time.Now(),
+	}
+	if !input.DryRun {
+		created, err := uc.repo.CreateErasure(ctx, repository.CreateErasureOptions{
+			Kind:        input.Kind,
+			Value:       value,
+			ValueNorm:   norm,
+			ProjectIDs:  input.ProjectIDs,
+			Reason:      input.Reason,
+			RequestedBy: input.RequestedBy,
+		})
+		if err != nil {
+			return erasure.EraseOutput{}, fmt.Errorf("%w: %v", erasure.ErrErase, err)
+		}
+		receipt = created
+
+		tombstoned, err := uc.repo.CreateTombstone(ctx, input.Kind, norm, receipt.ID)
+		if err != nil {
+			return erasure.EraseOutput{}, fmt.Errorf("%w: %v", erasure.ErrErase, err)
+		}
+		receipt.Removed.TombstoneCreated = tombstoned
+		if tombstoned {
+			if err := uc.cache.BumpTombstoneVersion(ctx); err != nil {
+				uc.l.Warnf(ctx, "erasure.usecase.Erase: failed to bump tombstone version: %v", err)
+			}
+			uc.forgetTombstones()
+		}
+	}
+
+	removed, err := uc.erase(ctx, input, variants)
+	removed.TombstoneCreated = receipt.Removed.TombstoneCreated
+	if err != nil {
+		uc.l.Errorf(ctx, "erasure.usecase.Erase: %s %q: %v", input.Kind, norm, err)
+		return erasure.EraseOutput{}, fmt.Errorf("%w: %v", erasure.ErrErase, err)
+	}
+
+	if input.DryRun {
+		receipt.Removed = removed
+		return erasure.EraseOutput{Receipt: receipt, DryRun: true}, nil
+	}
+
+	completed, err := uc.repo.CompleteErasure(ctx, receipt.ID, removed)
+	if err != nil {
+		return erasure.EraseOutput{}, fmt.Errorf("%w: %v", erasure.ErrErase, err)
+	}
+	uc.l.Infof(ctx, "erasure.usecase.Erase: %s %q erased: %d points, %d evidence references, %d documents",
+		input.Kind, norm, removed.PointsDeleted, removed.EvidenceReferences, removed.IndexedDocuments)
+	return erasure.EraseOutput{Receipt: completed}, nil
+}
+
+// erase removes the subject everywhere. Matches are collected from Qdrant first and
+// the Postgres rows keyed by them deleted before the points themselves: a failure
+// on either side leaves the points in place for the next run to match again.
+// A dry run only scrolls Qdrant, so its Postgres and Redis counts stay zero.
+func (uc *implUseCase) erase(ctx context.Context, input erasure.EraseInput, variants []string) (model.ErasureRemoved, error) {
+	var removed model.ErasureRemoved
+
+	names, err := uc.pointUC.ListCollections(ctx)
+	if err != nil {
+		return removed, err
+	}
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	matched := matchedPoints{ids: make(map[string]bool), projectIDs: make(map[string]bool)}
+	subject := subjectFilter(input.Kind, variants)
+	var targets []point.CollectionTarget
+	for _, target := range postTargets(names, existing, input.ProjectIDs) {
+		n, err := uc.collectMatches(ctx, target, target.Scope(subject), &matched)
+		if err != nil {
+			return removed, err
+		}
+		if n == 0 {
+			continue
+		}
+		targets = append(targets, target)
+		removed.Collections = append(removed.Collections, model.ErasureCollection{Collection: target.Collection, Points: n})
+		removed.PointsDeleted += n
+	}
+
+	// Insights cite documents by ID; URL subjects may also be cited by the URL itself.
+	refs := keys(matched.ids)
+	if input.Kind == erasure.KindURL {
+		refs = append(refs, variants...)
+	}
+	stripInsights := existing[point.CollectionMacroInsights] && len(refs) > 0
+
+	if input.DryRun {
+		if stripInsights {
+			if err := uc.stripEvidence(ctx, input, refs, &matched, &removed); err != nil {
+				return removed, err
+			}
+		}
+		removed.SearchCacheProjects = keys(matched.projectIDs)
+		return removed, nil
+	}
+
+	docs, err := uc.repo.DeleteDocuments(ctx, keys(matched.ids))
+	if err != nil {
+		return removed, err
+	}
+	removed.IndexedDocuments = docs.Deleted
+	for _, projectID := range docs.ProjectIDs {
+		matched.projectIDs[projectID] = true
+	}
+
+	dlq, err := uc.repo.DeleteDLQ(ctx, repository.DeleteDLQOptions{
+		AnalyticsIDs: keys(matched.ids),
+		Fields:       erasure.DLQFields(input.Kind),
+		Values:       variants,
+	})
+	if err != nil {
+		return removed, err
+	}
+	removed.DLQEntries = dlq
+
+	for _, target := range targets {
+		if err := uc.pointUC.Delete(ctx, point.DeleteInput{CollectionName: target.Collection, Filter: target.Scope(subject)}); err != nil {
+			return removed, err
+		}
+	}
+	if stripInsights {
+		if err := uc.stripEvidence(ctx, input, refs, &matched, &removed); err != nil {
+			return removed, err
+		}
+	}
+
+	// Cache cleanup is best effort: entries expire on their own and never resurrect points.
+	if out, err := uc.embeddingUC.DeleteCached(ctx, embedding.DeleteCachedInput{ContentHashes: docs.ContentHashes}); err != nil {
+		uc.l.Warnf(ctx, "erasure.usecase.erase: failed to delete cached embeddings: %v", err)
+	} else {
+		removed.EmbeddingKeys = out.Deleted
+	}
+	for _, projectID := range keys(matched.projectIDs) {
+		if err := uc.searchUC.InvalidateProject(ctx, projectID); err != nil {
+			uc.l.Warnf(ctx, "erasure.usecase.erase: failed to invalidate search cache of %s: %v", projectID, err)
+			continue
+		}
+		removed.SearchCacheProjects = append(removed.SearchCacheProjects, projectID)
+	}
+	return removed, nil
+}
+
+// collectMatches scrolls every point matching filter, recording its IDs and project.
+func (uc *implUseCase) collectMatches(ctx context.Context, target point.CollectionTarget, filter *pb.Filter, matched *matchedPoints) (uint64, error) {
+	collectionProject, _ := point.ProjectIDFromCollection(target.Collection)
+
+	var (
+		n      uint64
+		offset *string
+	)
+	for {
+		page, err := uc.pointUC.ScrollPage(ctx, point.ScrollInput{
+			CollectionName: target.Collection,
+			Filter:         filter,
+			Limit:          scrollPageSize,
+			WithPayload:    true,
+			Offset:         offset,
+		})
+		if err != nil {
+			return n, err
+		}
+		for _, p := range page.Points {
+			n++
+			matched.ids[p.ID] = true
+			for _, key := range []string{"analytics_id", "uap_id"} {
+				if id := payloadString(p.Payload, key); id != "" {
+					matched.ids[id] = true
+				}
+			}
+			if projectID := payloadString(p.Payload, "project_id"); projectID != "" {
+				matched.projectIDs[projectID] = true
+			} else if collectionProject != "" {
+				matched.projectIDs[collectionProject] = true
+			}
+		}
+		if page.NextOffset == nil {
+			return n, nil
+		}
+		offset = page.NextOffset
+	}
+}
+
+// stripEvidence removes erased documents from the evidence_references of macro insights.
+// Insights themselves are aggregates and stay.
+func (uc *implUseCase) stripEvidence(ctx context.Context, input erasure.EraseInput, refs []string, matched *matchedPoints, removed *model.ErasureRemoved) error {
+	erased := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		erased[ref] = true
+	}
+	target := point.CollectionTarget{Collection: point.CollectionMacroInsights, ProjectIDs: input.ProjectIDs}
+
+	updated := make(map[string]bool)
+	for start := 0; start < len(refs); start += evidenceMatchChunk {
+		end := min(start+evidenceMatchChunk, len(refs))
+		filter := target.Scope(&pb.Filter{Must: []*pb.Condition{
+			keywordsCondition(erasure.EvidenceReferencesField, refs[start:end]),
+		}})
+
+		var offset *string
+		for {
+			page, err := uc.pointUC.ScrollPage(ctx, point.ScrollInput{
+				CollectionName: point.CollectionMacroInsights,
+				Filter:         filter,
+				Limit:          scrollPageSize,
+				WithPayload:    true,
+				Offset:         offset,
+			})
+			if err != nil {
+				return err
+			}
+			for _, p := range page.Points {
+				if updated[p.ID] {
+					continue
+				}
+				evidence := payloadStrings(p.Payload, erasure.EvidenceReferencesField)
+				kept := make([]string, 0, len(evidence))
+				for _, ref := range evidence {
+					if !erased[ref] {
+						kept = append(kept, ref)
+					}
+				}
+				if len(kept) == len(evidence) {
+					continue
+				}
+				if !input.DryRun {
+					if err := uc.pointUC.SetPayload(ctx, point.SetPayloadInput{
+						CollectionName: point.CollectionMacroInsights,
+						PointID:        p.ID,
+						Payload:        map[string]interface{}{erasure.EvidenceReferencesField: kept},
+					}); err != nil {
+						return err
+					}
+				}
+				updated[p.ID] = true
+				removed.InsightsUpdated++
+				removed.EvidenceReferences += int64(len(evidence) - len(kept))
+				if projectID := payloadString(p.Payload, "project_id"); projectID != "" {
+					matched.projectIDs[projectID] = true
+				}
+			}
+			if page.NextOffset == nil {
+				break
+			}
+			offset = page.NextOffset
+		}
+	}
+	return nil
+}
+
+// postTargets - Post collections to erase from: every per-project collection and
+// the shared one, or only those of projectIDs (the shared one scoped by tenant).
+func postTargets(names []string, existing map[string]bool, projectIDs []string) []point.CollectionTarget {
+	var targets []point.CollectionTarget
+	if len(projectIDs) == 0 {
+		for _, name := range names {
+			if _, ok := point.ProjectIDFromCollection(name); ok || name == point.CollectionSharedPosts {
+				targets = append(targets, point.CollectionTarget{Collection: name})
+			}
+		}
+		return targets
+	}
+	for _, projectID := range projectIDs {
+		if name := point.CollectionForProject(projectID); existing[name] {
+			targets = append(targets, point.CollectionTarget{Collection: name})
+		}
+	}
+	if existing[point.CollectionSharedPosts] {
+		targets = append(targets, point.CollectionTarget{Collection: point.CollectionSharedPosts, ProjectIDs: projectIDs})
+	}
+	return targets
+}
+
+// subjectFilter matches points whose author/username/URL fields hold one of the variants.
+func subjectFilter(kind string, variants []string) *pb.Filter {
+	fields := erasure.PayloadFields(kind)
+	conds := make([]*pb.Condition, 0, len(fields))
+	for _, field := range fields {
+		conds = append(conds, keywordsCondition(field, variants))
+	}
+	return &pb.Filter{Should: conds}
+}
+
+func keywordsCondition(key string, values []string) *pb.Condition {
+	return &pb.Condition{
+		ConditionOneOf: &pb.Condition_Field{
+			Field: &pb.FieldCondition{
+				Key: key,
+				Match: &pb.Match{
+					MatchValue: &pb.Match_Keywords{
+						Keywords: &pb.RepeatedStrings{Strings: values},
+					},
+				},
+			},
+		},
+	}
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	if s, ok := payload[key].(string); ok {
+		return s
+	}
+	return ""
+}
+
+func payloadStrings(payload map[string]interface{}, key string) []string {
+	switch v := payload[key].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func keys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	return out
+}
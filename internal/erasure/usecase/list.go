@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"knowledge-srv/internal/erasure"
+	"knowledge-srv/internal/erasure/repository"
+	"knowledge-srv/internal/model"
+)
+
+func (uc *implUseCase) GetErasure(ctx context.Context, id string) (model.ErasureRequest, error) {
+	e, err := uc.repo.GetErasure(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.ErasureRequest{}, erasure.ErrErasureNotFound
+		}
+		return model.ErasureRequest{}, fmt.Errorf("%w: %v", erasure.ErrListErasures, err)
+	}
+	return e, nil
+}
+
+func (uc *implUseCase) ListErasures(ctx context.Context, input erasure.ListErasuresInput) (erasure.ListErasuresOutput, error) {
+	if input.Kind != "" && !erasure.IsValidKind(input.Kind) {
+		return erasure.ListErasuresOutput{}, erasure.ErrInvalidKind
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	opt := repository.ListErasuresOptions{Kind: input.Kind, Limit: limit}
+	if input.Value != "" {
+		// Normalization depends on the kind; without one, URL rules would mangle authors.
+		opt.ValueNorm = erasure.Normalize(input.Kind, input.Value)
+	}
+
+	erasures, err := uc.repo.ListErasures(ctx, opt)
+	if err != nil {
+		return erasure.ListErasuresOutput{}, fmt.Errorf("%w: %v", erasure.ErrListErasures, err)
+	}
+	return erasure.ListErasuresOutput{Erasures: erasures}, nil
+}
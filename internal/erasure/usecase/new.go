@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/erasure"
+	"knowledge-srv/internal/erasure/repository"
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
+
+	"github.com/smap-hcmut/shared-libs/go/log"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+
+	// scrollPageSize bounds the points read per Qdrant scroll while collecting matches.
+	scrollPageSize = 256
+	// tombstoneStaleAfter - without Redis, the in-memory tombstone set is reloaded this often.
+	tombstoneStaleAfter = time.Minute
+)
+
+type implUseCase struct {
+	repo        repository.PostgresRepository
+	cache       repository.CacheRepository
+	pointUC     point.UseCase
+	embeddingUC embedding.UseCase
+	searchUC    search.UseCase
+	l           log.Logger
+
+	// Tombstone set checked on every indexed document, keyed by kind then normalized value.
+	mu         sync.RWMutex
+	tombstones map[string]map[string]bool
+	version    int64
+	loadedAt   time.Time
+}
+
+func New(
+	repo repository.PostgresRepository,
+	cache repository.CacheRepository,
+	pointUC point.UseCase,
+	embeddingUC embedding.UseCase,
+	searchUC search.UseCase,
+	l log.Logger,
+) erasure.UseCase {
+	return &implUseCase{
+		repo:        repo,
+		cache:       cache,
+		pointUC:     pointUC,
+		embeddingUC: embeddingUC,
+		searchUC:    searchUC,
+		l:           l,
+	}
+}
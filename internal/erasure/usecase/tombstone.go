@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"knowledge-srv/internal/erasure"
+)
+
+// IsErased - Check a document about to be indexed against the tombstone set
+func (uc *implUseCase) IsErased(ctx context.Context, ref erasure.SourceRef) (bool, error) {
+	tombstones, err := uc.loadTombstones(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(tombstones) == 0 {
+		return false, nil
+	}
+
+	check := func(kind string, values []string) bool {
+		set := tombstones[kind]
+		if len(set) == 0 {
+			return false
+		}
+		for _, v := range values {
+			if v != "" && set[erasure.Normalize(kind, v)] {
+				return true
+			}
+		}
+		return false
+	}
+	return check(erasure.KindAuthor, ref.Authors) ||
+		check(erasure.KindUsername, ref.Usernames) ||
+		check(erasure.KindURL, ref.URLs), nil
+}
+
+// loadTombstones returns the cached set, reloading it from Postgres when another
+// replica bumped the Redis version (or, with Redis down, once the set is stale).
+func (uc *implUseCase) loadTombstones(ctx context.Context) (map[string]map[string]bool, error) {
+	version, verErr := uc.cache.TombstoneVersion(ctx)
+
+	uc.mu.RLock()
+	tombstones, loadedAt, current := uc.tombstones, uc.loadedAt, uc.version
+	uc.mu.RUnlock()
+
+	if tombstones != nil {
+		if verErr == nil && version == current {
+			return tombstones, nil
+		}
+		if verErr != nil && time.Since(loadedAt) < tombstoneStaleAfter {
+			return tombstones, nil
+		}
+	}
+
+	list, err := uc.repo.ListTombstones(ctx)
+	if err != nil {
+		if tombstones != nil {
+			uc.l.Warnf(ctx, "erasure.usecase.loadTombstones: reload failed, using cached set: %v", err)
+			return tombstones, nil
+		}
+		return nil, err
+	}
+
+	fresh := make(map[string]map[string]bool)
+	for _, t := range list {
+		if fresh[t.Kind] == nil {
+			fresh[t.Kind] = make(map[string]bool)
+		}
+		fresh[t.Kind][t.ValueNorm] = true
+	}
+
+	uc.mu.Lock()
+	uc.tombstones = fresh
+	uc.loadedAt = time.Now()
+	if verErr == nil {
+		uc.version = version
+	}
+	uc.mu.Unlock()
+	return fresh, nil
+}
+
+// forgetTombstones drops the cached set so the next check reloads it.
+func (uc *implUseCase) forgetTombstones() {
+	uc.mu.Lock()
+	uc.tombstones = nil
+	uc.mu.Unlock()
+}
@@ -0,0 +1,161 @@
+package factcheck
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FactsFromText collects every figure in text as a fact, small numbers included.
+func FactsFromText(text, source string) []Fact {
+	var facts []Fact
+	for _, f := range scanFigures(text) {
+		for _, r := range f.readings {
+			facts = append(facts, Fact{Value: r.value, Percent: f.percent, Rounding: f.rounding, Source: source})
+		}
+	}
+	return facts
+}
+
+// Check verifies the figures of text against facts and applies the configured mode.
+// regenerate may be nil, in which case ModeRegenerate behaves like ModeFlag.
+func Check(ctx context.Context, cfg Config, text string, facts []Fact, regenerate Regenerate) (string, Result) {
+	cfg = cfg.withDefaults()
+	res := Result{Mode: cfg.Mode}
+	if !cfg.Enabled() {
+		return text, res
+	}
+
+	claims, unsupported := verify(cfg, text, facts)
+	if len(unsupported) > 0 && cfg.Mode == ModeRegenerate && regenerate != nil {
+		rewritten, err := regenerate(ctx, text, feedback(unsupported))
+		if err == nil && strings.TrimSpace(rewritten) != "" {
+			if c, u := verify(cfg, rewritten, facts); len(u) < len(unsupported) {
+				text, claims, unsupported = rewritten, c, u
+				res.Regenerated = true
+			}
+		}
+	}
+	res.Checked = len(claims)
+	res.Supported = len(claims) - len(unsupported)
+
+	// Edit from the end so earlier offsets stay valid
+	sort.Slice(claims, func(i, j int) bool { return claims[i].start > claims[j].start })
+	for _, f := range claims {
+		fact, r, ok := supported(cfg, f, facts)
+		if !ok {
+			text = text[:f.end] + UnverifiedNote + text[f.end:]
+			res.Unsupported = append(res.Unsupported, Figure{Text: f.text, Value: f.readings[0].value, Percent: f.percent})
+			continue
+		}
+		// A figure within tolerance but beyond rounding of its fact is a misquote.
+		if cfg.Mode != ModeCorrect || math.Abs(r.value-fact.Value) <= math.Max(f.rounding, fact.Rounding)+1e-9 {
+			continue
+		}
+		number := formatNumber(fact.Value/f.multiplier, r, f.sep)
+		res.Corrections = append(res.Corrections, Figure{
+			Text:      f.text,
+			Value:     r.value,
+			Percent:   f.percent,
+			Source:    fact.Source,
+			Corrected: number + text[f.digitsEnd:f.end],
+		})
+		text = text[:f.start] + number + text[f.digitsEnd:]
+	}
+	// Report figures in reading order
+	slices.Reverse(res.Unsupported)
+	slices.Reverse(res.Corrections)
+	return text, res
+}
+
+// verify returns the claims of text and those no fact supports.
+func verify(cfg Config, text string, facts []Fact) (claims, unsupported []figure) {
+	for _, f := range scanFigures(text) {
+		if !isClaim(f) {
+			continue
+		}
+		claims = append(claims, f)
+		if _, _, ok := supported(cfg, f, facts); !ok {
+			unsupported = append(unsupported, f)
+		}
+	}
+	return claims, unsupported
+}
+
+// isClaim skips small counts, list numerals and years, which are rarely data.
+func isClaim(f figure) bool {
+	if f.percent {
+		return true
+	}
+	for _, r := range f.readings {
+		if r.value <= 10 {
+			return false
+		}
+		if f.multiplier == 1 && r.decimals == 0 && !r.grouped && r.value >= 1900 && r.value <= 2100 {
+			return false
+		}
+	}
+	return true
+}
+
+// supported finds the fact of the same kind nearest to any reading of f within
+// tolerance, with the reading it matched.
+func supported(cfg Config, f figure, facts []Fact) (Fact, reading, bool) {
+	var best Fact
+	var bestReading reading
+	bestDiff := math.Inf(1)
+	for _, r := range f.readings {
+		for _, fact := range facts {
+			if fact.Percent != f.percent {
+				continue
+			}
+			diff := math.Abs(r.value - fact.Value)
+			tolerance := math.Max(f.rounding, fact.Rounding)
+			if f.percent {
+				tolerance = math.Max(tolerance, cfg.PercentTolerance)
+			} else {
+				tolerance = math.Max(tolerance, cfg.CountTolerance*math.Abs(fact.Value))
+			}
+			if diff <= tolerance+1e-9 && diff < bestDiff {
+				best, bestReading, bestDiff = fact, r, diff
+			}
+		}
+	}
+	return best, bestReading, !math.IsInf(bestDiff, 1)
+}
+
+// formatNumber writes v the way the original figure was written: same decimals,
+// same separator, thousands groups when it had them.
+func formatNumber(v float64, r reading, sep byte) string {
+	if r.grouped {
+		digits := strconv.FormatInt(int64(math.Round(v)), 10)
+		var b strings.Builder
+		for i, d := range digits {
+			if i > 0 && (len(digits)-i)%3 == 0 {
+				b.WriteByte(sep)
+			}
+			b.WriteRune(d)
+		}
+		return b.String()
+	}
+	s := strconv.FormatFloat(v, 'f', r.decimals, 64)
+	if sep == ',' {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	return s
+}
+
+// feedback tells the model which figures had no source.
+func feedback(unsupported []figure) string {
+	texts := make([]string, 0, len(unsupported))
+	for _, f := range unsupported {
+		texts = append(texts, f.text)
+	}
+	return fmt.Sprintf("Các số liệu sau không có trong dữ liệu được cung cấp: %s. "+
+		"Viết lại câu trả lời, chỉ dùng số liệu xuất hiện trong dữ liệu; không tự tính hay ước lượng. "+
+		"Nếu không có số liệu phù hợp thì mô tả định tính.", strings.Join(texts, ", "))
+}
@@ -0,0 +1,208 @@
+package factcheck
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// Figure scanning, tolerance matching and ModeCorrect rewriting on Vietnamese and
+// English number formats.
+//
+//	go test ./internal/factcheck -v
+
+type scannedFigure struct {
+	text    string
+	percent bool
+	values  []float64 // one per reading, in readNumber order
+}
+
+func TestScanFigures(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want []scannedFigure
+	}{
+		{
+			name: "vietnamese decimal percent",
+			text: "Tỷ lệ tiêu cực 23,5% tuần này",
+			want: []scannedFigure{{text: "23,5%", percent: true, values: []float64{23.5}}},
+		},
+		{
+			name: "grouped number keeps both readings",
+			text: "Có 1.234 bài đăng",
+			want: []scannedFigure{{text: "1.234", values: []float64{1234, 1.234}}},
+		},
+		{
+			name: "magnitude word",
+			text: "đạt 1,2 triệu lượt xem",
+			want: []scannedFigure{{text: "1,2 triệu", values: []float64{1.2e6}}},
+		},
+		{
+			name: "short magnitude suffix",
+			text: "5k lượt thích",
+			want: []scannedFigure{{text: "5k", values: []float64{5000}}},
+		},
+		{
+			name: "letters after a space start a word, not a magnitude",
+			text: "10 bài viết",
+			want: []scannedFigure{{text: "10", values: []float64{10}}},
+		},
+		{
+			name: "english percent word",
+			text: "mentions grew 12.5 percent",
+			want: []scannedFigure{{text: "12.5 percent", percent: true, values: []float64{12.5}}},
+		},
+		{
+			name: "citations, urls, dates and times are skipped",
+			text: "theo [1, 2] và https://x.com/a/123 ngày 12/10/2026 lúc 10:30",
+		},
+		{
+			name: "numbers glued to words are skipped",
+			text: "mạng 4G trong Q3",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := scanFigures(tc.text)
+			if len(got) != len(tc.want) {
+				t.Fatalf("scanFigures(%q) = %d figures, want %d", tc.text, len(got), len(tc.want))
+			}
+			for i, want := range tc.want {
+				f := got[i]
+				if f.text != want.text || f.percent != want.percent {
+					t.Errorf("figure %d = %q percent=%v, want %q percent=%v", i, f.text, f.percent, want.text, want.percent)
+				}
+				if len(f.readings) != len(want.values) {
+					t.Fatalf("figure %q has %d readings, want %d", f.text, len(f.readings), len(want.values))
+				}
+				for j, v := range want.values {
+					if math.Abs(f.readings[j].value-v) > 1e-9 {
+						t.Errorf("figure %q reading %d = %v, want %v", f.text, j, f.readings[j].value, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSupported(t *testing.T) {
+	cases := []struct {
+		name  string
+		cfg   Config
+		text  string
+		facts []Fact
+		want  bool
+	}{
+		{"percent within default tolerance", Config{}, "23%", []Fact{{Value: 22.4, Percent: true}}, true},
+		{"percent outside default tolerance", Config{}, "25%", []Fact{{Value: 23.5, Percent: true}}, false},
+		{"percent within configured tolerance", Config{PercentTolerance: 2}, "25%", []Fact{{Value: 23.5, Percent: true}}, true},
+		{"count at the relative tolerance", Config{}, "1020", []Fact{{Value: 1000}}, true},
+		{"count outside the relative tolerance", Config{}, "1100", []Fact{{Value: 1000}}, false},
+		{"rounded magnitude matches the exact fact", Config{}, "1,2 triệu", []Fact{{Value: 1234567}}, true},
+		{"grouped reading matches", Config{}, "1.234", []Fact{{Value: 1234}}, true},
+		{"fact rounding widens the tolerance", Config{}, "1300", []Fact{{Value: 1250, Rounding: 50}}, true},
+		{"percent never matches a count", Config{}, "40%", []Fact{{Value: 40}}, false},
+		{"no facts", Config{}, "40%", nil, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			figures := scanFigures(tc.text)
+			if len(figures) != 1 {
+				t.Fatalf("scanFigures(%q) = %d figures, want 1", tc.text, len(figures))
+			}
+			if _, _, got := supported(tc.cfg.withDefaults(), figures[0], tc.facts); got != tc.want {
+				t.Errorf("supported(%q) = %v, want %v", tc.text, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckModeCorrect(t *testing.T) {
+	cases := []struct {
+		name          string
+		cfg           Config
+		text          string
+		facts         []Fact
+		want          string
+		wantCorrected int
+		wantFlagged   int
+	}{
+		{
+			name:          "misquote within tolerance takes the fact",
+			text:          "Tiêu cực chiếm 24% thảo luận.",
+			facts:         []Fact{{Value: 23.4, Percent: true}},
+			want:          "Tiêu cực chiếm 23% thảo luận.",
+			wantCorrected: 1,
+		},
+		{
+			name:        "figure outside tolerance is flagged, not corrected",
+			text:        "Tiêu cực chiếm 27% thảo luận.",
+			facts:       []Fact{{Value: 23.4, Percent: true}},
+			want:        "Tiêu cực chiếm 27%" + UnverifiedNote + " thảo luận.",
+			wantFlagged: 1,
+		},
+		{
+			name:          "configured tolerance widens the correction band",
+			cfg:           Config{PercentTolerance: 5},
+			text:          "Tiêu cực chiếm 27% thảo luận.",
+			facts:         []Fact{{Value: 23.4, Percent: true}},
+			want:          "Tiêu cực chiếm 23% thảo luận.",
+			wantCorrected: 1,
+		},
+		{
+			name:          "thousands separator is kept",
+			text:          "Có 12.200 lượt xem",
+			facts:         []Fact{{Value: 12000}},
+			want:          "Có 12.000 lượt xem",
+			wantCorrected: 1,
+		},
+		{
+			name:          "magnitude word and decimal comma are kept",
+			cfg:           Config{CountTolerance: 0.1},
+			text:          "đạt 1,5 triệu lượt xem",
+			facts:         []Fact{{Value: 1.4e6}},
+			want:          "đạt 1,4 triệu lượt xem",
+			wantCorrected: 1,
+		},
+		{
+			name:  "figure within rounding of its fact is untouched",
+			text:  "Tích cực 40%",
+			facts: []Fact{{Value: 40.3, Percent: true}},
+			want:  "Tích cực 40%",
+		},
+		{
+			name:  "figure more precise than a rounded fact is untouched",
+			text:  "Tích cực 40,4%",
+			facts: []Fact{{Value: 40, Percent: true, Rounding: 0.5}},
+			want:  "Tích cực 40,4%",
+		},
+		{
+			name:          "corrections keep earlier offsets valid",
+			text:          "24% tiêu cực, 60% trung lập",
+			facts:         []Fact{{Value: 23.4, Percent: true}},
+			want:          "23% tiêu cực, 60%" + UnverifiedNote + " trung lập",
+			wantCorrected: 1,
+			wantFlagged:   1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Mode = ModeCorrect
+			got, res := Check(context.Background(), cfg, tc.text, tc.facts, nil)
+			if got != tc.want {
+				t.Errorf("Check(%q) = %q, want %q", tc.text, got, tc.want)
+			}
+			if len(res.Corrections) != tc.wantCorrected {
+				t.Errorf("corrected = %d, want %d", len(res.Corrections), tc.wantCorrected)
+			}
+			if len(res.Unsupported) != tc.wantFlagged {
+				t.Errorf("flagged = %d, want %d", len(res.Unsupported), tc.wantFlagged)
+			}
+		})
+	}
+}
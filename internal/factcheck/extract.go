@@ -0,0 +1,161 @@
+package factcheck
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// A number with optional thousands groups or decimals, then an optional percent
+	// sign or magnitude word. Vietnamese writes 1.234,5 and English 1,234.5, so
+	// separators are resolved later.
+	numberPattern = regexp.MustCompile(`(?i)(\d{1,3}(?:[.,]\d{3})+|\d+(?:[.,]\d+)?)(\s*(?:%|phần trăm|percent|nghìn|ngàn|triệu|tỷ|tỉ|tr|k|m|b))?`)
+	// Citation markers, URLs and dates/times are not figures.
+	skipPattern = regexp.MustCompile(`\[T?\d{1,3}(?:\s*,\s*T?\d{1,3})*\]|https?://\S+|\d{1,4}[-/]\d{1,2}(?:[-/]\d{1,4})?|\d{1,2}:\d{2}`)
+)
+
+var magnitudes = map[string]float64{
+	"nghìn": 1e3, "ngàn": 1e3, "k": 1e3,
+	"triệu": 1e6, "m": 1e6, "tr": 1e6,
+	"tỷ": 1e9, "tỉ": 1e9, "b": 1e9,
+}
+
+// figure - A number found in text. Grouped numbers like "1.234" are ambiguous
+// between 1234 and 1.234, so every reading is kept.
+type figure struct {
+	text     string
+	readings []reading
+	percent  bool
+	// multiplier is the magnitude word's value, 1 without one.
+	multiplier float64
+	// rounding is half a unit of the last digit written, times the magnitude
+	// ("1,2 triệu" → 50000), so a rounded figure still matches the exact fact.
+	rounding float64
+	// sep is the first separator written, reused when a figure is corrected.
+	sep                   byte
+	start, digitsEnd, end int
+}
+
+// scanFigures returns every figure in text outside citation markers, URLs and dates.
+func scanFigures(text string) []figure {
+	skipped := skipPattern.FindAllStringIndex(text, -1)
+	inSkipped := func(start, end int) bool {
+		for _, s := range skipped {
+			if start < s[1] && end > s[0] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var figures []figure
+	for _, m := range numberPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[0], m[1]
+		if inSkipped(start, end) || gluedToWord(text, start, m[3]) {
+			continue
+		}
+		suffix := ""
+		if m[4] >= 0 {
+			suffix = strings.ToLower(strings.TrimSpace(text[m[4]:m[5]]))
+			// "10 bài", "5 món": the letters start a word, not a magnitude
+			if r, _ := utf8.DecodeRuneInString(text[end:]); suffix != "%" && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				suffix, end = "", m[3]
+			}
+		}
+		digits := text[m[2]:m[3]]
+		f := figure{
+			text:       strings.TrimSpace(text[start:end]),
+			multiplier: 1,
+			start:      start,
+			digitsEnd:  m[3],
+			end:        end,
+		}
+		if i := strings.IndexAny(digits, ".,"); i >= 0 {
+			f.sep = digits[i]
+		}
+		switch suffix {
+		case "":
+		case "%", "phần trăm", "percent":
+			f.percent = true
+		default:
+			f.multiplier = magnitudes[suffix]
+		}
+		for _, r := range readNumber(digits) {
+			f.rounding = math.Max(f.rounding, 0.5*math.Pow(10, -float64(r.decimals))*f.multiplier)
+			r.value *= f.multiplier
+			f.readings = append(f.readings, r)
+		}
+		if len(f.readings) > 0 {
+			figures = append(figures, f)
+		}
+	}
+	return figures
+}
+
+// gluedToWord reports numbers that are part of a word or code ("4G", "Q3", "v2.1").
+func gluedToWord(text string, start, digitsEnd int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(text[:start])
+		if unicode.IsLetter(r) || r == '_' || r == '.' || r == ',' || r == '#' {
+			return true
+		}
+	}
+	if digitsEnd < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[digitsEnd:])
+		if unicode.IsLetter(r) && !strings.ContainsRune("kKmMbBtTpPnN", r) {
+			return true
+		}
+	}
+	return false
+}
+
+type reading struct {
+	value    float64
+	decimals int
+	grouped  bool
+}
+
+// readNumber interprets digits written with either locale's separators.
+func readNumber(digits string) []reading {
+	seps := strings.Count(digits, ".") + strings.Count(digits, ",")
+	if seps == 0 {
+		v, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			return nil
+		}
+		return []reading{{value: v}}
+	}
+
+	var out []reading
+	// Thousands groups: every group after the first has exactly three digits.
+	if grouped := strings.NewReplacer(".", "", ",", "").Replace(digits); isGrouped(digits) {
+		if v, err := strconv.ParseFloat(grouped, 64); err == nil {
+			out = append(out, reading{value: v, grouped: true})
+		}
+	}
+	// Decimal: a single separator.
+	if seps == 1 {
+		normalized := strings.Replace(digits, ",", ".", 1)
+		if v, err := strconv.ParseFloat(normalized, 64); err == nil {
+			out = append(out, reading{value: v, decimals: len(normalized) - strings.Index(normalized, ".") - 1})
+		}
+	}
+	return out
+}
+
+func isGrouped(digits string) bool {
+	parts := strings.FieldsFunc(digits, func(r rune) bool { return r == '.' || r == ',' })
+	if len(parts) < 2 || len(parts[0]) > 3 {
+		return false
+	}
+	for _, p := range parts[1:] {
+		if len(p) != 3 {
+			return false
+		}
+	}
+	return true
+}
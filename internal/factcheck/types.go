@@ -0,0 +1,84 @@
+package factcheck
+
+import "context"
+
+// Modes
+const (
+	// ModeOff skips the check.
+	ModeOff = "off"
+	// ModeFlag marks unsupported figures in the text.
+	ModeFlag = "flag"
+	// ModeCorrect rewrites a figure within tolerance of a fact but off by more than
+	// rounding to that fact, and flags the unsupported ones.
+	ModeCorrect = "correct"
+	// ModeRegenerate asks the model for one rewrite with feedback, then flags
+	// whatever is still unsupported.
+	ModeRegenerate = "regenerate"
+)
+
+const (
+	// DefaultPercentTolerance - Percentage points a share may differ from a fact.
+	DefaultPercentTolerance = 1.0
+	// DefaultCountTolerance - Relative difference allowed between a count and a fact.
+	DefaultCountTolerance = 0.02
+
+	// UnverifiedNote is appended to a flagged figure.
+	UnverifiedNote = " (chưa kiểm chứng)"
+)
+
+// Config - Verifier settings. Zero values take the defaults.
+type Config struct {
+	Mode             string
+	PercentTolerance float64
+	CountTolerance   float64
+}
+
+// Enabled reports whether the check runs at all.
+func (c Config) Enabled() bool {
+	return c.Mode != ModeOff
+}
+
+func (c Config) withDefaults() Config {
+	if c.Mode == "" {
+		c.Mode = ModeFlag
+	}
+	if c.PercentTolerance <= 0 {
+		c.PercentTolerance = DefaultPercentTolerance
+	}
+	if c.CountTolerance <= 0 {
+		c.CountTolerance = DefaultCountTolerance
+	}
+	return c
+}
+
+// Fact - A figure the model was given: an aggregate, a KPI or a number quoted in
+// the evidence.
+type Fact struct {
+	Value   float64
+	Percent bool
+	// Rounding is how far the written fact may be from the exact value.
+	Rounding float64
+	Source   string
+}
+
+// Figure - A figure found in the generated text.
+type Figure struct {
+	Text      string  `json:"text"`
+	Value     float64 `json:"value"`
+	Percent   bool    `json:"percent,omitempty"`
+	Source    string  `json:"source,omitempty"`
+	Corrected string  `json:"corrected,omitempty"`
+}
+
+// Result - Outcome of one check, stored with the message or report.
+type Result struct {
+	Mode        string   `json:"mode"`
+	Checked     int      `json:"checked"`
+	Supported   int      `json:"supported"`
+	Unsupported []Figure `json:"unsupported,omitempty"`
+	Corrections []Figure `json:"corrections,omitempty"`
+	Regenerated bool     `json:"regenerated,omitempty"`
+}
+
+// Regenerate rewrites a draft given feedback on its unsupported figures.
+type Regenerate func(ctx context.Context, draft, feedback string) (string, error)
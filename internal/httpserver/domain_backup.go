@@ -0,0 +1,45 @@
+package httpserver
+
+import (
+	"context"
+	backupHTTP "knowledge-srv/internal/backup/delivery/http"
+	backupPostgre "knowledge-srv/internal/backup/repository/postgre"
+	backupQdrant "knowledge-srv/internal/backup/repository/qdrant"
+	backupRedis "knowledge-srv/internal/backup/repository/redis"
+	backupUsecase "knowledge-srv/internal/backup/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+// setupBackupDomain registers backup/restore routes and, when enabled, starts the
+// scheduled Qdrant snapshot job. Restores evict the project's cache through searchUC.
+func (srv *HTTPServer) setupBackupDomain(ctx context.Context, r *gin.RouterGroup, mw *middleware.Middleware) error {
+	cfg := srv.config.Backup
+	uc := backupUsecase.New(
+		backupPostgre.New(srv.postgresDB, srv.l),
+		backupQdrant.New(srv.qdrantClient, srv.l),
+		backupRedis.New(srv.redisClient, srv.l),
+		srv.pointUC,
+		srv.searchUC,
+		srv.minioClient,
+		srv.l,
+		backupUsecase.Config{
+			Enabled:   cfg.Enabled,
+			Interval:  time.Duration(cfg.IntervalHours) * time.Hour,
+			Bucket:    srv.config.MinIO.Bucket,
+			Prefix:    cfg.Prefix,
+			KeepLast:  cfg.KeepLast,
+			Retention: time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		},
+	)
+
+	srv.backupUC = uc
+
+	handler := backupHTTP.New(srv.l, uc, srv.discord)
+	handler.RegisterRoutes(r, mw)
+
+	srv.l.Infof(ctx, "Backup domain registered (scheduled=%v)", cfg.Enabled)
+	return nil
+}
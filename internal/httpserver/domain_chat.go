@@ -4,7 +4,9 @@ import (
 	"context"
 	chatHTTP "knowledge-srv/internal/chat/delivery/http"
 	chatPostgre "knowledge-srv/internal/chat/repository/postgre"
+	chatRedis "knowledge-srv/internal/chat/repository/redis"
 	chatUsecase "knowledge-srv/internal/chat/usecase"
+	"knowledge-srv/internal/factcheck"
 	"knowledge-srv/pkg/analytics"
 	"time"
 
@@ -19,11 +21,36 @@ func (srv *HTTPServer) setupChatDomain(ctx context.Context, r *gin.RouterGroup,
 		Timeout: time.Duration(srv.config.Analysis.Timeout) * time.Second,
 	})
 
-	uc := chatUsecase.New(repo, srv.searchUC, analyticsClient, srv.llmClient, srv.l)
+	understanding, agent, memory, fc := srv.config.Chat.Understanding, srv.config.Chat.Agent, srv.config.Chat.Memory, srv.config.FactCheck
+	answerCache := srv.config.Chat.AnswerCache
+	uc := chatUsecase.New(repo, chatRedis.New(srv.redisClient, srv.l), srv.searchUC, srv.embeddingUC, analyticsClient, srv.llmClient, srv.shareUC, srv.l, chatUsecase.Config{
+		UnderstandingMode:     understanding.Mode,
+		UnderstandingTimeout:  time.Duration(understanding.TimeoutMs) * time.Millisecond,
+		UnderstandingCacheTTL: time.Duration(understanding.CacheTTLHours) * time.Hour,
+		AgentEnabled:          agent.Enabled,
+		AgentMaxSteps:         agent.MaxSteps,
+		FactCheck: factcheck.Config{
+			Mode:             fc.Mode,
+			PercentTolerance: fc.PercentTolerance,
+			CountTolerance:   fc.CountTolerance,
+		},
+		MemoryEnabled:         memory.Enabled,
+		MemoryEveryTurns:      memory.EveryTurns,
+		MemoryRecentMessages:  memory.RecentMessages,
+		HistoryTokenBudget:    memory.HistoryTokenBudget,
+		PromptTokenBudget:     srv.config.LLM.PromptTokenBudget(),
+		AnswerCacheEnabled:    answerCache.Enabled,
+		AnswerCacheThreshold:  answerCache.SimilarityThreshold,
+		AnswerCacheTTL:        time.Duration(answerCache.TTLHours) * time.Hour,
+		AnswerCacheMaxEntries: answerCache.MaxEntries,
+	})
+
+	srv.chatUC = uc
 
 	handler := chatHTTP.New(srv.l, uc, srv.discord)
 	handler.RegisterRoutes(r, mw)
 
-	srv.l.Infof(ctx, "Chat domain registered")
+	srv.l.Infof(ctx, "Chat domain registered (understanding=%s, agent=%t, fact_check=%s, prompt_tokens=%d, answer_cache=%t)",
+		understanding.Mode, agent.Enabled, fc.Mode, srv.config.LLM.PromptTokenBudget(), answerCache.Enabled)
 	return nil
 }
@@ -16,7 +16,7 @@ func (srv *HTTPServer) setupCoreDomains(ctx context.Context) error {
 
 	pointQdrantRepo := pointRepo.New(srv.qdrantClient, srv.l)
 
-	srv.pointUC = pointUsecase.New(pointQdrantRepo, srv.l)
+	srv.pointUC = pointUsecase.New(pointQdrantRepo, srv.l, pointUsecase.ConfigFromQdrant(srv.config.Qdrant))
 
 	srv.l.Infof(ctx, "Core domains (Embedding, Point) initialized")
 	return nil
@@ -0,0 +1,31 @@
+package httpserver
+
+import (
+	"context"
+	erasureHTTP "knowledge-srv/internal/erasure/delivery/http"
+	erasurePostgre "knowledge-srv/internal/erasure/repository/postgre"
+	erasureRedis "knowledge-srv/internal/erasure/repository/redis"
+	erasureUsecase "knowledge-srv/internal/erasure/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+// setupErasureDomain registers the erasure endpoints and keeps the usecase so the
+// indexing domain can reject documents of erased subjects.
+func (srv *HTTPServer) setupErasureDomain(ctx context.Context, r *gin.RouterGroup, mw *middleware.Middleware) error {
+	srv.erasureUC = erasureUsecase.New(
+		erasurePostgre.New(srv.postgresDB, srv.l),
+		erasureRedis.New(srv.redisClient, srv.l),
+		srv.pointUC,
+		srv.embeddingUC,
+		srv.searchUC,
+		srv.l,
+	)
+
+	handler := erasureHTTP.New(srv.l, srv.erasureUC, srv.discord)
+	handler.RegisterRoutes(r, mw)
+
+	srv.l.Infof(ctx, "Erasure domain registered")
+	return nil
+}
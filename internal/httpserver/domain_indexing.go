@@ -4,7 +4,6 @@ import (
 	"context"
 	indexingHTTP "knowledge-srv/internal/indexing/delivery/http"
 	indexingPostgre "knowledge-srv/internal/indexing/repository/postgre"
-	indexingRedis "knowledge-srv/internal/indexing/repository/redis"
 	indexingUsecase "knowledge-srv/internal/indexing/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -13,9 +12,13 @@ import (
 
 func (srv *HTTPServer) setupIndexingDomain(ctx context.Context, r *gin.RouterGroup, mw *middleware.Middleware) error {
 	postgreRepo := indexingPostgre.New(srv.postgresDB, srv.l)
-	cacheRepo := indexingRedis.New(srv.redisClient, srv.l)
 
-	uc := indexingUsecase.New(srv.l, postgreRepo, srv.pointUC, srv.embeddingUC, cacheRepo, srv.minioClient)
+	uc := indexingUsecase.New(srv.l, postgreRepo, srv.pointUC, srv.embeddingUC, srv.searchUC, srv.minioClient, srv.erasureUC, indexingUsecase.Config{
+		NearDuplicateMode:        srv.config.Indexing.NearDuplicate.Mode,
+		NearDuplicateMaxDistance: srv.config.Indexing.NearDuplicate.MaxDistance,
+	})
+
+	srv.indexingUC = uc
 
 	handler := indexingHTTP.New(srv.l, uc, srv.discord)
 	handler.(interface {
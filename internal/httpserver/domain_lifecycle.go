@@ -0,0 +1,39 @@
+package httpserver
+
+import (
+	"context"
+	lifecycleHTTP "knowledge-srv/internal/lifecycle/delivery/http"
+	lifecyclePostgre "knowledge-srv/internal/lifecycle/repository/postgre"
+	lifecycleUsecase "knowledge-srv/internal/lifecycle/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+// setupLifecycleDomain registers the purge endpoints. Scheduled purges are run by
+// the worker of the consumer server; immediate ones start from the request.
+func (srv *HTTPServer) setupLifecycleDomain(ctx context.Context, r *gin.RouterGroup, mw *middleware.Middleware) error {
+	cfg := srv.config.Lifecycle
+	uc := lifecycleUsecase.New(
+		lifecyclePostgre.New(srv.postgresDB, srv.l),
+		srv.pointUC,
+		srv.indexingUC,
+		srv.backupUC,
+		srv.reportUC,
+		srv.chatUC,
+		srv.searchUC,
+		srv.l,
+		lifecycleUsecase.Config{
+			GracePeriod:  time.Duration(cfg.GraceHours) * time.Hour,
+			PollInterval: time.Duration(cfg.PollIntervalSeconds) * time.Second,
+			MaxAttempts:  cfg.MaxAttempts,
+		},
+	)
+
+	handler := lifecycleHTTP.New(srv.l, uc, srv.discord)
+	handler.RegisterRoutes(r, mw)
+
+	srv.l.Infof(ctx, "Lifecycle domain registered (grace=%dh)", cfg.GraceHours)
+	return nil
+}
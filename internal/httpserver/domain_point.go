@@ -0,0 +1,25 @@
+package httpserver
+
+import (
+	"context"
+	pointHTTP "knowledge-srv/internal/point/delivery/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+// setupPointDomain registers collection admin routes and migrates payload indexes
+// of existing collections to the declared schema in the background.
+func (srv *HTTPServer) setupPointDomain(ctx context.Context, r *gin.RouterGroup, mw *middleware.Middleware) error {
+	handler := pointHTTP.New(srv.l, srv.pointUC, srv.discord)
+	handler.RegisterRoutes(r, mw)
+
+	go func() {
+		if _, err := srv.pointUC.ReconcileSchemas(context.Background()); err != nil {
+			srv.l.Warnf(ctx, "httpserver.setupPointDomain: startup schema reconcile failed: %v", err)
+		}
+	}()
+
+	srv.l.Infof(ctx, "Point domain registered")
+	return nil
+}
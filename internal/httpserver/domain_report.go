@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"context"
+	"knowledge-srv/internal/factcheck"
 	reportHTTP "knowledge-srv/internal/report/delivery/http"
 	reportPostgre "knowledge-srv/internal/report/repository/postgre"
 	reportUsecase "knowledge-srv/internal/report/usecase"
@@ -19,10 +20,17 @@ func (srv *HTTPServer) setupReportDomain(ctx context.Context, r *gin.RouterGroup
 		Timeout: time.Duration(srv.config.Analysis.Timeout) * time.Second,
 	})
 
-	uc := reportUsecase.New(repo, srv.searchUC, analyticsClient, srv.llmClient, srv.minioClient, srv.l, reportUsecase.Config{
+	uc := reportUsecase.New(repo, srv.searchUC, srv.indexingUC, analyticsClient, srv.llmClient, srv.minioClient, srv.shareUC, srv.l, reportUsecase.Config{
 		ReportBucket: srv.config.MinIO.Bucket,
+		FactCheck: factcheck.Config{
+			Mode:             srv.config.FactCheck.Mode,
+			PercentTolerance: srv.config.FactCheck.PercentTolerance,
+			CountTolerance:   srv.config.FactCheck.CountTolerance,
+		},
 	})
 
+	srv.reportUC = uc
+
 	handler := reportHTTP.New(srv.l, uc, srv.discord)
 	handler.RegisterRoutes(r, mw)
 
@@ -3,6 +3,7 @@ package httpserver
 import (
 	"context"
 	searchHTTP "knowledge-srv/internal/search/delivery/http"
+	searchPostgre "knowledge-srv/internal/search/repository/postgre"
 	searchRedis "knowledge-srv/internal/search/repository/redis"
 	searchUsecase "knowledge-srv/internal/search/usecase"
 	"knowledge-srv/pkg/projectsrv"
@@ -13,13 +14,19 @@ import (
 
 func (srv *HTTPServer) setupSearchDomain(ctx context.Context, r *gin.RouterGroup, mw *middleware.Middleware) error {
 	cacheRepo := searchRedis.New(srv.redisClient, srv.l)
+	queryLogRepo := searchPostgre.New(srv.postgresDB, srv.l)
 
 	projectSrv := projectsrv.New(projectsrv.ProjectConfig{
 		BaseURL:     srv.config.Project.URL,
 		InternalKey: srv.config.InternalConfig.InternalKey,
 	})
 
-	uc := searchUsecase.New(srv.pointUC, srv.embeddingUC, cacheRepo, projectSrv, srv.l)
+	uc := searchUsecase.New(srv.pointUC, srv.embeddingUC, cacheRepo, queryLogRepo, projectSrv, srv.l, searchUsecase.Config{
+		DefaultRecencyHalfLifeHours: srv.config.Search.Recency.DefaultHalfLifeHours,
+		RecencyHalfLifeHours:        srv.config.Search.Recency.CampaignHalfLifeHours,
+		DiversityLambda:             srv.config.Search.Diversity.Lambda,
+		RedundancyThreshold:         srv.config.Search.Diversity.RedundancyThreshold,
+	})
 	srv.searchUC = uc
 
 	handler := searchHTTP.New(srv.l, uc, srv.discord)
@@ -0,0 +1,29 @@
+package httpserver
+
+import (
+	"context"
+	shareHTTP "knowledge-srv/internal/share/delivery/http"
+	sharePostgre "knowledge-srv/internal/share/repository/postgre"
+	shareUsecase "knowledge-srv/internal/share/usecase"
+	"knowledge-srv/pkg/projectsrv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+// setupShareDomain registers the share endpoints and keeps the usecase so the chat
+// and report domains can let shared readers in.
+func (srv *HTTPServer) setupShareDomain(ctx context.Context, r *gin.RouterGroup, mw *middleware.Middleware) error {
+	projectSrv := projectsrv.New(projectsrv.ProjectConfig{
+		BaseURL:     srv.config.Project.URL,
+		InternalKey: srv.config.InternalConfig.InternalKey,
+	})
+
+	srv.shareUC = shareUsecase.New(sharePostgre.New(srv.postgresDB, srv.l), projectSrv, srv.l)
+
+	handler := shareHTTP.New(srv.l, srv.shareUC, srv.discord)
+	handler.RegisterRoutes(r, mw)
+
+	srv.l.Infof(ctx, "Share domain registered")
+	return nil
+}
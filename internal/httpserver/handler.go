@@ -9,7 +9,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func (srv HTTPServer) mapHandlers() error {
+func (srv *HTTPServer) mapHandlers() error {
 	mw := middleware.New(middleware.Config{
 		JWTManager:       srv.jwtManager,
 		CookieName:       srv.cookieConfig.Name,
@@ -61,7 +61,7 @@ func (srv HTTPServer) registerSystemRoutes() {
 }
 
 // registerDomainRoutes initializes and registers all domain routes
-func (srv HTTPServer) registerDomainRoutes(mw *middleware.Middleware) error {
+func (srv *HTTPServer) registerDomainRoutes(mw *middleware.Middleware) error {
 	ctx := context.Background()
 
 	// Base route group (api/v1/knowledge)
@@ -72,13 +72,33 @@ func (srv HTTPServer) registerDomainRoutes(mw *middleware.Middleware) error {
 		return err
 	}
 
+	// Setup point domain (collection schema admin, startup index reconcile)
+	if err := srv.setupPointDomain(ctx, api, mw); err != nil {
+		return err
+	}
+
+	// Setup search domain (before the domains that evict its result cache)
+	if err := srv.setupSearchDomain(ctx, api, mw); err != nil {
+		return err
+	}
+
+	// Setup backup domain (Qdrant snapshots to MinIO, depends on pointUC and searchUC)
+	if err := srv.setupBackupDomain(ctx, api, mw); err != nil {
+		return err
+	}
+
+	// Setup erasure domain (right to be forgotten, before indexing which checks its tombstones)
+	if err := srv.setupErasureDomain(ctx, api, mw); err != nil {
+		return err
+	}
+
 	// Setup indexing domain
 	if err := srv.setupIndexingDomain(ctx, api, mw); err != nil {
 		return err
 	}
 
-	// Setup search domain
-	if err := srv.setupSearchDomain(ctx, api, mw); err != nil {
+	// Setup share domain (before chat and report, which honour its grants)
+	if err := srv.setupShareDomain(ctx, api, mw); err != nil {
 		return err
 	}
 
@@ -87,10 +107,16 @@ func (srv HTTPServer) registerDomainRoutes(mw *middleware.Middleware) error {
 		return err
 	}
 
-	// Setup report domain (depends on searchUC, geminiClient, minioClient)
+	// Setup report domain (depends on searchUC, indexingUC, geminiClient, minioClient)
 	if err := srv.setupReportDomain(ctx, api, mw); err != nil {
 		return err
 	}
 
+	// Setup lifecycle domain (purge of deleted projects/campaigns, last: it purges
+	// through the point, indexing, backup, report, chat and search usecases)
+	if err := srv.setupLifecycleDomain(ctx, api, mw); err != nil {
+		return err
+	}
+
 	return nil
 }
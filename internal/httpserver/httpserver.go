@@ -8,8 +8,9 @@ import (
 )
 
 // Run starts the HTTP server and blocks until the context is cancelled.
-// On context cancellation, it performs graceful shutdown with a 15s deadline.
-func (srv HTTPServer) Run(ctx context.Context) error {
+// On context cancellation, it performs graceful shutdown with a 15s deadline:
+// in-flight requests finish first, then domains stop their background jobs.
+func (srv *HTTPServer) Run(ctx context.Context) error {
 	if err := srv.mapHandlers(); err != nil {
 		return fmt.Errorf("map handlers: %w", err)
 	}
@@ -21,19 +22,32 @@ func (srv HTTPServer) Run(ctx context.Context) error {
 	}
 
 	// Graceful shutdown goroutine — triggers when parent context is cancelled
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 		if err := server.Shutdown(shutdownCtx); err != nil {
 			srv.l.Errorf(ctx, "Server shutdown error: %v", err)
 		}
+		if srv.backupUC != nil {
+			if err := srv.backupUC.Close(shutdownCtx); err != nil {
+				srv.l.Errorf(ctx, "Backup scheduler shutdown error: %v", err)
+			}
+		}
+		if srv.searchUC != nil {
+			if err := srv.searchUC.Close(shutdownCtx); err != nil {
+				srv.l.Errorf(ctx, "Search shutdown error: %v", err)
+			}
+		}
 	}()
 
 	srv.l.Infof(ctx, "HTTP server listening on %s", addr)
 	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		return err
 	}
+	<-shutdownDone
 
 	srv.l.Info(ctx, "HTTP server stopped")
 	return nil
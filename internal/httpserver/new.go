@@ -4,9 +4,15 @@ import (
 	"database/sql"
 	"errors"
 	"knowledge-srv/config"
+	"knowledge-srv/internal/backup"
+	"knowledge-srv/internal/chat"
 	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/erasure"
+	"knowledge-srv/internal/indexing"
 	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/report"
 	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/share"
 	pkgQdrant "knowledge-srv/pkg/qdrant"
 	"knowledge-srv/pkg/voyage"
 
@@ -52,6 +58,12 @@ type HTTPServer struct {
 	pointUC     point.UseCase
 	embeddingUC embedding.UseCase
 	searchUC    search.UseCase
+	backupUC    backup.UseCase
+	erasureUC   erasure.UseCase
+	indexingUC  indexing.UseCase
+	shareUC     share.UseCase
+	chatUC      chat.UseCase
+	reportUC    report.UseCase
 }
 
 type Config struct {
@@ -17,4 +17,6 @@ var (
 	ErrInsightTitleEmpty    = errors.New("indexing: insight title is empty")
 	ErrDigestBuildFailed    = errors.New("indexing: digest prose build failed")
 	ErrInsightSummaryEmpty  = errors.New("indexing: insight summary is empty")
+	ErrPurgeFailed          = errors.New("indexing: project purge failed")
+	ErrClusterLookup        = errors.New("indexing: near-duplicate cluster lookup failed")
 )
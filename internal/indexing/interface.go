@@ -13,4 +13,8 @@ type UseCase interface {
 	RetryFailed(ctx context.Context, ip RetryFailedInput) (RetryFailedOutput, error)
 	Reconcile(ctx context.Context, ip ReconcileInput) (ReconcileOutput, error)
 	GetStatistics(ctx context.Context, projectID string) (StatisticOutput, error)
+	// ClusterSizes returns the live member count of each known near-duplicate cluster ID.
+	ClusterSizes(ctx context.Context, clusterIDs []string) (map[string]int, error)
+	// PurgeProject deletes a deleted project's cached embeddings and indexing rows. Safe to re-run.
+	PurgeProject(ctx context.Context, projectID string) (PurgeProjectOutput, error)
 }
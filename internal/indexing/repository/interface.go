@@ -11,6 +11,8 @@ import (
 type PostgresRepository interface {
 	DocumentRepository
 	DLQRepository
+	NearDuplicateRepository
+	PurgeRepository
 }
 
 // DocumentRepository - Operations for indexed_documents table
@@ -33,12 +35,40 @@ type DLQRepository interface {
 	MarkResolvedDLQ(ctx context.Context, id string) error
 }
 
+// NearDuplicateRepository - Operations for near_duplicate_clusters / near_duplicate_cluster_members tables
+type NearDuplicateRepository interface {
+	// BeginClusterTx opens a transaction holding the project's cluster lock: cluster changes
+	// of the project are serialized across replicas until Commit or Rollback.
+	BeginClusterTx(ctx context.Context, projectID string) (ClusterTx, error)
+	// ClusterSizes returns the current member count of each known cluster ID.
+	ClusterSizes(ctx context.Context, clusterIDs []string) (map[string]int, error)
+}
+
+// ClusterTx - Cluster operations inside a project's locked transaction
+type ClusterTx interface {
+	// NearestCluster returns the project cluster closest to SimHash sharing at least one band,
+	// within MaxDistance (Hamming), oldest first on ties; ErrNotFound when there is none.
+	NearestCluster(ctx context.Context, opt NearestClusterOptions) (model.NearDuplicateCluster, error)
+	// CreateCluster inserts a cluster with PointID as its representative and first member.
+	CreateCluster(ctx context.Context, opt CreateClusterOptions) (model.NearDuplicateCluster, error)
+	// AddClusterMember registers a point in a cluster and returns the resulting member count
+	// (unchanged when the point already is a member).
+	AddClusterMember(ctx context.Context, clusterID, pointID string) (int, error)
+	Commit() error
+	// Rollback aborts the transaction; a no-op after Commit.
+	Rollback() error
+}
+
+// PurgeRepository - Removes a deleted project's indexing rows
+type PurgeRepository interface {
+	// ListProjectContentHashes returns content hashes of the project's indexed documents (embedding cache keys).
+	ListProjectContentHashes(ctx context.Context, projectID string) ([]string, error)
+	// DeleteProjectRows deletes the project's DLQ entries, indexed documents and near-duplicate
+	// clusters in one transaction.
+	DeleteProjectRows(ctx context.Context, projectID string) (DeletedProjectRows, error)
+}
+
 //go:generate mockery --name QdrantRepository
 type QdrantRepository interface {
 	UpsertPoint(ctx context.Context, opt UpsertPointOptions) error
 }
-
-//go:generate mockery --name CacheRepository
-type CacheRepository interface {
-	InvalidateSearchCache(ctx context.Context, projectID string) error
-}
@@ -22,6 +22,7 @@ type CreateDocumentOptions struct {
 	UpsertTimeMs    int
 	TotalTimeMs     int
 	IndexedAt       *time.Time
+	NearDuplicate   *NearDuplicateRef
 }
 
 // UpsertDocumentOptions - Options for Upsert operation
@@ -40,6 +41,14 @@ type UpsertDocumentOptions struct {
 	UpsertTimeMs    int
 	TotalTimeMs     int
 	IndexedAt       *time.Time
+	NearDuplicate   *NearDuplicateRef
+}
+
+// NearDuplicateRef - Fingerprint and cluster membership stored with an indexed document
+type NearDuplicateRef struct {
+	SimHash     string
+	ClusterID   string
+	DuplicateOf string // empty when the document is the cluster representative
 }
 
 // GetOneDocumentOptions - Options for GetOne query (single record by filters)
@@ -150,6 +159,34 @@ type ListDLQOptions struct {
 	OrderBy string // e.g., "created_at DESC"
 }
 
+// =====================================================
+// Near-duplicate Cluster Options
+// =====================================================
+
+// NearestClusterOptions - Find the closest cluster of ProjectID sharing a SimHash band
+// within MaxDistance (Hamming)
+type NearestClusterOptions struct {
+	ProjectID   string
+	SimHash     string
+	Bands       [4]int
+	MaxDistance int
+}
+
+// CreateClusterOptions - New cluster of ProjectID with PointID as its representative
+type CreateClusterOptions struct {
+	ProjectID string
+	PointID   string
+	SimHash   string
+	Bands     [4]int
+}
+
+// DeletedProjectRows - Rows DeleteProjectRows removed per table
+type DeletedProjectRows struct {
+	DLQEntries            int64
+	IndexedDocuments      int64
+	NearDuplicateClusters int64
+}
+
 // UpsertPointOptions - Options for UpsertPoint operation
 type UpsertPointOptions struct {
 	PointID string
@@ -14,7 +14,7 @@ func buildCreateDocument(opt repo.CreateDocumentOptions) *sqlboiler.IndexedDocum
 	dbDoc := &sqlboiler.IndexedDocument{
 		AnalyticsID:    opt.AnalyticsID,
 		ProjectID:      opt.ProjectID,
-		SourceID:       opt.SourceID,
+		SourceID:       null.NewString(opt.SourceID, opt.SourceID != ""),
 		QdrantPointID:  opt.QdrantPointID,
 		CollectionName: opt.CollectionName,
 		ContentHash:    opt.ContentHash,
@@ -43,6 +43,7 @@ func buildCreateDocument(opt repo.CreateDocumentOptions) *sqlboiler.IndexedDocum
 	if opt.IndexedAt != nil {
 		dbDoc.IndexedAt = null.TimeFrom(*opt.IndexedAt)
 	}
+	setNearDuplicate(dbDoc, opt.NearDuplicate)
 
 	return dbDoc
 }
@@ -52,7 +53,7 @@ func buildUpsertDocument(opt repo.UpsertDocumentOptions) *sqlboiler.IndexedDocum
 	dbDoc := &sqlboiler.IndexedDocument{
 		AnalyticsID:    opt.AnalyticsID,
 		ProjectID:      opt.ProjectID,
-		SourceID:       opt.SourceID,
+		SourceID:       null.NewString(opt.SourceID, opt.SourceID != ""),
 		QdrantPointID:  opt.QdrantPointID,
 		CollectionName: opt.CollectionName,
 		ContentHash:    opt.ContentHash,
@@ -80,6 +81,23 @@ func buildUpsertDocument(opt repo.UpsertDocumentOptions) *sqlboiler.IndexedDocum
 	if opt.IndexedAt != nil {
 		dbDoc.IndexedAt = null.TimeFrom(*opt.IndexedAt)
 	}
+	setNearDuplicate(dbDoc, opt.NearDuplicate)
 
 	return dbDoc
 }
+
+// setNearDuplicate - Copy fingerprint/cluster fields onto the sqlboiler entity
+func setNearDuplicate(dbDoc *sqlboiler.IndexedDocument, ref *repo.NearDuplicateRef) {
+	if ref == nil {
+		return
+	}
+	if ref.SimHash != "" {
+		dbDoc.Simhash = null.StringFrom(ref.SimHash)
+	}
+	if ref.ClusterID != "" {
+		dbDoc.ClusterID = null.StringFrom(ref.ClusterID)
+	}
+	if ref.DuplicateOf != "" {
+		dbDoc.DuplicateOf = null.StringFrom(ref.DuplicateOf)
+	}
+}
@@ -0,0 +1,167 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	repo "knowledge-srv/internal/indexing/repository"
+	"knowledge-srv/internal/model"
+)
+
+// clusterTx - A transaction holding the per-project advisory lock
+type clusterTx struct {
+	tx *sql.Tx
+	r  *implPostgresRepository
+}
+
+// BeginClusterTx - Open a transaction and take the project's advisory lock. The lock is
+// transaction-scoped, so workers on every replica serialize per project only and never
+// open twin clusters.
+func (r *implPostgresRepository) BeginClusterTx(ctx context.Context, projectID string) (repo.ClusterTx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.l.Errorf(ctx, "indexing.repository.postgre.BeginClusterTx: Failed to begin tx: %v", err)
+		return nil, repo.ErrFailedToUpsert
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, projectID); err != nil {
+		_ = tx.Rollback()
+		r.l.Errorf(ctx, "indexing.repository.postgre.BeginClusterTx: Failed to lock project %s: %v", projectID, err)
+		return nil, repo.ErrFailedToUpsert
+	}
+	return &clusterTx{tx: tx, r: r}, nil
+}
+
+// Commit - Persist the cluster changes and release the project lock
+func (c *clusterTx) Commit() error {
+	if err := c.tx.Commit(); err != nil {
+		return fmt.Errorf("%w: %v", repo.ErrFailedToUpsert, err)
+	}
+	return nil
+}
+
+// Rollback - Discard the cluster changes and release the project lock
+func (c *clusterTx) Rollback() error {
+	if err := c.tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+	return nil
+}
+
+// NearestCluster - Closest cluster of the project sharing at least one SimHash band,
+// by Hamming distance (popcount of the XOR of the hex fingerprints), oldest first on ties.
+func (c *clusterTx) NearestCluster(ctx context.Context, opt repo.NearestClusterOptions) (model.NearDuplicateCluster, error) {
+	const query = `
+		SELECT id, project_id, representative_point_id, simhash, member_count, created_at, updated_at
+		FROM (
+			SELECT *,
+			       length(replace((('x' || simhash)::bit(64) # ('x' || $6::text)::bit(64))::text, '0', '')) AS distance
+			FROM knowledge.near_duplicate_clusters
+			WHERE project_id = $1
+			  AND (band_0 = $2 OR band_1 = $3 OR band_2 = $4 OR band_3 = $5)
+		) c
+		WHERE distance <= $7
+		ORDER BY distance ASC, created_at ASC
+		LIMIT 1`
+
+	var cluster model.NearDuplicateCluster
+	err := c.tx.QueryRowContext(ctx, query,
+		opt.ProjectID, opt.Bands[0], opt.Bands[1], opt.Bands[2], opt.Bands[3], opt.SimHash, opt.MaxDistance,
+	).Scan(&cluster.ID, &cluster.ProjectID, &cluster.RepresentativePointID, &cluster.SimHash, &cluster.MemberCount, &cluster.CreatedAt, &cluster.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.NearDuplicateCluster{}, repo.ErrNotFound
+	}
+	if err != nil {
+		c.r.l.Errorf(ctx, "indexing.repository.postgre.NearestCluster: Failed to query clusters: %v", err)
+		return model.NearDuplicateCluster{}, repo.ErrFailedToList
+	}
+	return cluster, nil
+}
+
+// CreateCluster - Insert a cluster and register its representative as the first member
+func (c *clusterTx) CreateCluster(ctx context.Context, opt repo.CreateClusterOptions) (model.NearDuplicateCluster, error) {
+	const query = `
+		WITH cluster AS (
+			INSERT INTO knowledge.near_duplicate_clusters (
+				project_id, representative_point_id, simhash, band_0, band_1, band_2, band_3
+			) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, project_id, representative_point_id, simhash, member_count, created_at, updated_at
+		), member AS (
+			INSERT INTO knowledge.near_duplicate_cluster_members (cluster_id, point_id)
+			SELECT id, representative_point_id FROM cluster
+		)
+		SELECT id, project_id, representative_point_id, simhash, member_count, created_at, updated_at FROM cluster`
+
+	var cluster model.NearDuplicateCluster
+	err := c.tx.QueryRowContext(ctx, query,
+		opt.ProjectID, opt.PointID, opt.SimHash,
+		opt.Bands[0], opt.Bands[1], opt.Bands[2], opt.Bands[3],
+	).Scan(&cluster.ID, &cluster.ProjectID, &cluster.RepresentativePointID, &cluster.SimHash, &cluster.MemberCount, &cluster.CreatedAt, &cluster.UpdatedAt)
+	if err != nil {
+		c.r.l.Errorf(ctx, "indexing.repository.postgre.CreateCluster: Failed to insert cluster: %v", err)
+		return model.NearDuplicateCluster{}, repo.ErrFailedToInsert
+	}
+	return cluster, nil
+}
+
+// AddClusterMember - Register a point in a cluster; member_count only grows for new points
+func (c *clusterTx) AddClusterMember(ctx context.Context, clusterID, pointID string) (int, error) {
+	const query = `
+		WITH ins AS (
+			INSERT INTO knowledge.near_duplicate_cluster_members (cluster_id, point_id)
+			VALUES ($1, $2)
+			ON CONFLICT (cluster_id, point_id) DO NOTHING
+			RETURNING 1
+		)
+		UPDATE knowledge.near_duplicate_clusters
+		SET member_count = member_count + (SELECT COUNT(*) FROM ins),
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING member_count`
+
+	var size int
+	if err := c.tx.QueryRowContext(ctx, query, clusterID, pointID).Scan(&size); err != nil {
+		c.r.l.Errorf(ctx, "indexing.repository.postgre.AddClusterMember: Failed to add member: %v", err)
+		return 0, repo.ErrFailedToUpsert
+	}
+	return size, nil
+}
+
+// ClusterSizes - Current member_count of the given near-duplicate clusters
+func (r *implPostgresRepository) ClusterSizes(ctx context.Context, clusterIDs []string) (map[string]int, error) {
+	sizes := make(map[string]int, len(clusterIDs))
+	if len(clusterIDs) == 0 {
+		return sizes, nil
+	}
+
+	const query = `
+		SELECT id, member_count
+		FROM knowledge.near_duplicate_clusters
+		WHERE id = ANY($1::uuid[])`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(clusterIDs))
+	if err != nil {
+		r.l.Errorf(ctx, "indexing.repository.postgre.ClusterSizes: Failed to query clusters: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var size int
+		if err := rows.Scan(&id, &size); err != nil {
+			r.l.Errorf(ctx, "indexing.repository.postgre.ClusterSizes: Failed to scan cluster: %v", err)
+			return nil, repo.ErrFailedToList
+		}
+		sizes[id] = size
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "indexing.repository.postgre.ClusterSizes: Failed to iterate clusters: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	return sizes, nil
+}
@@ -0,0 +1,75 @@
+package postgre
+
+import (
+	"context"
+
+	repo "knowledge-srv/internal/indexing/repository"
+)
+
+// ListProjectContentHashes - Content hashes of the project's indexed documents
+func (r *implPostgresRepository) ListProjectContentHashes(ctx context.Context, projectID string) ([]string, error) {
+	const query = `
+		SELECT DISTINCT content_hash
+		FROM knowledge.indexed_documents
+		WHERE project_id = $1 AND content_hash IS NOT NULL AND content_hash <> ''`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		r.l.Errorf(ctx, "indexing.repository.postgre.ListProjectContentHashes: Failed to query: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			r.l.Errorf(ctx, "indexing.repository.postgre.ListProjectContentHashes: Failed to scan: %v", err)
+			return nil, repo.ErrFailedToList
+		}
+		hashes = append(hashes, h)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "indexing.repository.postgre.ListProjectContentHashes: Failed to iterate: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	return hashes, nil
+}
+
+// DeleteProjectRows - DLQ entries, indexed documents and near-duplicate clusters (members
+// cascade) of a project, in one transaction. DLQ rows are matched through their indexed document.
+func (r *implPostgresRepository) DeleteProjectRows(ctx context.Context, projectID string) (repo.DeletedProjectRows, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.l.Errorf(ctx, "indexing.repository.postgre.DeleteProjectRows: Failed to begin tx: %v", err)
+		return repo.DeletedProjectRows{}, repo.ErrFailedToDelete
+	}
+	defer tx.Rollback()
+
+	var deleted repo.DeletedProjectRows
+	steps := []struct {
+		query string
+		count *int64
+	}{
+		{`DELETE FROM knowledge.indexing_dlq
+		  WHERE analytics_id IN (SELECT analytics_id FROM knowledge.indexed_documents WHERE project_id = $1)`, &deleted.DLQEntries},
+		{`DELETE FROM knowledge.indexed_documents WHERE project_id = $1`, &deleted.IndexedDocuments},
+		{`DELETE FROM knowledge.near_duplicate_clusters WHERE project_id = $1`, &deleted.NearDuplicateClusters},
+	}
+	for _, step := range steps {
+		res, err := tx.ExecContext(ctx, step.query, projectID)
+		if err == nil {
+			*step.count, err = res.RowsAffected()
+		}
+		if err != nil {
+			r.l.Errorf(ctx, "indexing.repository.postgre.DeleteProjectRows: Failed to delete rows of project %s: %v", projectID, err)
+			return repo.DeletedProjectRows{}, repo.ErrFailedToDelete
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.l.Errorf(ctx, "indexing.repository.postgre.DeleteProjectRows: Failed to commit: %v", err)
+		return repo.DeletedProjectRows{}, repo.ErrFailedToDelete
+	}
+	return deleted, nil
+}
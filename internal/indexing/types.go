@@ -14,10 +14,16 @@ const (
 	DB_ERROR                  = "DB_ERROR"
 	VALIDATION_ERROR          = "VALIDATION_ERROR"
 	DUPLICATE_CONTENT         = "DUPLICATE_CONTENT"
+	ERASED_SOURCE             = "ERASED_SOURCE" // author/username/URL has an erasure tombstone
 	STATUS_INDEXED            = "INDEXED"
 	STATUS_SKIPPED            = "SKIPPED"
 	STATUS_FAILED             = "FAILED"
 	STATUS_PENDING            = "PENDING"
+
+	// Near-duplicate handling at index time
+	NearDuplicateModeAnnotate       = "annotate" // index with duplicate_of + cluster size
+	NearDuplicateModeSkip           = "skip"     // skip as DUPLICATE_CONTENT
+	DefaultNearDuplicateMaxDistance = 3          // SimHash Hamming distance; 4 bands guarantee recall up to 3
 )
 
 type IndexInput struct {
@@ -364,3 +370,11 @@ type IndexBatchOutput struct {
 	Failed       int
 	Duration     time.Duration
 }
+
+// PurgeProjectOutput - What PurgeProject removed
+type PurgeProjectOutput struct {
+	EmbeddingKeys         int64
+	DLQEntries            int64
+	IndexedDocuments      int64
+	NearDuplicateClusters int64
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"knowledge-srv/internal/erasure"
+	"knowledge-srv/internal/indexing"
+)
+
+// isErased checks a document against the erasure tombstones. Without an erasure
+// usecase (tests, tools) nothing is blocked.
+func (uc *implUseCase) isErased(ctx context.Context, ref erasure.SourceRef) (bool, error) {
+	if uc.erasureUC == nil {
+		return false, nil
+	}
+	return uc.erasureUC.IsErased(ctx, ref)
+}
+
+func insightSourceRef(src indexing.InsightSourceInput) erasure.SourceRef {
+	return erasure.SourceRef{
+		Authors:   []string{src.Author, src.AuthorDisplayName},
+		Usernames: []string{src.AuthorUsername},
+		URLs: []string{
+			src.URL, src.PostURL, src.OriginalURL, src.Permalink,
+			src.SourceURL, src.WebURL, src.CommentURL, src.ParentPostURL,
+		},
+	}
+}
+
+func recordSourceRef(meta indexing.UAPMetadata) erasure.SourceRef {
+	return erasure.SourceRef{
+		Authors:   []string{meta.Author, meta.AuthorDisplayName},
+		Usernames: []string{meta.AuthorUsername},
+		URLs: []string{
+			meta.URL, meta.PostURL, meta.OriginalURL, meta.Permalink,
+			meta.SourceURL, meta.WebURL, meta.CommentURL, meta.ParentPostURL,
+		},
+	}
+}
@@ -53,11 +53,9 @@ func (uc *implUseCase) Index(ctx context.Context, input indexing.IndexInput) (in
 	result := uc.processBatch(ctx, input, records)
 
 	// Step 5: Invalidate cache (nếu có records thành công)
-	// Keeps using CacheRepository (Redis) because InvalidateSearchCache is specific to existing Redis repo logic
-	// Ideally this should move to Search or Point domain too, but kept here for scope minimization.
-	// Actually, point domain doesn't expose Invalidate.
+	// The search domain owns the result cache, so eviction goes through its UseCase.
 	if result.Indexed > 0 {
-		if err := uc.cacheRepo.InvalidateSearchCache(ctx, input.ProjectID); err != nil {
+		if err := uc.searchUC.InvalidateProject(ctx, input.ProjectID); err != nil {
 			uc.l.Warnf(ctx, "indexing.usecase.Index: Failed to invalidate cache: %v", err)
 		}
 	}
@@ -138,6 +136,20 @@ func (uc *implUseCase) indexSingleRecord(ctx context.Context, ip indexing.IndexI
 		return indexing.IndexRecordResult{Status: "skipped"}
 	}
 
+	// Step 2b: Right-to-be-forgotten tombstones
+	if erased, err := uc.isErased(ctx, recordSourceRef(record.UAPMetadata)); err != nil {
+		return indexing.IndexRecordResult{
+			Status:       "failed",
+			ErrorType:    indexing.DB_ERROR,
+			ErrorMessage: err.Error(),
+		}
+	} else if erased {
+		return indexing.IndexRecordResult{
+			Status:    "skipped",
+			ErrorType: indexing.ERASED_SOURCE,
+		}
+	}
+
 	// Step 3: Check duplicate
 	contentHash := uc.generateContentHash(record.Content)
 
@@ -158,8 +170,17 @@ func (uc *implUseCase) indexSingleRecord(ctx context.Context, ip indexing.IndexI
 		}
 	}
 
+	// Step 3b: Near-duplicate fingerprint + cluster assignment
+	pointID := uc.pointUC.PointIDForProject(record.ProjectID, record.ID)
+	nearDup := uc.assignNearDuplicate(ctx, record.ProjectID, pointID, record.Content)
+	if uc.skipNearDuplicate(nearDup) {
+		return indexing.IndexRecordResult{
+			Status:    "skipped",
+			ErrorType: indexing.DUPLICATE_CONTENT,
+		}
+	}
+
 	// Step 4: Create/Update tracking record
-	pointID := record.ID
 	var trackingDoc model.IndexedDocument
 	var err error
 
@@ -173,6 +194,7 @@ func (uc *implUseCase) indexSingleRecord(ctx context.Context, ip indexing.IndexI
 			Status:        "PENDING",
 			BatchID:       &ip.BatchID,
 			RetryCount:    0,
+			NearDuplicate: nearDup.ref(),
 		})
 	} else {
 		trackingDoc, err = uc.postgreRepo.CreateDocument(ctx, repo.CreateDocumentOptions{
@@ -184,6 +206,7 @@ func (uc *implUseCase) indexSingleRecord(ctx context.Context, ip indexing.IndexI
 			Status:        "PENDING",
 			BatchID:       &ip.BatchID,
 			RetryCount:    0,
+			NearDuplicate: nearDup.ref(),
 		})
 	}
 	if err != nil {
@@ -214,13 +237,13 @@ func (uc *implUseCase) indexSingleRecord(ctx context.Context, ip indexing.IndexI
 	}
 
 	// Step 6: Prepare Qdrant payload
-	payload := uc.prepareQdrantPayload(record)
+	payload := uc.prepareQdrantPayload(record, nearDup)
 
 	// Step 7: Upsert to Qdrant (Via Point Domain)
 	upsertStart := time.Now()
 	// Call Point Domain
 	err = uc.pointUC.Upsert(ctx, point.UpsertInput{
-		CollectionName: fmt.Sprintf("proj_%s", ip.ProjectID),
+		CollectionName: uc.pointUC.CollectionForProject(ip.ProjectID),
 		Points: []model.Point{
 			{
 				ID:      pointID,
@@ -336,7 +359,7 @@ func (uc *implUseCase) generateContentHash(content string) string {
 }
 
 // prepareQdrantPayload - Build Qdrant payload from analytics post
-func (uc *implUseCase) prepareQdrantPayload(record indexing.AnalyticsPost) map[string]interface{} {
+func (uc *implUseCase) prepareQdrantPayload(record indexing.AnalyticsPost, nearDup nearDuplicateResult) map[string]interface{} {
 	payload := analyticsPayload{
 		AnalyticsID:           record.ID,
 		ProjectID:             record.ProjectID,
@@ -362,6 +385,10 @@ func (uc *implUseCase) prepareQdrantPayload(record indexing.AnalyticsPost) map[s
 		Language:              record.Language,
 		ToxicityScore:         record.ToxicityScore,
 		Aspects:               mapAnalyticsAspects(record.Aspects),
+		SimHash:               nearDup.simHash,
+		NearDupClusterID:      nearDup.clusterID,
+		DuplicateOf:           nearDup.duplicateOf,
+		NearDupClusterSize:    nearDup.clusterSize,
 		Metadata: analyticsMetadataPayload{
 			Author:            record.UAPMetadata.Author,
 			AuthorDisplayName: record.UAPMetadata.AuthorDisplayName,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"knowledge-srv/internal/embedding"
 	"knowledge-srv/internal/indexing"
+	repo "knowledge-srv/internal/indexing/repository"
 	"knowledge-srv/internal/model"
 	"knowledge-srv/internal/point"
 	"strings"
@@ -29,7 +30,7 @@ func (uc *implUseCase) IndexBatch(ctx context.Context, input indexing.IndexBatch
 		}, nil
 	}
 
-	collectionName := fmt.Sprintf("proj_%s", input.ProjectID)
+	collectionName := uc.pointUC.CollectionForProject(input.ProjectID)
 	if err := uc.pointUC.EnsureCollection(ctx, collectionName, defaultVectorSize); err != nil {
 		uc.l.Errorf(ctx, "indexing.usecase.IndexBatch: failed to ensure collection %s: %v", collectionName, err)
 		return indexing.IndexBatchOutput{}, err
@@ -40,7 +41,7 @@ func (uc *implUseCase) IndexBatch(ctx context.Context, input indexing.IndexBatch
 	result.Duration = time.Since(startTime)
 
 	if result.Indexed > 0 {
-		if err := uc.cacheRepo.InvalidateSearchCache(ctx, input.ProjectID); err != nil {
+		if err := uc.searchUC.InvalidateProject(ctx, input.ProjectID); err != nil {
 			uc.l.Warnf(ctx, "indexing.usecase.IndexBatch: Failed to invalidate cache: %v", err)
 		}
 	}
@@ -59,7 +60,7 @@ func (uc *implUseCase) processInsightBatch(ctx context.Context, input indexing.I
 		mu      sync.Mutex
 	)
 
-	collectionName := fmt.Sprintf("proj_%s", input.ProjectID)
+	collectionName := uc.pointUC.CollectionForProject(input.ProjectID)
 
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(indexing.MaxConcurrency)
@@ -112,30 +113,81 @@ func (uc *implUseCase) indexSingleInsight(
 		return indexing.STATUS_SKIPPED
 	}
 
+	erased, err := uc.isErased(ctx, insightSourceRef(doc.Source))
+	if err != nil {
+		uc.l.Errorf(ctx, "indexing.usecase.indexSingleInsight: tombstone check failed for %s: %v", doc.Identity.UapID, err)
+		return indexing.STATUS_FAILED
+	}
+	if erased {
+		return indexing.STATUS_SKIPPED
+	}
+
+	pointID := uc.pointUC.PointIDForProject(projectID, doc.Identity.UapID)
+	nearDup := uc.assignNearDuplicate(ctx, projectID, pointID, cleanText)
+	if uc.skipNearDuplicate(nearDup) {
+		return indexing.STATUS_SKIPPED
+	}
+
+	// Insights have no analytics UUID; the project-scoped UUID of uap_id keys the tracking record.
+	startTime := time.Now()
+	trackingDoc, err := uc.postgreRepo.UpsertDocument(ctx, repo.UpsertDocumentOptions{
+		AnalyticsID:    point.SharedPointID(projectID, doc.Identity.UapID),
+		ProjectID:      projectID,
+		QdrantPointID:  pointID,
+		CollectionName: collectionName,
+		ContentHash:    uc.generateContentHash(cleanText),
+		Status:         indexing.STATUS_PENDING,
+		NearDuplicate:  nearDup.ref(),
+	})
+	if err != nil {
+		uc.l.Errorf(ctx, "indexing.usecase.indexSingleInsight: tracking upsert failed for %s: %v", doc.Identity.UapID, err)
+		return indexing.STATUS_FAILED
+	}
+
+	embeddingStart := time.Now()
 	embeddingText := buildEmbeddingText(doc, cleanText)
 	genOutput, err := uc.embeddingUC.Generate(ctx, embedding.GenerateInput{Text: embeddingText})
+	embeddingTime := int(time.Since(embeddingStart).Milliseconds())
 	if err != nil {
 		uc.l.Errorf(ctx, "indexing.usecase.indexSingleInsight: embedding failed for %s: %v", doc.Identity.UapID, err)
+		uc.updateFailedStatus(ctx, trackingDoc.ID, indexing.EMBEDDING_ERROR, err.Error(), embeddingTime, 0)
 		return indexing.STATUS_FAILED
 	}
 
-	payload := uc.buildInsightPayload(projectID, campaignID, doc)
+	payload := uc.buildInsightPayload(projectID, campaignID, doc, nearDup)
 
+	upsertStart := time.Now()
 	err = uc.pointUC.Upsert(ctx, point.UpsertInput{
 		CollectionName: collectionName,
 		Points: []model.Point{
 			{
-				ID:      doc.Identity.UapID,
+				ID:      pointID,
 				Vector:  genOutput.Vector,
 				Payload: payload,
 			},
 		},
 	})
+	upsertTime := int(time.Since(upsertStart).Milliseconds())
 	if err != nil {
 		uc.l.Errorf(ctx, "indexing.usecase.indexSingleInsight: qdrant upsert failed for %s: %v", doc.Identity.UapID, err)
+		uc.updateFailedStatus(ctx, trackingDoc.ID, indexing.QDRANT_ERROR, err.Error(), embeddingTime, upsertTime)
 		return indexing.STATUS_FAILED
 	}
 
+	now := time.Now()
+	if _, err := uc.postgreRepo.UpdateDocumentStatus(ctx, repo.UpdateDocumentStatusOptions{
+		ID:     trackingDoc.ID,
+		Status: indexing.STATUS_INDEXED,
+		Metrics: repo.DocumentStatusMetrics{
+			IndexedAt:       &now,
+			EmbeddingTimeMs: embeddingTime,
+			UpsertTimeMs:    upsertTime,
+			TotalTimeMs:     int(time.Since(startTime).Milliseconds()),
+		},
+	}); err != nil {
+		uc.l.Errorf(ctx, "indexing.usecase.indexSingleInsight: failed to update status to INDEXED: %v", err)
+	}
+
 	return indexing.STATUS_INDEXED
 }
 
@@ -143,6 +195,7 @@ func (uc *implUseCase) buildInsightPayload(
 	projectID string,
 	campaignID string,
 	doc indexing.InsightMessageInput,
+	nearDup nearDuplicateResult,
 ) map[string]interface{} {
 	cleanText := strings.TrimSpace(doc.Content.CleanText)
 	payload := insightPayload{
@@ -153,6 +206,7 @@ func (uc *implUseCase) buildInsightPayload(
 		UapMediaType:      doc.Identity.UapMediaType,
 		Platform:          doc.Identity.Platform,
 		PublishedAt:       doc.Identity.PublishedAt,
+		ContentCreatedAt:  parseAnalysisWindow(doc.Identity.PublishedAt),
 		URL:               doc.Source.URL,
 		PostURL:           doc.Source.PostURL,
 		OriginalURL:       doc.Source.OriginalURL,
@@ -177,6 +231,7 @@ func (uc *implUseCase) buildInsightPayload(
 		SentimentScore:    doc.NLP.Sentiment.Score,
 		Aspects:           mapInsightAspects(doc.NLP.Aspects),
 		Entities:          mapInsightEntities(doc.NLP.Entities),
+		EntityKeys:        mapInsightEntityKeys(doc.NLP.Entities),
 		ImpactScore:       doc.Business.Impact.ImpactScore,
 		RelevanceScore:    doc.Business.RelevanceScore,
 		RelevanceReasons:  doc.Business.RelevanceReasons,
@@ -185,6 +240,10 @@ func (uc *implUseCase) buildInsightPayload(
 		Comments:          doc.Business.Impact.Engagement.Comments,
 		Shares:            doc.Business.Impact.Engagement.Shares,
 		Views:             doc.Business.Impact.Engagement.Views,
+		SimHash:           nearDup.simHash,
+		NearDupClusterID:  nearDup.clusterID,
+		DuplicateOf:       nearDup.duplicateOf,
+		DupClusterSize:    nearDup.clusterSize,
 	}
 
 	return uc.payloadFromStruct(payload)
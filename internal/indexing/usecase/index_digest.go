@@ -18,6 +18,9 @@ type digestPayload struct {
 	RAGDocumentType     string            `json:"rag_document_type"`
 	AnalysisWindowStart string            `json:"analysis_window_start"`
 	AnalysisWindowEnd   string            `json:"analysis_window_end"`
+	WindowStartUnix     int64             `json:"analysis_window_start_unix,omitempty"`
+	WindowEndUnix       int64             `json:"analysis_window_end_unix,omitempty"`
+	Content             string            `json:"content"`
 	DomainOverlay       string            `json:"domain_overlay"`
 	Platform            string            `json:"platform"`
 	TotalMentions       int               `json:"total_mentions"`
@@ -80,6 +83,9 @@ func (uc *implUseCase) IndexDigest(ctx context.Context, input indexing.IndexDige
 		RAGDocumentType:     "report_digest",
 		AnalysisWindowStart: input.AnalysisWindowStart,
 		AnalysisWindowEnd:   input.AnalysisWindowEnd,
+		WindowStartUnix:     parseAnalysisWindow(input.AnalysisWindowStart),
+		WindowEndUnix:       parseAnalysisWindow(input.AnalysisWindowEnd),
+		Content:             prose,
 		DomainOverlay:       input.DomainOverlay,
 		Platform:            input.Platform,
 		TotalMentions:       input.TotalMentions,
@@ -108,6 +114,11 @@ func (uc *implUseCase) IndexDigest(ctx context.Context, input indexing.IndexDige
 		return indexing.IndexDigestOutput{}, fmt.Errorf("%w: %v", indexing.ErrQdrantUpsertFailed, err)
 	}
 
+	// Blended search pages embed macro results, so drop the project's cached searches.
+	if err := uc.searchUC.InvalidateProject(ctx, input.ProjectID); err != nil {
+		uc.l.Warnf(ctx, "indexing.usecase.IndexDigest: Failed to invalidate cache: %v", err)
+	}
+
 	output := indexing.IndexDigestOutput{
 		PointID:  pointID,
 		Duration: time.Since(startTime),
@@ -22,6 +22,9 @@ type insightCardPayload struct {
 	Confidence          float64                `json:"confidence"`
 	AnalysisWindowStart string                 `json:"analysis_window_start"`
 	AnalysisWindowEnd   string                 `json:"analysis_window_end"`
+	WindowStartUnix     int64                  `json:"analysis_window_start_unix,omitempty"`
+	WindowEndUnix       int64                  `json:"analysis_window_end_unix,omitempty"`
+	Content             string                 `json:"content"`
 	SupportingMetrics   map[string]interface{} `json:"supporting_metrics,omitempty"`
 	EvidenceReferences  []string               `json:"evidence_references,omitempty"`
 }
@@ -58,6 +61,9 @@ func (uc *implUseCase) IndexInsight(ctx context.Context, input indexing.IndexIns
 		Confidence:          input.Confidence,
 		AnalysisWindowStart: input.AnalysisWindowStart,
 		AnalysisWindowEnd:   input.AnalysisWindowEnd,
+		WindowStartUnix:     parseAnalysisWindow(input.AnalysisWindowStart),
+		WindowEndUnix:       parseAnalysisWindow(input.AnalysisWindowEnd),
+		Content:             embedText,
 		SupportingMetrics:   input.SupportingMetrics,
 		EvidenceReferences:  input.EvidenceReferences,
 	}
@@ -82,6 +88,11 @@ func (uc *implUseCase) IndexInsight(ctx context.Context, input indexing.IndexIns
 		return indexing.IndexInsightOutput{}, fmt.Errorf("%w: %v", indexing.ErrQdrantUpsertFailed, err)
 	}
 
+	// Blended search pages embed macro results, so drop the project's cached searches.
+	if err := uc.searchUC.InvalidateProject(ctx, input.ProjectID); err != nil {
+		uc.l.Warnf(ctx, "indexing.usecase.IndexInsight: Failed to invalidate cache: %v", err)
+	}
+
 	output := indexing.IndexInsightOutput{
 		PointID:  pointID,
 		Duration: time.Since(startTime),
@@ -92,3 +103,17 @@ func (uc *implUseCase) IndexInsight(ctx context.Context, input indexing.IndexIns
 
 	return output, nil
 }
+
+// parseAnalysisWindow converts an RFC3339 analysis window bound into unix seconds
+// so retrieval can range-filter macro_insights by window. Returns 0 if unparsable.
+func parseAnalysisWindow(value string) int64 {
+	if value == "" {
+		return 0
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Unix()
+		}
+	}
+	return 0
+}
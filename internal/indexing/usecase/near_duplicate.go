@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"knowledge-srv/internal/indexing"
+	repo "knowledge-srv/internal/indexing/repository"
+	"knowledge-srv/internal/model"
+)
+
+const (
+	// simHashShingleSize is the number of consecutive tokens hashed together.
+	simHashShingleSize = 3
+	// simHashMinTokens skips fingerprinting for text too short to compare reliably.
+	simHashMinTokens = 5
+)
+
+// nearDuplicateResult is the fingerprint and cluster assignment of one point.
+type nearDuplicateResult struct {
+	simHash     string
+	clusterID   string
+	duplicateOf string // representative point ID; empty for representatives
+	clusterSize int
+}
+
+func (r nearDuplicateResult) isDuplicate() bool {
+	return r.duplicateOf != ""
+}
+
+// ref converts the result into the tracking-record form; nil when nothing was computed.
+func (r nearDuplicateResult) ref() *repo.NearDuplicateRef {
+	if r.simHash == "" {
+		return nil
+	}
+	return &repo.NearDuplicateRef{
+		SimHash:     r.simHash,
+		ClusterID:   r.clusterID,
+		DuplicateOf: r.duplicateOf,
+	}
+}
+
+// assignNearDuplicate fingerprints text and places pointID in the closest project
+// cluster within the configured Hamming distance, creating a new cluster otherwise.
+// Cluster bookkeeping is best-effort: DB failures are logged and the point is
+// indexed as if it were unique.
+func (uc *implUseCase) assignNearDuplicate(ctx context.Context, projectID, pointID, text string) nearDuplicateResult {
+	fingerprint, ok := simHash(text)
+	if !ok {
+		return nearDuplicateResult{}
+	}
+	result := nearDuplicateResult{simHash: formatSimHash(fingerprint)}
+
+	cluster, err := uc.assignCluster(ctx, projectID, pointID, result.simHash, simHashBands(fingerprint))
+	if err != nil {
+		uc.l.Warnf(ctx, "indexing.usecase.assignNearDuplicate: Failed to assign cluster: %v", err)
+		return result
+	}
+	result.clusterID = cluster.ID
+	result.clusterSize = cluster.MemberCount
+	if cluster.RepresentativePointID != pointID {
+		result.duplicateOf = cluster.RepresentativePointID
+	}
+	return result
+}
+
+// assignCluster joins the nearest band-sharing cluster of the project within the
+// configured distance, or makes pointID the representative of a new one. Lookup and
+// insert run under the project's cluster lock, so concurrent workers never open twins.
+func (uc *implUseCase) assignCluster(ctx context.Context, projectID, pointID, fingerprint string, bands [4]int) (model.NearDuplicateCluster, error) {
+	tx, err := uc.postgreRepo.BeginClusterTx(ctx, projectID)
+	if err != nil {
+		return model.NearDuplicateCluster{}, err
+	}
+	defer tx.Rollback()
+
+	cluster, err := tx.NearestCluster(ctx, repo.NearestClusterOptions{
+		ProjectID:   projectID,
+		SimHash:     fingerprint,
+		Bands:       bands,
+		MaxDistance: uc.config.NearDuplicateMaxDistance,
+	})
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		cluster, err = tx.CreateCluster(ctx, repo.CreateClusterOptions{
+			ProjectID: projectID,
+			PointID:   pointID,
+			SimHash:   fingerprint,
+			Bands:     bands,
+		})
+		if err != nil {
+			return model.NearDuplicateCluster{}, err
+		}
+	case err != nil:
+		return model.NearDuplicateCluster{}, err
+	default:
+		if cluster.MemberCount, err = tx.AddClusterMember(ctx, cluster.ID, pointID); err != nil {
+			return model.NearDuplicateCluster{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.NearDuplicateCluster{}, err
+	}
+	return cluster, nil
+}
+
+// ClusterSizes returns the live member count of each known cluster ID.
+func (uc *implUseCase) ClusterSizes(ctx context.Context, clusterIDs []string) (map[string]int, error) {
+	sizes, err := uc.postgreRepo.ClusterSizes(ctx, clusterIDs)
+	if err != nil {
+		uc.l.Errorf(ctx, "indexing.usecase.ClusterSizes: Failed to load cluster sizes: %v", err)
+		return nil, fmt.Errorf("%w: %v", indexing.ErrClusterLookup, err)
+	}
+	return sizes, nil
+}
+
+// skipNearDuplicate reports whether the configured mode drops this point.
+func (uc *implUseCase) skipNearDuplicate(nd nearDuplicateResult) bool {
+	return nd.isDuplicate() && uc.config.NearDuplicateMode == indexing.NearDuplicateModeSkip
+}
+
+// simHash computes a 64-bit SimHash over normalized token shingles.
+// Returns false when the text has too few tokens to fingerprint.
+func simHash(text string) (uint64, bool) {
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(tokens) < simHashMinTokens {
+		return 0, false
+	}
+
+	var weights [64]int
+	for i := 0; i+simHashShingleSize <= len(tokens); i++ {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(strings.Join(tokens[i:i+simHashShingleSize], " ")))
+		sum := h.Sum64()
+		for b := 0; b < 64; b++ {
+			if sum&(1<<uint(b)) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for b := 0; b < 64; b++ {
+		if weights[b] > 0 {
+			fingerprint |= 1 << uint(b)
+		}
+	}
+	return fingerprint, true
+}
+
+// simHashBands splits the fingerprint into four 16-bit bands for candidate lookup.
+func simHashBands(fingerprint uint64) [4]int {
+	var bands [4]int
+	for i := range bands {
+		bands[i] = int((fingerprint >> (16 * uint(i))) & 0xFFFF)
+	}
+	return bands
+}
+
+func formatSimHash(fingerprint uint64) string {
+	return fmt.Sprintf("%016x", fingerprint)
+}
@@ -2,22 +2,34 @@ package usecase
 
 import (
 	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/erasure"
 	"knowledge-srv/internal/indexing"
 	repo "knowledge-srv/internal/indexing/repository"
 	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
 
 	"github.com/smap-hcmut/shared-libs/go/log"
 	"github.com/smap-hcmut/shared-libs/go/minio"
 )
 
+// Config holds indexing behaviour knobs.
+type Config struct {
+	// NearDuplicateMode is indexing.NearDuplicateModeAnnotate (default) or indexing.NearDuplicateModeSkip.
+	NearDuplicateMode string
+	// NearDuplicateMaxDistance is the SimHash Hamming distance at which content joins a cluster.
+	NearDuplicateMaxDistance int
+}
+
 // implUseCase implements the indexing.UseCase interface
 type implUseCase struct {
 	l           log.Logger
 	postgreRepo repo.PostgresRepository
 	pointUC     point.UseCase
 	embeddingUC embedding.UseCase
-	cacheRepo   repo.CacheRepository
+	searchUC    search.UseCase // evicts cached results of re-indexed projects
 	minio       minio.MinIO
+	erasureUC   erasure.UseCase // nil = no tombstone check
+	config      Config
 }
 
 // New creates a new indexing usecase.
@@ -26,15 +38,25 @@ func New(
 	postgreRepo repo.PostgresRepository,
 	pointUC point.UseCase,
 	embeddingUC embedding.UseCase,
-	cacheRepo repo.CacheRepository,
+	searchUC search.UseCase,
 	minio minio.MinIO,
+	erasureUC erasure.UseCase,
+	cfg Config,
 ) indexing.UseCase {
+	if cfg.NearDuplicateMode != indexing.NearDuplicateModeSkip {
+		cfg.NearDuplicateMode = indexing.NearDuplicateModeAnnotate
+	}
+	if cfg.NearDuplicateMaxDistance <= 0 || cfg.NearDuplicateMaxDistance > indexing.DefaultNearDuplicateMaxDistance {
+		cfg.NearDuplicateMaxDistance = indexing.DefaultNearDuplicateMaxDistance
+	}
 	return &implUseCase{
 		l:           l,
 		postgreRepo: postgreRepo,
 		pointUC:     pointUC,
 		embeddingUC: embeddingUC,
-		cacheRepo:   cacheRepo,
+		searchUC:    searchUC,
 		minio:       minio,
+		erasureUC:   erasureUC,
+		config:      cfg,
 	}
 }
@@ -3,6 +3,7 @@ package usecase
 import (
 	"encoding/json"
 	"knowledge-srv/internal/indexing"
+	"knowledge-srv/internal/point"
 )
 
 type analyticsPayload struct {
@@ -30,6 +31,10 @@ type analyticsPayload struct {
 	Language              string                   `json:"language"`
 	ToxicityScore         float64                  `json:"toxicity_score"`
 	Aspects               []analyticsAspectPayload `json:"aspects,omitempty"`
+	SimHash               string                   `json:"simhash,omitempty"`
+	NearDupClusterID      string                   `json:"near_dup_cluster_id,omitempty"`
+	DuplicateOf           string                   `json:"duplicate_of,omitempty"`
+	NearDupClusterSize    int                      `json:"near_dup_cluster_size,omitempty"`
 	Metadata              analyticsMetadataPayload `json:"metadata"`
 }
 
@@ -83,6 +88,7 @@ type insightPayload struct {
 	UapMediaType      string                 `json:"uap_media_type"`
 	Platform          string                 `json:"platform"`
 	PublishedAt       string                 `json:"published_at"`
+	ContentCreatedAt  int64                  `json:"content_created_at,omitempty"` // unix seconds of PublishedAt, for date filters
 	URL               string                 `json:"url,omitempty"`
 	PostURL           string                 `json:"post_url,omitempty"`
 	OriginalURL       string                 `json:"original_url,omitempty"`
@@ -107,6 +113,7 @@ type insightPayload struct {
 	SentimentScore    float64                `json:"sentiment_score"`
 	Aspects           []insightAspectPayload `json:"aspects,omitempty"`
 	Entities          []insightEntityPayload `json:"entities,omitempty"`
+	EntityKeys        []string               `json:"entity_keys,omitempty"`
 	ImpactScore       float64                `json:"impact_score"`
 	RelevanceScore    float64                `json:"business_relevance_score"`
 	RelevanceReasons  []string               `json:"business_relevance_reasons,omitempty"`
@@ -115,6 +122,10 @@ type insightPayload struct {
 	Comments          int                    `json:"comments"`
 	Shares            int                    `json:"shares"`
 	Views             int                    `json:"views"`
+	SimHash           string                 `json:"simhash,omitempty"`
+	NearDupClusterID  string                 `json:"near_dup_cluster_id,omitempty"`
+	DuplicateOf       string                 `json:"duplicate_of,omitempty"`
+	DupClusterSize    int                    `json:"near_dup_cluster_size,omitempty"`
 }
 
 type insightAspectPayload struct {
@@ -123,8 +134,9 @@ type insightAspectPayload struct {
 }
 
 type insightEntityPayload struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	ValueNorm string `json:"value_norm,omitempty"`
 }
 
 func (uc *implUseCase) payloadFromStruct(v interface{}) map[string]interface{} {
@@ -185,10 +197,34 @@ func mapInsightEntities(entities []indexing.InsightEntityInput) []insightEntityP
 	out := make([]insightEntityPayload, len(entities))
 	for i, entity := range entities {
 		out[i] = insightEntityPayload{
-			Type:  entity.Type,
-			Value: entity.Value,
+			Type:      entity.Type,
+			Value:     entity.Value,
+			ValueNorm: point.NormalizeEntityValue(entity.Value),
 		}
 	}
 
 	return out
 }
+
+// mapInsightEntityKeys flattens entities into unique "TYPE:value" keys for faceting.
+func mapInsightEntityKeys(entities []indexing.InsightEntityInput) []string {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(entities))
+	out := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		if point.NormalizeEntityValue(entity.Value) == "" {
+			continue
+		}
+		key := point.EntityKey(entity.Type, entity.Value)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, key)
+	}
+
+	return out
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"knowledge-srv/internal/embedding"
+	"knowledge-srv/internal/indexing"
+)
+
+// PurgeProject deletes the cached embeddings of a project's documents before the rows
+// that reference them, so a failed attempt can still find them on retry.
+func (uc *implUseCase) PurgeProject(ctx context.Context, projectID string) (indexing.PurgeProjectOutput, error) {
+	var out indexing.PurgeProjectOutput
+
+	hashes, err := uc.postgreRepo.ListProjectContentHashes(ctx, projectID)
+	if err != nil {
+		uc.l.Errorf(ctx, "indexing.usecase.PurgeProject: Failed to list content hashes of project %s: %v", projectID, err)
+		return out, fmt.Errorf("%w: %v", indexing.ErrPurgeFailed, err)
+	}
+	if len(hashes) > 0 {
+		cached, err := uc.embeddingUC.DeleteCached(ctx, embedding.DeleteCachedInput{ContentHashes: hashes})
+		out.EmbeddingKeys = cached.Deleted
+		if err != nil {
+			uc.l.Errorf(ctx, "indexing.usecase.PurgeProject: Failed to delete embeddings of project %s: %v", projectID, err)
+			return out, fmt.Errorf("%w: %v", indexing.ErrPurgeFailed, err)
+		}
+	}
+
+	rows, err := uc.postgreRepo.DeleteProjectRows(ctx, projectID)
+	if err != nil {
+		uc.l.Errorf(ctx, "indexing.usecase.PurgeProject: Failed to delete rows of project %s: %v", projectID, err)
+		return out, fmt.Errorf("%w: %v", indexing.ErrPurgeFailed, err)
+	}
+	out.DLQEntries = rows.DLQEntries
+	out.IndexedDocuments = rows.IndexedDocuments
+	out.NearDuplicateClusters = rows.NearDuplicateClusters
+	return out, nil
+}
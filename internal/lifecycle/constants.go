@@ -0,0 +1,45 @@
+package lifecycle
+
+// Purge scopes.
+const (
+	ScopeProject  = "PROJECT"
+	ScopeCampaign = "CAMPAIGN"
+)
+
+// Purge request statuses (knowledge.purge_requests.status).
+const (
+	StatusPending   = "PENDING"
+	StatusRunning   = "RUNNING"
+	StatusCompleted = "COMPLETED"
+	StatusFailed    = "FAILED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Purge request sources.
+const (
+	SourceKafka = "KAFKA"
+	SourceAPI   = "API"
+)
+
+// project.events event types handled by knowledge-srv; other lifecycle
+// transitions (activate, pause, resume, archive) keep the data.
+const (
+	EventProjectDeleted   = "project.deleted"
+	EventProjectRestored  = "project.restored"
+	EventCampaignDeleted  = "campaign.deleted"
+	EventCampaignRestored = "campaign.restored"
+)
+
+// IsValidScope reports whether s is a purge scope.
+func IsValidScope(s string) bool {
+	return s == ScopeProject || s == ScopeCampaign
+}
+
+// IsValidStatus reports whether s is a purge request status.
+func IsValidStatus(s string) bool {
+	switch s {
+	case StatusPending, StatusRunning, StatusCompleted, StatusFailed, StatusCancelled:
+		return true
+	}
+	return false
+}
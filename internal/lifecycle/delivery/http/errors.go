@@ -0,0 +1,42 @@
+package http
+
+import (
+	"errors"
+	"knowledge-srv/internal/lifecycle"
+
+	pkgErrors "github.com/smap-hcmut/shared-libs/go/errors"
+)
+
+var (
+	errInvalidScope    = pkgErrors.NewHTTPError(400, "Invalid purge scope")
+	errScopeIDRequired = pkgErrors.NewHTTPError(400, "A valid scope ID is required")
+	errInvalidStatus   = pkgErrors.NewHTTPError(400, "Invalid purge status")
+	errPurgeNotFound   = pkgErrors.NewHTTPError(404, "Purge request not found")
+	errNotCancellable  = pkgErrors.NewHTTPError(409, "Purge request is no longer pending")
+	errRequestPurge    = pkgErrors.NewHTTPError(500, "Failed to request purge")
+	errListPurges      = pkgErrors.NewHTTPError(500, "Failed to list purge requests")
+	errCancelPurge     = pkgErrors.NewHTTPError(500, "Failed to cancel purge")
+)
+
+func (h *handler) mapError(err error) error {
+	switch {
+	case errors.Is(err, lifecycle.ErrInvalidScope):
+		return errInvalidScope
+	case errors.Is(err, lifecycle.ErrScopeIDRequired):
+		return errScopeIDRequired
+	case errors.Is(err, lifecycle.ErrInvalidStatus):
+		return errInvalidStatus
+	case errors.Is(err, lifecycle.ErrPurgeNotFound):
+		return errPurgeNotFound
+	case errors.Is(err, lifecycle.ErrNotCancellable):
+		return errNotCancellable
+	case errors.Is(err, lifecycle.ErrRequestPurge):
+		return errRequestPurge
+	case errors.Is(err, lifecycle.ErrListPurges):
+		return errListPurges
+	case errors.Is(err, lifecycle.ErrCancelPurge):
+		return errCancelPurge
+	default:
+		return pkgErrors.NewHTTPError(500, "Internal server error")
+	}
+}
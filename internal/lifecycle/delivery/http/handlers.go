@@ -0,0 +1,135 @@
+package http
+
+import (
+	"knowledge-srv/internal/lifecycle"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/response"
+)
+
+// RequestPurge - Handler cho POST /internal/purges
+// @Summary Request the purge of a deleted project or campaign
+// @Description Schedule the removal of everything knowledge-srv keeps for a project (Qdrant vectors, indexed documents, DLQ entries, near-duplicate clusters, backups, cached embeddings and search results) or a campaign (macro insights, reports, conversations, query logs). The purge runs after the configured grace period unless immediate is set; requesting a scope that already has an open purge returns it.
+// @Tags Lifecycle (Internal)
+// @Accept json
+// @Produce json
+// @Param body body requestPurgeReq true "Purge scope"
+// @Success 200 {object} purgeResp
+// @Failure 400 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/purges [post]
+func (h *handler) RequestPurge(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processRequestPurgeRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "lifecycle.delivery.http.RequestPurge: processRequestPurgeRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.RequestPurge(ctx, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "lifecycle.delivery.http.RequestPurge: usecase RequestPurge failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, newPurgeResp(output))
+}
+
+// ListPurges - Handler cho GET /internal/purges
+// @Summary List purge requests
+// @Description List purge requests and their audit of removed data, newest first
+// @Tags Lifecycle (Internal)
+// @Produce json
+// @Param scope query string false "PROJECT | CAMPAIGN"
+// @Param scope_id query string false "Project or campaign ID"
+// @Param status query string false "PENDING | RUNNING | COMPLETED | FAILED | CANCELLED"
+// @Param limit query int false "Max records (default 50, max 200)"
+// @Success 200 {object} listPurgesResp
+// @Failure 400 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/purges [get]
+func (h *handler) ListPurges(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processListPurgesRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "lifecycle.delivery.http.ListPurges: processListPurgesRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.ListPurges(ctx, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "lifecycle.delivery.http.ListPurges: usecase ListPurges failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newListPurgesResp(output))
+}
+
+// GetPurge - Handler cho GET /internal/purges/:id
+// @Summary Get a purge request
+// @Description Get a purge request with its audit of removed data
+// @Tags Lifecycle (Internal)
+// @Produce json
+// @Param id path string true "Purge request ID"
+// @Success 200 {object} purgeResp
+// @Failure 400 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/purges/{id} [get]
+func (h *handler) GetPurge(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processPurgeIDRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "lifecycle.delivery.http.GetPurge: processPurgeIDRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.GetPurge(ctx, req.ID)
+	if err != nil {
+		h.l.Errorf(ctx, "lifecycle.delivery.http.GetPurge: usecase GetPurge failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, newPurgeResp(output))
+}
+
+// CancelPurge - Handler cho POST /internal/purges/:id/cancel
+// @Summary Cancel a pending purge
+// @Description Cancel a purge that has not started (e.g. the project was restored within the grace period). Returns 409 once the purge is running or finished.
+// @Tags Lifecycle (Internal)
+// @Produce json
+// @Param id path string true "Purge request ID"
+// @Success 200 {object} purgeResp
+// @Failure 400 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 409 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/purges/{id}/cancel [post]
+func (h *handler) CancelPurge(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processPurgeIDRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "lifecycle.delivery.http.CancelPurge: processPurgeIDRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.CancelPurge(ctx, lifecycle.CancelPurgeInput{ID: req.ID})
+	if err != nil {
+		h.l.Errorf(ctx, "lifecycle.delivery.http.CancelPurge: usecase CancelPurge failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, newPurgeResp(output))
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"time"
+
+	"knowledge-srv/internal/lifecycle"
+	"knowledge-srv/internal/model"
+)
+
+type requestPurgeReq struct {
+	Scope       string   `json:"scope" binding:"required,oneof=PROJECT CAMPAIGN"`
+	ScopeID     string   `json:"scope_id" binding:"required,uuid"`
+	CampaignIDs []string `json:"campaign_ids" binding:"omitempty,dive,uuid"` // PROJECT: campaigns that contained the project (recorded only)
+	ProjectIDs  []string `json:"project_ids" binding:"omitempty,dive,uuid"`  // CAMPAIGN: projects whose search cache is evicted
+	Reason      string   `json:"reason"`
+	Immediate   bool     `json:"immediate"` // skip the grace period
+}
+
+func (r requestPurgeReq) toInput() lifecycle.RequestPurgeInput {
+	return lifecycle.RequestPurgeInput{
+		Scope:       r.Scope,
+		ScopeID:     r.ScopeID,
+		CampaignIDs: r.CampaignIDs,
+		ProjectIDs:  r.ProjectIDs,
+		Source:      lifecycle.SourceAPI,
+		Reason:      r.Reason,
+		Immediate:   r.Immediate,
+	}
+}
+
+type listPurgesReq struct {
+	Scope   string `form:"scope"`
+	ScopeID string `form:"scope_id"`
+	Status  string `form:"status"`
+	Limit   int    `form:"limit"`
+}
+
+func (r listPurgesReq) toInput() lifecycle.ListPurgesInput {
+	return lifecycle.ListPurgesInput{
+		Scope:   r.Scope,
+		ScopeID: r.ScopeID,
+		Status:  r.Status,
+		Limit:   r.Limit,
+	}
+}
+
+type purgeIDReq struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type purgeRemovedResp struct {
+	CollectionsDropped    []string `json:"collections_dropped"`
+	PointsDeleted         uint64   `json:"points_deleted"`
+	IndexedDocuments      int64    `json:"indexed_documents"`
+	DLQEntries            int64    `json:"dlq_entries"`
+	NearDuplicateClusters int64    `json:"near_duplicate_clusters"`
+	Backups               int64    `json:"backups"`
+	Reports               int64    `json:"reports"`
+	Conversations         int64    `json:"conversations"`
+	QueryLogs             int64    `json:"query_logs"`
+	EmbeddingKeys         int64    `json:"embedding_keys"`
+	SearchCacheProjects   []string `json:"search_cache_projects"`
+	Objects               int64    `json:"objects"`
+}
+
+type purgeResp struct {
+	ID           string           `json:"id"`
+	Scope        string           `json:"scope"`
+	ScopeID      string           `json:"scope_id"`
+	CampaignIDs  []string         `json:"campaign_ids"`
+	ProjectIDs   []string         `json:"project_ids"`
+	Source       string           `json:"source"`
+	Reason       string           `json:"reason,omitempty"`
+	Status       string           `json:"status"`
+	PurgeAfter   time.Time        `json:"purge_after"`
+	Attempts     int              `json:"attempts"`
+	ErrorMessage string           `json:"error_message,omitempty"`
+	Removed      purgeRemovedResp `json:"removed"`
+	CreatedAt    time.Time        `json:"created_at"`
+	StartedAt    *time.Time       `json:"started_at,omitempty"`
+	CompletedAt  *time.Time       `json:"completed_at,omitempty"`
+	CancelledAt  *time.Time       `json:"cancelled_at,omitempty"`
+}
+
+type listPurgesResp struct {
+	Purges []purgeResp `json:"purges"`
+}
+
+func (h *handler) newListPurgesResp(o lifecycle.ListPurgesOutput) listPurgesResp {
+	resp := listPurgesResp{Purges: make([]purgeResp, len(o.Purges))}
+	for i, p := range o.Purges {
+		resp.Purges[i] = newPurgeResp(p)
+	}
+	return resp
+}
+
+func newPurgeResp(p model.PurgeRequest) purgeResp {
+	return purgeResp{
+		ID:           p.ID,
+		Scope:        p.Scope,
+		ScopeID:      p.ScopeID,
+		CampaignIDs:  nonNil(p.CampaignIDs),
+		ProjectIDs:   nonNil(p.ProjectIDs),
+		Source:       p.Source,
+		Reason:       p.Reason,
+		Status:       p.Status,
+		PurgeAfter:   p.PurgeAfter,
+		Attempts:     p.Attempts,
+		ErrorMessage: p.ErrorMessage,
+		Removed: purgeRemovedResp{
+			CollectionsDropped:    nonNil(p.Removed.CollectionsDropped),
+			PointsDeleted:         p.Removed.PointsDeleted,
+			IndexedDocuments:      p.Removed.IndexedDocuments,
+			DLQEntries:            p.Removed.DLQEntries,
+			NearDuplicateClusters: p.Removed.NearDuplicateClusters,
+			Backups:               p.Removed.Backups,
+			Reports:               p.Removed.Reports,
+			Conversations:         p.Removed.Conversations,
+			QueryLogs:             p.Removed.QueryLogs,
+			EmbeddingKeys:         p.Removed.EmbeddingKeys,
+			SearchCacheProjects:   nonNil(p.Removed.SearchCacheProjects),
+			Objects:               p.Removed.Objects,
+		},
+		CreatedAt:   p.CreatedAt,
+		StartedAt:   p.StartedAt,
+		CompletedAt: p.CompletedAt,
+		CancelledAt: p.CancelledAt,
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
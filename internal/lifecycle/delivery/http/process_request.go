@@ -0,0 +1,32 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func (h *handler) processRequestPurgeRequest(c *gin.Context) (requestPurgeReq, error) {
+	var req requestPurgeReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (h *handler) processListPurgesRequest(c *gin.Context) (listPurgesReq, error) {
+	var req listPurgesReq
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (h *handler) processPurgeIDRequest(c *gin.Context) (purgeIDReq, error) {
+	var req purgeIDReq
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
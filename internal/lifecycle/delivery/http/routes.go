@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup, mw *middleware.Middleware) {
+	internal := r.Group("/internal")
+	internal.Use(mw.InternalAuth())
+	{
+		internal.POST("/purges", h.RequestPurge)
+		internal.GET("/purges", h.ListPurges)
+		internal.GET("/purges/:id", h.GetPurge)
+		internal.POST("/purges/:id/cancel", h.CancelPurge)
+	}
+}
@@ -0,0 +1,47 @@
+package consumer
+
+import (
+	"context"
+	"knowledge-srv/internal/lifecycle/delivery/kafka"
+	"time"
+)
+
+// ConsumeProjectEvents starts consuming project.events messages
+func (c *consumer) ConsumeProjectEvents(ctx context.Context) error {
+	group, err := c.createConsumerGroup(kafka.GroupIDProjectEvents)
+	if err != nil {
+		return err
+	}
+	c.projectEventsGroup = group
+
+	handler := &projectEventsHandler{consumer: c}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				if err := group.ConsumeWithContext(ctx, []string{kafka.TopicProjectEvents}, handler); err != nil {
+					c.l.Errorf(ctx, "lifecycle.delivery.kafka.consumer.ConsumeProjectEvents: Consumer error: %v", err)
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(5 * time.Second):
+					}
+				}
+			}
+		}
+	}()
+
+	go func() {
+		for err := range group.Errors() {
+			c.l.Errorf(ctx, "lifecycle.delivery.kafka.consumer.ConsumeProjectEvents: Consumer group error: %v", err)
+		}
+	}()
+
+	c.l.Infof(ctx, "lifecycle.delivery.kafka.consumer.ConsumeProjectEvents: Started consuming topic: %s (group: %s)",
+		kafka.TopicProjectEvents, kafka.GroupIDProjectEvents)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+)
+
+type projectEventsHandler struct {
+	consumer *consumer
+}
+
+func (h *projectEventsHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *projectEventsHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *projectEventsHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		if err := h.consumer.handleProjectEventMessage(msg); err != nil {
+			h.consumer.l.Errorf(context.Background(), "lifecycle.delivery.kafka.consumer.ConsumeProjectEvents: Failed to process message: %v", err)
+			continue
+		}
+		session.MarkMessage(msg, "")
+	}
+	return nil
+}
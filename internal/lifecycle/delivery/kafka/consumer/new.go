@@ -0,0 +1,72 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"knowledge-srv/config"
+	"knowledge-srv/internal/lifecycle"
+
+	"github.com/smap-hcmut/shared-libs/go/kafka"
+	"github.com/smap-hcmut/shared-libs/go/log"
+)
+
+// Consumer is the delivery interface for project lifecycle events.
+type Consumer interface {
+	ConsumeProjectEvents(ctx context.Context) error
+	Close() error
+}
+
+type Config struct {
+	Logger      log.Logger
+	KafkaConfig config.KafkaConfig
+	UseCase     lifecycle.UseCase
+}
+
+// consumer implements Consumer (thin layer: receive msg → normalize → delegate to usecase).
+type consumer struct {
+	l                  log.Logger
+	kafkaConfig        config.KafkaConfig
+	uc                 lifecycle.UseCase
+	projectEventsGroup kafka.IConsumer
+}
+
+func New(cfg Config) (Consumer, error) {
+	if cfg.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if cfg.UseCase == nil {
+		return nil, fmt.Errorf("usecase is required")
+	}
+	if len(cfg.KafkaConfig.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka brokers are required")
+	}
+
+	return &consumer{
+		l:           cfg.Logger,
+		kafkaConfig: cfg.KafkaConfig,
+		uc:          cfg.UseCase,
+	}, nil
+}
+
+func (c *consumer) Close() error {
+	if c.projectEventsGroup != nil {
+		if err := c.projectEventsGroup.Close(); err != nil {
+			c.l.Errorf(context.Background(), "lifecycle.delivery.kafka.consumer.Close: failed to close project events group: %v", err)
+			return ErrConsumerGroupNotFound
+		}
+	}
+	return nil
+}
+
+func (c *consumer) createConsumerGroup(groupID string) (kafka.IConsumer, error) {
+	consumerConfig := kafka.ConsumerConfig{
+		Brokers: c.kafkaConfig.Brokers,
+		GroupID: groupID,
+	}
+	group, err := kafka.NewConsumer(consumerConfig)
+	if err != nil {
+		c.l.Errorf(context.Background(), "lifecycle.delivery.kafka.consumer.createConsumerGroup: failed to create consumer group %s: %v", groupID, err)
+		return nil, ErrCreateConsumerGroupFailed
+	}
+	return group, nil
+}
@@ -0,0 +1,16 @@
+package consumer
+
+import (
+	"knowledge-srv/internal/lifecycle"
+	kafkaDelivery "knowledge-srv/internal/lifecycle/delivery/kafka"
+)
+
+func toProjectEvent(m kafkaDelivery.ProjectEventMessage) lifecycle.ProjectEvent {
+	return lifecycle.ProjectEvent{
+		EventType:  m.EventType,
+		ProjectID:  m.ProjectID,
+		CampaignID: m.CampaignID,
+		ProjectIDs: m.ProjectIDs,
+		OccurredAt: m.OccurredAt,
+	}
+}
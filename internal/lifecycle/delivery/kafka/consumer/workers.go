@@ -0,0 +1,43 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"knowledge-srv/internal/lifecycle"
+	"knowledge-srv/internal/lifecycle/delivery/kafka"
+
+	"github.com/IBM/sarama"
+)
+
+// handleProjectEventMessage schedules or cancels purges for deleted/restored projects and campaigns.
+func (c *consumer) handleProjectEventMessage(msg *sarama.ConsumerMessage) error {
+	ctx := context.Background()
+
+	var message kafka.ProjectEventMessage
+	if err := json.Unmarshal(msg.Value, &message); err != nil {
+		c.l.Warnf(ctx, "lifecycle.delivery.kafka.consumer.handleProjectEventMessage: Invalid message format (skipping): %v", err)
+		return nil
+	}
+
+	switch message.EventType {
+	case lifecycle.EventProjectDeleted, lifecycle.EventProjectRestored,
+		lifecycle.EventCampaignDeleted, lifecycle.EventCampaignRestored:
+	default:
+		return nil
+	}
+
+	c.l.Infof(ctx, "lifecycle.delivery.kafka.consumer.handleProjectEventMessage: %s project=%s campaign=%s (partition %d, offset %d)",
+		message.EventType, message.ProjectID, message.CampaignID, msg.Partition, msg.Offset)
+
+	if err := c.uc.HandleProjectEvent(ctx, toProjectEvent(message)); err != nil {
+		if errors.Is(err, lifecycle.ErrScopeIDRequired) || errors.Is(err, lifecycle.ErrInvalidScope) {
+			c.l.Warnf(ctx, "lifecycle.delivery.kafka.consumer.handleProjectEventMessage: Invalid event (skipping): %v", err)
+			return nil
+		}
+		c.l.Errorf(ctx, "lifecycle.delivery.kafka.consumer.handleProjectEventMessage: HandleProjectEvent failed: %v", err)
+		return fmt.Errorf("usecase error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package kafka
+
+import (
+	"time"
+
+	"github.com/smap-hcmut/shared-libs/go/constants"
+)
+
+// Topic & Group constants
+const (
+	TopicProjectEvents   = constants.TopicProjectEvents
+	GroupIDProjectEvents = "knowledge-lifecycle"
+)
+
+// ProjectEventMessage - project.events payload (project and campaign lifecycle transitions).
+type ProjectEventMessage struct {
+	EventType  string    `json:"event_type"`
+	ProjectID  string    `json:"project_id,omitempty"`
+	CampaignID string    `json:"campaign_id,omitempty"`
+	ProjectIDs []string  `json:"project_ids,omitempty"` // campaign.*: projects of the campaign
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,14 @@
+package lifecycle
+
+import "errors"
+
+var (
+	ErrInvalidScope    = errors.New("lifecycle: invalid purge scope")
+	ErrScopeIDRequired = errors.New("lifecycle: scope_id is required")
+	ErrInvalidStatus   = errors.New("lifecycle: invalid status filter")
+	ErrPurgeNotFound   = errors.New("lifecycle: purge request not found")
+	ErrNotCancellable  = errors.New("lifecycle: purge request is no longer pending")
+	ErrRequestPurge    = errors.New("lifecycle: failed to request purge")
+	ErrListPurges      = errors.New("lifecycle: failed to list purge requests")
+	ErrCancelPurge     = errors.New("lifecycle: failed to cancel purge")
+)
@@ -0,0 +1,20 @@
+package lifecycle
+
+import (
+	"context"
+
+	"knowledge-srv/internal/model"
+)
+
+//go:generate mockery --name UseCase
+type UseCase interface {
+	// RequestPurge schedules a purge after the grace period. Requesting a scope that
+	// already has an open purge returns that purge instead of creating another.
+	RequestPurge(ctx context.Context, input RequestPurgeInput) (model.PurgeRequest, error)
+	// CancelPurge cancels a purge that has not started yet.
+	CancelPurge(ctx context.Context, input CancelPurgeInput) (model.PurgeRequest, error)
+	GetPurge(ctx context.Context, id string) (model.PurgeRequest, error)
+	ListPurges(ctx context.Context, input ListPurgesInput) (ListPurgesOutput, error)
+	// HandleProjectEvent maps a project.events message to RequestPurge/CancelPurge.
+	HandleProjectEvent(ctx context.Context, event ProjectEvent) error
+}
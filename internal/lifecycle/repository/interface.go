@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"knowledge-srv/internal/model"
+)
+
+//go:generate mockery --name PostgresRepository
+type PostgresRepository interface {
+	PurgeRequestRepository
+}
+
+// PurgeRequestRepository - Operations for the purge_requests table
+type PurgeRequestRepository interface {
+	// UpsertPurge creates a PENDING purge, or merges the targets into the open purge of the
+	// same scope (keeping the earliest purge_after).
+	UpsertPurge(ctx context.Context, opt UpsertPurgeOptions) (model.PurgeRequest, error)
+	GetPurge(ctx context.Context, id string) (model.PurgeRequest, error)
+	// GetOpenPurge returns the PENDING/RUNNING purge of a scope, ErrNotFound when there is none.
+	GetOpenPurge(ctx context.Context, scope, scopeID string) (model.PurgeRequest, error)
+	ListPurges(ctx context.Context, opt ListPurgesOptions) ([]model.PurgeRequest, error)
+	// CancelPurge moves a PENDING purge to CANCELLED; ErrNotFound when it is not pending.
+	CancelPurge(ctx context.Context, id string) (model.PurgeRequest, error)
+	// ClaimPurges moves due PENDING purges (and RUNNING ones abandoned by a crashed worker)
+	// to RUNNING. Rows locked by another replica are skipped.
+	ClaimPurges(ctx context.Context, opt ClaimPurgesOptions) ([]model.PurgeRequest, error)
+	CompletePurge(ctx context.Context, id string, removed model.PurgeRemoved) error
+	// FailPurge records a failed attempt: back to PENDING at RetryAt, or FAILED when RetryAt is nil.
+	FailPurge(ctx context.Context, opt FailPurgeOptions) error
+}
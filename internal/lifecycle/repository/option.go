@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"knowledge-srv/internal/model"
+)
+
+// UpsertPurgeOptions - Options for UpsertPurge
+type UpsertPurgeOptions struct {
+	Scope       string
+	ScopeID     string
+	CampaignIDs []string
+	ProjectIDs  []string
+	Source      string
+	Reason      string
+	PurgeAfter  time.Time
+}
+
+// ListPurgesOptions - Filters for ListPurges (empty = any)
+type ListPurgesOptions struct {
+	Scope   string
+	ScopeID string
+	Status  string
+	Limit   int
+}
+
+// ClaimPurgesOptions - Options for ClaimPurges
+type ClaimPurgesOptions struct {
+	ID         string        // claim only this purge (immediate purges), regardless of purge_after
+	Limit      int           // max purges claimed
+	StaleAfter time.Duration // RUNNING purges started longer ago are reclaimed
+}
+
+// FailPurgeOptions - Options for FailPurge
+type FailPurgeOptions struct {
+	ID           string
+	ErrorMessage string
+	Removed      model.PurgeRemoved // cumulative across attempts
+	RetryAt      *time.Time
+}
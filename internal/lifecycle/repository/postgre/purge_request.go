@@ -0,0 +1,242 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	repo "knowledge-srv/internal/lifecycle/repository"
+	"knowledge-srv/internal/model"
+
+	"github.com/lib/pq"
+)
+
+const purgeColumns = `id, scope, scope_id, campaign_ids, project_ids, source, reason, status, purge_after, attempts,
+		error_message, removed, created_at, updated_at, started_at, completed_at, cancelled_at`
+
+// UpsertPurge - Create a PENDING purge or merge targets into the open purge of the scope
+func (r *implPostgresRepository) UpsertPurge(ctx context.Context, opt repo.UpsertPurgeOptions) (model.PurgeRequest, error) {
+	query := `
+		INSERT INTO knowledge.purge_requests (scope, scope_id, campaign_ids, project_ids, source, reason, purge_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (scope, scope_id) WHERE status IN ('PENDING', 'RUNNING') DO UPDATE
+		SET campaign_ids = ARRAY(SELECT DISTINCT unnest(purge_requests.campaign_ids || EXCLUDED.campaign_ids)),
+		    project_ids = ARRAY(SELECT DISTINCT unnest(purge_requests.project_ids || EXCLUDED.project_ids)),
+		    purge_after = LEAST(purge_requests.purge_after, EXCLUDED.purge_after),
+		    updated_at = NOW()
+		RETURNING ` + purgeColumns
+
+	p, err := scanPurge(r.db.QueryRowContext(ctx, query,
+		opt.Scope, opt.ScopeID, pq.Array(nonNil(opt.CampaignIDs)), pq.Array(nonNil(opt.ProjectIDs)),
+		opt.Source, opt.Reason, opt.PurgeAfter,
+	))
+	if err != nil {
+		r.l.Errorf(ctx, "lifecycle.repository.postgre.UpsertPurge: Failed to upsert purge %s/%s: %v", opt.Scope, opt.ScopeID, err)
+		return model.PurgeRequest{}, repo.ErrFailedToInsert
+	}
+	return p, nil
+}
+
+// GetPurge - Purge request by ID
+func (r *implPostgresRepository) GetPurge(ctx context.Context, id string) (model.PurgeRequest, error) {
+	query := `SELECT ` + purgeColumns + ` FROM knowledge.purge_requests WHERE id = $1`
+	return r.getPurge(ctx, "GetPurge", query, id)
+}
+
+// GetOpenPurge - PENDING/RUNNING purge of a scope
+func (r *implPostgresRepository) GetOpenPurge(ctx context.Context, scope, scopeID string) (model.PurgeRequest, error) {
+	query := `
+		SELECT ` + purgeColumns + `
+		FROM knowledge.purge_requests
+		WHERE scope = $1 AND scope_id = $2 AND status IN ('PENDING', 'RUNNING')`
+	return r.getPurge(ctx, "GetOpenPurge", query, scope, scopeID)
+}
+
+func (r *implPostgresRepository) getPurge(ctx context.Context, fn, query string, args ...interface{}) (model.PurgeRequest, error) {
+	p, err := scanPurge(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.PurgeRequest{}, repo.ErrNotFound
+		}
+		r.l.Errorf(ctx, "lifecycle.repository.postgre.%s: Failed to get purge: %v", fn, err)
+		return model.PurgeRequest{}, repo.ErrFailedToGet
+	}
+	return p, nil
+}
+
+// ListPurges - Purge requests matching the filters, newest first
+func (r *implPostgresRepository) ListPurges(ctx context.Context, opt repo.ListPurgesOptions) ([]model.PurgeRequest, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if opt.Scope != "" {
+		args = append(args, opt.Scope)
+		where = append(where, fmt.Sprintf("scope = $%d", len(args)))
+	}
+	if opt.ScopeID != "" {
+		args = append(args, opt.ScopeID)
+		where = append(where, fmt.Sprintf("scope_id = $%d", len(args)))
+	}
+	if opt.Status != "" {
+		args = append(args, opt.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + purgeColumns + ` FROM knowledge.purge_requests`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, opt.Limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
+
+	return r.queryPurges(ctx, "ListPurges", query, args...)
+}
+
+// CancelPurge - PENDING -> CANCELLED
+func (r *implPostgresRepository) CancelPurge(ctx context.Context, id string) (model.PurgeRequest, error) {
+	query := `
+		UPDATE knowledge.purge_requests
+		SET status = 'CANCELLED', cancelled_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'PENDING'
+		RETURNING ` + purgeColumns
+	return r.getPurge(ctx, "CancelPurge", query, id)
+}
+
+// ClaimPurges - Move due purges to RUNNING, skipping rows locked by other replicas
+func (r *implPostgresRepository) ClaimPurges(ctx context.Context, opt repo.ClaimPurgesOptions) ([]model.PurgeRequest, error) {
+	query := `
+		UPDATE knowledge.purge_requests
+		SET status = 'RUNNING', attempts = attempts + 1, started_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM knowledge.purge_requests
+			WHERE (
+				($1 = '' AND status = 'PENDING' AND purge_after <= NOW())
+				OR ($1 = '' AND status = 'RUNNING' AND started_at < NOW() - make_interval(secs => $2))
+				OR ($1 <> '' AND id::text = $1 AND status = 'PENDING')
+			)
+			ORDER BY purge_after
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + purgeColumns
+
+	limit := opt.Limit
+	if opt.ID != "" {
+		limit = 1
+	}
+	return r.queryPurges(ctx, "ClaimPurges", query, opt.ID, opt.StaleAfter.Seconds(), limit)
+}
+
+// CompletePurge - Record the audit of a finished purge
+func (r *implPostgresRepository) CompletePurge(ctx context.Context, id string, removed model.PurgeRemoved) error {
+	const query = `
+		UPDATE knowledge.purge_requests
+		SET status = 'COMPLETED', removed = $2, error_message = NULL, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1`
+
+	data, err := json.Marshal(removed)
+	if err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, query, id, data); err != nil {
+		r.l.Errorf(ctx, "lifecycle.repository.postgre.CompletePurge: Failed to update purge %s: %v", id, err)
+		return repo.ErrFailedToUpdate
+	}
+	return nil
+}
+
+// FailPurge - Record a failed attempt and schedule the retry (or give up)
+func (r *implPostgresRepository) FailPurge(ctx context.Context, opt repo.FailPurgeOptions) error {
+	const query = `
+		UPDATE knowledge.purge_requests
+		SET status = CASE WHEN $4::timestamptz IS NULL THEN 'FAILED' ELSE 'PENDING' END,
+		    purge_after = COALESCE($4::timestamptz, purge_after),
+		    error_message = $2, removed = $3, updated_at = NOW(),
+		    completed_at = CASE WHEN $4::timestamptz IS NULL THEN NOW() ELSE NULL END
+		WHERE id = $1`
+
+	data, err := json.Marshal(opt.Removed)
+	if err != nil {
+		return err
+	}
+	var retryAt sql.NullTime
+	if opt.RetryAt != nil {
+		retryAt = sql.NullTime{Time: *opt.RetryAt, Valid: true}
+	}
+	if _, err := r.db.ExecContext(ctx, query, opt.ID, opt.ErrorMessage, data, retryAt); err != nil {
+		r.l.Errorf(ctx, "lifecycle.repository.postgre.FailPurge: Failed to update purge %s: %v", opt.ID, err)
+		return repo.ErrFailedToUpdate
+	}
+	return nil
+}
+
+func (r *implPostgresRepository) queryPurges(ctx context.Context, fn, query string, args ...interface{}) ([]model.PurgeRequest, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.l.Errorf(ctx, "lifecycle.repository.postgre.%s: Failed to query purges: %v", fn, err)
+		return nil, repo.ErrFailedToList
+	}
+	defer rows.Close()
+
+	var purges []model.PurgeRequest
+	for rows.Next() {
+		p, err := scanPurge(rows)
+		if err != nil {
+			r.l.Errorf(ctx, "lifecycle.repository.postgre.%s: Failed to scan purge: %v", fn, err)
+			return nil, repo.ErrFailedToList
+		}
+		purges = append(purges, p)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "lifecycle.repository.postgre.%s: Failed to iterate purges: %v", fn, err)
+		return nil, repo.ErrFailedToList
+	}
+	return purges, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPurge(s scanner) (model.PurgeRequest, error) {
+	var (
+		p                                   model.PurgeRequest
+		reason, errMsg                      sql.NullString
+		removed                             []byte
+		startedAt, completedAt, cancelledAt sql.NullTime
+	)
+	err := s.Scan(&p.ID, &p.Scope, &p.ScopeID, pq.Array(&p.CampaignIDs), pq.Array(&p.ProjectIDs), &p.Source, &reason,
+		&p.Status, &p.PurgeAfter, &p.Attempts, &errMsg, &removed, &p.CreatedAt, &p.UpdatedAt,
+		&startedAt, &completedAt, &cancelledAt)
+	if err != nil {
+		return model.PurgeRequest{}, err
+	}
+	p.Reason = reason.String
+	p.ErrorMessage = errMsg.String
+	if len(removed) > 0 {
+		if err := json.Unmarshal(removed, &p.Removed); err != nil {
+			return model.PurgeRequest{}, err
+		}
+	}
+	if startedAt.Valid {
+		p.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		p.CompletedAt = &completedAt.Time
+	}
+	if cancelledAt.Valid {
+		p.CancelledAt = &cancelledAt.Time
+	}
+	return p, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,47 @@
+package lifecycle
+
+import (
+	"time"
+
+	"knowledge-srv/internal/model"
+)
+
+// RequestPurgeInput - Schedule the purge of a deleted project or campaign
+type RequestPurgeInput struct {
+	Scope       string
+	ScopeID     string
+	CampaignIDs []string // PROJECT: campaigns that contained the project (recorded; their reports go with the campaign purge)
+	ProjectIDs  []string // CAMPAIGN: projects whose cached search results are evicted
+	Source      string
+	Reason      string
+	Immediate   bool // skip the grace period and purge now
+}
+
+// CancelPurgeInput - Cancel the pending purge of a scope (restored before the grace period ended)
+type CancelPurgeInput struct {
+	ID      string // or Scope + ScopeID
+	Scope   string
+	ScopeID string
+}
+
+// ListPurgesInput - Filters for purge requests (empty = any)
+type ListPurgesInput struct {
+	Scope   string
+	ScopeID string
+	Status  string
+	Limit   int
+}
+
+// ListPurgesOutput - Purge requests, newest first
+type ListPurgesOutput struct {
+	Purges []model.PurgeRequest
+}
+
+// ProjectEvent - project.events payload from project-srv
+type ProjectEvent struct {
+	EventType  string
+	ProjectID  string
+	CampaignID string
+	ProjectIDs []string
+	OccurredAt time.Time
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"knowledge-srv/internal/lifecycle"
+)
+
+// HandleProjectEvent turns project-srv lifecycle events into purge requests.
+// Deleting a campaign also purges the projects it lists; restoring cancels the
+// pending purge. Other transitions keep the data and are ignored.
+func (uc *implUseCase) HandleProjectEvent(ctx context.Context, event lifecycle.ProjectEvent) error {
+	switch event.EventType {
+	case lifecycle.EventProjectDeleted:
+		var campaignIDs []string
+		if event.CampaignID != "" {
+			campaignIDs = []string{event.CampaignID}
+		}
+		_, err := uc.RequestPurge(ctx, lifecycle.RequestPurgeInput{
+			Scope:       lifecycle.ScopeProject,
+			ScopeID:     event.ProjectID,
+			CampaignIDs: campaignIDs,
+			Source:      lifecycle.SourceKafka,
+			Reason:      event.EventType,
+		})
+		return err
+
+	case lifecycle.EventCampaignDeleted:
+		if _, err := uc.RequestPurge(ctx, lifecycle.RequestPurgeInput{
+			Scope:      lifecycle.ScopeCampaign,
+			ScopeID:    event.CampaignID,
+			ProjectIDs: event.ProjectIDs,
+			Source:     lifecycle.SourceKafka,
+			Reason:     event.EventType,
+		}); err != nil {
+			return err
+		}
+		for _, projectID := range event.ProjectIDs {
+			if _, err := uc.RequestPurge(ctx, lifecycle.RequestPurgeInput{
+				Scope:       lifecycle.ScopeProject,
+				ScopeID:     projectID,
+				CampaignIDs: []string{event.CampaignID},
+				Source:      lifecycle.SourceKafka,
+				Reason:      event.EventType,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case lifecycle.EventProjectRestored:
+		return uc.cancelOnRestore(ctx, lifecycle.ScopeProject, event.ProjectID)
+
+	case lifecycle.EventCampaignRestored:
+		if err := uc.cancelOnRestore(ctx, lifecycle.ScopeCampaign, event.CampaignID); err != nil {
+			return err
+		}
+		for _, projectID := range event.ProjectIDs {
+			if err := uc.cancelOnRestore(ctx, lifecycle.ScopeProject, projectID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// cancelOnRestore cancels the pending purge of a restored scope. A purge that
+// already ran (or never existed) is not an error: there is nothing left to keep.
+func (uc *implUseCase) cancelOnRestore(ctx context.Context, scope, scopeID string) error {
+	_, err := uc.CancelPurge(ctx, lifecycle.CancelPurgeInput{Scope: scope, ScopeID: scopeID})
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, lifecycle.ErrPurgeNotFound) || errors.Is(err, lifecycle.ErrScopeIDRequired) {
+		return nil
+	}
+	if errors.Is(err, lifecycle.ErrNotCancellable) {
+		uc.l.Warnf(ctx, "lifecycle.usecase.HandleProjectEvent: %s/%s restored after its purge started", scope, scopeID)
+		return nil
+	}
+	return err
+}
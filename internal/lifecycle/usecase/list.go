@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"knowledge-srv/internal/lifecycle"
+	"knowledge-srv/internal/lifecycle/repository"
+	"knowledge-srv/internal/model"
+)
+
+func (uc *implUseCase) GetPurge(ctx context.Context, id string) (model.PurgeRequest, error) {
+	p, err := uc.repo.GetPurge(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.PurgeRequest{}, lifecycle.ErrPurgeNotFound
+		}
+		return model.PurgeRequest{}, fmt.Errorf("%w: %v", lifecycle.ErrListPurges, err)
+	}
+	return p, nil
+}
+
+func (uc *implUseCase) ListPurges(ctx context.Context, input lifecycle.ListPurgesInput) (lifecycle.ListPurgesOutput, error) {
+	if input.Scope != "" && !lifecycle.IsValidScope(input.Scope) {
+		return lifecycle.ListPurgesOutput{}, lifecycle.ErrInvalidScope
+	}
+	if input.Status != "" && !lifecycle.IsValidStatus(input.Status) {
+		return lifecycle.ListPurgesOutput{}, lifecycle.ErrInvalidStatus
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	purges, err := uc.repo.ListPurges(ctx, repository.ListPurgesOptions{
+		Scope:   input.Scope,
+		ScopeID: input.ScopeID,
+		Status:  input.Status,
+		Limit:   limit,
+	})
+	if err != nil {
+		return lifecycle.ListPurgesOutput{}, fmt.Errorf("%w: %v", lifecycle.ErrListPurges, err)
+	}
+	return lifecycle.ListPurgesOutput{Purges: purges}, nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"time"
+
+	"knowledge-srv/internal/backup"
+	"knowledge-srv/internal/chat"
+	"knowledge-srv/internal/indexing"
+	"knowledge-srv/internal/lifecycle"
+	"knowledge-srv/internal/lifecycle/repository"
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/report"
+	"knowledge-srv/internal/search"
+
+	"github.com/smap-hcmut/shared-libs/go/log"
+)
+
+const (
+	defaultPollInterval = time.Minute
+	defaultMaxAttempts  = 5
+	defaultListLimit    = 50
+	maxListLimit        = 200
+
+	// claimBatchSize bounds the purges one worker tick runs.
+	claimBatchSize = 10
+	// staleRunningAfter reclaims RUNNING purges whose worker died mid-run.
+	staleRunningAfter = time.Hour
+	maxRetryBackoff   = time.Hour
+)
+
+// Config - Grace period and worker settings of the purge workflow
+type Config struct {
+	GracePeriod  time.Duration // between the delete event and the purge (0 = purge on the next tick)
+	PollInterval time.Duration // between worker ticks
+	MaxAttempts  int           // failed purges are retried until this many attempts
+	RunWorker    bool          // start the purge worker in New
+}
+
+type implUseCase struct {
+	repo       repository.PostgresRepository
+	pointUC    point.UseCase
+	indexingUC indexing.UseCase
+	backupUC   backup.UseCase
+	reportUC   report.UseCase
+	chatUC     chat.UseCase
+	searchUC   search.UseCase
+	l          log.Logger
+	config     Config
+}
+
+func New(
+	repo repository.PostgresRepository,
+	pointUC point.UseCase,
+	indexingUC indexing.UseCase,
+	backupUC backup.UseCase,
+	reportUC report.UseCase,
+	chatUC chat.UseCase,
+	searchUC search.UseCase,
+	l log.Logger,
+	cfg Config,
+) lifecycle.UseCase {
+	if cfg.GracePeriod < 0 {
+		cfg.GracePeriod = 0
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+
+	uc := &implUseCase{
+		repo:       repo,
+		pointUC:    pointUC,
+		indexingUC: indexingUC,
+		backupUC:   backupUC,
+		reportUC:   reportUC,
+		chatUC:     chatUC,
+		searchUC:   searchUC,
+		l:          l,
+		config:     cfg,
+	}
+	if cfg.RunWorker {
+		go uc.runWorker()
+	}
+	return uc
+}
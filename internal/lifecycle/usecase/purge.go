@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"knowledge-srv/internal/lifecycle"
+	"knowledge-srv/internal/model"
+)
+
+// purge removes everything a deleted project or campaign left behind, through the
+// usecase of each domain that owns the data. Reports are campaign-wide, so only the
+// campaign purge removes them. Each step only touches what still exists, so a retry
+// after a partial failure picks up where the previous attempt stopped.
+func (uc *implUseCase) purge(ctx context.Context, p model.PurgeRequest) (model.PurgeRemoved, error) {
+	switch p.Scope {
+	case lifecycle.ScopeProject:
+		return uc.purgeProject(ctx, p)
+	case lifecycle.ScopeCampaign:
+		return uc.purgeCampaign(ctx, p)
+	}
+	return model.PurgeRemoved{}, lifecycle.ErrInvalidScope
+}
+
+func (uc *implUseCase) purgeProject(ctx context.Context, p model.PurgeRequest) (model.PurgeRemoved, error) {
+	var removed model.PurgeRemoved
+	projectID := p.ScopeID
+
+	// Collection backups (MinIO objects and records)
+	backups, err := uc.backupUC.PurgeProject(ctx, projectID)
+	removed.Objects += backups.Objects
+	removed.Backups += backups.Backups
+	if err != nil {
+		return removed, err
+	}
+
+	// Qdrant
+	points, err := uc.pointUC.PurgeProject(ctx, projectID)
+	removed.CollectionsDropped = points.CollectionsDropped
+	removed.PointsDeleted = points.PointsDeleted
+	if err != nil {
+		return removed, err
+	}
+
+	// Cached embeddings and indexing rows
+	indexed, err := uc.indexingUC.PurgeProject(ctx, projectID)
+	removed.EmbeddingKeys += indexed.EmbeddingKeys
+	removed.DLQEntries += indexed.DLQEntries
+	removed.IndexedDocuments += indexed.IndexedDocuments
+	removed.NearDuplicateClusters += indexed.NearDuplicateClusters
+	if err != nil {
+		return removed, err
+	}
+
+	// Redis: search results
+	if err := uc.searchUC.InvalidateProject(ctx, projectID); err != nil {
+		return removed, fmt.Errorf("invalidate search cache: %w", err)
+	}
+	removed.SearchCacheProjects = append(removed.SearchCacheProjects, projectID)
+	return removed, nil
+}
+
+func (uc *implUseCase) purgeCampaign(ctx context.Context, p model.PurgeRequest) (model.PurgeRemoved, error) {
+	var removed model.PurgeRemoved
+	campaignID := p.ScopeID
+
+	// Report files and records
+	reports, err := uc.reportUC.PurgeCampaign(ctx, campaignID)
+	removed.Objects += reports.Files
+	removed.Reports += reports.Reports
+	if err != nil {
+		return removed, err
+	}
+
+	// Qdrant: macro digests and insight cards
+	points, err := uc.pointUC.PurgeCampaign(ctx, campaignID)
+	removed.PointsDeleted += points.PointsDeleted
+	if err != nil {
+		return removed, err
+	}
+
+	conversations, err := uc.chatUC.PurgeCampaign(ctx, campaignID)
+	removed.Conversations += conversations.Conversations
+	if err != nil {
+		return removed, err
+	}
+
+	queryLogs, err := uc.searchUC.PurgeCampaign(ctx, campaignID)
+	removed.QueryLogs += queryLogs.QueryLogs
+	if err != nil {
+		return removed, err
+	}
+
+	for _, projectID := range p.ProjectIDs {
+		if err := uc.searchUC.InvalidateProject(ctx, projectID); err != nil {
+			return removed, fmt.Errorf("invalidate search cache: %w", err)
+		}
+		removed.SearchCacheProjects = append(removed.SearchCacheProjects, projectID)
+	}
+	return removed, nil
+}
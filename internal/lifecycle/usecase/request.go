@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"knowledge-srv/internal/lifecycle"
+	"knowledge-srv/internal/lifecycle/repository"
+	"knowledge-srv/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// RequestPurge schedules the purge of a deleted project or campaign once the grace
+// period ends. Requests for a scope with an open purge merge into it, so redelivered
+// events and repeated API calls are harmless.
+func (uc *implUseCase) RequestPurge(ctx context.Context, input lifecycle.RequestPurgeInput) (model.PurgeRequest, error) {
+	if !lifecycle.IsValidScope(input.Scope) {
+		return model.PurgeRequest{}, lifecycle.ErrInvalidScope
+	}
+	if _, err := uuid.Parse(input.ScopeID); err != nil {
+		return model.PurgeRequest{}, lifecycle.ErrScopeIDRequired
+	}
+	campaignIDs, err := validIDs(input.CampaignIDs)
+	if err != nil {
+		return model.PurgeRequest{}, err
+	}
+	projectIDs, err := validIDs(input.ProjectIDs)
+	if err != nil {
+		return model.PurgeRequest{}, err
+	}
+	if input.Source == "" {
+		input.Source = lifecycle.SourceAPI
+	}
+
+	purgeAfter := time.Now().Add(uc.config.GracePeriod)
+	if input.Immediate {
+		purgeAfter = time.Now()
+	}
+
+	p, err := uc.repo.UpsertPurge(ctx, repository.UpsertPurgeOptions{
+		Scope:       input.Scope,
+		ScopeID:     input.ScopeID,
+		CampaignIDs: campaignIDs,
+		ProjectIDs:  projectIDs,
+		Source:      input.Source,
+		Reason:      input.Reason,
+		PurgeAfter:  purgeAfter,
+	})
+	if err != nil {
+		uc.l.Errorf(ctx, "lifecycle.usecase.RequestPurge: UpsertPurge %s/%s failed: %v", input.Scope, input.ScopeID, err)
+		return model.PurgeRequest{}, fmt.Errorf("%w: %v", lifecycle.ErrRequestPurge, err)
+	}
+	uc.l.Infof(ctx, "lifecycle.usecase.RequestPurge: %s/%s purge %s scheduled at %s (source=%s)",
+		p.Scope, p.ScopeID, p.ID, p.PurgeAfter.Format(time.RFC3339), p.Source)
+
+	if input.Immediate && p.Status == lifecycle.StatusPending {
+		go uc.runClaimed(context.Background(), repository.ClaimPurgesOptions{ID: p.ID})
+	}
+	return p, nil
+}
+
+// CancelPurge cancels a pending purge by ID, or the open purge of a scope.
+func (uc *implUseCase) CancelPurge(ctx context.Context, input lifecycle.CancelPurgeInput) (model.PurgeRequest, error) {
+	id := input.ID
+	if id == "" {
+		if !lifecycle.IsValidScope(input.Scope) {
+			return model.PurgeRequest{}, lifecycle.ErrInvalidScope
+		}
+		if input.ScopeID == "" {
+			return model.PurgeRequest{}, lifecycle.ErrScopeIDRequired
+		}
+		open, err := uc.repo.GetOpenPurge(ctx, input.Scope, input.ScopeID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return model.PurgeRequest{}, lifecycle.ErrPurgeNotFound
+			}
+			return model.PurgeRequest{}, fmt.Errorf("%w: %v", lifecycle.ErrCancelPurge, err)
+		}
+		id = open.ID
+	}
+
+	p, err := uc.repo.CancelPurge(ctx, id)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return model.PurgeRequest{}, fmt.Errorf("%w: %v", lifecycle.ErrCancelPurge, err)
+		}
+		if _, getErr := uc.repo.GetPurge(ctx, id); getErr != nil {
+			return model.PurgeRequest{}, lifecycle.ErrPurgeNotFound
+		}
+		return model.PurgeRequest{}, lifecycle.ErrNotCancellable
+	}
+	uc.l.Infof(ctx, "lifecycle.usecase.CancelPurge: %s/%s purge %s cancelled", p.Scope, p.ScopeID, p.ID)
+	return p, nil
+}
+
+func validIDs(ids []string) ([]string, error) {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("%w: invalid id %q", lifecycle.ErrScopeIDRequired, id)
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"knowledge-srv/internal/lifecycle/repository"
+	"knowledge-srv/internal/model"
+)
+
+// runWorker claims due purges every PollInterval. Claims skip rows locked by other
+// replicas, so every replica may run a worker.
+func (uc *implUseCase) runWorker() {
+	ticker := time.NewTicker(uc.config.PollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		uc.runClaimed(context.Background(), repository.ClaimPurgesOptions{
+			Limit:      claimBatchSize,
+			StaleAfter: staleRunningAfter,
+		})
+	}
+}
+
+func (uc *implUseCase) runClaimed(ctx context.Context, opt repository.ClaimPurgesOptions) {
+	purges, err := uc.repo.ClaimPurges(ctx, opt)
+	if err != nil {
+		uc.l.Errorf(ctx, "lifecycle.usecase.runClaimed: ClaimPurges failed: %v", err)
+		return
+	}
+	for _, p := range purges {
+		uc.finish(ctx, p)
+	}
+}
+
+// finish runs one claimed purge and records the audit (or schedules the retry).
+func (uc *implUseCase) finish(ctx context.Context, p model.PurgeRequest) {
+	removed, err := uc.purge(ctx, p)
+
+	total := p.Removed
+	total.Add(removed)
+	if err == nil {
+		if err := uc.repo.CompletePurge(ctx, p.ID, total); err != nil {
+			uc.l.Errorf(ctx, "lifecycle.usecase.finish: CompletePurge %s failed: %v", p.ID, err)
+			return
+		}
+		uc.l.Infof(ctx, "lifecycle.usecase.finish: %s/%s purge %s completed: %+v", p.Scope, p.ScopeID, p.ID, total)
+		return
+	}
+
+	opt := repository.FailPurgeOptions{ID: p.ID, ErrorMessage: err.Error(), Removed: total}
+	if p.Attempts < uc.config.MaxAttempts {
+		retryAt := time.Now().Add(retryBackoff(p.Attempts))
+		opt.RetryAt = &retryAt
+		uc.l.Warnf(ctx, "lifecycle.usecase.finish: %s/%s purge %s attempt %d failed, retry at %s: %v",
+			p.Scope, p.ScopeID, p.ID, p.Attempts, retryAt.Format(time.RFC3339), err)
+	} else {
+		uc.l.Errorf(ctx, "lifecycle.usecase.finish: %s/%s purge %s failed after %d attempts: %v",
+			p.Scope, p.ScopeID, p.ID, p.Attempts, err)
+	}
+	if err := uc.repo.FailPurge(ctx, opt); err != nil {
+		uc.l.Errorf(ctx, "lifecycle.usecase.finish: FailPurge %s failed: %v", p.ID, err)
+	}
+}
+
+// retryBackoff - 2^attempts minutes, capped at maxRetryBackoff
+func retryBackoff(attempts int) time.Duration {
+	if attempts > 6 {
+		return maxRetryBackoff
+	}
+	d := time.Duration(1<<attempts) * time.Minute
+	if d > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return d
+}
@@ -20,6 +20,20 @@ type Conversation struct {
 	LastMessageAt *time.Time
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	// Rolling memory of the turns older than the recent history window
+	MemorySummary       string
+	MemoryKeyFacts      []string
+	MemoryActiveFilters json.RawMessage
+	MemoryMessageCount  int // messages [0, n) of the active branch are covered by the summary
+	MemoryUpdatedAt     *time.Time
+
+	// ActiveLeafID is the last message of the branch being shown, empty before the first turn
+	ActiveLeafID string
+
+	// CampaignIDs are every campaign the conversation involved, CampaignID first.
+	// Comparative turns add the campaigns they compare.
+	CampaignIDs []string
 }
 
 // NewConversationFromDB converts a SQLBoiler Conversation to model Conversation
@@ -35,6 +49,12 @@ func NewConversationFromDB(db *sqlboiler.Conversation) *Conversation {
 		Title:        db.Title,
 		Status:       db.Status,
 		MessageCount: db.MessageCount,
+
+		MemorySummary:      db.MemorySummary.String,
+		MemoryMessageCount: db.MemoryMessageCount,
+
+		ActiveLeafID: db.ActiveLeafID.String,
+		CampaignIDs:  db.CampaignIds,
 	}
 
 	// Handle nullable fields
@@ -47,6 +67,15 @@ func NewConversationFromDB(db *sqlboiler.Conversation) *Conversation {
 	if db.UpdatedAt.Valid {
 		conv.UpdatedAt = db.UpdatedAt.Time
 	}
+	if db.MemoryKeyFacts.Valid {
+		_ = json.Unmarshal(db.MemoryKeyFacts.JSON, &conv.MemoryKeyFacts)
+	}
+	if db.MemoryActiveFilters.Valid {
+		conv.MemoryActiveFilters = json.RawMessage(db.MemoryActiveFilters.JSON)
+	}
+	if db.MemoryUpdatedAt.Valid {
+		conv.MemoryUpdatedAt = &db.MemoryUpdatedAt.Time
+	}
 
 	return conv
 }
@@ -60,12 +89,33 @@ func (c *Conversation) ToDBConversation() *sqlboiler.Conversation {
 		Title:        c.Title,
 		Status:       c.Status,
 		MessageCount: c.MessageCount,
+
+		MemoryMessageCount: c.MemoryMessageCount,
+
+		CampaignIds: c.CampaignIDs,
 	}
 
 	// Handle nullable fields
 	if c.LastMessageAt != nil {
 		db.LastMessageAt = null.TimeFrom(*c.LastMessageAt)
 	}
+	if c.MemorySummary != "" {
+		db.MemorySummary = null.StringFrom(c.MemorySummary)
+	}
+	if len(c.MemoryKeyFacts) > 0 {
+		if facts, err := json.Marshal(c.MemoryKeyFacts); err == nil {
+			db.MemoryKeyFacts = null.JSONFrom(facts)
+		}
+	}
+	if len(c.MemoryActiveFilters) > 0 && string(c.MemoryActiveFilters) != "null" {
+		db.MemoryActiveFilters = null.JSONFrom(c.MemoryActiveFilters)
+	}
+	if c.MemoryUpdatedAt != nil {
+		db.MemoryUpdatedAt = null.TimeFrom(*c.MemoryUpdatedAt)
+	}
+	if c.ActiveLeafID != "" {
+		db.ActiveLeafID = null.StringFrom(c.ActiveLeafID)
+	}
 	db.CreatedAt = null.TimeFrom(c.CreatedAt)
 	db.UpdatedAt = null.TimeFrom(c.UpdatedAt)
 
@@ -77,6 +127,7 @@ func (c *Conversation) ToDBConversation() *sqlboiler.Conversation {
 type ConversationJSON struct {
 	ID            string     `json:"id"`
 	CampaignID    string     `json:"campaign_id"`
+	CampaignIDs   []string   `json:"campaign_ids,omitempty"`
 	UserID        string     `json:"user_id"`
 	Title         string     `json:"title"`
 	Status        string     `json:"status"`
@@ -91,6 +142,7 @@ func (c *Conversation) ToJSON() ConversationJSON {
 	return ConversationJSON{
 		ID:            c.ID,
 		CampaignID:    c.CampaignID,
+		CampaignIDs:   c.CampaignIDs,
 		UserID:        c.UserID,
 		Title:         c.Title,
 		Status:        c.Status,
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// ErasureRequest is one author/username/URL deletion run and its receipt.
+type ErasureRequest struct {
+	ID          string         `json:"id"`
+	Kind        string         `json:"kind"` // AUTHOR | USERNAME | URL
+	Value       string         `json:"value"`
+	ValueNorm   string         `json:"value_norm"`
+	ProjectIDs  []string       `json:"project_ids"` // empty = every project
+	Reason      string         `json:"reason"`
+	RequestedBy string         `json:"requested_by"`
+	Removed     ErasureRemoved `json:"removed"`
+	CreatedAt   time.Time      `json:"created_at"`
+	CompletedAt *time.Time     `json:"completed_at"`
+}
+
+// ErasureRemoved counts what an erasure matched and removed per store (stored as JSONB).
+type ErasureRemoved struct {
+	// Qdrant
+	Collections        []ErasureCollection `json:"collections,omitempty"`
+	PointsDeleted      uint64              `json:"points_deleted,omitempty"`
+	InsightsUpdated    int64               `json:"insights_updated,omitempty"`    // macro_insights points whose evidence was rewritten
+	EvidenceReferences int64               `json:"evidence_references,omitempty"` // references removed from them
+
+	// Postgres
+	IndexedDocuments int64 `json:"indexed_documents,omitempty"`
+	DLQEntries       int64 `json:"dlq_entries,omitempty"`
+
+	// Redis
+	EmbeddingKeys       int64    `json:"embedding_keys,omitempty"`
+	SearchCacheProjects []string `json:"search_cache_projects,omitempty"`
+
+	TombstoneCreated bool `json:"tombstone_created,omitempty"` // false when the subject was already tombstoned
+}
+
+// ErasureCollection - Points matched (and deleted) in one collection
+type ErasureCollection struct {
+	Collection string `json:"collection"`
+	Points     uint64 `json:"points"`
+}
+
+// ErasureTombstone blocks re-ingestion of an erased author, username or URL.
+type ErasureTombstone struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	ValueNorm string    `json:"value_norm"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
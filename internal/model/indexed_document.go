@@ -22,6 +22,11 @@ type IndexedDocument struct {
 	// Content Hash (for deduplication)
 	ContentHash string `json:"content_hash"`
 
+	// Near-duplicate fingerprint (SimHash) and cluster membership
+	SimHash     *string `json:"simhash,omitempty"`
+	ClusterID   *string `json:"cluster_id,omitempty"`
+	DuplicateOf *string `json:"duplicate_of,omitempty"`
+
 	// Indexing Status
 	Status       string  `json:"status"`
 	ErrorMessage *string `json:"error_message,omitempty"`
@@ -51,7 +56,7 @@ func NewIndexedDocumentFromDB(db *sqlboiler.IndexedDocument) *IndexedDocument {
 		ID:             db.ID,
 		AnalyticsID:    db.AnalyticsID,
 		ProjectID:      db.ProjectID,
-		SourceID:       db.SourceID,
+		SourceID:       db.SourceID.String,
 		QdrantPointID:  db.QdrantPointID,
 		CollectionName: db.CollectionName,
 		ContentHash:    db.ContentHash,
@@ -86,6 +91,15 @@ func NewIndexedDocumentFromDB(db *sqlboiler.IndexedDocument) *IndexedDocument {
 	if db.UpdatedAt.Valid {
 		doc.UpdatedAt = db.UpdatedAt.Time
 	}
+	if db.Simhash.Valid {
+		doc.SimHash = &db.Simhash.String
+	}
+	if db.ClusterID.Valid {
+		doc.ClusterID = &db.ClusterID.String
+	}
+	if db.DuplicateOf.Valid {
+		doc.DuplicateOf = &db.DuplicateOf.String
+	}
 
 	return doc
 }
@@ -96,7 +110,7 @@ func (d *IndexedDocument) ToDBIndexedDocument() *sqlboiler.IndexedDocument {
 		ID:             d.ID,
 		AnalyticsID:    d.AnalyticsID,
 		ProjectID:      d.ProjectID,
-		SourceID:       d.SourceID,
+		SourceID:       null.NewString(d.SourceID, d.SourceID != ""),
 		QdrantPointID:  d.QdrantPointID,
 		CollectionName: d.CollectionName,
 		ContentHash:    d.ContentHash,
@@ -125,6 +139,15 @@ func (d *IndexedDocument) ToDBIndexedDocument() *sqlboiler.IndexedDocument {
 	}
 	db.CreatedAt = null.TimeFrom(d.CreatedAt)
 	db.UpdatedAt = null.TimeFrom(d.UpdatedAt)
+	if d.SimHash != nil {
+		db.Simhash = null.StringFrom(*d.SimHash)
+	}
+	if d.ClusterID != nil {
+		db.ClusterID = null.StringFrom(*d.ClusterID)
+	}
+	if d.DuplicateOf != nil {
+		db.DuplicateOf = null.StringFrom(*d.DuplicateOf)
+	}
 
 	return db
 }
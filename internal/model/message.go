@@ -13,6 +13,7 @@ import (
 type Message struct {
 	ID             string
 	ConversationID string
+	ParentID       string // previous message on the branch, empty for the first question
 	Role           string // "user" | "assistant"
 	Content        string
 	Citations      json.RawMessage
@@ -31,6 +32,7 @@ func NewMessageFromDB(db *sqlboiler.Message) *Message {
 	msg := &Message{
 		ID:             db.ID,
 		ConversationID: db.ConversationID,
+		ParentID:       db.ParentID.String,
 		Role:           db.Role,
 		Content:        db.Content,
 	}
@@ -63,6 +65,9 @@ func (m *Message) ToDBMessage() *sqlboiler.Message {
 		Role:           m.Role,
 		Content:        m.Content,
 	}
+	if m.ParentID != "" {
+		db.ParentID = null.StringFrom(m.ParentID)
+	}
 
 	// Handle nullable JSON fields
 	if len(m.Citations) > 0 && string(m.Citations) != "null" {
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// NearDuplicateCluster groups near-identical content (by SimHash) within a project.
+type NearDuplicateCluster struct {
+	ID                    string    `json:"id"`
+	ProjectID             string    `json:"project_id"`
+	RepresentativePointID string    `json:"representative_point_id"`
+	SimHash               string    `json:"simhash"`
+	MemberCount           int       `json:"member_count"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
@@ -0,0 +1,79 @@
+package model
+
+import "time"
+
+// PurgeRequest is one purge of a deleted project or campaign, and its audit record.
+type PurgeRequest struct {
+	ID           string       `json:"id"`
+	Scope        string       `json:"scope"` // PROJECT | CAMPAIGN
+	ScopeID      string       `json:"scope_id"`
+	CampaignIDs  []string     `json:"campaign_ids"`
+	ProjectIDs   []string     `json:"project_ids"`
+	Source       string       `json:"source"` // KAFKA | API
+	Reason       string       `json:"reason"`
+	Status       string       `json:"status"` // PENDING | RUNNING | COMPLETED | FAILED | CANCELLED
+	PurgeAfter   time.Time    `json:"purge_after"`
+	Attempts     int          `json:"attempts"`
+	ErrorMessage string       `json:"error_message"`
+	Removed      PurgeRemoved `json:"removed"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	StartedAt    *time.Time   `json:"started_at"`
+	CompletedAt  *time.Time   `json:"completed_at"`
+	CancelledAt  *time.Time   `json:"cancelled_at"`
+}
+
+// PurgeRemoved counts what a purge removed per store (stored as JSONB, cumulative across attempts).
+type PurgeRemoved struct {
+	// Qdrant
+	CollectionsDropped []string `json:"collections_dropped,omitempty"`
+	PointsDeleted      uint64   `json:"points_deleted,omitempty"`
+
+	// Postgres
+	IndexedDocuments      int64 `json:"indexed_documents,omitempty"`
+	DLQEntries            int64 `json:"dlq_entries,omitempty"`
+	NearDuplicateClusters int64 `json:"near_duplicate_clusters,omitempty"`
+	Backups               int64 `json:"backups,omitempty"`
+	Reports               int64 `json:"reports,omitempty"`
+	Conversations         int64 `json:"conversations,omitempty"`
+	QueryLogs             int64 `json:"query_logs,omitempty"`
+
+	// Redis
+	EmbeddingKeys       int64    `json:"embedding_keys,omitempty"`
+	SearchCacheProjects []string `json:"search_cache_projects,omitempty"`
+
+	// MinIO
+	Objects int64 `json:"objects,omitempty"` // backup snapshots and report files
+}
+
+// Add accumulates other into r.
+func (r *PurgeRemoved) Add(other PurgeRemoved) {
+	r.CollectionsDropped = appendUnique(r.CollectionsDropped, other.CollectionsDropped...)
+	r.PointsDeleted += other.PointsDeleted
+	r.IndexedDocuments += other.IndexedDocuments
+	r.DLQEntries += other.DLQEntries
+	r.NearDuplicateClusters += other.NearDuplicateClusters
+	r.Backups += other.Backups
+	r.Reports += other.Reports
+	r.Conversations += other.Conversations
+	r.QueryLogs += other.QueryLogs
+	r.EmbeddingKeys += other.EmbeddingKeys
+	r.SearchCacheProjects = appendUnique(r.SearchCacheProjects, other.SearchCacheProjects...)
+	r.Objects += other.Objects
+}
+
+func appendUnique(dst []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, d := range dst {
+			if d == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// QdrantBackup is one collection snapshot uploaded to MinIO.
+type QdrantBackup struct {
+	ID             string     `json:"id"`
+	RunID          string     `json:"run_id"`
+	CollectionName string     `json:"collection_name"`
+	ProjectID      string     `json:"project_id"` // empty for non-project collections
+	SnapshotName   string     `json:"snapshot_name"`
+	ObjectName     string     `json:"object_name"`
+	SizeBytes      int64      `json:"size_bytes"`
+	Checksum       string     `json:"checksum"`
+	Trigger        string     `json:"trigger"` // SCHEDULED | MANUAL
+	Status         string     `json:"status"`  // RUNNING | SUCCEEDED | FAILED | EXPIRED
+	ErrorMessage   string     `json:"error_message"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	ExpiredAt      *time.Time `json:"expired_at"`
+	RestoredAt     *time.Time `json:"restored_at"`
+}
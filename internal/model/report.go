@@ -34,6 +34,7 @@ type Report struct {
 	TotalDocsAnalyzed int
 	SectionsCount     int
 	GenerationTimeMs  int64
+	NumericCheck      json.RawMessage // fact-check of the report's figures
 
 	// Timestamps
 	CompletedAt *time.Time
@@ -74,6 +75,9 @@ func NewReportFromDB(db *sqlboiler.Report) *Report {
 	if db.Filters.Valid {
 		rpt.Filters = json.RawMessage(db.Filters.JSON)
 	}
+	if db.NumericCheck.Valid {
+		rpt.NumericCheck = json.RawMessage(db.NumericCheck.JSON)
+	}
 
 	// Handle nullable numeric fields
 	if db.FileSizeBytes.Valid {
@@ -129,6 +133,9 @@ func (r *Report) ToDBReport() *sqlboiler.Report {
 	if len(r.Filters) > 0 && string(r.Filters) != "null" {
 		db.Filters = null.JSONFrom(r.Filters)
 	}
+	if len(r.NumericCheck) > 0 && string(r.NumericCheck) != "null" {
+		db.NumericCheck = null.JSONFrom(r.NumericCheck)
+	}
 	if r.FileSizeBytes > 0 {
 		db.FileSizeBytes = null.Int64From(r.FileSizeBytes)
 	}
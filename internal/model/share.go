@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Share grants read access to a conversation or report its owner does not hold alone.
+type Share struct {
+	ID            string     `json:"id"`
+	ResourceType  string     `json:"resource_type"` // CONVERSATION | REPORT
+	ResourceID    string     `json:"resource_id"`
+	CampaignID    string     `json:"campaign_id"`
+	GranteeType   string     `json:"grantee_type"`    // USER | CAMPAIGN | LINK
+	GranteeUserID string     `json:"grantee_user_id"` // USER grants only
+	TokenHash     string     `json:"-"`               // LINK grants only; the token itself is never stored
+	CreatedBy     string     `json:"created_by"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// IsActive reports whether the share still grants access at now.
+func (s Share) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && (s.ExpiresAt == nil || now.Before(*s.ExpiresAt))
+}
@@ -1,14 +1,106 @@
 package point
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
 
 const (
 	CollectionAnalyticsLegacy = "smap_analytics"
 	CollectionMacroInsights   = "macro_insights"
+	// CollectionSharedPosts holds every project's posts in the shared layout.
+	CollectionSharedPosts = "knowledge_posts"
+
+	projectCollectionPrefix = "proj_"
+)
+
+// Collection layouts: one proj_{id} collection per project, or a single shared
+// collection partitioned by the project_id tenant index.
+const (
+	LayoutPerProject = "per_project"
+	LayoutShared     = "shared"
+)
+
+// Collection profile names (size tiers) and quantization modes.
+const (
+	ProfileSmall  = "small"
+	ProfileMedium = "medium"
+	ProfileLarge  = "large"
+
+	QuantizationNone   = "none"
+	QuantizationScalar = "scalar"
+	QuantizationBinary = "binary"
 )
 
-// CollectionForProject returns the Qdrant collection name for a given project.
-// This matches the naming used by the indexing pipeline (index_batch.go).
+// CollectionForProject returns the per-project collection name of a project.
+// Callers should go through UseCase.CollectionForProject, which honours the configured layout.
 func CollectionForProject(projectID string) string {
-	return fmt.Sprintf("proj_%s", projectID)
+	return fmt.Sprintf("%s%s", projectCollectionPrefix, projectID)
+}
+
+// ProjectIDFromCollection is the inverse of CollectionForProject.
+func ProjectIDFromCollection(name string) (string, bool) {
+	projectID, found := strings.CutPrefix(name, projectCollectionPrefix)
+	return projectID, found && projectID != ""
+}
+
+// SourceIDFields are the payload fields holding the ID of the record a post point was
+// indexed from (analytics posts, then insight documents).
+var SourceIDFields = []string{"analytics_id", "uap_id"}
+
+// SharedPointID returns the ID of a project's point in the shared collection: a UUIDv5
+// of the source ID in the project's namespace, so the same post indexed by two projects
+// gets two points. Callers should go through UseCase.PointIDForProject.
+func SharedPointID(projectID, sourceID string) string {
+	namespace, err := uuid.Parse(projectID)
+	if err != nil {
+		namespace = uuid.NewSHA1(uuid.NameSpaceOID, []byte(projectID))
+	}
+	return uuid.NewSHA1(namespace, []byte(sourceID)).String()
+}
+
+// EntityKey builds the flattened "TYPE:value" key stored in the entity_keys payload
+// field, so entity facets return type and value together. Values are matched
+// case-insensitively via NormalizeEntityValue.
+func EntityKey(entityType, value string) string {
+	return strings.ToUpper(strings.TrimSpace(entityType)) + ":" + NormalizeEntityValue(value)
+}
+
+// SplitEntityKey is the inverse of EntityKey.
+func SplitEntityKey(key string) (entityType, value string) {
+	entityType, value, found := strings.Cut(key, ":")
+	if !found {
+		return "", key
+	}
+	return entityType, value
+}
+
+// NormalizeEntityValue lowercases and collapses whitespace for entity matching.
+func NormalizeEntityValue(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+// IsManagedCollection reports whether the collection follows a declared payload
+// schema (per-project and shared post collections, macro_insights).
+func IsManagedCollection(name string) bool {
+	return name == CollectionMacroInsights || name == CollectionSharedPosts || strings.HasPrefix(name, projectCollectionPrefix)
+}
+
+// IsValidQuantization reports whether q is a supported quantization mode ("" = none).
+func IsValidQuantization(q string) bool {
+	switch q {
+	case "", QuantizationNone, QuantizationScalar, QuantizationBinary:
+		return true
+	default:
+		return false
+	}
+}
+
+func normalizeQuantization(q string) string {
+	if q == "" {
+		return QuantizationNone
+	}
+	return q
 }
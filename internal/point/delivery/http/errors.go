@@ -0,0 +1,53 @@
+package http
+
+import (
+	"errors"
+	"knowledge-srv/internal/point"
+
+	pkgErrors "github.com/smap-hcmut/shared-libs/go/errors"
+)
+
+var (
+	errForbidden = pkgErrors.NewHTTPError(
+		403, "Forbidden",
+	)
+	errSchemaReconcile = pkgErrors.NewHTTPError(
+		500, "Failed to inspect or reconcile collection payload schema",
+	)
+	errUnknownProfile = pkgErrors.NewHTTPError(
+		400, "Unknown collection profile",
+	)
+	errNotManaged = pkgErrors.NewHTTPError(
+		400, "Collection is not a managed collection",
+	)
+	errApplyProfile = pkgErrors.NewHTTPError(
+		500, "Failed to apply collection profile",
+	)
+	errNotProject = pkgErrors.NewHTTPError(
+		400, "Collection is not a per-project collection",
+	)
+	errMigrateShared = pkgErrors.NewHTTPError(
+		500, "Failed to migrate collections to the shared collection",
+	)
+)
+
+func (h *handler) mapError(err error) error {
+	switch {
+	case errors.Is(err, point.ErrForbidden):
+		return errForbidden
+	case errors.Is(err, point.ErrSchemaReconcile):
+		return errSchemaReconcile
+	case errors.Is(err, point.ErrUnknownProfile):
+		return errUnknownProfile
+	case errors.Is(err, point.ErrNotManaged):
+		return errNotManaged
+	case errors.Is(err, point.ErrApplyProfile):
+		return errApplyProfile
+	case errors.Is(err, point.ErrNotProject):
+		return errNotProject
+	case errors.Is(err, point.ErrMigrateShared):
+		return errMigrateShared
+	default:
+		return pkgErrors.NewHTTPError(500, "Internal server error")
+	}
+}
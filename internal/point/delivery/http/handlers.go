@@ -0,0 +1,116 @@
+package http
+
+import (
+	"knowledge-srv/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/auth"
+	"github.com/smap-hcmut/shared-libs/go/response"
+)
+
+// SchemaDrift - Payload index drift per collection
+// @Summary Payload schema drift
+// @Description Compare the payload indexes of every managed Qdrant collection with the declared payload schema. Admin only.
+// @Tags Collections
+// @Produce json
+// @Success 200 {object} schemaDriftResp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /collections/schema/drift [get]
+func (h *handler) SchemaDrift(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	sc := model.ToScope(auth.GetScopeFromContext(ctx))
+	output, err := h.uc.SchemaDrift(ctx, sc)
+	if err != nil {
+		h.l.Errorf(ctx, "point.delivery.http.SchemaDrift: usecase SchemaDrift failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newSchemaDriftResp(output))
+}
+
+// ReconcileSchemas - Handler cho POST /internal/collections/schema/reconcile
+// @Summary Reconcile payload indexes
+// @Description Create missing or mismatched payload indexes on every managed collection (migrates old collections after schema changes)
+// @Tags Collections (Internal)
+// @Produce json
+// @Success 200 {object} reconcileSchemasResp
+// @Failure 500 {object} response.Resp
+// @Router /internal/collections/schema/reconcile [post]
+func (h *handler) ReconcileSchemas(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	output, err := h.uc.ReconcileSchemas(ctx)
+	if err != nil {
+		h.l.Errorf(ctx, "point.delivery.http.ReconcileSchemas: usecase ReconcileSchemas failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newReconcileSchemasResp(output))
+}
+
+// ApplyProfile - Apply a collection profile to existing collections
+// @Summary Apply collection profile
+// @Description Apply quantization, on-disk storage and HNSW settings to existing managed collections. Without "profile" each collection gets the profile of its size tier (by point count); without "collections" every managed collection is processed. Admin only.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param body body applyProfileReq true "Profile and target collections"
+// @Success 200 {object} applyProfileResp
+// @Failure 400 {object} response.Resp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /collections/profile/apply [post]
+func (h *handler) ApplyProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processApplyProfileRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "point.delivery.http.ApplyProfile: processApplyProfileRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.ApplyProfile(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "point.delivery.http.ApplyProfile: usecase ApplyProfile failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newApplyProfileResp(output))
+}
+
+// MigrateToShared - Handler cho POST /internal/collections/migrate-shared
+// @Summary Migrate per-project collections to the shared collection
+// @Description Copy proj_* collections (vectors, payload, point IDs) into the shared tenant-partitioned collection and verify counts; optionally drop each verified source. Safe to re-run. Switch qdrant.layout to "shared" once every collection is migrated.
+// @Tags Collections (Internal)
+// @Accept json
+// @Produce json
+// @Param body body migrateSharedReq false "Collections to migrate (empty = all)"
+// @Success 200 {object} migrateSharedResp
+// @Failure 400 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /internal/collections/migrate-shared [post]
+func (h *handler) MigrateToShared(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, err := h.processMigrateSharedRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "point.delivery.http.MigrateToShared: processMigrateSharedRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.MigrateToShared(ctx, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "point.delivery.http.MigrateToShared: usecase MigrateToShared failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newMigrateSharedResp(output))
+}
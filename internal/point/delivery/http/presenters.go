@@ -0,0 +1,189 @@
+package http
+
+import "knowledge-srv/internal/point"
+
+type schemaFieldResp struct {
+	Field     string `json:"field"`
+	Type      string `json:"type"`
+	Tokenizer string `json:"tokenizer,omitempty"`
+}
+
+type schemaMismatchResp struct {
+	Field             string `json:"field"`
+	ExpectedType      string `json:"expected_type"`
+	ActualType        string `json:"actual_type"`
+	ExpectedTokenizer string `json:"expected_tokenizer,omitempty"`
+	ActualTokenizer   string `json:"actual_tokenizer,omitempty"`
+	ExpectedTenant    bool   `json:"expected_tenant"`
+	ActualTenant      bool   `json:"actual_tenant"`
+}
+
+type collectionDriftResp struct {
+	Collection string               `json:"collection"`
+	InSync     bool                 `json:"in_sync"`
+	Missing    []schemaFieldResp    `json:"missing"`
+	Mismatched []schemaMismatchResp `json:"mismatched"`
+	Unexpected []string             `json:"unexpected"`
+}
+
+type schemaDriftResp struct {
+	Collections []collectionDriftResp `json:"collections"`
+	Drifted     int                   `json:"drifted"`
+}
+
+type reconcileSchemasResp struct {
+	Collections    int      `json:"collections"`
+	IndexesCreated int      `json:"indexes_created"`
+	Failed         []string `json:"failed"`
+}
+
+type applyProfileReq struct {
+	Collections []string `json:"collections"`
+	Profile     string   `json:"profile"` // empty = by size tier
+	DryRun      bool     `json:"dry_run"`
+}
+
+func (r applyProfileReq) toInput() point.ApplyProfileInput {
+	return point.ApplyProfileInput{
+		Collections: r.Collections,
+		Profile:     r.Profile,
+		DryRun:      r.DryRun,
+	}
+}
+
+type collectionProfileResultResp struct {
+	Collection  string `json:"collection"`
+	PointsCount uint64 `json:"points_count"`
+	Profile     string `json:"profile"`
+	Changed     bool   `json:"changed"`
+	Error       string `json:"error,omitempty"`
+}
+
+type applyProfileResp struct {
+	Results   []collectionProfileResultResp `json:"results"`
+	Applied   int                           `json:"applied"`
+	Unchanged int                           `json:"unchanged"`
+	Failed    int                           `json:"failed"`
+	DryRun    bool                          `json:"dry_run"`
+}
+
+type migrateSharedReq struct {
+	Collections  []string `json:"collections"` // empty = every proj_* collection
+	DeleteSource bool     `json:"delete_source"`
+	DryRun       bool     `json:"dry_run"`
+}
+
+func (r migrateSharedReq) toInput() point.MigrateToSharedInput {
+	return point.MigrateToSharedInput{
+		Collections:  r.Collections,
+		DeleteSource: r.DeleteSource,
+		DryRun:       r.DryRun,
+	}
+}
+
+type collectionMigrationResp struct {
+	Collection    string `json:"collection"`
+	ProjectID     string `json:"project_id"`
+	SourcePoints  uint64 `json:"source_points"`
+	Copied        uint64 `json:"copied"`
+	TargetPoints  uint64 `json:"target_points"`
+	SourceDeleted bool   `json:"source_deleted"`
+	Error         string `json:"error,omitempty"`
+}
+
+type migrateSharedResp struct {
+	Target   string                    `json:"target"`
+	Results  []collectionMigrationResp `json:"results"`
+	Migrated int                       `json:"migrated"`
+	Failed   int                       `json:"failed"`
+	DryRun   bool                      `json:"dry_run"`
+}
+
+func (h *handler) newSchemaDriftResp(o point.SchemaDriftOutput) schemaDriftResp {
+	resp := schemaDriftResp{
+		Collections: make([]collectionDriftResp, len(o.Collections)),
+		Drifted:     o.Drifted,
+	}
+	for i, d := range o.Collections {
+		item := collectionDriftResp{
+			Collection: d.Collection,
+			InSync:     d.InSync(),
+			Missing:    make([]schemaFieldResp, len(d.Missing)),
+			Mismatched: make([]schemaMismatchResp, len(d.Mismatched)),
+			Unexpected: d.Unexpected,
+		}
+		for j, f := range d.Missing {
+			item.Missing[j] = schemaFieldResp{Field: f.Name, Type: string(f.Type), Tokenizer: f.Tokenizer}
+		}
+		for j, m := range d.Mismatched {
+			item.Mismatched[j] = schemaMismatchResp{
+				Field:             m.Field,
+				ExpectedType:      string(m.ExpectedType),
+				ActualType:        string(m.ActualType),
+				ExpectedTokenizer: m.ExpectedTokenizer,
+				ActualTokenizer:   m.ActualTokenizer,
+				ExpectedTenant:    m.ExpectedTenant,
+				ActualTenant:      m.ActualTenant,
+			}
+		}
+		if item.Unexpected == nil {
+			item.Unexpected = []string{}
+		}
+		resp.Collections[i] = item
+	}
+	return resp
+}
+
+func (h *handler) newReconcileSchemasResp(o point.ReconcileSchemasOutput) reconcileSchemasResp {
+	resp := reconcileSchemasResp{
+		Collections:    o.Collections,
+		IndexesCreated: o.IndexesCreated,
+		Failed:         o.Failed,
+	}
+	if resp.Failed == nil {
+		resp.Failed = []string{}
+	}
+	return resp
+}
+
+func (h *handler) newApplyProfileResp(o point.ApplyProfileOutput) applyProfileResp {
+	resp := applyProfileResp{
+		Results:   make([]collectionProfileResultResp, len(o.Results)),
+		Applied:   o.Applied,
+		Unchanged: o.Unchanged,
+		Failed:    o.Failed,
+		DryRun:    o.DryRun,
+	}
+	for i, r := range o.Results {
+		resp.Results[i] = collectionProfileResultResp{
+			Collection:  r.Collection,
+			PointsCount: r.PointsCount,
+			Profile:     r.Profile,
+			Changed:     r.Changed,
+			Error:       r.Error,
+		}
+	}
+	return resp
+}
+
+func (h *handler) newMigrateSharedResp(o point.MigrateToSharedOutput) migrateSharedResp {
+	resp := migrateSharedResp{
+		Target:   o.Target,
+		Results:  make([]collectionMigrationResp, len(o.Results)),
+		Migrated: o.Migrated,
+		Failed:   o.Failed,
+		DryRun:   o.DryRun,
+	}
+	for i, r := range o.Results {
+		resp.Results[i] = collectionMigrationResp{
+			Collection:    r.Collection,
+			ProjectID:     r.ProjectID,
+			SourcePoints:  r.SourcePoints,
+			Copied:        r.Copied,
+			TargetPoints:  r.TargetPoints,
+			SourceDeleted: r.SourceDeleted,
+			Error:         r.Error,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"knowledge-srv/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/auth"
+)
+
+func (h *handler) processApplyProfileRequest(c *gin.Context) (applyProfileReq, model.Scope, error) {
+	var req applyProfileReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, model.Scope{}, err
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processMigrateSharedRequest(c *gin.Context) (migrateSharedReq, error) {
+	var req migrateSharedReq
+
+	// Empty body = migrate every per-project collection
+	if c.Request.ContentLength == 0 {
+		return req, nil
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
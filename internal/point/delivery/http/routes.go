@@ -0,0 +1,22 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup, mw *middleware.Middleware) {
+	admin := r.Group("/collections")
+	admin.Use(mw.Auth())
+	{
+		admin.GET("/schema/drift", h.SchemaDrift)
+		admin.POST("/profile/apply", h.ApplyProfile)
+	}
+
+	internal := r.Group("/internal")
+	internal.Use(mw.InternalAuth())
+	{
+		internal.POST("/collections/schema/reconcile", h.ReconcileSchemas)
+		internal.POST("/collections/migrate-shared", h.MigrateToShared)
+	}
+}
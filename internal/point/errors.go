@@ -0,0 +1,16 @@
+package point
+
+import "errors"
+
+var (
+	ErrForbidden       = errors.New("point: forbidden")
+	ErrSchemaReconcile = errors.New("point: payload schema reconcile failed")
+	ErrUnknownProfile  = errors.New("point: unknown collection profile")
+	ErrInvalidProfile  = errors.New("point: invalid collection profile")
+	ErrNotManaged      = errors.New("point: collection is not managed")
+	ErrApplyProfile    = errors.New("point: apply collection profile failed")
+	ErrNotProject      = errors.New("point: not a per-project collection")
+	ErrMigrateShared   = errors.New("point: migrate to shared collection failed")
+	ErrPurgeProject    = errors.New("point: purge project failed")
+	ErrPurgeCampaign   = errors.New("point: purge campaign failed")
+)
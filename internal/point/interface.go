@@ -12,6 +12,34 @@ type UseCase interface {
 	Count(ctx context.Context, input CountInput) (uint64, error)
 	Delete(ctx context.Context, input DeleteInput) error
 	Scroll(ctx context.Context, input ScrollInput) ([]model.Point, error)
+	// ScrollPage reads one page of at most Limit points from Offset, with the next-page cursor
+	ScrollPage(ctx context.Context, input ScrollInput) (ScrollPageOutput, error)
+	// SetPayload overwrites the given payload keys of one point
+	SetPayload(ctx context.Context, input SetPayloadInput) error
 	Facet(ctx context.Context, input FacetInput) ([]FacetOutput, error)
 	EnsureCollection(ctx context.Context, name string, vectorSize uint64) error
+	ListCollections(ctx context.Context) ([]string, error)
+
+	// CollectionForProject returns the collection holding a project's posts under the configured layout.
+	CollectionForProject(projectID string) string
+	// PointIDForProject returns the point ID of a project's source record under the configured
+	// layout; the source ID itself stays in the payload.
+	PointIDForProject(projectID, sourceID string) string
+	// CollectionTargets groups projects by collection; use CollectionTarget.Scope on every filter.
+	CollectionTargets(projectIDs []string) []CollectionTarget
+	// PurgeProject removes every vector of a project: drops its proj_* collection and deletes its
+	// points from the shared post collection and macro_insights. Safe to re-run.
+	PurgeProject(ctx context.Context, projectID string) (PurgeProjectOutput, error)
+	// PurgeCampaign deletes a campaign's macro digests and insight cards from macro_insights. Safe to re-run.
+	PurgeCampaign(ctx context.Context, campaignID string) (PurgeCampaignOutput, error)
+	// MigrateToShared copies per-project collections into the shared collection
+	MigrateToShared(ctx context.Context, input MigrateToSharedInput) (MigrateToSharedOutput, error)
+
+	// ReconcileSchemas brings the payload indexes of every managed collection in line
+	// with the declared schema (run on startup and when new indexed fields are added).
+	ReconcileSchemas(ctx context.Context) (ReconcileSchemasOutput, error)
+	// SchemaDrift reports per-collection payload index drift (admin only)
+	SchemaDrift(ctx context.Context, sc model.Scope) (SchemaDriftOutput, error)
+	// ApplyProfile applies a collection profile (explicit or by size tier) to existing collections (admin only)
+	ApplyProfile(ctx context.Context, sc model.Scope, input ApplyProfileInput) (ApplyProfileOutput, error)
 }
@@ -14,6 +14,23 @@ type QdrantRepository interface {
 	Count(ctx context.Context, opt CountOptions) (uint64, error)
 	Delete(ctx context.Context, opt DeleteOptions) error
 	Scroll(ctx context.Context, opt ScrollOptions) ([]model.Point, error)
+	// ScrollPage reads one page from opt.Offset; the returned cursor is nil on the last page.
+	ScrollPage(ctx context.Context, opt ScrollOptions) ([]model.Point, *string, error)
+	SetPayload(ctx context.Context, opt SetPayloadOptions) error
 	Facet(ctx context.Context, opt FacetOptions) ([]point.FacetOutput, error)
-	EnsureCollection(ctx context.Context, name string, vectorSize uint64) error
+	// EnsureCollection creates the collection with the given profile when it does not exist.
+	EnsureCollection(ctx context.Context, name string, vectorSize uint64, profile point.CollectionProfile) error
+	// CollectionStats returns the point count and currently applied profile of a collection.
+	CollectionStats(ctx context.Context, name string) (point.CollectionStats, error)
+	// ApplyProfile updates quantization, storage and HNSW settings of an existing collection.
+	ApplyProfile(ctx context.Context, name string, profile point.CollectionProfile) error
+	// CopyCollection copies every point of a collection into another, keeping point IDs unless
+	// PointID is set; returns points copied.
+	CopyCollection(ctx context.Context, opt CopyCollectionOptions) (uint64, error)
+	DeleteCollection(ctx context.Context, name string) error
+	ListCollections(ctx context.Context) ([]string, error)
+	// SchemaDrift compares a collection's payload indexes with its declared schema.
+	SchemaDrift(ctx context.Context, name string) (point.CollectionSchemaDrift, error)
+	// ReconcileSchema creates missing or mismatched payload indexes; returns how many were created.
+	ReconcileSchema(ctx context.Context, name string) (int, error)
 }
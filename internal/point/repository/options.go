@@ -2,6 +2,7 @@ package repository
 
 import (
 	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
 
 	"github.com/qdrant/go-client/qdrant"
 )
@@ -12,7 +13,9 @@ type SearchOptions struct {
 	Filter         *qdrant.Filter
 	Limit          uint64
 	WithPayload    bool
+	WithVectors    bool
 	ScoreThreshold float32
+	Params         point.SearchParams
 }
 
 type UpsertOptions struct {
@@ -39,9 +42,24 @@ type ScrollOptions struct {
 	Offset         *string
 }
 
+type SetPayloadOptions struct {
+	CollectionName string
+	PointID        string
+	Payload        map[string]interface{}
+}
+
 type FacetOptions struct {
 	CollectionName string
 	Key            string
 	Filter         *qdrant.Filter
 	Limit          uint64
 }
+
+type CopyCollectionOptions struct {
+	Source    string
+	Target    string
+	Payload   map[string]interface{} // written into every copied point
+	BatchSize uint32
+	// PointID returns the target ID of a copied point from its source ID and payload (nil = keep IDs)
+	PointID func(id string, payload map[string]interface{}) string
+}
@@ -3,25 +3,16 @@ package qdrant
 import (
 	"context"
 	"fmt"
+	"sort"
+
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/point/repository"
+	pkgQdrant "knowledge-srv/pkg/qdrant"
 
 	pb "github.com/qdrant/go-client/qdrant"
 )
 
-// analyticsPayloadIndexes lists all fields that need payload indexes for faceting and filtering.
-// keyword indexes are required for Facet queries; float index for range filters on timestamps.
-var analyticsPayloadIndexes = []struct {
-	field     string
-	fieldType pb.FieldType
-}{
-	{"platform", pb.FieldType_FieldTypeKeyword},
-	{"overall_sentiment", pb.FieldType_FieldTypeKeyword},
-	{"sentiment_label", pb.FieldType_FieldTypeKeyword},
-	{"risk_level", pb.FieldType_FieldTypeKeyword},
-	{"aspects.aspect", pb.FieldType_FieldTypeKeyword},
-	{"content_created_at", pb.FieldType_FieldTypeFloat},
-}
-
-func (r *implRepository) EnsureCollection(ctx context.Context, name string, vectorSize uint64) error {
+func (r *implRepository) EnsureCollection(ctx context.Context, name string, vectorSize uint64, profile point.CollectionProfile) error {
 	exists, err := r.client.CollectionExists(ctx, name)
 	if err != nil {
 		r.l.Errorf(ctx, "point.repository.qdrant.EnsureCollection: failed to check collection %s: %v", name, err)
@@ -29,30 +20,255 @@ func (r *implRepository) EnsureCollection(ctx context.Context, name string, vect
 	}
 
 	if !exists {
-		r.l.Infof(ctx, "point.repository.qdrant.EnsureCollection: creating collection %s (vectorSize=%d)", name, vectorSize)
-		if err := r.client.CreateCollection(ctx, name, vectorSize, pb.Distance_Cosine); err != nil {
+		r.l.Infof(ctx, "point.repository.qdrant.EnsureCollection: creating collection %s (vectorSize=%d, profile=%s)", name, vectorSize, profile.Name)
+		if err := r.client.CreateCollectionWithProfile(ctx, name, vectorSize, pb.Distance_Cosine, toPkgProfile(profile)); err != nil {
 			r.l.Errorf(ctx, "point.repository.qdrant.EnsureCollection: failed to create collection %s: %v", name, err)
 			return err
 		}
 		r.l.Infof(ctx, "point.repository.qdrant.EnsureCollection: collection %s created successfully", name)
 	}
 
-	// Always ensure payload indexes exist (idempotent — safe to call on existing indexes).
-	if err := r.ensurePayloadIndexes(ctx, name); err != nil {
+	// Bring payload indexes in line with the declared schema (no-op when in sync).
+	if _, err := r.ReconcileSchema(ctx, name); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// ensurePayloadIndexes creates all required payload indexes for the collection.
-// Qdrant silently accepts duplicate CreateFieldIndex calls, so this is idempotent.
-func (r *implRepository) ensurePayloadIndexes(ctx context.Context, name string) error {
-	for _, idx := range analyticsPayloadIndexes {
-		if err := r.client.CreateFieldIndex(ctx, name, idx.field, idx.fieldType); err != nil {
-			return fmt.Errorf("point.repository.qdrant.ensurePayloadIndexes: failed to create index %s on %s: %w", idx.field, name, err)
+func (r *implRepository) CollectionStats(ctx context.Context, name string) (point.CollectionStats, error) {
+	info, err := r.client.GetCollectionInfo(ctx, name)
+	if err != nil {
+		r.l.Errorf(ctx, "point.repository.qdrant.CollectionStats: failed to get collection %s: %v", name, err)
+		return point.CollectionStats{}, err
+	}
+	return point.CollectionStats{
+		VectorSize:  info.VectorSize,
+		PointsCount: info.PointsCount,
+		Profile: point.CollectionProfile{
+			OnDiskVectors:         info.Profile.OnDiskVectors,
+			OnDiskPayload:         info.Profile.OnDiskPayload,
+			HnswM:                 info.Profile.HnswM,
+			HnswEfConstruct:       info.Profile.HnswEfConstruct,
+			IndexingThreshold:     info.Profile.IndexingThreshold,
+			Quantization:          info.Profile.Quantization,
+			QuantizationAlwaysRAM: info.Profile.QuantizationAlwaysRAM,
+		},
+	}, nil
+}
+
+func (r *implRepository) ApplyProfile(ctx context.Context, name string, profile point.CollectionProfile) error {
+	if err := r.client.UpdateCollectionProfile(ctx, name, toPkgProfile(profile)); err != nil {
+		r.l.Errorf(ctx, "point.repository.qdrant.ApplyProfile: failed to apply profile %s to %s: %v", profile.Name, name, err)
+		return err
+	}
+	r.l.Infof(ctx, "point.repository.qdrant.ApplyProfile: applied profile %s to %s", profile.Name, name)
+	return nil
+}
+
+func (r *implRepository) CopyCollection(ctx context.Context, opt repository.CopyCollectionOptions) (uint64, error) {
+	var (
+		copied uint64
+		offset *pb.PointId
+	)
+	for {
+		n, next, err := r.client.CopyPoints(ctx, opt.Source, opt.Target, opt.BatchSize, offset, opt.Payload, opt.PointID)
+		if err != nil {
+			r.l.Errorf(ctx, "point.repository.qdrant.CopyCollection: %s -> %s after %d points: %v", opt.Source, opt.Target, copied, err)
+			return copied, err
 		}
+		copied += uint64(n)
+		if next == nil || n == 0 {
+			return copied, nil
+		}
+		offset = next
+	}
+}
+
+func (r *implRepository) DeleteCollection(ctx context.Context, name string) error {
+	if err := r.client.DeleteCollection(ctx, name); err != nil {
+		r.l.Errorf(ctx, "point.repository.qdrant.DeleteCollection: failed to delete %s: %v", name, err)
+		return err
 	}
-	r.l.Infof(ctx, "point.repository.qdrant.ensurePayloadIndexes: ensured %d payload indexes on %s", len(analyticsPayloadIndexes), name)
 	return nil
 }
+
+func toPkgProfile(p point.CollectionProfile) pkgQdrant.CollectionProfile {
+	return pkgQdrant.CollectionProfile{
+		OnDiskVectors:         p.OnDiskVectors,
+		OnDiskPayload:         p.OnDiskPayload,
+		HnswM:                 p.HnswM,
+		HnswEfConstruct:       p.HnswEfConstruct,
+		IndexingThreshold:     p.IndexingThreshold,
+		Quantization:          p.Quantization,
+		QuantizationAlwaysRAM: p.QuantizationAlwaysRAM,
+	}
+}
+
+func (r *implRepository) ListCollections(ctx context.Context) ([]string, error) {
+	names, err := r.client.ListCollections(ctx)
+	if err != nil {
+		r.l.Errorf(ctx, "point.repository.qdrant.ListCollections: failed to list collections: %v", err)
+		return nil, err
+	}
+	return names, nil
+}
+
+// SchemaDrift diffs the collection's existing payload indexes against point.PayloadSchemaFor.
+func (r *implRepository) SchemaDrift(ctx context.Context, name string) (point.CollectionSchemaDrift, error) {
+	info, err := r.client.GetCollectionInfo(ctx, name)
+	if err != nil {
+		r.l.Errorf(ctx, "point.repository.qdrant.SchemaDrift: failed to get collection %s: %v", name, err)
+		return point.CollectionSchemaDrift{}, err
+	}
+
+	drift := point.CollectionSchemaDrift{Collection: name}
+	declared := make(map[string]struct{})
+	for _, field := range point.IndexedFields(point.PayloadSchemaFor(name)) {
+		declared[field.Name] = struct{}{}
+		existing, ok := info.PayloadSchema[field.Name]
+		if !ok {
+			drift.Missing = append(drift.Missing, field)
+			continue
+		}
+		actualType := payloadFieldTypeFromSchema(existing.DataType)
+		actualTokenizer := tokenizerFromSchema(existing.Tokenizer)
+		if actualType != field.Type ||
+			(field.Type == point.PayloadText && actualTokenizer != field.Tokenizer) ||
+			(field.Type == point.PayloadKeyword && existing.IsTenant != field.Tenant) {
+			drift.Mismatched = append(drift.Mismatched, point.SchemaFieldMismatch{
+				Field:             field.Name,
+				ExpectedType:      field.Type,
+				ActualType:        actualType,
+				ExpectedTokenizer: field.Tokenizer,
+				ActualTokenizer:   actualTokenizer,
+				ExpectedTenant:    field.Tenant,
+				ActualTenant:      existing.IsTenant,
+			})
+		}
+	}
+	for field := range info.PayloadSchema {
+		if _, ok := declared[field]; !ok {
+			drift.Unexpected = append(drift.Unexpected, field)
+		}
+	}
+	sort.Strings(drift.Unexpected)
+
+	return drift, nil
+}
+
+// ReconcileSchema creates every missing or mismatched payload index of the collection.
+// Qdrant replaces an index re-created with a different type, so both cases are one call.
+func (r *implRepository) ReconcileSchema(ctx context.Context, name string) (int, error) {
+	drift, err := r.SchemaDrift(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	if drift.InSync() {
+		return 0, nil
+	}
+
+	fields := append([]point.PayloadField{}, drift.Missing...)
+	for _, m := range drift.Mismatched {
+		fields = append(fields, point.PayloadField{Name: m.Field, Type: m.ExpectedType, Indexed: true, Tokenizer: m.ExpectedTokenizer, Tenant: m.ExpectedTenant})
+	}
+
+	for _, field := range fields {
+		if err := r.createPayloadIndex(ctx, name, field); err != nil {
+			return 0, fmt.Errorf("point.repository.qdrant.ReconcileSchema: failed to create index %s on %s: %w", field.Name, name, err)
+		}
+	}
+	r.l.Infof(ctx, "point.repository.qdrant.ReconcileSchema: created %d payload indexes on %s", len(fields), name)
+	return len(fields), nil
+}
+
+func (r *implRepository) createPayloadIndex(ctx context.Context, collection string, field point.PayloadField) error {
+	fieldType := fieldTypeForPayload(field.Type)
+	if field.Type == point.PayloadKeyword && field.Tenant {
+		isTenant := true
+		params := &pb.PayloadIndexParams{
+			IndexParams: &pb.PayloadIndexParams_KeywordIndexParams{
+				KeywordIndexParams: &pb.KeywordIndexParams{IsTenant: &isTenant},
+			},
+		}
+		return r.client.CreateFieldIndexWithParams(ctx, collection, field.Name, fieldType, params)
+	}
+	if field.Type != point.PayloadText {
+		return r.client.CreateFieldIndex(ctx, collection, field.Name, fieldType)
+	}
+
+	lowercase := true
+	params := &pb.PayloadIndexParams{
+		IndexParams: &pb.PayloadIndexParams_TextIndexParams{
+			TextIndexParams: &pb.TextIndexParams{
+				Tokenizer: tokenizerType(field.Tokenizer),
+				Lowercase: &lowercase,
+			},
+		},
+	}
+	return r.client.CreateFieldIndexWithParams(ctx, collection, field.Name, fieldType, params)
+}
+
+func fieldTypeForPayload(t point.PayloadFieldType) pb.FieldType {
+	switch t {
+	case point.PayloadInteger:
+		return pb.FieldType_FieldTypeInteger
+	case point.PayloadFloat:
+		return pb.FieldType_FieldTypeFloat
+	case point.PayloadBool:
+		return pb.FieldType_FieldTypeBool
+	case point.PayloadDatetime:
+		return pb.FieldType_FieldTypeDatetime
+	case point.PayloadText:
+		return pb.FieldType_FieldTypeText
+	default:
+		return pb.FieldType_FieldTypeKeyword
+	}
+}
+
+func payloadFieldTypeFromSchema(t pb.PayloadSchemaType) point.PayloadFieldType {
+	switch t {
+	case pb.PayloadSchemaType_Keyword:
+		return point.PayloadKeyword
+	case pb.PayloadSchemaType_Integer:
+		return point.PayloadInteger
+	case pb.PayloadSchemaType_Float:
+		return point.PayloadFloat
+	case pb.PayloadSchemaType_Bool:
+		return point.PayloadBool
+	case pb.PayloadSchemaType_Datetime:
+		return point.PayloadDatetime
+	case pb.PayloadSchemaType_Text:
+		return point.PayloadText
+	default:
+		return point.PayloadFieldType(t.String())
+	}
+}
+
+func tokenizerType(tokenizer string) pb.TokenizerType {
+	switch tokenizer {
+	case point.TokenizerWhitespace:
+		return pb.TokenizerType_Whitespace
+	case point.TokenizerPrefix:
+		return pb.TokenizerType_Prefix
+	case point.TokenizerMultilingual:
+		return pb.TokenizerType_Multilingual
+	default:
+		return pb.TokenizerType_Word
+	}
+}
+
+func tokenizerFromSchema(t pb.TokenizerType) string {
+	switch t {
+	case pb.TokenizerType_Whitespace:
+		return point.TokenizerWhitespace
+	case pb.TokenizerType_Prefix:
+		return point.TokenizerPrefix
+	case pb.TokenizerType_Multilingual:
+		return point.TokenizerMultilingual
+	case pb.TokenizerType_Word:
+		return point.TokenizerWord
+	default:
+		return ""
+	}
+}
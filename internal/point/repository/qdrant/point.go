@@ -13,7 +13,12 @@ import (
 )
 
 func (r *implRepository) Search(ctx context.Context, opt repository.SearchOptions) ([]point.SearchOutput, error) {
-	pkgResults, err := r.client.SearchWithFilter(ctx, opt.CollectionName, opt.Vector, opt.Limit, opt.Filter, opt.ScoreThreshold)
+	params := pkgQdrant.SearchParams{
+		HnswEf:       opt.Params.HnswEf,
+		Oversampling: opt.Params.Oversampling,
+		Rescore:      opt.Params.Rescore,
+	}
+	pkgResults, err := r.client.SearchWithParams(ctx, opt.CollectionName, opt.Vector, opt.Limit, opt.Filter, opt.ScoreThreshold, opt.WithVectors, params)
 	if err != nil {
 		if errors.Is(err, pkgQdrant.ErrCollectionNotFound) {
 			return nil, err
@@ -28,6 +33,7 @@ func (r *implRepository) Search(ctx context.Context, opt repository.SearchOption
 			ID:      pr.ID,
 			Score:   pr.Score,
 			Payload: pr.Payload,
+			Vector:  pr.Vector,
 		}
 	}
 	return results, nil
@@ -50,11 +56,31 @@ func (r *implRepository) Upsert(ctx context.Context, opt repository.UpsertOption
 }
 
 func (r *implRepository) Count(ctx context.Context, opt repository.CountOptions) (uint64, error) {
-	return r.client.CountPoints(ctx, opt.CollectionName)
+	if opt.Filter == nil {
+		return r.client.CountPoints(ctx, opt.CollectionName)
+	}
+	return r.client.CountPointsWithFilter(ctx, opt.CollectionName, opt.Filter)
 }
 
+// Delete removes the listed points, or every point matching the filter when no IDs are given.
 func (r *implRepository) Delete(ctx context.Context, opt repository.DeleteOptions) error {
-	return fmt.Errorf("not implemented")
+	if opt.CollectionName == "" {
+		return fmt.Errorf("collection name is required")
+	}
+	if len(opt.Points) == 0 {
+		if err := r.client.DeletePointsWithFilter(ctx, opt.CollectionName, opt.Filter); err != nil {
+			r.l.Errorf(ctx, "point.repository.qdrant.Delete: failed to delete by filter in %s: %v", opt.CollectionName, err)
+			return err
+		}
+		return nil
+	}
+	for _, id := range opt.Points {
+		if err := r.client.DeletePoint(ctx, opt.CollectionName, id); err != nil {
+			r.l.Errorf(ctx, "point.repository.qdrant.Delete: failed to delete point %s in %s: %v", id, opt.CollectionName, err)
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *implRepository) Scroll(ctx context.Context, opt repository.ScrollOptions) ([]model.Point, error) {
@@ -101,3 +127,43 @@ func (r *implRepository) Scroll(ctx context.Context, opt repository.ScrollOption
 	}
 	return all, nil
 }
+
+func (r *implRepository) ScrollPage(ctx context.Context, opt repository.ScrollOptions) ([]model.Point, *string, error) {
+	if opt.CollectionName == "" {
+		return nil, nil, fmt.Errorf("collection name is required")
+	}
+	var offset *pb.PointId
+	if opt.Offset != nil {
+		offset = pkgQdrant.ParsePointID(*opt.Offset)
+	}
+	points, next, err := r.client.ScrollPoints(ctx, opt.CollectionName, opt.Filter, uint32(opt.Limit), opt.WithPayload, offset)
+	if err != nil {
+		if errors.Is(err, pkgQdrant.ErrCollectionNotFound) {
+			return nil, nil, err
+		}
+		r.l.Errorf(ctx, "point.repository.qdrant.ScrollPage: %s: %v", opt.CollectionName, err)
+		return nil, nil, err
+	}
+
+	page := make([]model.Point, 0, len(points))
+	for _, p := range points {
+		page = append(page, model.Point{
+			ID:      p.ID,
+			Vector:  p.Vector,
+			Payload: p.Payload,
+		})
+	}
+	if next == nil || len(points) == 0 {
+		return page, nil, nil
+	}
+	cursor := pkgQdrant.PointIDString(next)
+	return page, &cursor, nil
+}
+
+func (r *implRepository) SetPayload(ctx context.Context, opt repository.SetPayloadOptions) error {
+	if err := r.client.SetPayload(ctx, opt.CollectionName, opt.PointID, opt.Payload); err != nil {
+		r.l.Errorf(ctx, "point.repository.qdrant.SetPayload: %s/%s: %v", opt.CollectionName, opt.PointID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package point
+
+// PayloadFieldType is the payload index type of a schema field.
+type PayloadFieldType string
+
+const (
+	PayloadKeyword  PayloadFieldType = "keyword"
+	PayloadInteger  PayloadFieldType = "integer"
+	PayloadFloat    PayloadFieldType = "float"
+	PayloadBool     PayloadFieldType = "bool"
+	PayloadDatetime PayloadFieldType = "datetime"
+	PayloadText     PayloadFieldType = "text"
+)
+
+// Tokenizers for PayloadText fields.
+const (
+	TokenizerWord         = "word"
+	TokenizerWhitespace   = "whitespace"
+	TokenizerPrefix       = "prefix"
+	TokenizerMultilingual = "multilingual"
+)
+
+// PayloadField declares one payload field of a collection. Only Indexed fields
+// get a Qdrant payload index; the others are listed so the schema documents the
+// full payload shape. Nested array fields use dotted paths ("aspects.aspect").
+type PayloadField struct {
+	Name      string
+	Type      PayloadFieldType
+	Indexed   bool
+	Tokenizer string // PayloadText only
+	Tenant    bool   // PayloadKeyword only: tenant key of a shared collection
+}
+
+// PostPayloadSchema is the payload schema of per-project post collections
+// (analytics and insight payload formats share one collection).
+var PostPayloadSchema = []PayloadField{
+	{Name: "platform", Type: PayloadKeyword, Indexed: true},
+	{Name: "overall_sentiment", Type: PayloadKeyword, Indexed: true},
+	{Name: "sentiment_label", Type: PayloadKeyword, Indexed: true},
+	{Name: "risk_level", Type: PayloadKeyword, Indexed: true},
+	{Name: "aspects.aspect", Type: PayloadKeyword, Indexed: true},
+	{Name: "entities.type", Type: PayloadKeyword, Indexed: true},
+	{Name: "entities.value", Type: PayloadKeyword, Indexed: true},
+	{Name: "entities.value_norm", Type: PayloadKeyword, Indexed: true},
+	{Name: "entity_keys", Type: PayloadKeyword, Indexed: true},
+	{Name: "near_dup_cluster_id", Type: PayloadKeyword, Indexed: true},
+	{Name: "content_created_at", Type: PayloadFloat, Indexed: true},
+	{Name: "engagement_score", Type: PayloadFloat, Indexed: true},
+	{Name: "project_id", Type: PayloadKeyword},
+	{Name: "campaign_id", Type: PayloadKeyword},
+	{Name: "content", Type: PayloadText}, // not filtered on; semantic search reads the vector
+	{Name: "duplicate_of", Type: PayloadKeyword},
+}
+
+// SharedPostPayloadSchema is PostPayloadSchema for the shared posts collection:
+// project_id becomes a tenant-keyed index so Qdrant co-locates each project's points.
+var SharedPostPayloadSchema = sharedPostPayloadSchema()
+
+func sharedPostPayloadSchema() []PayloadField {
+	schema := make([]PayloadField, 0, len(PostPayloadSchema))
+	for _, f := range PostPayloadSchema {
+		if f.Name == "project_id" {
+			f.Indexed = true
+			f.Tenant = true
+		}
+		schema = append(schema, f)
+	}
+	return schema
+}
+
+// MacroInsightPayloadSchema is the payload schema of the shared macro_insights
+// collection (campaign scope, document type, analysis window).
+var MacroInsightPayloadSchema = []PayloadField{
+	{Name: "campaign_id", Type: PayloadKeyword, Indexed: true},
+	{Name: "rag_document_type", Type: PayloadKeyword, Indexed: true},
+	{Name: "analysis_window_start_unix", Type: PayloadFloat, Indexed: true},
+	{Name: "analysis_window_end_unix", Type: PayloadFloat, Indexed: true},
+	{Name: "project_id", Type: PayloadKeyword},
+	{Name: "content", Type: PayloadText},
+}
+
+// PayloadSchemaFor returns the declared schema of a collection.
+func PayloadSchemaFor(collectionName string) []PayloadField {
+	switch collectionName {
+	case CollectionMacroInsights:
+		return MacroInsightPayloadSchema
+	case CollectionSharedPosts:
+		return SharedPostPayloadSchema
+	default:
+		return PostPayloadSchema
+	}
+}
+
+// IndexedFields returns the fields of schema that require a payload index.
+func IndexedFields(schema []PayloadField) []PayloadField {
+	out := make([]PayloadField, 0, len(schema))
+	for _, f := range schema {
+		if f.Indexed {
+			out = append(out, f)
+		}
+	}
+	return out
+}
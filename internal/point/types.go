@@ -8,19 +8,67 @@ import (
 
 type Filter = qdrant.Filter
 
+// CollectionTarget - One collection to read for a set of projects.
+// ProjectIDs is set only when the collection is shared between tenants.
+type CollectionTarget struct {
+	Collection string
+	ProjectIDs []string
+}
+
+// Scope returns filter restricted to the target's projects. For per-project
+// collections it returns filter unchanged; filter itself is never modified.
+func (t CollectionTarget) Scope(filter *Filter) *Filter {
+	if len(t.ProjectIDs) == 0 {
+		if filter == nil {
+			return &Filter{}
+		}
+		return filter
+	}
+	tenant := &qdrant.Condition{
+		ConditionOneOf: &qdrant.Condition_Field{
+			Field: &qdrant.FieldCondition{
+				Key: "project_id",
+				Match: &qdrant.Match{
+					MatchValue: &qdrant.Match_Keywords{
+						Keywords: &qdrant.RepeatedStrings{Strings: t.ProjectIDs},
+					},
+				},
+			},
+		},
+	}
+	scoped := &Filter{Must: []*qdrant.Condition{tenant}}
+	if filter != nil {
+		scoped.Must = append(scoped.Must, filter.GetMust()...)
+		scoped.Should = filter.GetShould()
+		scoped.MustNot = filter.GetMustNot()
+		scoped.MinShould = filter.GetMinShould()
+	}
+	return scoped
+}
+
 type SearchInput struct {
 	CollectionName string
 	Vector         []float32
 	Filter         *Filter
 	Limit          uint64
 	WithPayload    bool
+	WithVectors    bool
 	ScoreThreshold float32
+	Params         *SearchParams // nil = configured defaults
+}
+
+// SearchParams - HNSW ef and quantization oversampling/rescore for one search
+type SearchParams struct {
+	HnswEf       uint64
+	Oversampling float64
+	Rescore      bool
 }
 
 type SearchOutput struct {
 	ID      string
 	Score   float32
 	Payload map[string]interface{}
+	Vector  []float32 // only set when WithVectors
 }
 
 type UpsertInput struct {
@@ -47,6 +95,17 @@ type ScrollInput struct {
 	Offset         *string
 }
 
+type ScrollPageOutput struct {
+	Points     []model.Point
+	NextOffset *string // nil = last page
+}
+
+type SetPayloadInput struct {
+	CollectionName string
+	PointID        string // as returned by Scroll/Search
+	Payload        map[string]interface{}
+}
+
 type FacetInput struct {
 	CollectionName string
 	Key            string
@@ -58,3 +117,164 @@ type FacetOutput struct {
 	Value string
 	Count uint64
 }
+
+// =====================================================
+// Payload Schema Drift
+// =====================================================
+
+// SchemaFieldMismatch - An indexed field whose existing index differs from the schema
+type SchemaFieldMismatch struct {
+	Field             string
+	ExpectedType      PayloadFieldType
+	ActualType        PayloadFieldType
+	ExpectedTokenizer string
+	ActualTokenizer   string
+	ExpectedTenant    bool
+	ActualTenant      bool
+}
+
+// CollectionSchemaDrift - Differences between a collection's payload indexes and its schema
+type CollectionSchemaDrift struct {
+	Collection string
+	Missing    []PayloadField        // declared indexed, no index in Qdrant
+	Mismatched []SchemaFieldMismatch // index exists with another type/tokenizer
+	Unexpected []string              // indexed in Qdrant, not declared as indexed
+}
+
+// InSync reports whether every declared index exists as declared.
+// Unexpected indexes are reported but do not count as drift.
+func (d CollectionSchemaDrift) InSync() bool {
+	return len(d.Missing) == 0 && len(d.Mismatched) == 0
+}
+
+type SchemaDriftOutput struct {
+	Collections []CollectionSchemaDrift
+	Drifted     int
+}
+
+type ReconcileSchemasOutput struct {
+	Collections    int
+	IndexesCreated int
+	Failed         []string // collections that could not be reconciled
+}
+
+// =====================================================
+// Collection Profiles
+// =====================================================
+
+// CollectionProfile - Storage / index configuration applied to a collection.
+// Zero numeric values keep the Qdrant defaults.
+type CollectionProfile struct {
+	Name                  string
+	OnDiskVectors         bool
+	OnDiskPayload         bool
+	HnswM                 uint64
+	HnswEfConstruct       uint64
+	IndexingThreshold     uint64 // KB of vectors before a segment is HNSW-indexed
+	Quantization          string // QuantizationNone | QuantizationScalar | QuantizationBinary
+	QuantizationAlwaysRAM bool
+}
+
+// Matches reports whether the current collection configuration already satisfies the profile.
+// Numeric settings left at zero in the profile are not compared.
+func (p CollectionProfile) Matches(current CollectionProfile) bool {
+	if p.OnDiskVectors != current.OnDiskVectors || p.OnDiskPayload != current.OnDiskPayload {
+		return false
+	}
+	if normalizeQuantization(p.Quantization) != normalizeQuantization(current.Quantization) {
+		return false
+	}
+	if normalizeQuantization(p.Quantization) != QuantizationNone && p.QuantizationAlwaysRAM != current.QuantizationAlwaysRAM {
+		return false
+	}
+	if p.HnswM > 0 && p.HnswM != current.HnswM {
+		return false
+	}
+	if p.HnswEfConstruct > 0 && p.HnswEfConstruct != current.HnswEfConstruct {
+		return false
+	}
+	if p.IndexingThreshold > 0 && p.IndexingThreshold != current.IndexingThreshold {
+		return false
+	}
+	return true
+}
+
+// SizeTier - Collections with at most MaxPoints points use Profile (MaxPoints 0 = unbounded)
+type SizeTier struct {
+	MaxPoints uint64
+	Profile   string
+}
+
+// CollectionStats - Point count and applied profile of a collection
+type CollectionStats struct {
+	VectorSize  uint64
+	PointsCount uint64
+	Profile     CollectionProfile
+}
+
+type ApplyProfileInput struct {
+	Collections []string // empty = every managed collection
+	Profile     string   // empty = pick by size tier
+	DryRun      bool
+}
+
+// CollectionProfileResult - Outcome of applying a profile to one collection
+type CollectionProfileResult struct {
+	Collection  string
+	PointsCount uint64
+	Profile     string
+	Changed     bool
+	Error       string
+}
+
+type ApplyProfileOutput struct {
+	Results   []CollectionProfileResult
+	Applied   int
+	Unchanged int
+	Failed    int
+	DryRun    bool
+}
+
+// =====================================================
+// Shared Layout Migration
+// =====================================================
+
+type MigrateToSharedInput struct {
+	Collections  []string // proj_* collections; empty = all of them
+	DeleteSource bool     // drop each source collection once its points are verified in the shared one
+	DryRun       bool
+}
+
+// CollectionMigrationResult - Outcome of moving one per-project collection
+type CollectionMigrationResult struct {
+	Collection    string
+	ProjectID     string
+	SourcePoints  uint64
+	Copied        uint64
+	TargetPoints  uint64 // project points in the shared collection after the copy
+	SourceDeleted bool
+	Error         string
+}
+
+type MigrateToSharedOutput struct {
+	Target   string
+	Results  []CollectionMigrationResult
+	Migrated int
+	Failed   int
+	DryRun   bool
+}
+
+// =====================================================
+// Project Purge
+// =====================================================
+
+// PurgeProjectOutput - What PurgeProject removed from Qdrant
+type PurgeProjectOutput struct {
+	CollectionsDropped []string // proj_{id} (either layout: a pre-migration collection may still exist)
+	PointsDeleted      uint64   // project points removed from shared collections (knowledge_posts, macro_insights)
+}
+
+// PurgeCampaignOutput - What PurgeCampaign removed from Qdrant
+type PurgeCampaignOutput struct {
+	PointsDeleted uint64 // campaign points removed from macro_insights
+}
@@ -1,7 +1,88 @@
 package usecase
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"math"
 
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+)
+
+// EnsureCollection creates missing collections with the smallest tier's profile;
+// ApplyProfile moves them to a larger tier as they grow. The shared collection
+// starts on the largest tier since it holds every project.
 func (uc *implUseCase) EnsureCollection(ctx context.Context, name string, vectorSize uint64) error {
-	return uc.repo.EnsureCollection(ctx, name, vectorSize)
+	profile, _ := uc.profileForPoints(0)
+	if name == point.CollectionSharedPosts {
+		profile, _ = uc.profileForPoints(math.MaxUint64)
+	}
+	return uc.repo.EnsureCollection(ctx, name, vectorSize, profile)
+}
+
+func (uc *implUseCase) ListCollections(ctx context.Context) ([]string, error) {
+	return uc.repo.ListCollections(ctx)
+}
+
+// ReconcileSchemas reconciles every managed collection. A failing collection is
+// recorded and skipped so one broken collection does not block the rest.
+func (uc *implUseCase) ReconcileSchemas(ctx context.Context) (point.ReconcileSchemasOutput, error) {
+	names, err := uc.managedCollections(ctx)
+	if err != nil {
+		return point.ReconcileSchemasOutput{}, err
+	}
+
+	output := point.ReconcileSchemasOutput{Collections: len(names)}
+	for _, name := range names {
+		created, err := uc.repo.ReconcileSchema(ctx, name)
+		if err != nil {
+			uc.l.Warnf(ctx, "point.usecase.ReconcileSchemas: %s: %v", name, err)
+			output.Failed = append(output.Failed, name)
+			continue
+		}
+		output.IndexesCreated += created
+	}
+
+	uc.l.Infof(ctx, "point.usecase.ReconcileSchemas: %d collections, %d indexes created, %d failed",
+		output.Collections, output.IndexesCreated, len(output.Failed))
+	return output, nil
+}
+
+func (uc *implUseCase) SchemaDrift(ctx context.Context, sc model.Scope) (point.SchemaDriftOutput, error) {
+	if !sc.IsAdmin() {
+		return point.SchemaDriftOutput{}, point.ErrForbidden
+	}
+
+	names, err := uc.managedCollections(ctx)
+	if err != nil {
+		return point.SchemaDriftOutput{}, err
+	}
+
+	output := point.SchemaDriftOutput{Collections: make([]point.CollectionSchemaDrift, 0, len(names))}
+	for _, name := range names {
+		drift, err := uc.repo.SchemaDrift(ctx, name)
+		if err != nil {
+			uc.l.Errorf(ctx, "point.usecase.SchemaDrift: %s: %v", name, err)
+			return point.SchemaDriftOutput{}, fmt.Errorf("%w: %v", point.ErrSchemaReconcile, err)
+		}
+		if !drift.InSync() {
+			output.Drifted++
+		}
+		output.Collections = append(output.Collections, drift)
+	}
+	return output, nil
+}
+
+func (uc *implUseCase) managedCollections(ctx context.Context) ([]string, error) {
+	names, err := uc.repo.ListCollections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", point.ErrSchemaReconcile, err)
+	}
+	managed := make([]string, 0, len(names))
+	for _, name := range names {
+		if point.IsManagedCollection(name) {
+			managed = append(managed, name)
+		}
+	}
+	return managed, nil
 }
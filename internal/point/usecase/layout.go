@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/point/repository"
+)
+
+const migrateBatchSize = 256
+
+func (uc *implUseCase) CollectionForProject(projectID string) string {
+	if uc.config.Layout == point.LayoutShared {
+		return point.CollectionSharedPosts
+	}
+	return point.CollectionForProject(projectID)
+}
+
+// PointIDForProject keeps source IDs as point IDs in the per-project layout, and
+// namespaces them by project in the shared layout (see point.SharedPointID).
+func (uc *implUseCase) PointIDForProject(projectID, sourceID string) string {
+	if uc.config.Layout == point.LayoutShared {
+		return point.SharedPointID(projectID, sourceID)
+	}
+	return sourceID
+}
+
+// CollectionTargets returns one target per project in the per-project layout, or a
+// single tenant-scoped target in the shared layout (one query instead of a fan-out).
+func (uc *implUseCase) CollectionTargets(projectIDs []string) []point.CollectionTarget {
+	if len(projectIDs) == 0 {
+		return nil
+	}
+	if uc.config.Layout == point.LayoutShared {
+		return []point.CollectionTarget{{
+			Collection: point.CollectionSharedPosts,
+			ProjectIDs: projectIDs,
+		}}
+	}
+	targets := make([]point.CollectionTarget, 0, len(projectIDs))
+	for _, pid := range projectIDs {
+		targets = append(targets, point.CollectionTarget{Collection: point.CollectionForProject(pid)})
+	}
+	return targets
+}
+
+// MigrateToShared copies per-project collections into the shared collection,
+// stamping project_id on every point. Copies get the project-namespaced ID of their
+// source record (see PointIDForProject), which is deterministic, so re-running after
+// more data was indexed into the old layout is safe. A source collection is only
+// dropped (DeleteSource) once the shared collection holds at least as many of its
+// project's points.
+func (uc *implUseCase) MigrateToShared(ctx context.Context, input point.MigrateToSharedInput) (point.MigrateToSharedOutput, error) {
+	names := input.Collections
+	if len(names) == 0 {
+		all, err := uc.repo.ListCollections(ctx)
+		if err != nil {
+			return point.MigrateToSharedOutput{}, fmt.Errorf("%w: %v", point.ErrMigrateShared, err)
+		}
+		for _, name := range all {
+			if _, ok := point.ProjectIDFromCollection(name); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	for _, name := range names {
+		if _, ok := point.ProjectIDFromCollection(name); !ok {
+			return point.MigrateToSharedOutput{}, fmt.Errorf("%w: %s", point.ErrNotProject, name)
+		}
+	}
+
+	output := point.MigrateToSharedOutput{
+		Target:  point.CollectionSharedPosts,
+		Results: make([]point.CollectionMigrationResult, 0, len(names)),
+		DryRun:  input.DryRun,
+	}
+	for _, name := range names {
+		result := uc.migrateCollection(ctx, name, input)
+		if result.Error != "" {
+			output.Failed++
+		} else if !input.DryRun {
+			output.Migrated++
+		}
+		output.Results = append(output.Results, result)
+	}
+
+	uc.l.Infof(ctx, "point.usecase.MigrateToShared: %d collections, %d migrated, %d failed (dry_run=%t)",
+		len(names), output.Migrated, output.Failed, input.DryRun)
+	return output, nil
+}
+
+func (uc *implUseCase) migrateCollection(ctx context.Context, name string, input point.MigrateToSharedInput) point.CollectionMigrationResult {
+	projectID, _ := point.ProjectIDFromCollection(name)
+	result := point.CollectionMigrationResult{Collection: name, ProjectID: projectID}
+	fail := func(err error) point.CollectionMigrationResult {
+		uc.l.Warnf(ctx, "point.usecase.MigrateToShared: %s: %v", name, err)
+		result.Error = err.Error()
+		return result
+	}
+
+	stats, err := uc.repo.CollectionStats(ctx, name)
+	if err != nil {
+		return fail(err)
+	}
+	result.SourcePoints = stats.PointsCount
+	if input.DryRun {
+		return result
+	}
+
+	if err := uc.EnsureCollection(ctx, point.CollectionSharedPosts, stats.VectorSize); err != nil {
+		return fail(err)
+	}
+
+	copied, err := uc.repo.CopyCollection(ctx, repository.CopyCollectionOptions{
+		Source:    name,
+		Target:    point.CollectionSharedPosts,
+		Payload:   map[string]interface{}{"project_id": projectID},
+		BatchSize: migrateBatchSize,
+		PointID: func(id string, payload map[string]interface{}) string {
+			return point.SharedPointID(projectID, sourceIDOf(id, payload))
+		},
+	})
+	result.Copied = copied
+	if err != nil {
+		return fail(err)
+	}
+
+	target := point.CollectionTarget{Collection: point.CollectionSharedPosts, ProjectIDs: []string{projectID}}
+	result.TargetPoints, err = uc.repo.Count(ctx, repository.CountOptions{
+		CollectionName: target.Collection,
+		Filter:         target.Scope(nil),
+	})
+	if err != nil {
+		return fail(err)
+	}
+	if result.TargetPoints < result.SourcePoints {
+		return fail(fmt.Errorf("verification failed: %d source points, %d in %s", result.SourcePoints, result.TargetPoints, target.Collection))
+	}
+
+	if input.DeleteSource {
+		if err := uc.repo.DeleteCollection(ctx, name); err != nil {
+			return fail(err)
+		}
+		result.SourceDeleted = true
+	}
+	return result
+}
+
+// sourceIDOf returns the ID of the record a per-project point was indexed from. Points
+// without one fall back to their own ID, which is the source ID when it is a UUID.
+func sourceIDOf(id string, payload map[string]interface{}) string {
+	for _, field := range point.SourceIDFields {
+		if v, ok := payload[field].(string); ok && v != "" {
+			return v
+		}
+	}
+	return id
+}
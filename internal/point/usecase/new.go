@@ -1,20 +1,130 @@
 package usecase
 
 import (
+	"sort"
+
+	"knowledge-srv/config"
 	"knowledge-srv/internal/point"
 	"knowledge-srv/internal/point/repository"
 
 	"github.com/smap-hcmut/shared-libs/go/log"
 )
 
+// Config - Collection layout, profiles, size tiers and default search tuning
+type Config struct {
+	Layout   string                             // point.LayoutPerProject (default) | point.LayoutShared
+	Profiles map[string]point.CollectionProfile // by profile name
+	Tiers    []point.SizeTier                   // sorted by MaxPoints in New; last tier should be unbounded
+	Search   point.SearchParams                 // used when SearchInput.Params is nil
+}
+
+// ConfigFromQdrant maps the qdrant config section to collection profiles, size tiers and search defaults.
+func ConfigFromQdrant(cfg config.QdrantConfig) Config {
+	return Config{
+		Layout: cfg.Layout,
+		Profiles: map[string]point.CollectionProfile{
+			point.ProfileSmall:  collectionProfile(cfg.Profiles.Small),
+			point.ProfileMedium: collectionProfile(cfg.Profiles.Medium),
+			point.ProfileLarge:  collectionProfile(cfg.Profiles.Large),
+		},
+		Tiers: []point.SizeTier{
+			{MaxPoints: uint64(cfg.Tiers.SmallMaxPoints), Profile: point.ProfileSmall},
+			{MaxPoints: uint64(cfg.Tiers.MediumMaxPoints), Profile: point.ProfileMedium},
+			{MaxPoints: 0, Profile: point.ProfileLarge},
+		},
+		Search: point.SearchParams{
+			HnswEf:       uint64(cfg.Search.HnswEf),
+			Oversampling: cfg.Search.Oversampling,
+			Rescore:      cfg.Search.Rescore,
+		},
+	}
+}
+
+func collectionProfile(cfg config.QdrantProfileConfig) point.CollectionProfile {
+	return point.CollectionProfile{
+		OnDiskVectors:         cfg.OnDiskVectors,
+		OnDiskPayload:         cfg.OnDiskPayload,
+		HnswM:                 uint64(cfg.HnswM),
+		HnswEfConstruct:       uint64(cfg.HnswEfConstruct),
+		IndexingThreshold:     uint64(cfg.IndexingThreshold),
+		Quantization:          cfg.Quantization,
+		QuantizationAlwaysRAM: cfg.QuantizationAlwaysRAM,
+	}
+}
+
 type implUseCase struct {
-	repo repository.QdrantRepository
-	l    log.Logger
+	repo   repository.QdrantRepository
+	l      log.Logger
+	config Config
 }
 
-func New(repo repository.QdrantRepository, l log.Logger) point.UseCase {
+func New(repo repository.QdrantRepository, l log.Logger, cfg Config) point.UseCase {
+	if cfg.Layout != point.LayoutShared {
+		cfg.Layout = point.LayoutPerProject
+	}
+	if len(cfg.Profiles) == 0 {
+		cfg.Profiles = defaultProfiles()
+	}
+	for name, profile := range cfg.Profiles {
+		profile.Name = name
+		cfg.Profiles[name] = profile
+	}
+	if len(cfg.Tiers) == 0 {
+		cfg.Tiers = defaultTiers()
+	}
+	sort.SliceStable(cfg.Tiers, func(i, j int) bool {
+		a, b := cfg.Tiers[i].MaxPoints, cfg.Tiers[j].MaxPoints
+		if a == 0 || b == 0 {
+			return b == 0 && a != 0
+		}
+		return a < b
+	})
+	if cfg.Search.Oversampling < 0 {
+		cfg.Search.Oversampling = 0
+	}
+
 	return &implUseCase{
-		repo: repo,
-		l:    l,
+		repo:   repo,
+		l:      l,
+		config: cfg,
+	}
+}
+
+// defaultProfiles keeps small collections fully in RAM without quantization and
+// moves larger ones to on-disk originals with RAM-resident quantized vectors.
+func defaultProfiles() map[string]point.CollectionProfile {
+	return map[string]point.CollectionProfile{
+		point.ProfileSmall: {
+			HnswM:             16,
+			HnswEfConstruct:   100,
+			IndexingThreshold: 20000,
+			Quantization:      point.QuantizationNone,
+		},
+		point.ProfileMedium: {
+			OnDiskVectors:         true,
+			OnDiskPayload:         true,
+			HnswM:                 16,
+			HnswEfConstruct:       100,
+			IndexingThreshold:     20000,
+			Quantization:          point.QuantizationScalar,
+			QuantizationAlwaysRAM: true,
+		},
+		point.ProfileLarge: {
+			OnDiskVectors:         true,
+			OnDiskPayload:         true,
+			HnswM:                 32,
+			HnswEfConstruct:       200,
+			IndexingThreshold:     50000,
+			Quantization:          point.QuantizationBinary,
+			QuantizationAlwaysRAM: true,
+		},
+	}
+}
+
+func defaultTiers() []point.SizeTier {
+	return []point.SizeTier{
+		{MaxPoints: 10000, Profile: point.ProfileSmall},
+		{MaxPoints: 200000, Profile: point.ProfileMedium},
+		{MaxPoints: 0, Profile: point.ProfileLarge},
 	}
 }
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+)
+
+// ApplyProfile applies a profile to the requested (or all managed) collections.
+// Without an explicit profile each collection gets the profile of its size tier.
+// Collections already matching their target are left untouched; a failing
+// collection is recorded and does not stop the rest.
+func (uc *implUseCase) ApplyProfile(ctx context.Context, sc model.Scope, input point.ApplyProfileInput) (point.ApplyProfileOutput, error) {
+	if !sc.IsAdmin() {
+		return point.ApplyProfileOutput{}, point.ErrForbidden
+	}
+
+	if input.Profile != "" {
+		if _, ok := uc.config.Profiles[input.Profile]; !ok {
+			return point.ApplyProfileOutput{}, point.ErrUnknownProfile
+		}
+	}
+
+	names := input.Collections
+	if len(names) == 0 {
+		managed, err := uc.managedCollections(ctx)
+		if err != nil {
+			return point.ApplyProfileOutput{}, fmt.Errorf("%w: %v", point.ErrApplyProfile, err)
+		}
+		names = managed
+	}
+	for _, name := range names {
+		if !point.IsManagedCollection(name) {
+			return point.ApplyProfileOutput{}, fmt.Errorf("%w: %s", point.ErrNotManaged, name)
+		}
+	}
+
+	output := point.ApplyProfileOutput{
+		Results: make([]point.CollectionProfileResult, 0, len(names)),
+		DryRun:  input.DryRun,
+	}
+	for _, name := range names {
+		result := uc.applyProfile(ctx, name, input.Profile, input.DryRun)
+		switch {
+		case result.Error != "":
+			output.Failed++
+		case result.Changed:
+			output.Applied++
+		default:
+			output.Unchanged++
+		}
+		output.Results = append(output.Results, result)
+	}
+
+	uc.l.Infof(ctx, "point.usecase.ApplyProfile: %d collections, %d applied, %d unchanged, %d failed (dry_run=%t)",
+		len(names), output.Applied, output.Unchanged, output.Failed, input.DryRun)
+	return output, nil
+}
+
+func (uc *implUseCase) applyProfile(ctx context.Context, name, profileName string, dryRun bool) point.CollectionProfileResult {
+	result := point.CollectionProfileResult{Collection: name}
+
+	stats, err := uc.repo.CollectionStats(ctx, name)
+	if err != nil {
+		uc.l.Warnf(ctx, "point.usecase.ApplyProfile: %s: %v", name, err)
+		result.Error = err.Error()
+		return result
+	}
+	result.PointsCount = stats.PointsCount
+
+	var profile point.CollectionProfile
+	if profileName != "" {
+		profile = uc.config.Profiles[profileName]
+	} else {
+		var ok bool
+		if profile, ok = uc.profileForPoints(stats.PointsCount); !ok {
+			result.Error = point.ErrUnknownProfile.Error()
+			return result
+		}
+	}
+	result.Profile = profile.Name
+	if !point.IsValidQuantization(profile.Quantization) {
+		result.Error = point.ErrInvalidProfile.Error()
+		return result
+	}
+
+	if profile.Matches(stats.Profile) {
+		return result
+	}
+	result.Changed = true
+	if dryRun {
+		return result
+	}
+
+	if err := uc.repo.ApplyProfile(ctx, name, profile); err != nil {
+		uc.l.Warnf(ctx, "point.usecase.ApplyProfile: %s: %v", name, err)
+		result.Changed = false
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// profileForPoints returns the profile of the first size tier that fits pointsCount.
+func (uc *implUseCase) profileForPoints(pointsCount uint64) (point.CollectionProfile, bool) {
+	for _, tier := range uc.config.Tiers {
+		if tier.MaxPoints == 0 || pointsCount <= tier.MaxPoints {
+			profile, ok := uc.config.Profiles[tier.Profile]
+			return profile, ok
+		}
+	}
+	return point.CollectionProfile{}, false
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/point/repository"
+
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// PurgeProject drops the project's own collection and deletes its points from the
+// collections shared across projects. Missing collections are skipped, so the
+// purge can be retried after a partial failure.
+func (uc *implUseCase) PurgeProject(ctx context.Context, projectID string) (point.PurgeProjectOutput, error) {
+	var output point.PurgeProjectOutput
+	if projectID == "" {
+		return output, fmt.Errorf("%w: project id is required", point.ErrPurgeProject)
+	}
+
+	names, err := uc.repo.ListCollections(ctx)
+	if err != nil {
+		return output, fmt.Errorf("%w: %v", point.ErrPurgeProject, err)
+	}
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	if own := point.CollectionForProject(projectID); existing[own] {
+		if err := uc.repo.DeleteCollection(ctx, own); err != nil {
+			return output, fmt.Errorf("%w: %v", point.ErrPurgeProject, err)
+		}
+		output.CollectionsDropped = append(output.CollectionsDropped, own)
+	}
+
+	for _, name := range []string{point.CollectionSharedPosts, point.CollectionMacroInsights} {
+		if !existing[name] {
+			continue
+		}
+		target := point.CollectionTarget{Collection: name, ProjectIDs: []string{projectID}}
+		filter := target.Scope(nil)
+		n, err := uc.repo.Count(ctx, repository.CountOptions{CollectionName: name, Filter: filter})
+		if err != nil {
+			return output, fmt.Errorf("%w: %v", point.ErrPurgeProject, err)
+		}
+		if n == 0 {
+			continue
+		}
+		if err := uc.repo.Delete(ctx, repository.DeleteOptions{CollectionName: name, Filter: filter}); err != nil {
+			return output, fmt.Errorf("%w: %v", point.ErrPurgeProject, err)
+		}
+		output.PointsDeleted += n
+	}
+
+	uc.l.Infof(ctx, "point.usecase.PurgeProject: project=%s dropped=%v points_deleted=%d", projectID, output.CollectionsDropped, output.PointsDeleted)
+	return output, nil
+}
+
+// PurgeCampaign deletes the campaign's points from macro_insights. Post collections
+// are left alone: their points belong to projects, which are purged on their own.
+func (uc *implUseCase) PurgeCampaign(ctx context.Context, campaignID string) (point.PurgeCampaignOutput, error) {
+	var output point.PurgeCampaignOutput
+	if campaignID == "" {
+		return output, fmt.Errorf("%w: campaign id is required", point.ErrPurgeCampaign)
+	}
+
+	names, err := uc.repo.ListCollections(ctx)
+	if err != nil {
+		return output, fmt.Errorf("%w: %v", point.ErrPurgeCampaign, err)
+	}
+	if !slices.Contains(names, point.CollectionMacroInsights) {
+		return output, nil
+	}
+
+	filter := &point.Filter{Must: []*qdrant.Condition{{
+		ConditionOneOf: &qdrant.Condition_Field{
+			Field: &qdrant.FieldCondition{
+				Key:   "campaign_id",
+				Match: &qdrant.Match{MatchValue: &qdrant.Match_Keyword{Keyword: campaignID}},
+			},
+		},
+	}}}
+	n, err := uc.repo.Count(ctx, repository.CountOptions{CollectionName: point.CollectionMacroInsights, Filter: filter})
+	if err != nil {
+		return output, fmt.Errorf("%w: %v", point.ErrPurgeCampaign, err)
+	}
+	if n == 0 {
+		return output, nil
+	}
+	if err := uc.repo.Delete(ctx, repository.DeleteOptions{CollectionName: point.CollectionMacroInsights, Filter: filter}); err != nil {
+		return output, fmt.Errorf("%w: %v", point.ErrPurgeCampaign, err)
+	}
+	output.PointsDeleted = n
+
+	uc.l.Infof(ctx, "point.usecase.PurgeCampaign: campaign=%s points_deleted=%d", campaignID, n)
+	return output, nil
+}
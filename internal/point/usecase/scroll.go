@@ -16,3 +16,25 @@ func (uc *implUseCase) Scroll(ctx context.Context, input point.ScrollInput) ([]m
 		Offset:         input.Offset,
 	})
 }
+
+func (uc *implUseCase) ScrollPage(ctx context.Context, input point.ScrollInput) (point.ScrollPageOutput, error) {
+	points, next, err := uc.repo.ScrollPage(ctx, repository.ScrollOptions{
+		CollectionName: input.CollectionName,
+		Filter:         input.Filter,
+		Limit:          input.Limit,
+		WithPayload:    input.WithPayload,
+		Offset:         input.Offset,
+	})
+	if err != nil {
+		return point.ScrollPageOutput{}, err
+	}
+	return point.ScrollPageOutput{Points: points, NextOffset: next}, nil
+}
+
+func (uc *implUseCase) SetPayload(ctx context.Context, input point.SetPayloadInput) error {
+	return uc.repo.SetPayload(ctx, repository.SetPayloadOptions{
+		CollectionName: input.CollectionName,
+		PointID:        input.PointID,
+		Payload:        input.Payload,
+	})
+}
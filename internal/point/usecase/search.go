@@ -7,12 +7,18 @@ import (
 )
 
 func (uc *implUseCase) Search(ctx context.Context, input point.SearchInput) ([]point.SearchOutput, error) {
+	params := uc.config.Search
+	if input.Params != nil {
+		params = *input.Params
+	}
 	return uc.repo.Search(ctx, repository.SearchOptions{
 		CollectionName: input.CollectionName,
 		Vector:         input.Vector,
 		Filter:         input.Filter,
 		Limit:          input.Limit,
 		WithPayload:    input.WithPayload,
+		WithVectors:    input.WithVectors,
 		ScoreThreshold: input.ScoreThreshold,
+		Params:         params,
 	})
 }
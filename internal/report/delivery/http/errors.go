@@ -17,6 +17,7 @@ var (
 	errDuplicateProcessing = pkgErrors.NewHTTPError(409, "Report is already being processed")
 	errDownloadURLFailed   = pkgErrors.NewHTTPError(500, "Failed to generate download URL")
 	errReportDeleteFailed  = pkgErrors.NewHTTPError(500, "Failed to delete report")
+	errInvalidCursor       = pkgErrors.NewHTTPError(400, "Invalid or expired cursor")
 )
 
 func (h *handler) mapError(err error) error {
@@ -39,6 +40,8 @@ func (h *handler) mapError(err error) error {
 		return errDownloadURLFailed
 	case errors.Is(err, report.ErrReportDeleteFailed):
 		return errReportDeleteFailed
+	case errors.Is(err, report.ErrInvalidCursor):
+		return errInvalidCursor
 	default:
 		return pkgErrors.NewHTTPError(500, "Internal server error")
 	}
@@ -75,6 +75,7 @@ func (h *handler) ListReports(c *gin.Context) {
 // @Tags Report
 // @Produce json
 // @Param report_id path string true "Report ID"
+// @Param share_token query string false "Share link token"
 // @Success 200 {object} reportResp
 // @Failure 400 {object} response.Resp
 // @Failure 404 {object} response.Resp
@@ -105,6 +106,7 @@ func (h *handler) GetReport(c *gin.Context) {
 // @Tags Report
 // @Produce json
 // @Param report_id path string true "Report ID"
+// @Param share_token query string false "Share link token"
 // @Success 200 {object} reportProcessResp
 // @Failure 404 {object} response.Resp
 // @Failure 500 {object} response.Resp
@@ -130,11 +132,16 @@ func (h *handler) GetReportProcess(c *gin.Context) {
 }
 
 // @Summary List report evidence posts
-// @Description Return indexed evidence posts used to review a report
+// @Description Return indexed evidence posts used to review a report. total counts the posts of the returned page; follow has_more/next_cursor to page. Pages past the search depth limit are empty
 // @Tags Report
 // @Produce json
 // @Param report_id path string true "Report ID"
+// @Param share_token query string false "Share link token"
+// @Param page query int false "Page number (ignored when cursor is set)"
+// @Param page_size query int false "Page size"
+// @Param cursor query string false "next_cursor from the previous page"
 // @Success 200 {object} listReportPostsResp
+// @Failure 400 {object} response.Resp
 // @Failure 404 {object} response.Resp
 // @Failure 500 {object} response.Resp
 // @Router /reports/{report_id}/posts [get]
@@ -191,6 +198,7 @@ func (h *handler) ListPostComments(c *gin.Context) {
 // @Tags Report
 // @Produce json
 // @Param report_id path string true "Report ID"
+// @Param share_token query string false "Share link token"
 // @Success 200 {object} downloadResp
 // @Failure 400 {object} response.Resp
 // @Failure 404 {object} response.Resp
@@ -221,6 +229,7 @@ func (h *handler) DownloadReport(c *gin.Context) {
 // @Tags Report
 // @Produce json
 // @Param report_id path string true "Report ID"
+// @Param share_token query string false "Share link token"
 // @Success 200 {object} reportContentResp
 // @Failure 400 {object} response.Resp
 // @Failure 404 {object} response.Resp
@@ -236,7 +245,7 @@ func (h *handler) GetReportContent(c *gin.Context) {
 		return
 	}
 
-	o, err := h.uc.GetReportContent(ctx, sc, report.GetReportContentInput{ReportID: req.ReportID})
+	o, err := h.uc.GetReportContent(ctx, sc, report.GetReportContentInput{ReportID: req.ReportID, ShareToken: req.ShareToken})
 	if err != nil {
 		h.logUsecaseError(ctx, "report.delivery.http.GetReportContent: usecase GetReportContent failed", err)
 		response.Error(c, h.mapError(err), h.discord)
@@ -88,40 +88,48 @@ func (r listReportsReq) toInput() report.ListReportsInput {
 }
 
 type getReportReq struct {
-	ReportID string
+	ReportID   string
+	ShareToken string
 }
 
 func (r getReportReq) toInput() report.GetReportInput {
 	return report.GetReportInput{
-		ReportID: r.ReportID,
+		ReportID:   r.ReportID,
+		ShareToken: r.ShareToken,
 	}
 }
 
 type getReportProcessReq struct {
-	ReportID string
+	ReportID   string
+	ShareToken string
 }
 
 func (r getReportProcessReq) toInput() report.GetReportProcessInput {
 	return report.GetReportProcessInput{
-		ReportID: r.ReportID,
+		ReportID:   r.ReportID,
+		ShareToken: r.ShareToken,
 	}
 }
 
 type listReportPostsReq struct {
-	ReportID  string
-	Page      int
-	PageSize  int
-	Sentiment string
-	Platform  string
+	ReportID   string
+	Page       int
+	PageSize   int
+	Sentiment  string
+	Platform   string
+	Cursor     string
+	ShareToken string
 }
 
 func (r listReportPostsReq) toInput() report.ListReportPostsInput {
 	return report.ListReportPostsInput{
-		ReportID:  r.ReportID,
-		Page:      r.Page,
-		PageSize:  r.PageSize,
-		Sentiment: r.Sentiment,
-		Platform:  r.Platform,
+		ReportID:   r.ReportID,
+		Page:       r.Page,
+		PageSize:   r.PageSize,
+		Sentiment:  r.Sentiment,
+		Platform:   r.Platform,
+		Cursor:     r.Cursor,
+		ShareToken: r.ShareToken,
 	}
 }
 
@@ -140,12 +148,14 @@ func (r listPostCommentsReq) toInput() report.ListPostCommentsInput {
 }
 
 type downloadReportReq struct {
-	ReportID string
+	ReportID   string
+	ShareToken string
 }
 
 func (r downloadReportReq) toInput() report.DownloadReportInput {
 	return report.DownloadReportInput{
-		ReportID: r.ReportID,
+		ReportID:   r.ReportID,
+		ShareToken: r.ShareToken,
 	}
 }
 
@@ -200,6 +210,7 @@ type reportResp struct {
 	SectionsCount     int         `json:"sections_count,omitempty"`
 	GenerationTimeMs  int64       `json:"generation_time_ms,omitempty"`
 	Filters           interface{} `json:"filters,omitempty" swaggertype:"object"`
+	NumericCheck      interface{} `json:"numeric_check,omitempty" swaggertype:"object"`
 	CompletedAt       *string     `json:"completed_at,omitempty"`
 	CreatedAt         string      `json:"created_at"`
 }
@@ -228,10 +239,12 @@ type competitorProgressResp struct {
 }
 
 type listReportPostsResp struct {
-	Items    []reportPostResp `json:"items"`
-	Total    int              `json:"total"`
-	Page     int              `json:"page"`
-	PageSize int              `json:"page_size"`
+	Items      []reportPostResp `json:"items"`
+	Total      int              `json:"total"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	HasMore    bool             `json:"has_more"`
 }
 
 type reportPostResp struct {
@@ -356,6 +369,12 @@ func (h *handler) newReportResp(o report.ReportOutput) reportResp {
 			resp.Filters = filters
 		}
 	}
+	if len(o.NumericCheck) > 0 {
+		var numericCheck interface{}
+		if err := json.Unmarshal(o.NumericCheck, &numericCheck); err == nil {
+			resp.NumericCheck = numericCheck
+		}
+	}
 	return resp
 }
 
@@ -390,10 +409,12 @@ func (h *handler) newListReportPostsResp(o report.ListReportPostsOutput) listRep
 		items = append(items, h.newReportPostResp(item))
 	}
 	return listReportPostsResp{
-		Items:    items,
-		Total:    o.Total,
-		Page:     o.Page,
-		PageSize: o.PageSize,
+		Items:      items,
+		Total:      o.Total,
+		Page:       o.Page,
+		PageSize:   o.PageSize,
+		NextCursor: o.NextCursor,
+		HasMore:    o.HasMore,
 	}
 }
 
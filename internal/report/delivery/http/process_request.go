@@ -3,6 +3,7 @@ package http
 import (
 	"knowledge-srv/internal/model"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/smap-hcmut/shared-libs/go/auth"
@@ -35,7 +36,8 @@ func (h *handler) processListReportsRequest(c *gin.Context) (listReportsReq, mod
 
 func (h *handler) processGetReportRequest(c *gin.Context) (getReportReq, model.Scope, error) {
 	req := getReportReq{
-		ReportID: c.Param("report_id"),
+		ReportID:   c.Param("report_id"),
+		ShareToken: strings.TrimSpace(c.Query("share_token")),
 	}
 
 	sc := auth.GetScopeFromContext(c.Request.Context())
@@ -44,7 +46,8 @@ func (h *handler) processGetReportRequest(c *gin.Context) (getReportReq, model.S
 
 func (h *handler) processGetReportProcessRequest(c *gin.Context) (getReportProcessReq, model.Scope, error) {
 	req := getReportProcessReq{
-		ReportID: c.Param("report_id"),
+		ReportID:   c.Param("report_id"),
+		ShareToken: strings.TrimSpace(c.Query("share_token")),
 	}
 
 	sc := auth.GetScopeFromContext(c.Request.Context())
@@ -53,11 +56,13 @@ func (h *handler) processGetReportProcessRequest(c *gin.Context) (getReportProce
 
 func (h *handler) processListReportPostsRequest(c *gin.Context) (listReportPostsReq, model.Scope, error) {
 	req := listReportPostsReq{
-		ReportID:  c.Param("report_id"),
-		Page:      queryInt(c, "page", 1),
-		PageSize:  queryInt(c, "page_size", 20),
-		Sentiment: c.Query("sentiment"),
-		Platform:  c.Query("platform"),
+		ReportID:   c.Param("report_id"),
+		Page:       queryInt(c, "page", 1),
+		PageSize:   queryInt(c, "page_size", 20),
+		Sentiment:  c.Query("sentiment"),
+		Platform:   c.Query("platform"),
+		Cursor:     c.Query("cursor"),
+		ShareToken: strings.TrimSpace(c.Query("share_token")),
 	}
 
 	sc := auth.GetScopeFromContext(c.Request.Context())
@@ -77,7 +82,8 @@ func (h *handler) processListPostCommentsRequest(c *gin.Context) (listPostCommen
 
 func (h *handler) processDownloadReportRequest(c *gin.Context) (downloadReportReq, model.Scope, error) {
 	req := downloadReportReq{
-		ReportID: c.Param("report_id"),
+		ReportID:   c.Param("report_id"),
+		ShareToken: strings.TrimSpace(c.Query("share_token")),
 	}
 
 	sc := auth.GetScopeFromContext(c.Request.Context())
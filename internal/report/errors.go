@@ -12,4 +12,6 @@ var (
 	ErrDuplicateProcessing = errors.New("duplicate report is already being processed")
 	ErrDownloadURLFailed   = errors.New("failed to generate download URL")
 	ErrReportDeleteFailed  = errors.New("failed to delete report")
+	ErrCampaignPurgeFailed = errors.New("failed to purge campaign reports")
+	ErrInvalidCursor       = errors.New("invalid or expired cursor")
 )
@@ -18,4 +18,6 @@ type UseCase interface {
 	CancelReport(ctx context.Context, sc model.Scope, input CancelReportInput) (CancelOutput, error)
 	RetryReport(ctx context.Context, sc model.Scope, input RetryReportInput) (RetryOutput, error)
 	DeleteReport(ctx context.Context, sc model.Scope, input DeleteReportInput) (DeleteOutput, error)
+	// PurgeCampaign deletes a deleted campaign's report files and records. Safe to re-run.
+	PurgeCampaign(ctx context.Context, campaignID string) (PurgeCampaignOutput, error)
 }
@@ -16,6 +16,8 @@ type ReportRepository interface {
 	UpdateProcessing(ctx context.Context, opts UpdateProcessingOptions) error
 	UpdateCancelled(ctx context.Context, opts UpdateCancelledOptions) error
 	DeleteReport(ctx context.Context, opts DeleteReportOptions) error
+	// DeleteCampaignReports removes every report of a campaign, returning the rows deleted.
+	DeleteCampaignReports(ctx context.Context, campaignID string) (int64, error)
 	ListReports(ctx context.Context, opts ListReportsOptions) ([]*model.Report, error)
 	CountReports(ctx context.Context, opts ListReportsOptions) (int, error)
 }
@@ -1,6 +1,9 @@
 package repository
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type CreateReportOptions struct {
 	ID         string
@@ -26,6 +29,7 @@ type UpdateCompletedOptions struct {
 	SectionsCount     int
 	GenerationTimeMs  int64
 	CompletedAt       time.Time
+	NumericCheck      json.RawMessage // nil leaves the column NULL
 }
 
 type UpdateFailedOptions struct {
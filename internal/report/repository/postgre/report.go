@@ -7,6 +7,7 @@ import (
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
 
 	"knowledge-srv/internal/model"
 	"knowledge-srv/internal/report/repository"
@@ -71,6 +72,9 @@ func (r *implRepository) UpdateCompleted(ctx context.Context, opts repository.Up
 	dbReport.SectionsCount = null.IntFrom(opts.SectionsCount)
 	dbReport.GenerationTimeMS = null.Int64From(opts.GenerationTimeMs)
 	dbReport.CompletedAt = null.TimeFrom(opts.CompletedAt)
+	if len(opts.NumericCheck) > 0 {
+		dbReport.NumericCheck = null.JSONFrom(opts.NumericCheck)
+	}
 	dbReport.UpdatedAt = null.TimeFrom(time.Now())
 
 	_, err = dbReport.Update(ctx, r.db, boil.Infer())
@@ -168,6 +172,16 @@ func (r *implRepository) DeleteReport(ctx context.Context, opts repository.Delet
 	return nil
 }
 
+// DeleteCampaignReports - Permanently removes every report metadata record of a campaign.
+func (r *implRepository) DeleteCampaignReports(ctx context.Context, campaignID string) (int64, error) {
+	rows, err := sqlboiler.Reports(qm.Where("campaign_id = ?", campaignID)).DeleteAll(ctx, r.db)
+	if err != nil {
+		r.l.Errorf(ctx, "report.repository.postgre.DeleteCampaignReports: Failed to delete reports: %v", err)
+		return 0, repository.ErrReportDeleteFailed
+	}
+	return rows, nil
+}
+
 // ListReports - List reports with filters and pagination.
 func (r *implRepository) ListReports(ctx context.Context, opts repository.ListReportsOptions) ([]*model.Report, error) {
 	mods := r.buildListReportsQuery(opts)
@@ -39,7 +39,8 @@ func (f ReportFilters) ToJSON() ([]byte, error) {
 }
 
 type GetReportInput struct {
-	ReportID string
+	ReportID   string
+	ShareToken string // link share token, for readers other than the owner
 }
 
 type ListReportsInput struct {
@@ -50,15 +51,18 @@ type ListReportsInput struct {
 }
 
 type DownloadReportInput struct {
-	ReportID string
+	ReportID   string
+	ShareToken string // link share token, for readers other than the owner
 }
 
 type GetReportContentInput struct {
-	ReportID string
+	ReportID   string
+	ShareToken string // link share token, for readers other than the owner
 }
 
 type GetReportProcessInput struct {
-	ReportID string
+	ReportID   string
+	ShareToken string // link share token, for readers other than the owner
 }
 
 type ListReportPostsInput struct {
@@ -67,6 +71,9 @@ type ListReportPostsInput struct {
 	PageSize  int
 	Sentiment string
 	Platform  string
+	// Cursor is NextCursor from the previous page; takes precedence over Page.
+	Cursor     string
+	ShareToken string
 }
 
 type ListPostCommentsInput struct {
@@ -114,6 +121,7 @@ type ReportOutput struct {
 	SectionsCount     int             `json:"sections_count,omitempty"`
 	GenerationTimeMs  int64           `json:"generation_time_ms,omitempty"`
 	Filters           json.RawMessage `json:"filters,omitempty"`
+	NumericCheck      json.RawMessage `json:"numeric_check,omitempty"`
 	CompletedAt       *string         `json:"completed_at,omitempty"`
 	CreatedAt         string          `json:"created_at"`
 }
@@ -141,11 +149,15 @@ type ReportCompetitorProgress struct {
 	Status   string `json:"status"`
 }
 
+// ListReportPostsOutput - One page of report evidence. Total counts every campaign
+// document matching the report filters; the relevance cut-off only shapes the pages.
 type ListReportPostsOutput struct {
-	Items    []ReportPostOutput `json:"items"`
-	Total    int                `json:"total"`
-	Page     int                `json:"page"`
-	PageSize int                `json:"page_size"`
+	Items      []ReportPostOutput `json:"items"`
+	Total      int                `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	HasMore    bool               `json:"has_more"`
 }
 
 type ReportPostOutput struct {
@@ -225,6 +237,12 @@ type DeleteOutput struct {
 	OK bool `json:"ok"`
 }
 
+// PurgeCampaignOutput - What PurgeCampaign removed
+type PurgeCampaignOutput struct {
+	Files   int64 // report files deleted from MinIO
+	Reports int64 // report records deleted
+}
+
 type SectionTemplate struct {
 	Title  string
 	Prompt string
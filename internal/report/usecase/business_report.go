@@ -15,6 +15,10 @@ import (
 const (
 	defaultBusinessEvidenceLimit = 24
 	maxEvidenceContentRunes      = 420
+
+	// coordinatedSeedingClusterSize flags evidence whose near-duplicate cluster
+	// is at least this large when the report is generated.
+	coordinatedSeedingClusterSize = 5
 )
 
 type businessPromptData struct {
@@ -40,6 +44,8 @@ type businessEvidence struct {
 	Aspects     []string
 	RankScore   float64
 	SearchScore float64
+	ClusterID   string
+	ClusterSize int // near-duplicate cluster size; refreshed from Postgres before prompting
 }
 
 func buildBusinessEvidencePack(results []search.SearchResult, limit int) []businessEvidence {
@@ -188,6 +194,8 @@ func businessEvidenceFromSearchResult(result search.SearchResult) businessEviden
 		Keywords:    result.Keywords,
 		Aspects:     evidenceAspects(result.Aspects),
 		SearchScore: result.Score,
+		ClusterID:   stringFromPayload(metadata, "near_dup_cluster_id"),
+		ClusterSize: intFromPayload(metadata, "near_dup_cluster_size"),
 	}
 }
 
@@ -427,6 +435,9 @@ func formatBusinessEvidenceForPrompt(evidence []businessEvidence) string {
 		if len(ev.Keywords) > 0 {
 			sb.WriteString(fmt.Sprintf("  keywords=%s\n", strings.Join(limitStrings(ev.Keywords, 8), ", ")))
 		}
+		if ev.ClusterSize >= coordinatedSeedingClusterSize {
+			sb.WriteString(fmt.Sprintf("  near_duplicates=%d (nội dung gần trùng lặp, có thể là seeding có tổ chức)\n", ev.ClusterSize))
+		}
 		sb.WriteString(fmt.Sprintf("  content=%q\n", truncateRunes(ev.Content, maxEvidenceContentRunes)))
 	}
 	return sb.String()
@@ -3,7 +3,9 @@ package usecase
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"knowledge-srv/internal/factcheck"
 	"knowledge-srv/internal/model"
 	"knowledge-srv/internal/report"
 	"knowledge-srv/internal/report/repository"
@@ -54,23 +56,25 @@ func (uc *implUseCase) generateInBackground(ctx context.Context, reportID string
 	}
 
 	totalDocs := len(searchOutput.Results)
-	uc.l.Infof(ctx, "report.usecase.generateInBackground: Found %d documents for report %s", totalDocs, reportID)
+	uc.l.Infof(ctx, "report.usecase.generateInBackground: Found %d documents for report %s (suppressed %d redundant)", totalDocs, reportID, searchOutput.SuppressedRedundant)
 
 	// Phase 2: Evidence - Select representative, business-grade documents.
 	// Use the full retrieval set here so the evidence pack can cover sentiment
 	// and platform diversity instead of only mirroring the top semantic hits.
 	evidence := buildBusinessEvidencePack(searchOutput.Results, uc.config.SampleSize)
+	uc.refreshClusterSizes(ctx, evidence)
 	analyticsSummary := uc.loadReportAnalyticsSummary(ctx, input.CampaignID)
 
 	// Phase 3: Generate - one coherent business report, grounded by evidence IDs.
-	prompt := buildBusinessReportPrompt(input, businessPromptData{
+	promptData := businessPromptData{
 		TotalDocs:        totalDocs,
 		Aggregation:      formatAggregation(searchOutput.Aggregations),
 		AnalyticsSummary: analyticsSummary,
 		Evidence:         formatBusinessEvidenceForPrompt(evidence),
 		Sections:         strings.Join(input.Filters.Sections, ", "),
 		CompetitorURLs:   strings.Join(input.Filters.CompetitorURLs, ", "),
-	})
+	}
+	prompt := buildBusinessReportPrompt(input, promptData)
 
 	llmCtx, cancel := context.WithTimeout(ctx, 4*time.Minute)
 	content, err := uc.llm.Generate(llmCtx, prompt)
@@ -84,6 +88,7 @@ func (uc *implUseCase) generateInBackground(ctx context.Context, reportID string
 		return
 	}
 	content = normalizeBusinessReportMarkdown(content)
+	content, numericCheck := uc.checkReportFigures(ctx, content, prompt, input, promptData)
 	sectionsCount := countBusinessSections(content)
 
 	uc.l.Infof(ctx, "report.usecase.generateInBackground: Generated business report with %d sections for report %s", sectionsCount, reportID)
@@ -143,6 +148,7 @@ func (uc *implUseCase) generateInBackground(ctx context.Context, reportID string
 		SectionsCount:     sectionsCount,
 		GenerationTimeMs:  generationTimeMs,
 		CompletedAt:       completedAt,
+		NumericCheck:      numericCheck,
 	})
 	if err != nil {
 		uc.l.Errorf(ctx, "report.usecase.generateInBackground: Failed to update completed status: %v", err)
@@ -152,6 +158,68 @@ func (uc *implUseCase) generateInBackground(ctx context.Context, reportID string
 	uc.l.Infof(ctx, "report.usecase.generateInBackground: Report %s completed in %dms", reportID, generationTimeMs)
 }
 
+// checkReportFigures verifies the report's figures against the data in its prompt.
+// Returns the checked content and the result as JSON, nil when the check is off or
+// the report states no figure.
+func (uc *implUseCase) checkReportFigures(ctx context.Context, content, prompt string, input report.GenerateInput, data businessPromptData) (string, json.RawMessage) {
+	if !uc.config.FactCheck.Enabled() {
+		return content, nil
+	}
+	facts := []factcheck.Fact{{Value: float64(data.TotalDocs), Source: "total_docs"}}
+	facts = append(facts, factcheck.FactsFromText(data.Aggregation, "aggregation")...)
+	facts = append(facts, factcheck.FactsFromText(data.AnalyticsSummary, "analytics")...)
+	facts = append(facts, factcheck.FactsFromText(data.Evidence, "evidence")...)
+	facts = append(facts, factcheck.FactsFromText(input.Filters.Prompt, "request")...)
+
+	checkCtx, cancel := context.WithTimeout(ctx, 4*time.Minute)
+	defer cancel()
+	checked, res := factcheck.Check(checkCtx, uc.config.FactCheck, content, facts, func(ctx context.Context, draft, feedback string) (string, error) {
+		rewritten, err := uc.llm.Generate(ctx, fmt.Sprintf("%s\n\nBản nháp trước:\n%s\n\n%s\n", prompt, draft, feedback))
+		if err != nil {
+			return "", err
+		}
+		return normalizeBusinessReportMarkdown(rewritten), nil
+	})
+	if res.Checked == 0 {
+		return checked, nil
+	}
+	if len(res.Unsupported) > 0 {
+		uc.l.Warnf(ctx, "report.usecase.checkReportFigures: %d/%d figures unsupported (mode=%s, regenerated=%v)",
+			len(res.Unsupported), res.Checked, res.Mode, res.Regenerated)
+	}
+	out, err := json.Marshal(res)
+	if err != nil {
+		return checked, nil
+	}
+	return checked, out
+}
+
+// refreshClusterSizes replaces the cluster sizes frozen in the point payloads with the
+// live member counts: in skip mode later duplicates are never indexed, so only the
+// cluster row knows how large a seeding campaign grew. Keeps payload sizes on failure.
+func (uc *implUseCase) refreshClusterSizes(ctx context.Context, evidence []businessEvidence) {
+	ids := make([]string, 0, len(evidence))
+	for _, ev := range evidence {
+		if ev.ClusterID != "" {
+			ids = append(ids, ev.ClusterID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	sizes, err := uc.indexingUC.ClusterSizes(ctx, ids)
+	if err != nil {
+		uc.l.Warnf(ctx, "report.usecase.refreshClusterSizes: ClusterSizes failed: %v", err)
+		return
+	}
+	for i := range evidence {
+		if size, ok := sizes[evidence[i].ClusterID]; ok {
+			evidence[i].ClusterSize = size
+		}
+	}
+}
+
 // aggregateDocs searches for relevant documents using the search UseCase.
 func (uc *implUseCase) aggregateDocs(ctx context.Context, input report.GenerateInput) (search.SearchOutput, error) {
 	sc := model.Scope{} // System-level scope for background tasks
@@ -161,6 +229,10 @@ func (uc *implUseCase) aggregateDocs(ctx context.Context, input report.GenerateI
 		Query:      buildReportRetrievalQuery(input.ReportType, input.Filters),
 		Limit:      uc.config.MaxDocs,
 		MinScore:   0.45,
+		Intent:     search.IntentReport,
+		// Diverse retrieval keeps the evidence pack from filling up with
+		// near-identical complaints about the same issue.
+		Diversity: &search.DiversityOptions{},
 		Filters: search.SearchFilters{
 			Sentiments: input.Filters.Sentiments,
 			Aspects:    input.Filters.Aspects,
@@ -1,9 +1,12 @@
 package usecase
 
 import (
+	"knowledge-srv/internal/factcheck"
+	"knowledge-srv/internal/indexing"
 	"knowledge-srv/internal/report"
 	"knowledge-srv/internal/report/repository"
 	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/share"
 	"knowledge-srv/pkg/analytics"
 
 	"github.com/smap-hcmut/shared-libs/go/llm"
@@ -22,26 +25,31 @@ type Config struct {
 	ReportBucket string
 	MaxDocs      int
 	SampleSize   int
+	FactCheck    factcheck.Config // verifies the report's figures against its source data
 }
 
 type implUseCase struct {
-	repo      repository.PostgresRepository
-	searchUC  search.UseCase
-	analytics analytics.Client
-	llm       llm.LLM
-	minio     minio.MinIO
-	l         log.Logger
-	config    Config
-	reportSem chan struct{}
+	repo       repository.PostgresRepository
+	searchUC   search.UseCase
+	indexingUC indexing.UseCase // live near-duplicate cluster sizes
+	analytics  analytics.Client
+	llm        llm.LLM
+	minio      minio.MinIO
+	shareUC    share.UseCase // nil = reports are readable by their owner and admins only
+	l          log.Logger
+	config     Config
+	reportSem  chan struct{}
 }
 
 // New creates a new report UseCase implementation.
 func New(
 	repo repository.PostgresRepository,
 	searchUC search.UseCase,
+	indexingUC indexing.UseCase,
 	analyticsClient analytics.Client,
 	llmClient llm.LLM,
 	minioClient minio.MinIO,
+	shareUC share.UseCase,
 	l log.Logger,
 	cfg Config,
 ) report.UseCase {
@@ -56,13 +64,15 @@ func New(
 	}
 
 	return &implUseCase{
-		repo:      repo,
-		searchUC:  searchUC,
-		analytics: analyticsClient,
-		llm:       llmClient,
-		minio:     minioClient,
-		l:         l,
-		config:    cfg,
-		reportSem: make(chan struct{}, 5),
+		repo:       repo,
+		searchUC:   searchUC,
+		indexingUC: indexingUC,
+		analytics:  analyticsClient,
+		llm:        llmClient,
+		minio:      minioClient,
+		shareUC:    shareUC,
+		l:          l,
+		config:     cfg,
+		reportSem:  make(chan struct{}, 5),
 	}
 }
@@ -116,7 +116,7 @@ func (uc *implUseCase) GetReport(ctx context.Context, sc model.Scope, input repo
 		uc.l.Errorf(ctx, "report.usecase.GetReport: Failed to get report: %v", err)
 		return report.ReportOutput{}, report.ErrReportNotFound
 	}
-	if !canAccessReport(sc, rpt) {
+	if !uc.canReadReport(ctx, sc, rpt, input.ShareToken) {
 		return report.ReportOutput{}, report.ErrReportForbidden
 	}
 
@@ -130,7 +130,7 @@ func (uc *implUseCase) DownloadReport(ctx context.Context, sc model.Scope, input
 		uc.l.Errorf(ctx, "report.usecase.DownloadReport: Failed to get report: %v", err)
 		return report.DownloadOutput{}, report.ErrReportNotFound
 	}
-	if !canAccessReport(sc, rpt) {
+	if !uc.canReadReport(ctx, sc, rpt, input.ShareToken) {
 		return report.DownloadOutput{}, report.ErrReportForbidden
 	}
 
@@ -169,7 +169,7 @@ func (uc *implUseCase) GetReportContent(ctx context.Context, sc model.Scope, inp
 		uc.l.Errorf(ctx, "report.usecase.GetReportContent: Failed to get report: %v", err)
 		return report.ReportContentOutput{}, report.ErrReportNotFound
 	}
-	if !canAccessReport(sc, rpt) {
+	if !uc.canReadReport(ctx, sc, rpt, input.ShareToken) {
 		return report.ReportContentOutput{}, report.ErrReportForbidden
 	}
 	if rpt.Status != report.StatusCompleted || rpt.FileURL == "" {
@@ -253,6 +253,7 @@ func (uc *implUseCase) buildReportOutput(rpt *model.Report) report.ReportOutput
 		SectionsCount:     rpt.SectionsCount,
 		GenerationTimeMs:  rpt.GenerationTimeMs,
 		Filters:           rpt.Filters,
+		NumericCheck:      rpt.NumericCheck,
 		CreatedAt:         rpt.CreatedAt.Format(time.RFC3339),
 	}
 
@@ -3,11 +3,13 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"knowledge-srv/internal/model"
 	"knowledge-srv/internal/report"
 	"knowledge-srv/internal/report/repository"
 	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/share"
 	"strings"
 	"time"
 )
@@ -65,7 +67,7 @@ func (uc *implUseCase) GetReportProcess(ctx context.Context, sc model.Scope, inp
 		uc.l.Errorf(ctx, "report.usecase.GetReportProcess: Failed to get report: %v", err)
 		return report.ReportProcessOutput{}, report.ErrReportNotFound
 	}
-	if !canAccessReport(sc, rpt) {
+	if !uc.canReadReport(ctx, sc, rpt, input.ShareToken) {
 		return report.ReportProcessOutput{}, report.ErrReportForbidden
 	}
 	return uc.buildProcessOutput(rpt), nil
@@ -77,7 +79,7 @@ func (uc *implUseCase) ListReportPosts(ctx context.Context, sc model.Scope, inpu
 		uc.l.Errorf(ctx, "report.usecase.ListReportPosts: Failed to get report: %v", err)
 		return report.ListReportPostsOutput{}, report.ErrReportNotFound
 	}
-	if !canAccessReport(sc, rpt) {
+	if !uc.canReadReport(ctx, sc, rpt, input.ShareToken) {
 		return report.ListReportPostsOutput{}, report.ErrReportForbidden
 	}
 
@@ -90,46 +92,67 @@ func (uc *implUseCase) ListReportPosts(ctx context.Context, sc model.Scope, inpu
 		filters.Platforms = []string{strings.ToLower(input.Platform)}
 	}
 
+	searchFilters := search.SearchFilters{
+		Sentiments: filters.Sentiments,
+		Aspects:    filters.Aspects,
+		Platforms:  filters.Platforms,
+		DateFrom:   filters.DateFrom,
+		DateTo:     filters.DateTo,
+		RiskLevels: filters.RiskLevels,
+	}
+
+	// Total counts the whole evidence set, independent of the page.
+	countOutput, err := uc.searchUC.Count(ctx, sc, search.CountInput{
+		CampaignID: rpt.CampaignID,
+		Filters:    searchFilters,
+	})
+	if err != nil {
+		uc.l.Errorf(ctx, "report.usecase.ListReportPosts: Count failed: %v", err)
+		return report.ListReportPostsOutput{}, report.ErrGenerationFailed
+	}
+
+	// Search pages stop at MaxSearchDepth, so deeper page numbers are empty.
+	if input.Cursor == "" && offset >= search.MaxSearchDepth {
+		return report.ListReportPostsOutput{
+			Items:    []report.ReportPostOutput{},
+			Total:    int(countOutput.Total),
+			Page:     page,
+			PageSize: pageSize,
+		}, nil
+	}
+
+	// Cursor requests resume directly; page-number requests start at the page offset.
 	searchOutput, err := uc.searchUC.Search(ctx, sc, search.SearchInput{
 		CampaignID: rpt.CampaignID,
 		Query:      buildReportEvidenceQuery(rpt.ReportType, filters),
-		Limit:      maxListPageSize,
+		Limit:      pageSize,
 		MinScore:   reportSearchMinScore,
-		Filters: search.SearchFilters{
-			Sentiments: filters.Sentiments,
-			Aspects:    filters.Aspects,
-			Platforms:  filters.Platforms,
-			DateFrom:   filters.DateFrom,
-			DateTo:     filters.DateTo,
-			RiskLevels: filters.RiskLevels,
-		},
+		Cursor:     input.Cursor,
+		Offset:     offset,
+		Intent:     search.IntentReport,
+		Filters:    searchFilters,
 	})
 	if err != nil {
+		if errors.Is(err, search.ErrInvalidCursor) {
+			return report.ListReportPostsOutput{}, report.ErrInvalidCursor
+		}
 		uc.l.Errorf(ctx, "report.usecase.ListReportPosts: Search failed: %v", err)
 		return report.ListReportPostsOutput{}, report.ErrGenerationFailed
 	}
 	searchOutput = sanitizeReportSearchOutput(searchOutput)
 
-	results := searchOutput.Results
-	if offset > len(results) {
-		results = nil
-	} else {
-		results = results[offset:]
-		if len(results) > pageSize {
-			results = results[:pageSize]
-		}
-	}
-
-	items := make([]report.ReportPostOutput, 0, len(results))
-	for _, result := range results {
+	items := make([]report.ReportPostOutput, 0, len(searchOutput.Results))
+	for _, result := range searchOutput.Results {
 		items = append(items, mapSearchResultToReportPost(rpt.ID, result))
 	}
 
 	return report.ListReportPostsOutput{
-		Items:    items,
-		Total:    searchOutput.TotalFound,
-		Page:     page,
-		PageSize: pageSize,
+		Items:      items,
+		Total:      int(countOutput.Total),
+		Page:       page,
+		PageSize:   pageSize,
+		NextCursor: searchOutput.NextCursor,
+		HasMore:    searchOutput.HasMore,
 	}, nil
 }
 
@@ -231,6 +254,34 @@ func (uc *implUseCase) DeleteReport(ctx context.Context, sc model.Scope, input r
 	return report.DeleteOutput{OK: true}, nil
 }
 
+// PurgeCampaign deletes the report files of a campaign before their records, so a failed
+// attempt can still find the files on retry.
+func (uc *implUseCase) PurgeCampaign(ctx context.Context, campaignID string) (report.PurgeCampaignOutput, error) {
+	var out report.PurgeCampaignOutput
+
+	reports, err := uc.repo.ListReports(ctx, repository.ListReportsOptions{CampaignID: campaignID})
+	if err != nil {
+		uc.l.Errorf(ctx, "report.usecase.PurgeCampaign: Failed to list reports of campaign %s: %v", campaignID, err)
+		return out, fmt.Errorf("%w: %v", report.ErrCampaignPurgeFailed, err)
+	}
+	for _, rpt := range reports {
+		if rpt.FileURL == "" {
+			continue
+		}
+		if err := uc.minio.DeleteFile(ctx, uc.config.ReportBucket, rpt.FileURL); err != nil {
+			uc.l.Errorf(ctx, "report.usecase.PurgeCampaign: Failed to delete artifact: report_id=%s object=%s err=%v", rpt.ID, rpt.FileURL, err)
+			return out, fmt.Errorf("%w: %v", report.ErrCampaignPurgeFailed, err)
+		}
+		out.Files++
+	}
+
+	if out.Reports, err = uc.repo.DeleteCampaignReports(ctx, campaignID); err != nil {
+		uc.l.Errorf(ctx, "report.usecase.PurgeCampaign: Failed to delete reports of campaign %s: %v", campaignID, err)
+		return out, fmt.Errorf("%w: %v", report.ErrCampaignPurgeFailed, err)
+	}
+	return out, nil
+}
+
 func normalizePagination(page, pageSize int) (int, int, int) {
 	if page <= 0 {
 		page = 1
@@ -261,6 +312,22 @@ func canAccessReport(sc model.Scope, rpt *model.Report) bool {
 	return sc.IsAdmin() || (sc.UserID != "" && sc.UserID == rpt.UserID)
 }
 
+// canReadReport - Owners and admins, or whoever the report is shared with.
+func (uc *implUseCase) canReadReport(ctx context.Context, sc model.Scope, rpt *model.Report, token string) bool {
+	if canAccessReport(sc, rpt) {
+		return true
+	}
+	if rpt == nil || uc.shareUC == nil {
+		return false
+	}
+	return uc.shareUC.CanRead(ctx, sc, share.Resource{
+		Type:       share.ResourceReport,
+		ID:         rpt.ID,
+		CampaignID: rpt.CampaignID,
+		OwnerID:    rpt.UserID,
+	}, token)
+}
+
 func canDeleteReport(sc model.Scope, rpt *model.Report) bool {
 	if rpt == nil {
 		return false
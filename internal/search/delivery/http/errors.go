@@ -29,6 +29,15 @@ var (
 	errInvalidFilters = pkgErrors.NewHTTPError(
 		400, "Invalid search filters",
 	)
+	errInvalidCursor = pkgErrors.NewHTTPError(
+		400, "Invalid or expired cursor for this query",
+	)
+	errForbidden = pkgErrors.NewHTTPError(
+		403, "Forbidden",
+	)
+	errAnalyticsFailed = pkgErrors.NewHTTPError(
+		500, "Failed to load query analytics",
+	)
 )
 
 func (h *handler) mapError(err error) error {
@@ -47,6 +56,12 @@ func (h *handler) mapError(err error) error {
 		return errSearchFailed
 	case errors.Is(err, search.ErrInvalidFilters):
 		return errInvalidFilters
+	case errors.Is(err, search.ErrInvalidCursor):
+		return errInvalidCursor
+	case errors.Is(err, search.ErrForbidden):
+		return errForbidden
+	case errors.Is(err, search.ErrAnalyticsFailed):
+		return errAnalyticsFailed
 	default:
 		return pkgErrors.NewHTTPError(500, "Internal server error")
 	}
@@ -1,13 +1,16 @@
 package http
 
 import (
+	"knowledge-srv/internal/model"
+
 	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/auth"
 	"github.com/smap-hcmut/shared-libs/go/response"
 )
 
 // Search - Search for analytics posts with filters
 // @Summary Search analytics posts
-// @Description Search for analytics posts by query with optional filters (sentiments, aspects, platforms, dates, risk levels)
+// @Description Search for analytics posts by query with optional filters (sentiments, aspects, platforms, dates, risk levels). Pass next_cursor back as cursor (with the same query and filters) to fetch the next page.
 // @Tags Search
 // @Accept json
 // @Produce json
@@ -42,3 +45,188 @@ func (h *handler) Search(c *gin.Context) {
 	resp := h.newSearchResp(output)
 	response.OK(c, resp)
 }
+
+// TopEntities - Most mentioned entities of a campaign
+// @Summary Top entities
+// @Description Most mentioned NLP entities (brands, products, people...) of a campaign with the sentiment mix of the posts mentioning them.
+// @Tags Search
+// @Produce json
+// @Param campaign_id path string true "Campaign ID"
+// @Param types query string false "Comma-separated entity types to include (e.g. BRAND,PRODUCT)"
+// @Param limit query int false "Max entities (default 10, max 50)"
+// @Success 200 {object} topEntitiesResp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /search/campaigns/{campaign_id}/entities [get]
+func (h *handler) TopEntities(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processTopEntitiesRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.TopEntities: processTopEntitiesRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.TopEntities(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.TopEntities: usecase TopEntities failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newTopEntitiesResp(output))
+}
+
+// TopQueries - Most frequent queries of a campaign
+// @Summary Top search queries
+// @Description Most frequent normalized queries across search, chat and report retrieval. Admin only.
+// @Tags Search Analytics
+// @Produce json
+// @Param campaign_id path string true "Campaign ID"
+// @Param from query int false "Window start (unix seconds, default now-7d)"
+// @Param to query int false "Window end (unix seconds, default now)"
+// @Param limit query int false "Max queries (default 20, max 100)"
+// @Success 200 {object} queryStatsResp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /search/analytics/campaigns/{campaign_id}/top-queries [get]
+func (h *handler) TopQueries(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processQueryAnalyticsRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.TopQueries: processQueryAnalyticsRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.TopQueries(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.TopQueries: usecase TopQueries failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newQueryStatsResp(output.Queries))
+}
+
+// ZeroResultQueries - Queries that returned no results
+// @Summary Zero-result queries
+// @Description Most frequent queries that returned no results (content gaps). Admin only.
+// @Tags Search Analytics
+// @Produce json
+// @Param campaign_id path string true "Campaign ID"
+// @Param from query int false "Window start (unix seconds, default now-7d)"
+// @Param to query int false "Window end (unix seconds, default now)"
+// @Param limit query int false "Max queries (default 20, max 100)"
+// @Success 200 {object} queryStatsResp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /search/analytics/campaigns/{campaign_id}/zero-result-queries [get]
+func (h *handler) ZeroResultQueries(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processQueryAnalyticsRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.ZeroResultQueries: processQueryAnalyticsRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.ZeroResultQueries(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.ZeroResultQueries: usecase ZeroResultQueries failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newQueryStatsResp(output.Queries))
+}
+
+// ScoreDistribution - Top-score histogram of retrievals
+// @Summary Retrieval score distribution
+// @Description Histogram of the top result score per retrieval. Admin only.
+// @Tags Search Analytics
+// @Produce json
+// @Param campaign_id path string true "Campaign ID"
+// @Param from query int false "Window start (unix seconds, default now-7d)"
+// @Param to query int false "Window end (unix seconds, default now)"
+// @Success 200 {object} scoreDistributionResp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /search/analytics/campaigns/{campaign_id}/score-distribution [get]
+func (h *handler) ScoreDistribution(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processQueryAnalyticsRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.ScoreDistribution: processQueryAnalyticsRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.ScoreDistribution(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.ScoreDistribution: usecase ScoreDistribution failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newScoreDistributionResp(output))
+}
+
+// LatencyPercentiles - Retrieval latency percentiles
+// @Summary Retrieval latency percentiles
+// @Description p50/p90/p95/p99 retrieval latency and cache hit rate. Admin only.
+// @Tags Search Analytics
+// @Produce json
+// @Param campaign_id path string true "Campaign ID"
+// @Param from query int false "Window start (unix seconds, default now-7d)"
+// @Param to query int false "Window end (unix seconds, default now)"
+// @Success 200 {object} latencyPercentilesResp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /search/analytics/campaigns/{campaign_id}/latency [get]
+func (h *handler) LatencyPercentiles(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processQueryAnalyticsRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.LatencyPercentiles: processQueryAnalyticsRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.LatencyPercentiles(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.LatencyPercentiles: usecase LatencyPercentiles failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newLatencyPercentilesResp(output))
+}
+
+// CacheStats - Search/aggregate cache counters
+// @Summary Search cache stats
+// @Description Cumulative hit/miss/evict counters of the project-tagged search and aggregate cache. Admin only.
+// @Tags Search Analytics
+// @Produce json
+// @Success 200 {object} cacheStatsResp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /search/cache/stats [get]
+func (h *handler) CacheStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	sc := model.ToScope(auth.GetScopeFromContext(ctx))
+	output, err := h.uc.CacheStats(ctx, sc)
+	if err != nil {
+		h.l.Errorf(ctx, "search.delivery.http.CacheStats: usecase CacheStats failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newCacheStatsResp(output))
+}
@@ -12,16 +12,46 @@ type searchReq struct {
 	Filters    *searchFilterReq `json:"filters,omitempty"`
 	Limit      int              `json:"limit,omitempty"`
 	MinScore   float64          `json:"min_score,omitempty"`
+	Cursor     string           `json:"cursor,omitempty"`
+	Recency    *recencyReq      `json:"recency,omitempty"`
+	Layers     *layersReq       `json:"layers,omitempty"`
+	Diversity  *diversityReq    `json:"diversity,omitempty"`
+}
+
+// diversityReq enables MMR diversification (0 → server default).
+type diversityReq struct {
+	Lambda              float64 `json:"lambda,omitempty"`
+	RedundancyThreshold float64 `json:"redundancy_threshold,omitempty"`
+}
+
+// layersReq blends macro digests/insight cards into the first page (0 → default, <0 → off).
+type layersReq struct {
+	Digests  int `json:"digests,omitempty"`
+	Insights int `json:"insights,omitempty"`
+}
+
+type recencyReq struct {
+	Decay         string  `json:"decay,omitempty"` // exponential | gaussian
+	HalfLifeHours float64 `json:"half_life_hours,omitempty"`
+	Weight        float64 `json:"weight,omitempty"`
 }
 
 type searchFilterReq struct {
-	Sentiments    []string `json:"sentiments,omitempty"`
-	Aspects       []string `json:"aspects,omitempty"`
-	Platforms     []string `json:"platforms,omitempty"`
-	DateFrom      *int64   `json:"date_from,omitempty"`
-	DateTo        *int64   `json:"date_to,omitempty"`
-	RiskLevels    []string `json:"risk_levels,omitempty"`
-	MinEngagement *float64 `json:"min_engagement,omitempty"`
+	Sentiments    []string         `json:"sentiments,omitempty"`
+	Aspects       []string         `json:"aspects,omitempty"`
+	Platforms     []string         `json:"platforms,omitempty"`
+	DateFrom      *int64           `json:"date_from,omitempty"`
+	DateTo        *int64           `json:"date_to,omitempty"`
+	RiskLevels    []string         `json:"risk_levels,omitempty"`
+	MinEngagement *float64         `json:"min_engagement,omitempty"`
+	Entities      *entityFilterReq `json:"entities,omitempty"`
+}
+
+// entityFilterReq matches posts by NLP entities (values case-insensitive).
+type entityFilterReq struct {
+	Types  []string `json:"types,omitempty"`
+	Values []string `json:"values,omitempty"`
+	Match  string   `json:"match,omitempty"` // any (default) | all
 }
 
 func (r searchReq) toInput() search.SearchInput {
@@ -30,6 +60,26 @@ func (r searchReq) toInput() search.SearchInput {
 		Query:      r.Query,
 		Limit:      r.Limit,
 		MinScore:   r.MinScore,
+		Cursor:     r.Cursor,
+	}
+	if r.Recency != nil {
+		input.Recency = &search.RecencyOptions{
+			Decay:         r.Recency.Decay,
+			HalfLifeHours: r.Recency.HalfLifeHours,
+			Weight:        r.Recency.Weight,
+		}
+	}
+	if r.Layers != nil {
+		input.Layers = &search.LayerQuotas{
+			Digests:  r.Layers.Digests,
+			Insights: r.Layers.Insights,
+		}
+	}
+	if r.Diversity != nil {
+		input.Diversity = &search.DiversityOptions{
+			Lambda:              r.Diversity.Lambda,
+			RedundancyThreshold: r.Diversity.RedundancyThreshold,
+		}
 	}
 	if r.Filters != nil {
 		input.Filters = search.SearchFilters{
@@ -41,6 +91,13 @@ func (r searchReq) toInput() search.SearchInput {
 			RiskLevels:    r.Filters.RiskLevels,
 			MinEngagement: r.Filters.MinEngagement,
 		}
+		if r.Filters.Entities != nil {
+			input.Filters.Entities = &search.EntityFilter{
+				Types:  r.Filters.Entities.Types,
+				Values: r.Filters.Entities.Values,
+				Match:  r.Filters.Entities.Match,
+			}
+		}
 	}
 	return input
 }
@@ -50,12 +107,15 @@ func (r searchReq) toInput() search.SearchInput {
 // =====================================================
 
 type searchResp struct {
-	Results           []searchResultResp `json:"results"`
-	TotalFound        int                `json:"total_found"`
-	Aggregations      aggregationsResp   `json:"aggregations"`
-	NoRelevantContext bool               `json:"no_relevant_context"`
-	CacheHit          bool               `json:"cache_hit"`
-	ProcessingTimeMs  int64              `json:"processing_time_ms"`
+	Results             []searchResultResp `json:"results"`
+	TotalFound          int                `json:"total_found"`
+	Aggregations        aggregationsResp   `json:"aggregations"`
+	NoRelevantContext   bool               `json:"no_relevant_context"`
+	CacheHit            bool               `json:"cache_hit"`
+	ProcessingTimeMs    int64              `json:"processing_time_ms"`
+	NextCursor          string             `json:"next_cursor,omitempty"`
+	HasMore             bool               `json:"has_more"`
+	SuppressedRedundant int                `json:"suppressed_redundant"`
 }
 
 type searchResultResp struct {
@@ -71,6 +131,8 @@ type searchResultResp struct {
 	RiskLevel        string             `json:"risk_level"`
 	EngagementScore  float64            `json:"engagement_score"`
 	ContentCreatedAt int64              `json:"content_created_at"`
+	RecencyFactor    float64            `json:"recency_factor"`
+	Layer            string             `json:"layer"`
 }
 
 type aspectResultResp struct {
@@ -107,10 +169,13 @@ type platformAggResp struct {
 
 func (h *handler) newSearchResp(output search.SearchOutput) searchResp {
 	resp := searchResp{
-		TotalFound:        output.TotalFound,
-		NoRelevantContext: output.NoRelevantContext,
-		CacheHit:          output.CacheHit,
-		ProcessingTimeMs:  output.ProcessingTimeMs,
+		TotalFound:          output.TotalFound,
+		NoRelevantContext:   output.NoRelevantContext,
+		CacheHit:            output.CacheHit,
+		ProcessingTimeMs:    output.ProcessingTimeMs,
+		NextCursor:          output.NextCursor,
+		HasMore:             output.HasMore,
+		SuppressedRedundant: output.SuppressedRedundant,
 	}
 
 	// Map results
@@ -127,6 +192,8 @@ func (h *handler) newSearchResp(output search.SearchOutput) searchResp {
 			RiskLevel:        r.RiskLevel,
 			EngagementScore:  r.EngagementScore,
 			ContentCreatedAt: r.ContentCreatedAt,
+			RecencyFactor:    r.RecencyFactor,
+			Layer:            r.Layer,
 			Keywords:         r.Keywords,
 		}
 		for _, a := range r.Aspects {
@@ -163,3 +230,163 @@ func (h *handler) newSearchResp(output search.SearchOutput) searchResp {
 
 	return resp
 }
+
+// =====================================================
+// Top Entities DTOs
+// =====================================================
+
+type topEntitiesReq struct {
+	CampaignID string
+	Types      []string
+	Limit      int
+}
+
+func (r topEntitiesReq) toInput() search.TopEntitiesInput {
+	return search.TopEntitiesInput{
+		CampaignID: r.CampaignID,
+		Types:      r.Types,
+		Limit:      r.Limit,
+	}
+}
+
+type entityStatResp struct {
+	Type         string            `json:"type"`
+	Value        string            `json:"value"`
+	Mentions     uint64            `json:"mentions"`
+	SentimentMix map[string]uint64 `json:"sentiment_mix"`
+}
+
+type topEntitiesResp struct {
+	Entities []entityStatResp `json:"entities"`
+}
+
+func (h *handler) newTopEntitiesResp(o search.TopEntitiesOutput) topEntitiesResp {
+	resp := topEntitiesResp{Entities: make([]entityStatResp, len(o.Entities))}
+	for i, e := range o.Entities {
+		resp.Entities[i] = entityStatResp{
+			Type:         e.Type,
+			Value:        e.Value,
+			Mentions:     e.Mentions,
+			SentimentMix: e.SentimentMix,
+		}
+	}
+	return resp
+}
+
+// =====================================================
+// Query Analytics DTOs
+// =====================================================
+
+type queryAnalyticsReq struct {
+	CampaignID string
+	From       *int64
+	To         *int64
+	Limit      int
+}
+
+func (r queryAnalyticsReq) toInput() search.QueryAnalyticsInput {
+	return search.QueryAnalyticsInput{
+		CampaignID: r.CampaignID,
+		From:       r.From,
+		To:         r.To,
+		Limit:      r.Limit,
+	}
+}
+
+type queryStatResp struct {
+	Query           string  `json:"query"`
+	Count           int     `json:"count"`
+	ZeroResultCount int     `json:"zero_result_count"`
+	AvgResultCount  float64 `json:"avg_result_count"`
+	AvgTopScore     float64 `json:"avg_top_score"`
+	LastSeenAt      int64   `json:"last_seen_at"`
+}
+
+type queryStatsResp struct {
+	Queries []queryStatResp `json:"queries"`
+}
+
+type scoreBucketResp struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+type scoreDistributionResp struct {
+	Total      int               `json:"total"`
+	ZeroResult int               `json:"zero_result"`
+	Buckets    []scoreBucketResp `json:"buckets"`
+}
+
+type latencyPercentilesResp struct {
+	Total        int     `json:"total"`
+	CacheHitRate float64 `json:"cache_hit_rate"`
+	AvgMs        float64 `json:"avg_ms"`
+	P50Ms        float64 `json:"p50_ms"`
+	P90Ms        float64 `json:"p90_ms"`
+	P95Ms        float64 `json:"p95_ms"`
+	P99Ms        float64 `json:"p99_ms"`
+}
+
+func (h *handler) newQueryStatsResp(stats []search.QueryStat) queryStatsResp {
+	resp := queryStatsResp{Queries: make([]queryStatResp, len(stats))}
+	for i, s := range stats {
+		resp.Queries[i] = queryStatResp{
+			Query:           s.NormalizedQuery,
+			Count:           s.Count,
+			ZeroResultCount: s.ZeroResultCount,
+			AvgResultCount:  s.AvgResultCount,
+			AvgTopScore:     s.AvgTopScore,
+			LastSeenAt:      s.LastSeenAt,
+		}
+	}
+	return resp
+}
+
+func (h *handler) newScoreDistributionResp(o search.ScoreDistributionOutput) scoreDistributionResp {
+	resp := scoreDistributionResp{
+		Total:      o.Total,
+		ZeroResult: o.ZeroResult,
+		Buckets:    make([]scoreBucketResp, len(o.Buckets)),
+	}
+	for i, b := range o.Buckets {
+		resp.Buckets[i] = scoreBucketResp{From: b.From, To: b.To, Count: b.Count}
+	}
+	return resp
+}
+
+func (h *handler) newLatencyPercentilesResp(o search.LatencyPercentilesOutput) latencyPercentilesResp {
+	return latencyPercentilesResp{
+		Total:        o.Total,
+		CacheHitRate: o.CacheHitRate,
+		AvgMs:        o.AvgMs,
+		P50Ms:        o.P50Ms,
+		P90Ms:        o.P90Ms,
+		P95Ms:        o.P95Ms,
+		P99Ms:        o.P99Ms,
+	}
+}
+
+type cacheStatsResp struct {
+	SearchHits       int64   `json:"search_hits"`
+	SearchMisses     int64   `json:"search_misses"`
+	SearchHitRate    float64 `json:"search_hit_rate"`
+	AggregateHits    int64   `json:"aggregate_hits"`
+	AggregateMisses  int64   `json:"aggregate_misses"`
+	AggregateHitRate float64 `json:"aggregate_hit_rate"`
+	Evictions        int64   `json:"evictions"`
+	EvictedKeys      int64   `json:"evicted_keys"`
+}
+
+func (h *handler) newCacheStatsResp(o search.CacheStatsOutput) cacheStatsResp {
+	return cacheStatsResp{
+		SearchHits:       o.SearchHits,
+		SearchMisses:     o.SearchMisses,
+		SearchHitRate:    o.SearchHitRate,
+		AggregateHits:    o.AggregateHits,
+		AggregateMisses:  o.AggregateMisses,
+		AggregateHitRate: o.AggregateHitRate,
+		Evictions:        o.Evictions,
+		EvictedKeys:      o.EvictedKeys,
+	}
+}
@@ -1,6 +1,9 @@
 package http
 
 import (
+	"strconv"
+	"strings"
+
 	"knowledge-srv/internal/model"
 
 	"github.com/gin-gonic/gin"
@@ -17,3 +20,53 @@ func (h *handler) processSearchRequest(c *gin.Context) (searchReq, model.Scope,
 	sc := auth.GetScopeFromContext(c.Request.Context())
 	return req, model.ToScope(sc), nil
 }
+
+func (h *handler) processQueryAnalyticsRequest(c *gin.Context) (queryAnalyticsReq, model.Scope, error) {
+	req := queryAnalyticsReq{
+		CampaignID: c.Param("campaign_id"),
+		From:       queryInt64Ptr(c, "from"),
+		To:         queryInt64Ptr(c, "to"),
+		Limit:      queryInt(c, "limit", 0),
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processTopEntitiesRequest(c *gin.Context) (topEntitiesReq, model.Scope, error) {
+	req := topEntitiesReq{
+		CampaignID: c.Param("campaign_id"),
+		Limit:      queryInt(c, "limit", 0),
+	}
+	// Accept both ?types=BRAND,PRODUCT and repeated ?types=BRAND&types=PRODUCT
+	for _, raw := range c.QueryArray("types") {
+		req.Types = append(req.Types, strings.Split(raw, ",")...)
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func queryInt(c *gin.Context, key string, fallback int) int {
+	raw := c.Query(key)
+	if raw == "" {
+		return fallback
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func queryInt64Ptr(c *gin.Context, key string) *int64 {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
@@ -9,5 +9,15 @@ func (h *handler) RegisterRoutes(r *gin.RouterGroup, mw *middleware.Middleware)
 	r.Use(mw.Auth())
 	{
 		r.POST("/search", h.Search)
+		r.GET("/search/campaigns/:campaign_id/entities", h.TopEntities)
+
+		// Query analytics (admin)
+		analytics := r.Group("/search/analytics/campaigns/:campaign_id")
+		analytics.GET("/top-queries", h.TopQueries)
+		analytics.GET("/zero-result-queries", h.ZeroResultQueries)
+		analytics.GET("/score-distribution", h.ScoreDistribution)
+		analytics.GET("/latency", h.LatencyPercentiles)
+
+		r.GET("/search/cache/stats", h.CacheStats)
 	}
 }
@@ -10,4 +10,9 @@ var (
 	ErrEmbeddingFailed    = errors.New("search: embedding generation failed")
 	ErrSearchFailed       = errors.New("search: qdrant search failed")
 	ErrInvalidFilters     = errors.New("search: invalid filters")
+	ErrInvalidCursor      = errors.New("search: invalid cursor")
+	ErrInvalidTimeRange   = errors.New("search: invalid time range")
+	ErrForbidden          = errors.New("search: forbidden")
+	ErrAnalyticsFailed    = errors.New("search: query analytics failed")
+	ErrPurgeFailed        = errors.New("search: campaign purge failed")
 )
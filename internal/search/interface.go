@@ -9,4 +9,38 @@ import (
 type UseCase interface {
 	Search(ctx context.Context, sc model.Scope, input SearchInput) (SearchOutput, error)
 	Aggregate(ctx context.Context, sc model.Scope, input AggregateInput) (AggregateOutput, error)
+	// Count counts the campaign documents matching the filters, regardless of relevance
+	Count(ctx context.Context, sc model.Scope, input CountInput) (CountOutput, error)
+	// TimeSeries counts campaign documents per day, week or month with their sentiment mix
+	TimeSeries(ctx context.Context, sc model.Scope, input TimeSeriesInput) (TimeSeriesOutput, error)
+	// TopEntities lists the most mentioned entities of a campaign with their sentiment mix
+	TopEntities(ctx context.Context, sc model.Scope, input TopEntitiesInput) (TopEntitiesOutput, error)
+
+	// Query analytics (admin only)
+	TopQueries(ctx context.Context, sc model.Scope, input QueryAnalyticsInput) (TopQueriesOutput, error)
+	ZeroResultQueries(ctx context.Context, sc model.Scope, input QueryAnalyticsInput) (ZeroResultQueriesOutput, error)
+	ScoreDistribution(ctx context.Context, sc model.Scope, input QueryAnalyticsInput) (ScoreDistributionOutput, error)
+	LatencyPercentiles(ctx context.Context, sc model.Scope, input QueryAnalyticsInput) (LatencyPercentilesOutput, error)
+
+	// CampaignProjects resolves the project IDs of a campaign (cached like Search does)
+	CampaignProjects(ctx context.Context, campaignID string) ([]string, error)
+	// CampaignAccess resolves a campaign the caller may read: admins read every
+	// campaign, other users those with a project they can access (ErrForbidden otherwise)
+	CampaignAccess(ctx context.Context, sc model.Scope, campaignID string) (CampaignAccessOutput, error)
+
+	// CacheStats returns search/aggregate cache counters (admin only)
+	CacheStats(ctx context.Context, sc model.Scope) (CacheStatsOutput, error)
+
+	// InvalidateProject evicts every cached search/aggregate entry and chat answer that
+	// covers projectID; domains that change a project's data call it afterwards
+	InvalidateProject(ctx context.Context, projectID string) error
+	// TagCachedAnswer registers a chat answer cached under cacheKey with the projects it
+	// was built from, so InvalidateProject evicts it too
+	TagCachedAnswer(ctx context.Context, input TagCachedAnswerInput) error
+	// PurgeCampaign deletes the query logs of a deleted campaign. Safe to re-run.
+	PurgeCampaign(ctx context.Context, campaignID string) (PurgeCampaignOutput, error)
+
+	// Close stops the query log writer once the buffered records are written;
+	// ctx bounds the flush. Call after the HTTP server stopped serving.
+	Close(ctx context.Context) error
 }
@@ -0,0 +1,36 @@
+package repository
+
+import "fmt"
+
+// Redis layout for tag-indexed result caching. Other domains tag and evict
+// entries through search.UseCase, never through these keys.
+const (
+	CacheTagKeyPrefix = "search_tag:"
+	CacheStatsKey     = "search_cache:stats"
+
+	// AnswerTagKeyPrefix tags the chat answer cache. Its entries live for hours,
+	// so they get their own tag sets: search entries refresh a tag's TTL to minutes.
+	AnswerTagKeyPrefix = "chat_answer_tag:"
+
+	CacheStatSearchHit     = "search_hit"
+	CacheStatSearchMiss    = "search_miss"
+	CacheStatAggregateHit  = "aggregate_hit"
+	CacheStatAggregateMiss = "aggregate_miss"
+	CacheStatEvictions     = "evictions"
+	CacheStatEvictedKeys   = "evicted_keys"
+)
+
+// CacheTagKey - Redis SET holding the cache keys that cover projectID
+func CacheTagKey(projectID string) string {
+	return fmt.Sprintf("%s%s", CacheTagKeyPrefix, projectID)
+}
+
+// AnswerTagKey - Redis SET holding the cached chat answers that cover projectID
+func AnswerTagKey(projectID string) string {
+	return fmt.Sprintf("%s%s", AnswerTagKeyPrefix, projectID)
+}
+
+// ProjectTagKeys - Every tag set evicted when the data of projectID changes
+func ProjectTagKeys(projectID string) []string {
+	return []string{CacheTagKey(projectID), AnswerTagKey(projectID)}
+}
@@ -8,4 +8,7 @@ var (
 	ErrCacheMiss         = errors.New("repository: cache miss")
 	ErrCacheSetFailed    = errors.New("repository: failed to set cache")
 	ErrCacheDeleteFailed = errors.New("repository: failed to delete cache")
+	ErrFailedToInsert    = errors.New("repository: failed to insert")
+	ErrFailedToQuery     = errors.New("repository: failed to query")
+	ErrFailedToDelete    = errors.New("repository: failed to delete")
 )
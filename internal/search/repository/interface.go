@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 )
 
 // QdrantRepository is removed in favor of point.UseCase
@@ -14,8 +15,68 @@ type CacheRepository interface {
 	GetCampaignName(ctx context.Context, campaignID string) (string, error)
 	SaveCampaignName(ctx context.Context, campaignID string, name string) error
 
+	// Cached entries are tag-indexed by the project IDs they cover.
 	GetSearchResults(ctx context.Context, cacheKey string) ([]byte, error)
-	SaveSearchResults(ctx context.Context, cacheKey string, data []byte) error
+	SaveSearchResults(ctx context.Context, cacheKey string, data []byte, projectIDs []string) error
 
+	GetAggregateResults(ctx context.Context, cacheKey string) ([]byte, error)
+	SaveAggregateResults(ctx context.Context, cacheKey string, data []byte, projectIDs []string) error
+
+	// TagAnswer adds a cached chat answer to the answer tag sets of projectIDs.
+	TagAnswer(ctx context.Context, cacheKey string, projectIDs []string, ttl time.Duration) error
+	// InvalidateSearchCache evicts every search/aggregate entry and chat answer tagged with projectID.
 	InvalidateSearchCache(ctx context.Context, projectID string) error
+
+	GetCacheStats(ctx context.Context) (CacheStats, error)
+}
+
+// CacheStats - Cumulative hit/miss/evict counters shared across instances
+type CacheStats struct {
+	SearchHits      int64
+	SearchMisses    int64
+	AggregateHits   int64
+	AggregateMisses int64
+	Evictions       int64 // tag invalidations
+	EvictedKeys     int64 // cache entries removed by tag invalidations
+}
+
+// QueryLogRepository - Operations for search_query_logs table
+//
+//go:generate mockery --name QueryLogRepository
+type QueryLogRepository interface {
+	CreateQueryLog(ctx context.Context, opt CreateQueryLogOptions) error
+	TopQueries(ctx context.Context, opt QueryAnalyticsOptions) ([]QueryStat, error)
+	ZeroResultQueries(ctx context.Context, opt QueryAnalyticsOptions) ([]QueryStat, error)
+	ScoreDistribution(ctx context.Context, opt QueryAnalyticsOptions) (ScoreDistribution, error)
+	LatencyPercentiles(ctx context.Context, opt QueryAnalyticsOptions) (LatencyStats, error)
+	// DeleteCampaignQueryLogs deletes every query log of a campaign, returning the rows removed.
+	DeleteCampaignQueryLogs(ctx context.Context, campaignID string) (int64, error)
+}
+
+// QueryStat - Aggregated stats for one normalized query
+type QueryStat struct {
+	NormalizedQuery string
+	Count           int
+	ZeroResultCount int
+	AvgResultCount  float64
+	AvgTopScore     float64
+	LastSeenAt      time.Time
+}
+
+// ScoreDistribution - Top-score histogram over [0, 1] in equal-width buckets
+type ScoreDistribution struct {
+	Total      int
+	ZeroResult int
+	Counts     []int // len == QueryAnalyticsOptions.Buckets
+}
+
+// LatencyStats - Latency percentiles (ms) for a campaign
+type LatencyStats struct {
+	Total    int
+	CacheHit int
+	AvgMs    float64
+	P50Ms    float64
+	P90Ms    float64
+	P95Ms    float64
+	P99Ms    float64
 }
@@ -1,6 +1,11 @@
 package repository
 
-import pb "github.com/qdrant/go-client/qdrant"
+import (
+	"encoding/json"
+	"time"
+
+	pb "github.com/qdrant/go-client/qdrant"
+)
 
 // SearchPointsOptions - Options cho SearchPoints
 type SearchPointsOptions struct {
@@ -10,3 +15,28 @@ type SearchPointsOptions struct {
 	ScoreThreshold *float32   // Min score threshold (optional)
 	WithPayload    []string   // Fields to include in payload (selective retrieval)
 }
+
+// CreateQueryLogOptions - Options for recording one search/chat retrieval
+type CreateQueryLogOptions struct {
+	CampaignID        string
+	UserID            string // empty for system-initiated retrievals
+	QueryText         string
+	NormalizedQuery   string
+	Intent            string
+	Filters           json.RawMessage
+	ResultCount       int
+	TopScore          float64
+	NoRelevantContext bool
+	CacheHit          bool
+	LatencyMs         int64
+	CreatedAt         time.Time
+}
+
+// QueryAnalyticsOptions - Options for query analytics reads
+type QueryAnalyticsOptions struct {
+	CampaignID string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Buckets    int // ScoreDistribution only
+}
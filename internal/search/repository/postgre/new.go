@@ -0,0 +1,21 @@
+package postgre
+
+import (
+	"database/sql"
+	"knowledge-srv/internal/search/repository"
+
+	"github.com/smap-hcmut/shared-libs/go/log"
+)
+
+type implPostgresRepository struct {
+	db *sql.DB
+	l  log.Logger
+}
+
+// New - Factory
+func New(db *sql.DB, l log.Logger) repository.QueryLogRepository {
+	return &implPostgresRepository{
+		db: db,
+		l:  l,
+	}
+}
@@ -0,0 +1,195 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+
+	"knowledge-srv/internal/search/repository"
+)
+
+// CreateQueryLog inserts one retrieval record.
+func (r *implPostgresRepository) CreateQueryLog(ctx context.Context, opt repository.CreateQueryLogOptions) error {
+	const query = `
+		INSERT INTO knowledge.search_query_logs (
+			campaign_id, user_id, query_text, normalized_query, intent, filters,
+			result_count, top_score, no_relevant_context, cache_hit, latency_ms, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	var userID sql.NullString
+	if opt.UserID != "" {
+		userID = sql.NullString{String: opt.UserID, Valid: true}
+	}
+	var filters interface{}
+	if len(opt.Filters) > 0 {
+		filters = []byte(opt.Filters)
+	}
+	var topScore sql.NullFloat64
+	if opt.ResultCount > 0 {
+		topScore = sql.NullFloat64{Float64: opt.TopScore, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		opt.CampaignID, userID, opt.QueryText, opt.NormalizedQuery, opt.Intent, filters,
+		opt.ResultCount, topScore, opt.NoRelevantContext, opt.CacheHit, opt.LatencyMs, opt.CreatedAt,
+	)
+	if err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.CreateQueryLog: Failed to insert query log: %v", err)
+		return repository.ErrFailedToInsert
+	}
+	return nil
+}
+
+// DeleteCampaignQueryLogs removes every retrieval record of a campaign.
+func (r *implPostgresRepository) DeleteCampaignQueryLogs(ctx context.Context, campaignID string) (int64, error) {
+	const query = `DELETE FROM knowledge.search_query_logs WHERE campaign_id = $1`
+
+	res, err := r.db.ExecContext(ctx, query, campaignID)
+	if err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.DeleteCampaignQueryLogs: Failed to delete query logs: %v", err)
+		return 0, repository.ErrFailedToDelete
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.DeleteCampaignQueryLogs: Failed to count deleted rows: %v", err)
+		return 0, repository.ErrFailedToDelete
+	}
+	return n, nil
+}
+
+// TopQueries returns the most frequent normalized queries in the window.
+func (r *implPostgresRepository) TopQueries(ctx context.Context, opt repository.QueryAnalyticsOptions) ([]repository.QueryStat, error) {
+	const query = `
+		SELECT normalized_query,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE result_count = 0),
+		       AVG(result_count),
+		       COALESCE(AVG(top_score), 0),
+		       MAX(created_at)
+		FROM knowledge.search_query_logs
+		WHERE campaign_id = $1 AND created_at >= $2 AND created_at < $3
+		GROUP BY normalized_query
+		ORDER BY COUNT(*) DESC, MAX(created_at) DESC
+		LIMIT $4`
+
+	stats, err := r.queryStats(ctx, query, opt)
+	if err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.TopQueries: Failed to query top queries: %v", err)
+		return nil, repository.ErrFailedToQuery
+	}
+	return stats, nil
+}
+
+// ZeroResultQueries returns the most frequent queries that returned nothing (content gaps).
+func (r *implPostgresRepository) ZeroResultQueries(ctx context.Context, opt repository.QueryAnalyticsOptions) ([]repository.QueryStat, error) {
+	const query = `
+		SELECT normalized_query,
+		       COUNT(*),
+		       COUNT(*),
+		       0::float8,
+		       0::float8,
+		       MAX(created_at)
+		FROM knowledge.search_query_logs
+		WHERE campaign_id = $1 AND created_at >= $2 AND created_at < $3 AND result_count = 0
+		GROUP BY normalized_query
+		ORDER BY COUNT(*) DESC, MAX(created_at) DESC
+		LIMIT $4`
+
+	stats, err := r.queryStats(ctx, query, opt)
+	if err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.ZeroResultQueries: Failed to query zero-result queries: %v", err)
+		return nil, repository.ErrFailedToQuery
+	}
+	return stats, nil
+}
+
+func (r *implPostgresRepository) queryStats(ctx context.Context, query string, opt repository.QueryAnalyticsOptions) ([]repository.QueryStat, error) {
+	rows, err := r.db.QueryContext(ctx, query, opt.CampaignID, opt.From, opt.To, opt.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]repository.QueryStat, 0, opt.Limit)
+	for rows.Next() {
+		var s repository.QueryStat
+		if err := rows.Scan(&s.NormalizedQuery, &s.Count, &s.ZeroResultCount, &s.AvgResultCount, &s.AvgTopScore, &s.LastSeenAt); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// ScoreDistribution buckets top_score over [0, 1] into opt.Buckets equal-width buckets.
+func (r *implPostgresRepository) ScoreDistribution(ctx context.Context, opt repository.QueryAnalyticsOptions) (repository.ScoreDistribution, error) {
+	const query = `
+		SELECT LEAST(GREATEST(width_bucket(top_score, 0, 1, $4), 1), $4) AS bucket,
+		       COUNT(*)
+		FROM knowledge.search_query_logs
+		WHERE campaign_id = $1 AND created_at >= $2 AND created_at < $3 AND top_score IS NOT NULL
+		GROUP BY bucket`
+
+	const totalsQuery = `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE result_count = 0)
+		FROM knowledge.search_query_logs
+		WHERE campaign_id = $1 AND created_at >= $2 AND created_at < $3`
+
+	dist := repository.ScoreDistribution{Counts: make([]int, opt.Buckets)}
+	if err := r.db.QueryRowContext(ctx, totalsQuery, opt.CampaignID, opt.From, opt.To).Scan(&dist.Total, &dist.ZeroResult); err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.ScoreDistribution: Failed to count query logs: %v", err)
+		return repository.ScoreDistribution{}, repository.ErrFailedToQuery
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, opt.CampaignID, opt.From, opt.To, opt.Buckets)
+	if err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.ScoreDistribution: Failed to query score buckets: %v", err)
+		return repository.ScoreDistribution{}, repository.ErrFailedToQuery
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bucket, count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			r.l.Errorf(ctx, "search.repository.postgre.ScoreDistribution: Failed to scan score bucket: %v", err)
+			return repository.ScoreDistribution{}, repository.ErrFailedToQuery
+		}
+		if bucket >= 1 && bucket <= opt.Buckets {
+			dist.Counts[bucket-1] = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.ScoreDistribution: Failed to iterate score buckets: %v", err)
+		return repository.ScoreDistribution{}, repository.ErrFailedToQuery
+	}
+	return dist, nil
+}
+
+// LatencyPercentiles computes latency percentiles and cache-hit counts for the window.
+func (r *implPostgresRepository) LatencyPercentiles(ctx context.Context, opt repository.QueryAnalyticsOptions) (repository.LatencyStats, error) {
+	const query = `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE cache_hit),
+		       COALESCE(AVG(latency_ms), 0),
+		       COALESCE(percentile_cont(0.50) WITHIN GROUP (ORDER BY latency_ms), 0),
+		       COALESCE(percentile_cont(0.90) WITHIN GROUP (ORDER BY latency_ms), 0),
+		       COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_ms), 0),
+		       COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY latency_ms), 0)
+		FROM knowledge.search_query_logs
+		WHERE campaign_id = $1 AND created_at >= $2 AND created_at < $3`
+
+	var stats repository.LatencyStats
+	err := r.db.QueryRowContext(ctx, query, opt.CampaignID, opt.From, opt.To).Scan(
+		&stats.Total,
+		&stats.CacheHit,
+		&stats.AvgMs,
+		&stats.P50Ms,
+		&stats.P90Ms,
+		&stats.P95Ms,
+		&stats.P99Ms,
+	)
+	if err != nil {
+		r.l.Errorf(ctx, "search.repository.postgre.LatencyPercentiles: Failed to compute latency percentiles: %v", err)
+		return repository.LatencyStats{}, repository.ErrFailedToQuery
+	}
+	return stats, nil
+}
@@ -4,11 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
+	"knowledge-srv/internal/search/repository"
+
 	goredis "github.com/redis/go-redis/v9"
 )
 
+const (
+	searchResultsTTL    = 5 * time.Minute
+	aggregateResultsTTL = 5 * time.Minute
+)
+
 // =====================================================
 // Tầng 2: Campaign Projects Cache (TTL 10 min)
 // =====================================================
@@ -55,19 +63,21 @@ func (r *implCacheRepository) SaveCampaignProjects(ctx context.Context, campaign
 }
 
 // =====================================================
-// Tầng 3: Search Results Cache (TTL 5 min)
+// Tầng 3: Search Results Cache (TTL 5 min), tag-indexed by project
 // =====================================================
 
 func (r *implCacheRepository) GetSearchResults(ctx context.Context, cacheKey string) ([]byte, error) {
 	data, err := r.redis.GetClient().Get(ctx, cacheKey).Result()
 	if err != nil {
+		r.incrStat(ctx, repository.CacheStatSearchMiss)
 		return nil, err
 	}
+	r.incrStat(ctx, repository.CacheStatSearchHit)
 	return []byte(data), nil
 }
 
-func (r *implCacheRepository) SaveSearchResults(ctx context.Context, cacheKey string, data []byte) error {
-	if err := r.redis.GetClient().Set(ctx, cacheKey, data, 5*time.Minute).Err(); err != nil {
+func (r *implCacheRepository) SaveSearchResults(ctx context.Context, cacheKey string, data []byte, projectIDs []string) error {
+	if err := r.saveTagged(ctx, cacheKey, data, searchResultsTTL, projectIDs); err != nil {
 		r.l.Warnf(ctx, "search.repository.redis.SaveSearchResults: Failed to save to cache: %v", err)
 		return err
 	}
@@ -75,34 +85,129 @@ func (r *implCacheRepository) SaveSearchResults(ctx context.Context, cacheKey st
 }
 
 // =====================================================
-// Cache Invalidation
+// Aggregate Results Cache (TTL 5 min), tag-indexed by project
 // =====================================================
 
+func (r *implCacheRepository) GetAggregateResults(ctx context.Context, cacheKey string) ([]byte, error) {
+	data, err := r.redis.GetClient().Get(ctx, cacheKey).Result()
+	if err != nil {
+		r.incrStat(ctx, repository.CacheStatAggregateMiss)
+		return nil, err
+	}
+	r.incrStat(ctx, repository.CacheStatAggregateHit)
+	return []byte(data), nil
+}
+
+func (r *implCacheRepository) SaveAggregateResults(ctx context.Context, cacheKey string, data []byte, projectIDs []string) error {
+	if err := r.saveTagged(ctx, cacheKey, data, aggregateResultsTTL, projectIDs); err != nil {
+		r.l.Warnf(ctx, "search.repository.redis.SaveAggregateResults: Failed to save to cache: %v", err)
+		return err
+	}
+	return nil
+}
+
+// saveTagged stores the entry and registers its key under every project tag it
+// covers, so ingestion on one project evicts exactly the entries that include it.
+// Tag sets outlive entries slightly; stale members are harmless on eviction.
+func (r *implCacheRepository) saveTagged(ctx context.Context, cacheKey string, data []byte, ttl time.Duration, projectIDs []string) error {
+	pipe := r.redis.GetClient().TxPipeline()
+	pipe.Set(ctx, cacheKey, data, ttl)
+	for _, projectID := range projectIDs {
+		tagKey := repository.CacheTagKey(projectID)
+		pipe.SAdd(ctx, tagKey, cacheKey)
+		pipe.Expire(ctx, tagKey, ttl+time.Minute)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TagAnswer registers a chat answer under the answer tag of every project it covers.
+// Answers live for hours, so they use their own tag sets (see AnswerTagKeyPrefix).
+func (r *implCacheRepository) TagAnswer(ctx context.Context, cacheKey string, projectIDs []string, ttl time.Duration) error {
+	pipe := r.redis.GetClient().TxPipeline()
+	for _, projectID := range projectIDs {
+		tagKey := repository.AnswerTagKey(projectID)
+		pipe.SAdd(ctx, tagKey, cacheKey)
+		pipe.Expire(ctx, tagKey, ttl+time.Minute)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.l.Warnf(ctx, "search.repository.redis.TagAnswer: cache unavailable: %v", err)
+		return err
+	}
+	return nil
+}
+
+// =====================================================
+// Cache Invalidation (by project tag)
+// =====================================================
+
+// InvalidateSearchCache evicts every cached search/aggregate entry and chat answer
+// tagged with projectID and bumps the eviction counters. Other domains reach it
+// through search.UseCase.InvalidateProject.
 func (r *implCacheRepository) InvalidateSearchCache(ctx context.Context, projectID string) error {
-	pattern := fmt.Sprintf("search:*%s*", projectID)
+	evicted, err := r.invalidateProject(ctx, projectID)
+	if err != nil {
+		r.l.Warnf(ctx, "search.repository.redis.InvalidateSearchCache: cache unavailable: %v", err)
+		return err
+	}
+	r.l.Debugf(ctx, "search.repository.redis.InvalidateSearchCache: project=%s evicted=%d", projectID, evicted)
+	return nil
+}
+
+// invalidateProject deletes the tagged entries and the tag sets; returns the entries deleted.
+func (r *implCacheRepository) invalidateProject(ctx context.Context, projectID string) (int64, error) {
 	client := r.redis.GetClient()
+	tagKeys := repository.ProjectTagKeys(projectID)
 
-	var cursor uint64
-	for {
-		keys, nextCursor, err := client.Scan(ctx, cursor, pattern, 100).Result()
-		if err != nil {
-			r.l.Warnf(ctx, "search.repository.redis.InvalidateSearchCache: cache scan unavailable: %v", err)
-			return err
-		}
-		if len(keys) > 0 {
-			pipe := client.Pipeline()
-			for _, key := range keys {
-				pipe.Del(ctx, key)
-			}
-			if _, err := pipe.Exec(ctx); err != nil && err != goredis.Nil {
-				r.l.Warnf(ctx, "search.repository.redis.InvalidateSearchCache: cache delete unavailable: %v", err)
-				return err
-			}
-		}
-		cursor = nextCursor
-		if cursor == 0 {
-			break
-		}
+	keys, err := client.SUnion(ctx, tagKeys...).Result()
+	if err != nil && err != goredis.Nil {
+		return 0, err
+	}
+
+	pipe := client.TxPipeline()
+	var deleted *goredis.IntCmd
+	if len(keys) > 0 {
+		deleted = pipe.Del(ctx, keys...)
+	}
+	pipe.Del(ctx, tagKeys...)
+	pipe.HIncrBy(ctx, repository.CacheStatsKey, repository.CacheStatEvictions, 1)
+	if _, err := pipe.Exec(ctx); err != nil && err != goredis.Nil {
+		return 0, err
+	}
+
+	if deleted == nil || deleted.Val() == 0 {
+		return 0, nil
+	}
+	client.HIncrBy(ctx, repository.CacheStatsKey, repository.CacheStatEvictedKeys, deleted.Val())
+	return deleted.Val(), nil
+}
+
+// =====================================================
+// Cache Counters
+// =====================================================
+
+func (r *implCacheRepository) GetCacheStats(ctx context.Context) (repository.CacheStats, error) {
+	values, err := r.redis.GetClient().HGetAll(ctx, repository.CacheStatsKey).Result()
+	if err != nil && err != goredis.Nil {
+		return repository.CacheStats{}, err
+	}
+	counter := func(field string) int64 {
+		n, _ := strconv.ParseInt(values[field], 10, 64)
+		return n
+	}
+	return repository.CacheStats{
+		SearchHits:      counter(repository.CacheStatSearchHit),
+		SearchMisses:    counter(repository.CacheStatSearchMiss),
+		AggregateHits:   counter(repository.CacheStatAggregateHit),
+		AggregateMisses: counter(repository.CacheStatAggregateMiss),
+		Evictions:       counter(repository.CacheStatEvictions),
+		EvictedKeys:     counter(repository.CacheStatEvictedKeys),
+	}, nil
+}
+
+// incrStat bumps a cache counter (best-effort, never fails the caller).
+func (r *implCacheRepository) incrStat(ctx context.Context, field string) {
+	if err := r.redis.GetClient().HIncrBy(ctx, repository.CacheStatsKey, field, 1).Err(); err != nil {
+		r.l.Debugf(ctx, "search.repository.redis.incrStat: Failed to bump %s: %v", field, err)
 	}
-	return nil
 }
@@ -1,10 +1,57 @@
 package search
 
+import "time"
+
 const (
 	MinScore       = 0.55
 	MaxResults     = 10
 	MinQueryLength = 3
 	MaxQueryLength = 1000
+
+	// MaxSearchDepth bounds how deep cursor pagination can walk into the merged
+	// result ordering of a single query.
+	MaxSearchDepth = 500
+
+	// Retrieval intents recorded in the query analytics log.
+	IntentSearch = "SEARCH"
+	IntentReport = "REPORT"
+
+	// Recency decay modes
+	RecencyDecayExponential = "exponential"
+	RecencyDecayGaussian    = "gaussian"
+
+	DefaultRecencyHalfLifeHours = 72
+	DefaultRecencyWeight        = 0.5
+
+	// Retrieval layers. Digests (layer 1) and insight cards (layer 2) come from the
+	// campaign-wide macro_insights collection; posts (layer 3) from per-project collections.
+	LayerDigest  = "digest"
+	LayerInsight = "insight"
+	LayerPost    = "post"
+
+	DefaultDigestQuota  = 1
+	DefaultInsightQuota = 3
+
+	DefaultDiversityLambda     = 0.7
+	DefaultRedundancyThreshold = 0.92
+
+	// Entity filter match modes
+	EntityMatchAny = "any"
+	EntityMatchAll = "all"
+
+	DefaultTopEntitiesLimit = 10
+	MaxTopEntitiesLimit     = 50
+
+	DefaultAnalyticsLimit = 20
+	MaxAnalyticsLimit     = 100
+	DefaultAnalyticsDays  = 7
+
+	// Time series intervals
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+
+	MaxTimeSeriesBuckets = 90
 )
 
 type SearchInput struct {
@@ -13,6 +60,44 @@ type SearchInput struct {
 	Filters    SearchFilters
 	Limit      int
 	MinScore   float64
+	// Cursor is the opaque NextCursor of a previous page. Empty means first page.
+	Cursor string
+	// Offset starts the page at this position of the result ordering, for callers
+	// that page by number. Ignored when Cursor is set.
+	Offset int
+	// Intent labels the retrieval in the query analytics log (chat intent, REPORT...).
+	// Defaults to IntentSearch.
+	Intent string
+	// Recency enables time-decay ranking. Nil disables it.
+	Recency *RecencyOptions
+	// Layers blends macro-level digests and insight cards into the first page.
+	// Nil keeps retrieval post-level only.
+	Layers *LayerQuotas
+	// Diversity enables MMR selection over result vectors. Nil disables it.
+	Diversity *DiversityOptions
+}
+
+// DiversityOptions - Maximal Marginal Relevance over result embeddings.
+// Each pick maximizes Lambda*relevance - (1-Lambda)*max similarity to results already
+// picked; candidates at least RedundancyThreshold similar to a picked result are suppressed.
+type DiversityOptions struct {
+	Lambda              float64 // (0, 1], 1 = relevance only; 0 → config default
+	RedundancyThreshold float64 // cosine similarity in (0, 1]; 0 → config default
+}
+
+// LayerQuotas - Max digests/insight cards mixed into the first page of results.
+// Zero picks the default; a negative value disables that layer. Posts fill the rest.
+type LayerQuotas struct {
+	Digests  int
+	Insights int
+}
+
+// RecencyOptions - Time-decay ranking on content_created_at.
+// Final score = similarity * (1 - Weight + Weight*decay), decay = 0.5 at one half-life.
+type RecencyOptions struct {
+	Decay         string  // RecencyDecayExponential (default) | RecencyDecayGaussian
+	HalfLifeHours float64 // 0 → per-campaign config → DefaultRecencyHalfLifeHours
+	Weight        float64 // share of the score subject to decay, (0, 1]; 0 → DefaultRecencyWeight
 }
 
 type SearchFilters struct {
@@ -23,6 +108,18 @@ type SearchFilters struct {
 	DateTo        *int64
 	RiskLevels    []string
 	MinEngagement *float64
+	// Entities restricts results to posts mentioning the given NLP entities. Nil disables it.
+	Entities *EntityFilter
+}
+
+// EntityFilter - Match posts by extracted entities (type/value pairs).
+// Values are compared case-insensitively. With EntityMatchAny a post must mention
+// at least one value; with EntityMatchAll it must mention every value. When only
+// Types is set, the same any/all semantics apply to entity types.
+type EntityFilter struct {
+	Types  []string
+	Values []string
+	Match  string // EntityMatchAny (default) | EntityMatchAll
 }
 
 type SearchOutput struct {
@@ -32,6 +129,12 @@ type SearchOutput struct {
 	NoRelevantContext bool
 	CacheHit          bool
 	ProcessingTimeMs  int64
+	// NextCursor continues the same query on the next page. Empty when HasMore is false.
+	NextCursor string
+	HasMore    bool
+	// SuppressedRedundant counts post candidates dropped by diversity selection as
+	// near-duplicates, up to and including this page. 0 when diversity is off.
+	SuppressedRedundant int
 }
 
 type SearchResult struct {
@@ -47,7 +150,11 @@ type SearchResult struct {
 	RiskLevel        string
 	EngagementScore  float64
 	ContentCreatedAt int64
-	Metadata         map[string]interface{}
+	// RecencyFactor is the decay multiplier applied to Score (1 when recency is off).
+	RecencyFactor float64
+	// Layer is LayerPost, LayerInsight or LayerDigest.
+	Layer    string
+	Metadata map[string]interface{}
 }
 
 type AspectResult struct {
@@ -83,8 +190,27 @@ type PlatformAgg struct {
 	Percentage float64
 }
 
+// CampaignAccessOutput - A campaign the caller may read.
+type CampaignAccessOutput struct {
+	CampaignID string
+	Name       string // empty when Project Service has none
+	ProjectIDs []string
+}
+
 type AggregateInput struct {
 	CampaignID string
+	// Filters narrows the documents aggregated. The zero value aggregates the whole campaign.
+	Filters SearchFilters
+}
+
+type CountInput struct {
+	CampaignID string
+	// Filters narrows the documents counted. The zero value counts the whole campaign.
+	Filters SearchFilters
+}
+
+type CountOutput struct {
+	Total uint64
 }
 
 type AggregateOutput struct {
@@ -92,9 +218,142 @@ type AggregateOutput struct {
 	SentimentBreakdown map[string]uint64
 	PlatformBreakdown  map[string]uint64
 	TopNegativeAspects []AspectCount
+	TopEntities        []EntityCount
 }
 
 type AspectCount struct {
 	Aspect string
 	Count  uint64
 }
+
+type EntityCount struct {
+	Type  string
+	Value string // normalized (lowercase) entity value
+	Count uint64
+}
+
+// =====================================================
+// Time Series
+// =====================================================
+
+// TimeSeriesInput - Document counts per interval over [From, To] (unix seconds).
+// Filters narrow the documents counted; their date range is replaced by each bucket's.
+type TimeSeriesInput struct {
+	CampaignID string
+	From       int64
+	To         int64
+	Interval   string // IntervalDay (default) | IntervalWeek | IntervalMonth
+	Filters    SearchFilters
+}
+
+type TimeSeriesBucket struct {
+	Start              int64
+	End                int64 // inclusive
+	Count              uint64
+	SentimentBreakdown map[string]uint64
+}
+
+type TimeSeriesOutput struct {
+	Interval  string
+	TotalDocs uint64
+	Buckets   []TimeSeriesBucket
+}
+
+// =====================================================
+// Top Entities
+// =====================================================
+
+// TopEntitiesInput - Most mentioned entities of a campaign, optionally restricted to types.
+type TopEntitiesInput struct {
+	CampaignID string
+	Types      []string
+	Limit      int
+}
+
+type EntityStat struct {
+	Type     string
+	Value    string
+	Mentions uint64
+	// SentimentMix counts mentioning posts by sentiment label (POSITIVE, NEGATIVE, ...).
+	SentimentMix map[string]uint64
+}
+
+type TopEntitiesOutput struct {
+	Entities []EntityStat
+}
+
+// =====================================================
+// Query Analytics
+// =====================================================
+
+// QueryAnalyticsInput - Time window (unix seconds) and size for analytics queries.
+// Window defaults to the last DefaultAnalyticsDays days.
+type QueryAnalyticsInput struct {
+	CampaignID string
+	From       *int64
+	To         *int64
+	Limit      int
+}
+
+type QueryStat struct {
+	NormalizedQuery string
+	Count           int
+	ZeroResultCount int
+	AvgResultCount  float64
+	AvgTopScore     float64
+	LastSeenAt      int64
+}
+
+type TopQueriesOutput struct {
+	Queries []QueryStat
+}
+
+type ZeroResultQueriesOutput struct {
+	Queries []QueryStat
+}
+
+type ScoreBucket struct {
+	From  float64
+	To    float64
+	Count int
+}
+
+type ScoreDistributionOutput struct {
+	Total      int
+	ZeroResult int
+	Buckets    []ScoreBucket
+}
+
+type LatencyPercentilesOutput struct {
+	Total        int
+	CacheHitRate float64
+	AvgMs        float64
+	P50Ms        float64
+	P90Ms        float64
+	P95Ms        float64
+	P99Ms        float64
+}
+
+// TagCachedAnswerInput - A chat answer cached by the chat domain, evicted with its projects
+type TagCachedAnswerInput struct {
+	CacheKey   string
+	ProjectIDs []string
+	TTL        time.Duration // lifetime of the cached answer
+}
+
+// PurgeCampaignOutput - What PurgeCampaign removed
+type PurgeCampaignOutput struct {
+	QueryLogs int64
+}
+
+// CacheStatsOutput - Cumulative search/aggregate cache counters
+type CacheStatsOutput struct {
+	SearchHits       int64
+	SearchMisses     int64
+	SearchHitRate    float64
+	AggregateHits    int64
+	AggregateMisses  int64
+	AggregateHitRate float64
+	Evictions        int64
+	EvictedKeys      int64
+}
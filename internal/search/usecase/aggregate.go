@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -15,6 +16,16 @@ import (
 )
 
 func (uc *implUseCase) Aggregate(ctx context.Context, sc model.Scope, input search.AggregateInput) (search.AggregateOutput, error) {
+	// Step 0: Check aggregate cache (tag-indexed by project, evicted on ingestion)
+	cacheKey := generateAggregateCacheKey(input)
+	if cachedData, err := uc.cacheRepo.GetAggregateResults(ctx, cacheKey); err == nil && cachedData != nil {
+		var cached search.AggregateOutput
+		if err := json.Unmarshal(cachedData, &cached); err == nil {
+			uc.l.Debugf(ctx, "search.usecase.Aggregate: cache hit for key %s", cacheKey)
+			return cached, nil
+		}
+	}
+
 	// Step 1: Resolve campaign -> projects
 	projectIDs, err := uc.resolveCampaignProjects(ctx, input.CampaignID)
 	if err != nil {
@@ -27,21 +38,22 @@ func (uc *implUseCase) Aggregate(ctx context.Context, sc model.Scope, input sear
 		}, nil
 	}
 
-	// Step 2: Query per-project collections in parallel, merge results
+	// Step 2: Query the project collections (or the shared one) in parallel, merge results
 	var (
 		totalDocs    uint64
 		sentimentMap = make(map[string]uint64)
 		platformMap  = make(map[string]uint64)
 		aspectMap    = make(map[string]uint64)
+		entityMap    = make(map[string]uint64)
 		mu           sync.Mutex
 	)
 
 	g, gCtx := errgroup.WithContext(ctx)
+	filter := uc.buildSearchFilter(projectIDs, input.Filters)
 
-	for _, pid := range projectIDs {
-		collectionName := point.CollectionForProject(pid)
+	for _, target := range uc.pointUC.CollectionTargets(projectIDs) {
 		g.Go(func() error {
-			return uc.aggregateCollection(gCtx, collectionName, &totalDocs, sentimentMap, platformMap, aspectMap, &mu)
+			return uc.aggregateCollection(gCtx, target, filter, &totalDocs, sentimentMap, platformMap, aspectMap, entityMap, &mu)
 		})
 	}
 
@@ -63,20 +75,32 @@ func (uc *implUseCase) Aggregate(ctx context.Context, sc model.Scope, input sear
 		})
 	}
 
+	output.TopEntities = topEntityCounts(entityMap, aggregateEntityFacetLimit)
+
+	// Step 3: Cache, tagged by every project aggregated
+	if data, err := json.Marshal(output); err == nil {
+		if err := uc.cacheRepo.SaveAggregateResults(ctx, cacheKey, data, projectIDs); err != nil {
+			uc.l.Warnf(ctx, "search.usecase.Aggregate: Failed to save cache: %v", err)
+		}
+	}
+
 	return output, nil
 }
 
 // aggregateCollection runs count and facet queries on a single Qdrant collection,
-// merging results into the shared maps. Non-existent collections are skipped.
+// restricted to filter, merging results into the shared maps. Non-existent
+// collections are skipped.
 func (uc *implUseCase) aggregateCollection(
 	ctx context.Context,
-	collectionName string,
+	target point.CollectionTarget,
+	filter *pb.Filter,
 	totalDocs *uint64,
-	sentimentMap, platformMap, aspectMap map[string]uint64,
+	sentimentMap, platformMap, aspectMap, entityMap map[string]uint64,
 	mu *sync.Mutex,
 ) error {
-	// No project_id filter needed — collection is already per-project
-	emptyFilter := &pb.Filter{}
+	// Tenant filter in the shared layout; empty for per-project collections
+	collectionName := target.Collection
+	baseFilter := target.Scope(withConditions(filter))
 
 	var (
 		colTotal   uint64
@@ -85,6 +109,7 @@ func (uc *implUseCase) aggregateCollection(
 		platformR        []point.FacetOutput
 		aspectLegacyR    []point.FacetOutput
 		aspectNewR       []point.FacetOutput
+		entityR          []point.FacetOutput
 	)
 
 	g, gCtx := errgroup.WithContext(ctx)
@@ -93,7 +118,7 @@ func (uc *implUseCase) aggregateCollection(
 	g.Go(func() error {
 		count, err := uc.pointUC.Count(gCtx, point.CountInput{
 			CollectionName: collectionName,
-			Filter:         emptyFilter,
+			Filter:         baseFilter,
 		})
 		if err != nil {
 			if isCollectionNotFoundError(err) {
@@ -110,7 +135,7 @@ func (uc *implUseCase) aggregateCollection(
 		res, err := uc.pointUC.Facet(gCtx, point.FacetInput{
 			CollectionName: collectionName,
 			Key:            "overall_sentiment",
-			Filter:         emptyFilter,
+			Filter:         baseFilter,
 			Limit:          10,
 		})
 		if err != nil {
@@ -128,7 +153,7 @@ func (uc *implUseCase) aggregateCollection(
 		res, err := uc.pointUC.Facet(gCtx, point.FacetInput{
 			CollectionName: collectionName,
 			Key:            "sentiment_label",
-			Filter:         emptyFilter,
+			Filter:         baseFilter,
 			Limit:          10,
 		})
 		if err != nil {
@@ -149,7 +174,7 @@ func (uc *implUseCase) aggregateCollection(
 		res, err := uc.pointUC.Facet(gCtx, point.FacetInput{
 			CollectionName: collectionName,
 			Key:            "platform",
-			Filter:         emptyFilter,
+			Filter:         baseFilter,
 			Limit:          10,
 		})
 		if err != nil {
@@ -164,24 +189,20 @@ func (uc *implUseCase) aggregateCollection(
 
 	// Negative aspects (legacy payload)
 	g.Go(func() error {
-		negFilter := &pb.Filter{
-			Must: []*pb.Condition{
-				{
-					ConditionOneOf: &pb.Condition_Field{
-						Field: &pb.FieldCondition{
-							Key: "overall_sentiment",
-							Match: &pb.Match{
-								MatchValue: &pb.Match_Keyword{Keyword: "NEGATIVE"},
-							},
-						},
+		negFilter := withConditions(filter, &pb.Condition{
+			ConditionOneOf: &pb.Condition_Field{
+				Field: &pb.FieldCondition{
+					Key: "overall_sentiment",
+					Match: &pb.Match{
+						MatchValue: &pb.Match_Keyword{Keyword: "NEGATIVE"},
 					},
 				},
 			},
-		}
+		})
 		res, err := uc.pointUC.Facet(gCtx, point.FacetInput{
 			CollectionName: collectionName,
 			Key:            "aspects.aspect",
-			Filter:         negFilter,
+			Filter:         target.Scope(negFilter),
 			Limit:          5,
 		})
 		if err != nil {
@@ -196,24 +217,20 @@ func (uc *implUseCase) aggregateCollection(
 
 	// Negative aspects (new payload format)
 	g.Go(func() error {
-		negFilter := &pb.Filter{
-			Must: []*pb.Condition{
-				{
-					ConditionOneOf: &pb.Condition_Field{
-						Field: &pb.FieldCondition{
-							Key: "sentiment_label",
-							Match: &pb.Match{
-								MatchValue: &pb.Match_Keyword{Keyword: "NEGATIVE"},
-							},
-						},
+		negFilter := withConditions(filter, &pb.Condition{
+			ConditionOneOf: &pb.Condition_Field{
+				Field: &pb.FieldCondition{
+					Key: "sentiment_label",
+					Match: &pb.Match{
+						MatchValue: &pb.Match_Keyword{Keyword: "NEGATIVE"},
 					},
 				},
 			},
-		}
+		})
 		res, err := uc.pointUC.Facet(gCtx, point.FacetInput{
 			CollectionName: collectionName,
 			Key:            "aspects.aspect",
-			Filter:         negFilter,
+			Filter:         target.Scope(negFilter),
 			Limit:          5,
 		})
 		if err != nil {
@@ -229,6 +246,24 @@ func (uc *implUseCase) aggregateCollection(
 		return nil
 	})
 
+	// Entities (insight payload only)
+	g.Go(func() error {
+		res, err := uc.pointUC.Facet(gCtx, point.FacetInput{
+			CollectionName: collectionName,
+			Key:            "entity_keys",
+			Filter:         baseFilter,
+			Limit:          aggregateEntityFacetLimit,
+		})
+		if err != nil {
+			if isCollectionNotFoundError(err) || isMissingFacetIndexError(err, "entity_keys") {
+				return nil
+			}
+			return fmt.Errorf("failed to facet entities in %s: %w", collectionName, err)
+		}
+		entityR = res
+		return nil
+	})
+
 	if err := g.Wait(); err != nil {
 		return err
 	}
@@ -253,10 +288,27 @@ func (uc *implUseCase) aggregateCollection(
 	for _, a := range aspectNewR {
 		aspectMap[a.Value] += a.Count
 	}
+	for _, e := range entityR {
+		entityMap[e.Value] += e.Count
+	}
 
 	return nil
 }
 
+// withConditions returns a copy of filter with extra Must conditions, leaving filter
+// untouched so it can be shared by concurrent queries.
+func withConditions(filter *pb.Filter, extra ...*pb.Condition) *pb.Filter {
+	must := make([]*pb.Condition, 0, len(filter.GetMust())+len(extra))
+	must = append(must, filter.GetMust()...)
+	must = append(must, extra...)
+	return &pb.Filter{
+		Must:      must,
+		Should:    filter.GetShould(),
+		MustNot:   filter.GetMustNot(),
+		MinShould: filter.GetMinShould(),
+	}
+}
+
 func isMissingFacetIndexError(err error, field string) bool {
 	if err == nil {
 		return false
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
+)
+
+// CacheStats - Hit/miss/evict counters of the tag-indexed result cache
+func (uc *implUseCase) CacheStats(ctx context.Context, sc model.Scope) (search.CacheStatsOutput, error) {
+	if !sc.IsAdmin() {
+		return search.CacheStatsOutput{}, search.ErrForbidden
+	}
+
+	stats, err := uc.cacheRepo.GetCacheStats(ctx)
+	if err != nil {
+		uc.l.Errorf(ctx, "search.usecase.CacheStats: Failed to load cache stats: %v", err)
+		return search.CacheStatsOutput{}, fmt.Errorf("%w: %v", search.ErrAnalyticsFailed, err)
+	}
+
+	return search.CacheStatsOutput{
+		SearchHits:       stats.SearchHits,
+		SearchMisses:     stats.SearchMisses,
+		SearchHitRate:    hitRate(stats.SearchHits, stats.SearchMisses),
+		AggregateHits:    stats.AggregateHits,
+		AggregateMisses:  stats.AggregateMisses,
+		AggregateHitRate: hitRate(stats.AggregateHits, stats.AggregateMisses),
+		Evictions:        stats.Evictions,
+		EvictedKeys:      stats.EvictedKeys,
+	}, nil
+}
+
+func hitRate(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
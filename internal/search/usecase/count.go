@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Count - Number of campaign documents matching the filters, summed over the
+// project collections (or the shared one). Non-existent collections count zero.
+func (uc *implUseCase) Count(ctx context.Context, sc model.Scope, input search.CountInput) (search.CountOutput, error) {
+	if input.CampaignID == "" {
+		return search.CountOutput{}, search.ErrCampaignNotFound
+	}
+
+	// Step 0: Cache (shares the aggregate cache, evicted on ingestion)
+	cacheKey := generateCountCacheKey(input)
+	if cachedData, err := uc.cacheRepo.GetAggregateResults(ctx, cacheKey); err == nil && cachedData != nil {
+		var cached search.CountOutput
+		if err := json.Unmarshal(cachedData, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	// Step 1: Resolve campaign -> projects
+	projectIDs, err := uc.resolveCampaignProjects(ctx, input.CampaignID)
+	if err != nil {
+		return search.CountOutput{}, err
+	}
+	if len(projectIDs) == 0 {
+		return search.CountOutput{}, nil
+	}
+
+	// Step 2: Count per collection, sum
+	var (
+		output search.CountOutput
+		mu     sync.Mutex
+	)
+	filter := uc.buildSearchFilter(projectIDs, input.Filters)
+	g, gCtx := errgroup.WithContext(ctx)
+	for _, target := range uc.pointUC.CollectionTargets(projectIDs) {
+		g.Go(func() error {
+			count, err := uc.pointUC.Count(gCtx, point.CountInput{
+				CollectionName: target.Collection,
+				Filter:         target.Scope(withConditions(filter)),
+			})
+			if err != nil {
+				if isCollectionNotFoundError(err) {
+					return nil
+				}
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			output.Total += count
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		uc.l.Errorf(ctx, "search.usecase.Count: count failed: %v", err)
+		return search.CountOutput{}, fmt.Errorf("%w: %v", search.ErrSearchFailed, err)
+	}
+
+	// Step 3: Cache, tagged by every project counted
+	if data, err := json.Marshal(output); err == nil {
+		if err := uc.cacheRepo.SaveAggregateResults(ctx, cacheKey, data, projectIDs); err != nil {
+			uc.l.Warnf(ctx, "search.usecase.Count: Failed to save cache: %v", err)
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"knowledge-srv/internal/search"
+)
+
+const (
+	cursorVersion = 1
+	// rerankWindowFactor sets how many stable-order candidates (as a multiple of the
+	// page size) are reranked together. Reranking only inside fixed windows keeps
+	// earlier pages unchanged when later pages fetch deeper.
+	rerankWindowFactor = 3
+)
+
+// searchCursor is the decoded form of an opaque continuation token.
+// Offset points into the merged, deduped, reranked ordering of the query.
+type searchCursor struct {
+	Version     int    `json:"v"`
+	Fingerprint string `json:"fp"`
+	Offset      int    `json:"o"`
+	RefTime     int64  `json:"t,omitempty"` // recency decay clock (unix seconds)
+}
+
+// queryFingerprint identifies everything that shapes the result ordering, so a
+// token issued for one query cannot be replayed against different filters.
+func queryFingerprint(input search.SearchInput, limit int, minScore float64) string {
+	filterJSON, _ := json.Marshal(input.Filters)
+	recencyJSON, _ := json.Marshal(input.Recency)
+	layersJSON, _ := json.Marshal(input.Layers)
+	diversityJSON, _ := json.Marshal(input.Diversity)
+	raw := fmt.Sprintf("%s:%s:%s:%d:%.2f:%s:%s:%s", input.CampaignID, input.Query, string(filterJSON), limit, minScore, string(recencyJSON), string(layersJSON), string(diversityJSON))
+	hash := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(hash[:8])
+}
+
+func encodeCursor(c searchCursor) string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(token, fingerprint string) (searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return searchCursor{}, fmt.Errorf("%w: %v", search.ErrInvalidCursor, err)
+	}
+	var c searchCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return searchCursor{}, fmt.Errorf("%w: %v", search.ErrInvalidCursor, err)
+	}
+	if c.Version != cursorVersion {
+		return searchCursor{}, fmt.Errorf("%w: unsupported version %d", search.ErrInvalidCursor, c.Version)
+	}
+	if c.Fingerprint != fingerprint {
+		return searchCursor{}, fmt.Errorf("%w: query or filters changed", search.ErrInvalidCursor)
+	}
+	if c.Offset < 0 || c.Offset >= search.MaxSearchDepth {
+		return searchCursor{}, fmt.Errorf("%w: offset %d out of range", search.ErrInvalidCursor, c.Offset)
+	}
+	return c, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"math"
+
+	"knowledge-srv/internal/search"
+)
+
+// mmrSelector is a resolved, request-scoped MMR configuration.
+type mmrSelector struct {
+	lambda    float64
+	threshold float64
+}
+
+// windowSuppression records, after each rerank window, how long the selected
+// ordering is and how many candidates have been suppressed so far.
+type windowSuppression struct {
+	selected   int
+	suppressed int
+}
+
+// resolveDiversity merges request options with config defaults. Returns nil when disabled.
+func (uc *implUseCase) resolveDiversity(input search.SearchInput) *mmrSelector {
+	if input.Diversity == nil {
+		return nil
+	}
+
+	lambda := input.Diversity.Lambda
+	if lambda <= 0 || lambda > 1 {
+		lambda = uc.config.DiversityLambda
+	}
+	threshold := input.Diversity.RedundancyThreshold
+	if threshold <= 0 || threshold > 1 {
+		threshold = uc.config.RedundancyThreshold
+	}
+	return &mmrSelector{lambda: lambda, threshold: threshold}
+}
+
+// selectInWindows runs greedy MMR inside consecutive fixed-size windows of the
+// stable ordering (like rerankInWindows, so page boundaries never shift).
+// Novelty is measured against every result selected so far, including earlier
+// windows. Candidates at least threshold-similar to a selected result are dropped.
+func (m *mmrSelector) selectInWindows(candidates []search.SearchResult, vectors map[string][]float32, window int) ([]search.SearchResult, []windowSuppression) {
+	out := make([]search.SearchResult, 0, len(candidates))
+	var selectedVecs [][]float32
+	var stats []windowSuppression
+	suppressed := 0
+
+	for start := 0; start < len(candidates); start += window {
+		end := start + window
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		block := candidates[start:end]
+
+		rel := normalizedRankScores(block)
+		maxSim := make([]float64, len(block))
+		for i, c := range block {
+			for _, sv := range selectedVecs {
+				if sim := cosineSimilarity(vectors[c.ID], sv); sim > maxSim[i] {
+					maxSim[i] = sim
+				}
+			}
+		}
+
+		remaining := make([]bool, len(block))
+		for i := range remaining {
+			remaining[i] = true
+		}
+		for {
+			best := -1
+			bestScore := math.Inf(-1)
+			for i := range block {
+				if !remaining[i] {
+					continue
+				}
+				if maxSim[i] >= m.threshold {
+					remaining[i] = false
+					suppressed++
+					continue
+				}
+				score := m.lambda*rel[i] - (1-m.lambda)*maxSim[i]
+				if score > bestScore {
+					best, bestScore = i, score
+				}
+			}
+			if best < 0 {
+				break
+			}
+
+			remaining[best] = false
+			out = append(out, block[best])
+			picked := vectors[block[best].ID]
+			selectedVecs = append(selectedVecs, picked)
+			for i := range block {
+				if !remaining[i] {
+					continue
+				}
+				if sim := cosineSimilarity(vectors[block[i].ID], picked); sim > maxSim[i] {
+					maxSim[i] = sim
+				}
+			}
+		}
+
+		stats = append(stats, windowSuppression{selected: len(out), suppressed: suppressed})
+	}
+	return out, stats
+}
+
+// suppressedThrough returns how many candidates were suppressed in the windows
+// needed to produce the first n selected results.
+func suppressedThrough(stats []windowSuppression, n int) int {
+	for _, s := range stats {
+		if s.selected >= n {
+			return s.suppressed
+		}
+	}
+	if len(stats) == 0 {
+		return 0
+	}
+	return stats[len(stats)-1].suppressed
+}
+
+// normalizedRankScores scales searchResultRankScore into [0, 1] within a window
+// so relevance and cosine similarity share a range in the MMR objective.
+func normalizedRankScores(block []search.SearchResult) []float64 {
+	scores := make([]float64, len(block))
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, r := range block {
+		scores[i] = searchResultRankScore(r)
+		lo = math.Min(lo, scores[i])
+		hi = math.Max(hi, scores[i])
+	}
+	for i := range scores {
+		if hi > lo {
+			scores[i] = (scores[i] - lo) / (hi - lo)
+		} else {
+			scores[i] = 1
+		}
+	}
+	return scores
+}
+
+// cosineSimilarity returns 0 when either vector is missing or mismatched.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
+
+	pb "github.com/qdrant/go-client/qdrant"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// aggregateEntityFacetLimit is the number of entities Aggregate reports.
+	aggregateEntityFacetLimit = 10
+	// entityFacetOverfetch widens per-project facets so the merged top list is stable.
+	entityFacetOverfetch = 3
+	// entitySentimentConcurrency bounds sentiment-mix facet calls in flight.
+	entitySentimentConcurrency = 8
+)
+
+// TopEntities - Most mentioned entities of a campaign with per-entity sentiment mix.
+// Counts come from the entity_keys payload facet, so only points indexed with
+// entity keys are included.
+func (uc *implUseCase) TopEntities(ctx context.Context, sc model.Scope, input search.TopEntitiesInput) (search.TopEntitiesOutput, error) {
+	if input.CampaignID == "" {
+		return search.TopEntitiesOutput{}, search.ErrCampaignNotFound
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = search.DefaultTopEntitiesLimit
+	}
+	if limit > search.MaxTopEntitiesLimit {
+		limit = search.MaxTopEntitiesLimit
+	}
+	types := make(map[string]struct{}, len(input.Types))
+	for _, t := range uniqueNonEmpty(input.Types) {
+		types[strings.ToUpper(t)] = struct{}{}
+	}
+
+	// Step 0: Cache (shares the aggregate cache, evicted on ingestion)
+	cacheKey := generateTopEntitiesCacheKey(input.CampaignID, types, limit)
+	if cachedData, err := uc.cacheRepo.GetAggregateResults(ctx, cacheKey); err == nil && cachedData != nil {
+		var cached search.TopEntitiesOutput
+		if err := json.Unmarshal(cachedData, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	// Step 1: Resolve campaign -> projects
+	projectIDs, err := uc.resolveCampaignProjects(ctx, input.CampaignID)
+	if err != nil {
+		return search.TopEntitiesOutput{}, err
+	}
+
+	// Step 2: Facet entity keys per collection, merge
+	var typeFilter *pb.Filter
+	if len(types) > 0 {
+		typeFilter = &pb.Filter{Must: buildEntityConditions(search.EntityFilter{Types: input.Types})}
+	}
+	entityMap := make(map[string]uint64)
+	var mu sync.Mutex
+	g, gCtx := errgroup.WithContext(ctx)
+	targets := uc.pointUC.CollectionTargets(projectIDs)
+	for _, target := range targets {
+		g.Go(func() error {
+			res, err := uc.facetEntityField(gCtx, target, "entity_keys", typeFilter, uint64(limit*entityFacetOverfetch))
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, r := range res {
+				entityMap[r.Value] += r.Count
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		uc.l.Errorf(ctx, "search.usecase.TopEntities: entity facet failed: %v", err)
+		return search.TopEntitiesOutput{}, fmt.Errorf("%w: %v", search.ErrSearchFailed, err)
+	}
+
+	// Facets count every key of matching points, so drop keys of other types.
+	if len(types) > 0 {
+		for key := range entityMap {
+			entityType, _ := point.SplitEntityKey(key)
+			if _, ok := types[entityType]; !ok {
+				delete(entityMap, key)
+			}
+		}
+	}
+	top := topEntityCounts(entityMap, limit)
+
+	// Step 3: Sentiment mix per entity
+	output := search.TopEntitiesOutput{Entities: make([]search.EntityStat, len(top))}
+	g, gCtx = errgroup.WithContext(ctx)
+	g.SetLimit(entitySentimentConcurrency)
+	for i, e := range top {
+		output.Entities[i] = search.EntityStat{
+			Type:         e.Type,
+			Value:        e.Value,
+			Mentions:     e.Count,
+			SentimentMix: make(map[string]uint64),
+		}
+		keyFilter := &pb.Filter{Must: []*pb.Condition{keywordsCondition("entity_keys", []string{point.EntityKey(e.Type, e.Value)})}}
+		for _, target := range targets {
+			g.Go(func() error {
+				res, err := uc.facetEntityField(gCtx, target, "sentiment_label", keyFilter, 10)
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for _, r := range res {
+					output.Entities[i].SentimentMix[r.Value] += r.Count
+				}
+				return nil
+			})
+		}
+	}
+	if err := g.Wait(); err != nil {
+		uc.l.Errorf(ctx, "search.usecase.TopEntities: sentiment facet failed: %v", err)
+		return search.TopEntitiesOutput{}, fmt.Errorf("%w: %v", search.ErrSearchFailed, err)
+	}
+
+	if data, err := json.Marshal(output); err == nil {
+		if err := uc.cacheRepo.SaveAggregateResults(ctx, cacheKey, data, projectIDs); err != nil {
+			uc.l.Warnf(ctx, "search.usecase.TopEntities: Failed to save cache: %v", err)
+		}
+	}
+
+	return output, nil
+}
+
+// facetEntityField - Facet one collection, treating a missing collection or
+// missing payload index (not yet re-ensured) as no data.
+func (uc *implUseCase) facetEntityField(ctx context.Context, target point.CollectionTarget, key string, filter *pb.Filter, limit uint64) ([]point.FacetOutput, error) {
+	res, err := uc.pointUC.Facet(ctx, point.FacetInput{
+		CollectionName: target.Collection,
+		Key:            key,
+		Filter:         target.Scope(filter),
+		Limit:          limit,
+	})
+	if err != nil {
+		if isCollectionNotFoundError(err) || isMissingFacetIndexError(err, key) || isMissingFacetIndexError(err, "entity_keys") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to facet %s in %s: %w", key, target.Collection, err)
+	}
+	return res, nil
+}
+
+// topEntityCounts - Sort "TYPE:value" counts descending and keep the first limit.
+func topEntityCounts(counts map[string]uint64, limit int) []search.EntityCount {
+	out := make([]search.EntityCount, 0, len(counts))
+	for key, count := range counts {
+		entityType, value := point.SplitEntityKey(key)
+		out = append(out, search.EntityCount{Type: entityType, Value: value, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Type+out[i].Value < out[j].Type+out[j].Value
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func generateTopEntitiesCacheKey(campaignID string, types map[string]struct{}, limit int) string {
+	keys := make([]string, 0, len(types))
+	for t := range types {
+		keys = append(keys, t)
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("aggregate:entities:%s:%s:%d", campaignID, strings.Join(keys, ","), limit)
+}
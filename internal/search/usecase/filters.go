@@ -1,19 +1,22 @@
 package usecase
 
 import (
+	"strings"
+
+	"knowledge-srv/internal/point"
 	"knowledge-srv/internal/search"
 
 	pb "github.com/qdrant/go-client/qdrant"
 )
 
 // buildSearchFilter - Build Qdrant filter from domain filters.
-// projectIDs is no longer used for filtering since we now query per-project collections directly.
-// The parameter is kept for API compatibility but ignored.
+// projectIDs is not used here: project scoping is added per collection by
+// point.CollectionTarget.Scope (a no-op for per-project collections, a project_id
+// tenant condition in the shared layout). The parameter is kept for API compatibility.
 func (uc *implUseCase) buildSearchFilter(projectIDs []string, filters search.SearchFilters) *pb.Filter {
 	must := []*pb.Condition{}
 
-	// Note: project_id filtering is implicit — we query proj_{project_id} collections directly.
-	// No need for a project_id condition in the filter.
+	// Note: project_id filtering is applied per collection target (see CollectionTarget.Scope).
 
 	// 1. Filter by Platform
 	if len(filters.Platforms) > 0 {
@@ -148,6 +151,113 @@ func (uc *implUseCase) buildSearchFilter(projectIDs []string, filters search.Sea
 		})
 	}
 
+	// 8. Filter by Entities (Nested)
+	if filters.Entities != nil {
+		must = append(must, buildEntityConditions(*filters.Entities)...)
+	}
+
 	// Construct final filter
 	return &pb.Filter{Must: must}
 }
+
+// buildEntityConditions - Nested conditions on the entities payload array.
+// Each condition matches one entity object, so a type restriction always applies
+// to the same entity as the value it accompanies.
+func buildEntityConditions(f search.EntityFilter) []*pb.Condition {
+	types := entityTypeVariants(f.Types)
+	values := uniqueNonEmpty(f.Values)
+
+	entityMatch := func(typeGroup, valueGroup []string) *pb.Condition {
+		nested := &pb.Filter{}
+		if len(typeGroup) > 0 {
+			nested.Must = append(nested.Must, keywordsCondition("type", typeGroup))
+		}
+		if len(valueGroup) > 0 {
+			norms := make([]string, 0, len(valueGroup))
+			for _, v := range valueGroup {
+				norms = append(norms, point.NormalizeEntityValue(v))
+			}
+			// value_norm for points indexed with normalized values, raw value for older ones
+			nested.Must = append(nested.Must, &pb.Condition{
+				ConditionOneOf: &pb.Condition_Filter{
+					Filter: &pb.Filter{
+						Should: []*pb.Condition{
+							keywordsCondition("value_norm", uniqueNonEmpty(norms)),
+							keywordsCondition("value", valueGroup),
+						},
+					},
+				},
+			})
+		}
+		return &pb.Condition{
+			ConditionOneOf: &pb.Condition_Nested{
+				Nested: &pb.NestedCondition{Key: "entities", Filter: nested},
+			},
+		}
+	}
+
+	switch {
+	case len(values) == 0 && len(types) == 0:
+		return nil
+	case len(values) == 0:
+		if f.Match != search.EntityMatchAll {
+			return []*pb.Condition{entityMatch(types, nil)}
+		}
+		conds := make([]*pb.Condition, 0, len(f.Types))
+		for _, t := range uniqueNonEmpty(f.Types) {
+			conds = append(conds, entityMatch(entityTypeVariants([]string{t}), nil))
+		}
+		return conds
+	case f.Match == search.EntityMatchAll:
+		conds := make([]*pb.Condition, 0, len(values))
+		for _, v := range values {
+			conds = append(conds, entityMatch(types, []string{v}))
+		}
+		return conds
+	default:
+		return []*pb.Condition{entityMatch(types, values)}
+	}
+}
+
+func keywordsCondition(key string, values []string) *pb.Condition {
+	return &pb.Condition{
+		ConditionOneOf: &pb.Condition_Field{
+			Field: &pb.FieldCondition{
+				Key: key,
+				Match: &pb.Match{
+					MatchValue: &pb.Match_Keywords{
+						Keywords: &pb.RepeatedStrings{Strings: values},
+					},
+				},
+			},
+		},
+	}
+}
+
+// entityTypeVariants - Entity types are not case-normalized upstream, so match
+// the given spelling plus its upper/lower case forms.
+func entityTypeVariants(types []string) []string {
+	variants := make([]string, 0, len(types)*3)
+	for _, t := range types {
+		t = strings.TrimSpace(t)
+		variants = append(variants, t, strings.ToUpper(t), strings.ToLower(t))
+	}
+	return uniqueNonEmpty(variants)
+}
+
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
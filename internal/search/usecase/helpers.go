@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"knowledge-srv/internal/model"
 	"knowledge-srv/internal/point"
 	"knowledge-srv/internal/search"
 )
@@ -47,6 +48,37 @@ func (uc *implUseCase) resolveCampaignProjects(ctx context.Context, campaignID s
 	return campaign.ProjectIDs, nil
 }
 
+func (uc *implUseCase) CampaignProjects(ctx context.Context, campaignID string) ([]string, error) {
+	return uc.resolveCampaignProjects(ctx, campaignID)
+}
+
+func (uc *implUseCase) CampaignAccess(ctx context.Context, sc model.Scope, campaignID string) (search.CampaignAccessOutput, error) {
+	projectIDs, err := uc.resolveCampaignProjects(ctx, campaignID)
+	if err != nil {
+		return search.CampaignAccessOutput{}, err
+	}
+	output := search.CampaignAccessOutput{
+		CampaignID: campaignID,
+		Name:       uc.resolveCampaignName(ctx, campaignID),
+		ProjectIDs: projectIDs,
+	}
+	if sc.UserID == "" || sc.IsAdmin() {
+		return output, nil
+	}
+
+	for _, projectID := range projectIDs {
+		ok, err := uc.projectSrv.ValidateProjectAccess(ctx, sc.UserID, projectID)
+		if err != nil {
+			uc.l.Warnf(ctx, "search.usecase.CampaignAccess: ValidateProjectAccess %s failed: %v", projectID, err)
+			continue
+		}
+		if ok {
+			return output, nil
+		}
+	}
+	return search.CampaignAccessOutput{}, search.ErrForbidden
+}
+
 // resolveCampaignName returns the campaign display name for query enrichment.
 // Always a cache hit after resolveCampaignProjects has run in the same request.
 // Returns empty string on any error (enrichment is best-effort).
@@ -69,11 +101,28 @@ func (uc *implUseCase) resolveCampaignName(ctx context.Context, campaignID strin
 // generateCacheKey - Generate Tầng 3 cache key
 func (uc *implUseCase) generateCacheKey(input search.SearchInput) string {
 	filterJSON, _ := json.Marshal(input.Filters)
-	raw := fmt.Sprintf("v3:%s:%s:%s:%d:%.2f", input.CampaignID, input.Query, string(filterJSON), input.Limit, input.MinScore)
+	recencyJSON, _ := json.Marshal(input.Recency)
+	layersJSON, _ := json.Marshal(input.Layers)
+	diversityJSON, _ := json.Marshal(input.Diversity)
+	raw := fmt.Sprintf("v8:%s:%s:%s:%d:%.2f:%s:%d:%s:%s:%s", input.CampaignID, input.Query, string(filterJSON), input.Limit, input.MinScore, input.Cursor, input.Offset, string(recencyJSON), string(layersJSON), string(diversityJSON))
 	hash := sha256.Sum256([]byte(raw))
 	return fmt.Sprintf("search:%s:%x", input.CampaignID, hash)
 }
 
+// generateCountCacheKey - Cache key for Count results
+func generateCountCacheKey(input search.CountInput) string {
+	filterJSON, _ := json.Marshal(input.Filters)
+	hash := sha256.Sum256(filterJSON)
+	return fmt.Sprintf("aggregate:count:%s:%x", input.CampaignID, hash[:8])
+}
+
+// generateAggregateCacheKey - Cache key for Aggregate results
+func generateAggregateCacheKey(input search.AggregateInput) string {
+	filterJSON, _ := json.Marshal(input.Filters)
+	hash := sha256.Sum256(filterJSON)
+	return fmt.Sprintf("aggregate:v3:%s:%x", input.CampaignID, hash[:8])
+}
+
 // mapQdrantResult - Map Point SearchOutput → Domain SearchResult
 func (uc *implUseCase) mapQdrantResult(r point.SearchOutput) search.SearchResult {
 	result := search.SearchResult{
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"knowledge-srv/internal/search"
+)
+
+// InvalidateProject - Evict the cached results tagged with projectID (internal, no scope check)
+func (uc *implUseCase) InvalidateProject(ctx context.Context, projectID string) error {
+	if err := uc.cacheRepo.InvalidateSearchCache(ctx, projectID); err != nil {
+		uc.l.Warnf(ctx, "search.usecase.InvalidateProject: Failed to invalidate project %s: %v", projectID, err)
+		return err
+	}
+	return nil
+}
+
+// TagCachedAnswer - Make a cached chat answer evictable by InvalidateProject
+func (uc *implUseCase) TagCachedAnswer(ctx context.Context, input search.TagCachedAnswerInput) error {
+	if input.CacheKey == "" || len(input.ProjectIDs) == 0 {
+		return nil
+	}
+	if err := uc.cacheRepo.TagAnswer(ctx, input.CacheKey, input.ProjectIDs, input.TTL); err != nil {
+		uc.l.Warnf(ctx, "search.usecase.TagCachedAnswer: Failed to tag %s: %v", input.CacheKey, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
+
+	pb "github.com/qdrant/go-client/qdrant"
+)
+
+const (
+	ragDocumentTypeDigest  = "report_digest"
+	ragDocumentTypeInsight = "insight_card"
+
+	// macroFetchFactor over-fetches macro hits so both layers can fill their quotas.
+	macroFetchFactor = 3
+)
+
+// layerQuotas is the resolved number of digests and insight cards a first page may hold.
+type layerQuotas struct {
+	digests  int
+	insights int
+}
+
+func (q layerQuotas) total() int {
+	return q.digests + q.insights
+}
+
+// resolveLayerQuotas applies defaults and caps macro results at half the page,
+// so post-level evidence always keeps the majority of slots.
+func resolveLayerQuotas(layers *search.LayerQuotas, limit int) layerQuotas {
+	if layers == nil {
+		return layerQuotas{}
+	}
+
+	q := layerQuotas{digests: layers.Digests, insights: layers.Insights}
+	if q.digests == 0 {
+		q.digests = search.DefaultDigestQuota
+	}
+	if q.insights == 0 {
+		q.insights = search.DefaultInsightQuota
+	}
+	if q.digests < 0 {
+		q.digests = 0
+	}
+	if q.insights < 0 {
+		q.insights = 0
+	}
+
+	maxMacro := limit / 2
+	for q.total() > maxMacro {
+		if q.insights >= q.digests && q.insights > 0 {
+			q.insights--
+		} else {
+			q.digests--
+		}
+	}
+	return q
+}
+
+// searchMacroLayers retrieves digests and insight cards for the campaign from
+// macro_insights, restricted to analysis windows overlapping the date filter.
+// Macro retrieval is best-effort: failures are logged and yield no results.
+func (uc *implUseCase) searchMacroLayers(
+	ctx context.Context,
+	campaignID string,
+	vector []float32,
+	filters search.SearchFilters,
+	scoreThreshold float32,
+	quotas layerQuotas,
+) []search.SearchResult {
+	if quotas.total() == 0 {
+		return nil
+	}
+
+	hits, err := uc.pointUC.Search(ctx, point.SearchInput{
+		CollectionName: point.CollectionMacroInsights,
+		Vector:         vector,
+		Filter:         buildMacroFilter(campaignID, filters, quotas),
+		Limit:          uint64(quotas.total() * macroFetchFactor),
+		WithPayload:    true,
+		ScoreThreshold: scoreThreshold,
+	})
+	if err != nil {
+		if isCollectionNotFoundError(err) {
+			uc.l.Debugf(ctx, "search.usecase.searchMacroLayers: collection %s not found, skipping", point.CollectionMacroInsights)
+		} else {
+			uc.l.Warnf(ctx, "search.usecase.searchMacroLayers: macro search failed: %v", err)
+		}
+		return nil
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	var digests, insights []search.SearchResult
+	for _, h := range hits {
+		mapped := mapMacroResult(h)
+		if strings.TrimSpace(mapped.Content) == "" {
+			continue
+		}
+		switch mapped.Layer {
+		case search.LayerDigest:
+			if len(digests) < quotas.digests {
+				digests = append(digests, mapped)
+			}
+		case search.LayerInsight:
+			if len(insights) < quotas.insights {
+				insights = append(insights, mapped)
+			}
+		}
+	}
+
+	return append(digests, insights...)
+}
+
+// buildMacroFilter scopes macro_insights to the campaign, the enabled layers and
+// (when a date filter is set) analysis windows that overlap it. Points indexed
+// before window timestamps were stored have no *_unix fields and are kept.
+func buildMacroFilter(campaignID string, filters search.SearchFilters, quotas layerQuotas) *pb.Filter {
+	docTypes := make([]string, 0, 2)
+	if quotas.digests > 0 {
+		docTypes = append(docTypes, ragDocumentTypeDigest)
+	}
+	if quotas.insights > 0 {
+		docTypes = append(docTypes, ragDocumentTypeInsight)
+	}
+
+	must := []*pb.Condition{
+		{
+			ConditionOneOf: &pb.Condition_Field{
+				Field: &pb.FieldCondition{
+					Key:   "campaign_id",
+					Match: &pb.Match{MatchValue: &pb.Match_Keyword{Keyword: campaignID}},
+				},
+			},
+		},
+		{
+			ConditionOneOf: &pb.Condition_Field{
+				Field: &pb.FieldCondition{
+					Key: "rag_document_type",
+					Match: &pb.Match{
+						MatchValue: &pb.Match_Keywords{
+							Keywords: &pb.RepeatedStrings{Strings: docTypes},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Window overlap: window_end >= DateFrom AND window_start <= DateTo.
+	if filters.DateFrom != nil {
+		val := float64(*filters.DateFrom)
+		must = append(must, rangeOrMissing("analysis_window_end_unix", &pb.Range{Gte: &val}))
+	}
+	if filters.DateTo != nil {
+		val := float64(*filters.DateTo)
+		must = append(must, rangeOrMissing("analysis_window_start_unix", &pb.Range{Lte: &val}))
+	}
+
+	return &pb.Filter{Must: must}
+}
+
+// rangeOrMissing matches points whose key is within rng or not set at all.
+func rangeOrMissing(key string, rng *pb.Range) *pb.Condition {
+	return &pb.Condition{
+		ConditionOneOf: &pb.Condition_Filter{
+			Filter: &pb.Filter{
+				Should: []*pb.Condition{
+					{
+						ConditionOneOf: &pb.Condition_Field{
+							Field: &pb.FieldCondition{Key: key, Range: rng},
+						},
+					},
+					{
+						ConditionOneOf: &pb.Condition_IsEmpty{
+							IsEmpty: &pb.IsEmptyCondition{Key: key},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mapMacroResult converts a macro_insights hit into a layer-labelled SearchResult.
+func mapMacroResult(r point.SearchOutput) search.SearchResult {
+	result := search.SearchResult{
+		ID:            r.ID,
+		Score:         float64(r.Score),
+		ProjectID:     stringFromPayload(r.Payload, "project_id"),
+		Platform:      stringFromPayload(r.Payload, "platform"),
+		RecencyFactor: 1,
+		Metadata:      r.Payload,
+	}
+	if v, ok := r.Payload["analysis_window_end_unix"].(float64); ok {
+		result.ContentCreatedAt = int64(v)
+	}
+
+	switch stringFromPayload(r.Payload, "rag_document_type") {
+	case ragDocumentTypeDigest:
+		result.Layer = search.LayerDigest
+	case ragDocumentTypeInsight:
+		result.Layer = search.LayerInsight
+	}
+
+	result.Content = strings.TrimSpace(stringFromPayload(r.Payload, "content"))
+	if result.Content == "" {
+		result.Content = macroContentFallback(result.Layer, r.Payload)
+	}
+	return result
+}
+
+// macroContentFallback rebuilds display text for points indexed before the
+// embedded text was stored in the payload.
+func macroContentFallback(layer string, payload map[string]interface{}) string {
+	switch layer {
+	case search.LayerInsight:
+		title := strings.TrimSpace(stringFromPayload(payload, "title"))
+		summary := strings.TrimSpace(stringFromPayload(payload, "summary"))
+		if title == "" {
+			return summary
+		}
+		if summary == "" {
+			return title
+		}
+		return title + ". " + summary
+	case search.LayerDigest:
+		var b strings.Builder
+		fmt.Fprintf(&b, "Campaign Report: %s | Platform: %s | Total Mentions: %.0f | Analysis Window: %s to %s",
+			stringFromPayload(payload, "domain_overlay"),
+			stringFromPayload(payload, "platform"),
+			numberFromPayload(payload, "total_mentions"),
+			stringFromPayload(payload, "analysis_window_start"),
+			stringFromPayload(payload, "analysis_window_end"),
+		)
+		if names := digestNames(payload, "top_entities", "entity_name"); len(names) > 0 {
+			fmt.Fprintf(&b, " | Top Brands: %s", strings.Join(names, ", "))
+		}
+		if names := digestNames(payload, "top_topics", "topic_label"); len(names) > 0 {
+			fmt.Fprintf(&b, " | Key Topics: %s", strings.Join(names, ", "))
+		}
+		if names := digestNames(payload, "top_issues", "issue_category"); len(names) > 0 {
+			fmt.Fprintf(&b, " | Critical Issues: %s", strings.Join(names, ", "))
+		}
+		return b.String()
+	}
+	return ""
+}
+
+func digestNames(payload map[string]interface{}, listKey, nameKey string) []string {
+	items, ok := payload[listKey].([]interface{})
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name := strings.TrimSpace(stringFromPayload(m, nameKey)); name != "" {
+			names = append(names, name)
+		}
+		if len(names) == 5 {
+			break
+		}
+	}
+	return names
+}
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"sync"
+
 	"knowledge-srv/internal/embedding"
 	"knowledge-srv/internal/point"
 	"knowledge-srv/internal/search"
@@ -10,13 +12,32 @@ import (
 	"github.com/smap-hcmut/shared-libs/go/log"
 )
 
+// Config - Search ranking configuration
+type Config struct {
+	// DefaultRecencyHalfLifeHours applies when a request enables recency without a half-life.
+	DefaultRecencyHalfLifeHours float64
+	// RecencyHalfLifeHours overrides the default per campaign ID.
+	RecencyHalfLifeHours map[string]float64
+	// DiversityLambda is the default MMR trade-off when a request enables diversity.
+	DiversityLambda float64
+	// RedundancyThreshold is the default similarity at which MMR suppresses a candidate.
+	RedundancyThreshold float64
+}
+
 // implUseCase - Implementation của UseCase interface
 type implUseCase struct {
-	pointUC     point.UseCase
-	embeddingUC embedding.UseCase
-	cacheRepo   repository.CacheRepository
-	projectSrv  projectsrv.IProject
-	l           log.Logger
+	pointUC      point.UseCase
+	embeddingUC  embedding.UseCase
+	cacheRepo    repository.CacheRepository
+	queryLogRepo repository.QueryLogRepository
+	projectSrv   projectsrv.IProject
+	l            log.Logger
+	config       Config
+
+	queryLogCh   chan repository.CreateQueryLogOptions
+	queryLogStop chan struct{} // closed by Close
+	queryLogDone chan struct{} // closed when the writer has flushed and exited
+	closeOnce    sync.Once
 }
 
 // New - Factory function
@@ -24,14 +45,32 @@ func New(
 	pointUC point.UseCase,
 	embeddingUC embedding.UseCase,
 	cacheRepo repository.CacheRepository,
+	queryLogRepo repository.QueryLogRepository,
 	projectSrv projectsrv.IProject,
 	l log.Logger,
+	cfg Config,
 ) search.UseCase {
-	return &implUseCase{
-		pointUC:     pointUC,
-		embeddingUC: embeddingUC,
-		cacheRepo:   cacheRepo,
-		projectSrv:  projectSrv,
-		l:           l,
+	if cfg.DefaultRecencyHalfLifeHours <= 0 {
+		cfg.DefaultRecencyHalfLifeHours = search.DefaultRecencyHalfLifeHours
+	}
+	if cfg.DiversityLambda <= 0 || cfg.DiversityLambda > 1 {
+		cfg.DiversityLambda = search.DefaultDiversityLambda
+	}
+	if cfg.RedundancyThreshold <= 0 || cfg.RedundancyThreshold > 1 {
+		cfg.RedundancyThreshold = search.DefaultRedundancyThreshold
+	}
+	uc := &implUseCase{
+		pointUC:      pointUC,
+		embeddingUC:  embeddingUC,
+		cacheRepo:    cacheRepo,
+		queryLogRepo: queryLogRepo,
+		projectSrv:   projectSrv,
+		l:            l,
+		config:       cfg,
+		queryLogCh:   make(chan repository.CreateQueryLogOptions, queryLogBufferSize),
+		queryLogStop: make(chan struct{}),
+		queryLogDone: make(chan struct{}),
 	}
+	go uc.runQueryLogWriter()
+	return uc
 }
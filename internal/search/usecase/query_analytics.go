@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/search/repository"
+)
+
+const scoreDistributionBuckets = 10
+
+// TopQueries - Most frequent queries of a campaign (relevance tuning)
+func (uc *implUseCase) TopQueries(ctx context.Context, sc model.Scope, input search.QueryAnalyticsInput) (search.TopQueriesOutput, error) {
+	opt, err := uc.buildAnalyticsOptions(sc, input)
+	if err != nil {
+		return search.TopQueriesOutput{}, err
+	}
+
+	stats, err := uc.queryLogRepo.TopQueries(ctx, opt)
+	if err != nil {
+		uc.l.Errorf(ctx, "search.usecase.TopQueries: Failed to load top queries: %v", err)
+		return search.TopQueriesOutput{}, fmt.Errorf("%w: %v", search.ErrAnalyticsFailed, err)
+	}
+	return search.TopQueriesOutput{Queries: toQueryStats(stats)}, nil
+}
+
+// ZeroResultQueries - Most frequent queries with no results (content gaps for crawling)
+func (uc *implUseCase) ZeroResultQueries(ctx context.Context, sc model.Scope, input search.QueryAnalyticsInput) (search.ZeroResultQueriesOutput, error) {
+	opt, err := uc.buildAnalyticsOptions(sc, input)
+	if err != nil {
+		return search.ZeroResultQueriesOutput{}, err
+	}
+
+	stats, err := uc.queryLogRepo.ZeroResultQueries(ctx, opt)
+	if err != nil {
+		uc.l.Errorf(ctx, "search.usecase.ZeroResultQueries: Failed to load zero-result queries: %v", err)
+		return search.ZeroResultQueriesOutput{}, fmt.Errorf("%w: %v", search.ErrAnalyticsFailed, err)
+	}
+	return search.ZeroResultQueriesOutput{Queries: toQueryStats(stats)}, nil
+}
+
+// ScoreDistribution - Histogram of top scores per retrieval
+func (uc *implUseCase) ScoreDistribution(ctx context.Context, sc model.Scope, input search.QueryAnalyticsInput) (search.ScoreDistributionOutput, error) {
+	opt, err := uc.buildAnalyticsOptions(sc, input)
+	if err != nil {
+		return search.ScoreDistributionOutput{}, err
+	}
+	opt.Buckets = scoreDistributionBuckets
+
+	dist, err := uc.queryLogRepo.ScoreDistribution(ctx, opt)
+	if err != nil {
+		uc.l.Errorf(ctx, "search.usecase.ScoreDistribution: Failed to load score distribution: %v", err)
+		return search.ScoreDistributionOutput{}, fmt.Errorf("%w: %v", search.ErrAnalyticsFailed, err)
+	}
+
+	width := 1.0 / float64(opt.Buckets)
+	buckets := make([]search.ScoreBucket, len(dist.Counts))
+	for i, count := range dist.Counts {
+		buckets[i] = search.ScoreBucket{
+			From:  float64(i) * width,
+			To:    float64(i+1) * width,
+			Count: count,
+		}
+	}
+	return search.ScoreDistributionOutput{
+		Total:      dist.Total,
+		ZeroResult: dist.ZeroResult,
+		Buckets:    buckets,
+	}, nil
+}
+
+// LatencyPercentiles - Retrieval latency percentiles and cache hit rate
+func (uc *implUseCase) LatencyPercentiles(ctx context.Context, sc model.Scope, input search.QueryAnalyticsInput) (search.LatencyPercentilesOutput, error) {
+	opt, err := uc.buildAnalyticsOptions(sc, input)
+	if err != nil {
+		return search.LatencyPercentilesOutput{}, err
+	}
+
+	stats, err := uc.queryLogRepo.LatencyPercentiles(ctx, opt)
+	if err != nil {
+		uc.l.Errorf(ctx, "search.usecase.LatencyPercentiles: Failed to load latency percentiles: %v", err)
+		return search.LatencyPercentilesOutput{}, fmt.Errorf("%w: %v", search.ErrAnalyticsFailed, err)
+	}
+
+	var cacheHitRate float64
+	if stats.Total > 0 {
+		cacheHitRate = float64(stats.CacheHit) / float64(stats.Total)
+	}
+	return search.LatencyPercentilesOutput{
+		Total:        stats.Total,
+		CacheHitRate: cacheHitRate,
+		AvgMs:        stats.AvgMs,
+		P50Ms:        stats.P50Ms,
+		P90Ms:        stats.P90Ms,
+		P95Ms:        stats.P95Ms,
+		P99Ms:        stats.P99Ms,
+	}, nil
+}
+
+// buildAnalyticsOptions - Admin check + default window/limit
+func (uc *implUseCase) buildAnalyticsOptions(sc model.Scope, input search.QueryAnalyticsInput) (repository.QueryAnalyticsOptions, error) {
+	if !sc.IsAdmin() {
+		return repository.QueryAnalyticsOptions{}, search.ErrForbidden
+	}
+	if input.CampaignID == "" {
+		return repository.QueryAnalyticsOptions{}, search.ErrCampaignNotFound
+	}
+
+	to := time.Now()
+	if input.To != nil {
+		to = time.Unix(*input.To, 0)
+	}
+	from := to.AddDate(0, 0, -search.DefaultAnalyticsDays)
+	if input.From != nil {
+		from = time.Unix(*input.From, 0)
+	}
+	if !from.Before(to) {
+		return repository.QueryAnalyticsOptions{}, search.ErrInvalidFilters
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = search.DefaultAnalyticsLimit
+	}
+	if limit > search.MaxAnalyticsLimit {
+		limit = search.MaxAnalyticsLimit
+	}
+
+	return repository.QueryAnalyticsOptions{
+		CampaignID: input.CampaignID,
+		From:       from,
+		To:         to,
+		Limit:      limit,
+	}, nil
+}
+
+func toQueryStats(stats []repository.QueryStat) []search.QueryStat {
+	out := make([]search.QueryStat, len(stats))
+	for i, s := range stats {
+		out[i] = search.QueryStat{
+			NormalizedQuery: s.NormalizedQuery,
+			Count:           s.Count,
+			ZeroResultCount: s.ZeroResultCount,
+			AvgResultCount:  s.AvgResultCount,
+			AvgTopScore:     s.AvgTopScore,
+			LastSeenAt:      s.LastSeenAt.Unix(),
+		}
+	}
+	return out
+}
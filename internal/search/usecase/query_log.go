@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/search"
+	"knowledge-srv/internal/search/repository"
+)
+
+const (
+	queryLogBufferSize   = 1024
+	queryLogWriteTimeout = 5 * time.Second
+)
+
+// recordQuery enqueues a query analytics record without blocking the request path.
+// Only first pages are recorded so cursor or offset paging does not inflate query counts.
+// When the writer falls behind, records are dropped rather than slowing search.
+func (uc *implUseCase) recordQuery(ctx context.Context, sc model.Scope, input search.SearchInput, output search.SearchOutput) {
+	if uc.queryLogRepo == nil || input.Cursor != "" || input.Offset > 0 {
+		return
+	}
+
+	intent := strings.TrimSpace(input.Intent)
+	if intent == "" {
+		intent = search.IntentSearch
+	}
+	var topScore float64
+	for _, r := range output.Results {
+		if r.Score > topScore {
+			topScore = r.Score
+		}
+	}
+	filters, _ := json.Marshal(input.Filters)
+
+	opt := repository.CreateQueryLogOptions{
+		CampaignID:        input.CampaignID,
+		UserID:            sc.UserID,
+		QueryText:         input.Query,
+		NormalizedQuery:   normalizeQuery(input.Query),
+		Intent:            strings.ToUpper(intent),
+		Filters:           filters,
+		ResultCount:       len(output.Results),
+		TopScore:          topScore,
+		NoRelevantContext: output.NoRelevantContext,
+		CacheHit:          output.CacheHit,
+		LatencyMs:         output.ProcessingTimeMs,
+		CreatedAt:         time.Now(),
+	}
+
+	select {
+	case uc.queryLogCh <- opt:
+	default:
+		uc.l.Warnf(ctx, "search.usecase.recordQuery: query log buffer full, dropping record for campaign %s", input.CampaignID)
+	}
+}
+
+// runQueryLogWriter drains the query log buffer into Postgres until Close, then
+// writes whatever is still buffered and exits. The channel itself is never closed,
+// so a late recordQuery cannot panic; its record is simply not written.
+func (uc *implUseCase) runQueryLogWriter() {
+	defer close(uc.queryLogDone)
+	for {
+		select {
+		case opt := <-uc.queryLogCh:
+			uc.writeQueryLog(opt)
+		case <-uc.queryLogStop:
+			for {
+				select {
+				case opt := <-uc.queryLogCh:
+					uc.writeQueryLog(opt)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (uc *implUseCase) writeQueryLog(opt repository.CreateQueryLogOptions) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryLogWriteTimeout)
+	defer cancel()
+	if err := uc.queryLogRepo.CreateQueryLog(ctx, opt); err != nil {
+		uc.l.Warnf(ctx, "search.usecase.runQueryLogWriter: Failed to write query log: %v", err)
+	}
+}
+
+// Close stops the query log writer after it has flushed the buffer.
+func (uc *implUseCase) Close(ctx context.Context) error {
+	uc.closeOnce.Do(func() { close(uc.queryLogStop) })
+	select {
+	case <-uc.queryLogDone:
+		return nil
+	case <-ctx.Done():
+		uc.l.Warnf(ctx, "search.usecase.Close: query log flush interrupted, %d records not written", len(uc.queryLogCh))
+		return ctx.Err()
+	}
+}
+
+// PurgeCampaign deletes the query logs of a deleted campaign (internal, no scope check).
+// Records still buffered for the campaign may land afterwards; a re-run removes them.
+func (uc *implUseCase) PurgeCampaign(ctx context.Context, campaignID string) (search.PurgeCampaignOutput, error) {
+	if uc.queryLogRepo == nil {
+		return search.PurgeCampaignOutput{}, nil
+	}
+	n, err := uc.queryLogRepo.DeleteCampaignQueryLogs(ctx, campaignID)
+	if err != nil {
+		uc.l.Errorf(ctx, "search.usecase.PurgeCampaign: Failed to delete query logs of campaign %s: %v", campaignID, err)
+		return search.PurgeCampaignOutput{}, fmt.Errorf("%w: %v", search.ErrPurgeFailed, err)
+	}
+	return search.PurgeCampaignOutput{QueryLogs: n}, nil
+}
+
+// normalizeQuery lowercases, trims and collapses whitespace so that trivially
+// different spellings of the same query group together.
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
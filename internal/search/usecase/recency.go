@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
+)
+
+// recencyDecay is a resolved, request-scoped time-decay function.
+// ref is pinned per query (and carried in the cursor) so every page and every
+// collection in the fan-out decays against the same clock.
+type recencyDecay struct {
+	mode     string
+	halfLife float64 // hours
+	weight   float64
+	ref      time.Time
+}
+
+// resolveRecency merges request options with per-campaign config. Returns nil when disabled.
+func (uc *implUseCase) resolveRecency(input search.SearchInput, ref time.Time) *recencyDecay {
+	if input.Recency == nil {
+		return nil
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(input.Recency.Decay))
+	if mode != search.RecencyDecayGaussian {
+		mode = search.RecencyDecayExponential
+	}
+
+	halfLife := input.Recency.HalfLifeHours
+	if halfLife <= 0 {
+		halfLife = uc.config.RecencyHalfLifeHours[input.CampaignID]
+	}
+	if halfLife <= 0 {
+		halfLife = uc.config.DefaultRecencyHalfLifeHours
+	}
+	if halfLife <= 0 {
+		halfLife = search.DefaultRecencyHalfLifeHours
+	}
+
+	weight := input.Recency.Weight
+	if weight <= 0 || weight > 1 {
+		weight = search.DefaultRecencyWeight
+	}
+
+	return &recencyDecay{mode: mode, halfLife: halfLife, weight: weight, ref: ref}
+}
+
+// factor returns the score multiplier for content created at createdAt (unix seconds).
+// Undated content is treated as one half-life old; future timestamps are not boosted.
+func (d *recencyDecay) factor(createdAt int64) float64 {
+	if d == nil {
+		return 1
+	}
+
+	decay := 0.5
+	if createdAt > 0 {
+		ageHours := math.Max(d.ref.Sub(time.Unix(createdAt, 0)).Hours(), 0)
+		x := ageHours / d.halfLife
+		switch d.mode {
+		case search.RecencyDecayGaussian:
+			decay = math.Exp(-math.Ln2 * x * x)
+		default:
+			decay = math.Pow(0.5, x)
+		}
+	}
+	return 1 - d.weight + d.weight*decay
+}
+
+// apply rescales a raw Qdrant hit in place.
+func (d *recencyDecay) apply(r *point.SearchOutput) {
+	if d == nil {
+		return
+	}
+	r.Score = float32(float64(r.Score) * d.factor(createdAtFromPayload(r.Payload)))
+}
+
+func createdAtFromPayload(payload map[string]interface{}) int64 {
+	if v, ok := payload["content_created_at"].(float64); ok {
+		return int64(v)
+	}
+	return 0
+}
@@ -22,7 +22,7 @@ import (
 )
 
 // Search - Main search method
-// Flow: check cache → resolve campaign → embed query → search per-project Qdrant collections → filter by Score → aggregate → cache → return
+// Flow: decode cursor → check cache → resolve campaign → embed query → search per-project Qdrant collections (+ macro_insights on the first page) → merge/dedupe/rerank → page → blend layers → aggregate → cache → log query → return
 func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input search.SearchInput) (search.SearchOutput, error) {
 	startTime := time.Now()
 
@@ -44,6 +44,28 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input search.
 		minScore = search.MinScore
 	}
 
+	// Resolve continuation token (bound to this exact query + filters).
+	// The recency reference time is pinned on the first page and carried forward.
+	fingerprint := queryFingerprint(input, limit, minScore)
+	offset := 0
+	refTime := startTime
+	if input.Cursor != "" {
+		cursor, err := decodeCursor(input.Cursor, fingerprint)
+		if err != nil {
+			uc.l.Warnf(ctx, "search.usecase.Search: rejected cursor: %v", err)
+			return search.SearchOutput{}, err
+		}
+		offset = cursor.Offset
+		if cursor.RefTime > 0 {
+			refTime = time.Unix(cursor.RefTime, 0)
+		}
+	} else if input.Offset > 0 {
+		offset = input.Offset
+	}
+	decay := uc.resolveRecency(input, refTime)
+	quotas := resolveLayerQuotas(input.Layers, limit)
+	mmr := uc.resolveDiversity(input)
+
 	// Step 1: Check Tầng 3 — Search Results Cache
 	cacheKey := uc.generateCacheKey(input)
 	cachedData, err := uc.cacheRepo.GetSearchResults(ctx, cacheKey)
@@ -53,6 +75,7 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input search.
 			cached.CacheHit = true
 			cached.ProcessingTimeMs = time.Since(startTime).Milliseconds()
 			uc.l.Debugf(ctx, "search.usecase.Search: cache hit for key %s", cacheKey)
+			uc.recordQuery(ctx, sc, input, cached)
 			return cached, nil
 		}
 	}
@@ -63,10 +86,12 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input search.
 		return search.SearchOutput{}, err
 	}
 	if len(projectIDs) == 0 {
-		return search.SearchOutput{
+		output := search.SearchOutput{
 			NoRelevantContext: true,
 			ProcessingTimeMs:  time.Since(startTime).Milliseconds(),
-		}, nil
+		}
+		uc.recordQuery(ctx, sc, input, output)
+		return output, nil
 	}
 
 	// Step 3: Enrich query with campaign name for better semantic matching.
@@ -92,50 +117,65 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input search.
 	// Step 5: Build Qdrant filter (without project_id — implicit by collection)
 	filter := uc.buildSearchFilter(nil, input.Filters)
 
-	// Step 6: Search per-project Qdrant collections in parallel (server-side score filtering).
-	// Over-fetch a bit so snapshot dedupe still leaves enough documents for the prompt.
-	fetchLimit := limit * 3
-	if fetchLimit > 50 {
-		fetchLimit = 50
+	// Step 6: Fan out over per-project Qdrant collections and merge into one stable
+	// ordering (score desc, point ID asc), deduped and reranked in fixed windows.
+	// Fetch deep enough to cover every rerank window touched by the requested page.
+	// Macro layers (digests, insight cards) are campaign-wide and only join the first
+	// page, so they are fetched alongside and never enter the cursor ordering.
+	var macroResults []search.SearchResult
+	var macroGroup errgroup.Group
+	if offset == 0 && quotas.total() > 0 {
+		macroGroup.Go(func() error {
+			macroResults = uc.searchMacroLayers(ctx, input.CampaignID, vector, input.Filters, float32(minScore), quotas)
+			return nil
+		})
 	}
-	pointResults, err := uc.searchMultipleCollections(ctx, projectIDs, vector, filter, uint64(fetchLimit), float32(minScore))
+	// With diversity on, each window is MMR-selected instead of reranked.
+	window := limit * rerankWindowFactor
+	need := ((offset+limit-1)/window + 1) * window
+	ranked, err := uc.rankCandidates(ctx, projectIDs, vector, filter, float32(minScore), decay, mmr, window, need, offset+limit)
+	_ = macroGroup.Wait()
 	if err != nil {
 		uc.l.Errorf(ctx, "search.usecase.Search: Multi-collection search failed: %v", err)
 		return search.SearchOutput{}, fmt.Errorf("%w: %v", search.ErrSearchFailed, err)
 	}
-
-	// Step 7: Sort by score descending, collapse repeated snapshots of the same logical
-	// post/UAP, then apply the final limit.
-	sort.Slice(pointResults, func(i, j int) bool {
-		return pointResults[i].Score > pointResults[j].Score
-	})
-	preDedupeCount := len(pointResults)
-	pointResults = uc.dedupePointResults(pointResults)
-
-	var candidates []search.SearchResult
-	for _, r := range pointResults {
-		mapped := uc.mapQdrantResult(r)
-		if !isUsefulSearchResult(mapped) {
-			continue
+	ordered, candidates := ranked.ordered, ranked.results
+
+	// Step 7: Slice the requested page and issue the next continuation token.
+	// Posts fill whatever the macro layers left of the page; the cursor only
+	// advances through the post ordering.
+	postLimit := limit - len(macroResults)
+	var posts []search.SearchResult
+	if offset < len(candidates) {
+		end := offset + postLimit
+		if end > len(candidates) {
+			end = len(candidates)
 		}
-		candidates = append(candidates, mapped)
+		posts = candidates[offset:end]
+	}
+	nextOffset := offset + postLimit
+	hasMore := nextOffset < search.MaxSearchDepth &&
+		(nextOffset < len(candidates) || (!ordered.exhausted && len(ordered.candidates) >= ranked.need))
+	nextCursor := ""
+	if hasMore {
+		nextCursor = encodeCursor(searchCursor{Version: cursorVersion, Fingerprint: fingerprint, Offset: nextOffset, RefTime: refTime.Unix()})
 	}
-	sort.SliceStable(candidates, func(i, j int) bool {
-		return searchResultRankScore(candidates[i]) > searchResultRankScore(candidates[j])
-	})
 
-	results := candidates
-	if len(results) > limit {
-		results = results[:limit]
+	// Step 8: Blend layers (digests → insights → posts) and label post results
+	for i := range posts {
+		posts[i].Layer = search.LayerPost
 	}
+	results := make([]search.SearchResult, 0, len(macroResults)+len(posts))
+	results = append(results, macroResults...)
+	results = append(results, posts...)
 
-	// Step 8: Hallucination control — NO relevant context flag
+	// Step 9: Hallucination control — NO relevant context flag
 	noRelevantContext := len(results) == 0
 
-	// Step 9: Build aggregations
-	aggregations := uc.buildAggregations(results)
+	// Step 10: Build aggregations (post-level evidence only)
+	aggregations := uc.buildAggregations(posts)
 
-	// Step 10: Build output
+	// Step 11: Build output
 	output := search.SearchOutput{
 		Results:           results,
 		TotalFound:        len(results),
@@ -143,17 +183,25 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input search.
 		NoRelevantContext: noRelevantContext,
 		CacheHit:          false,
 		ProcessingTimeMs:  time.Since(startTime).Milliseconds(),
+		NextCursor:        nextCursor,
+		HasMore:           hasMore,
+	}
+	if mmr != nil {
+		output.SuppressedRedundant = suppressedThrough(ranked.suppression, offset+len(posts))
 	}
 
-	// Step 11: Cache results (Tầng 3)
+	// Step 12: Cache results (Tầng 3), tagged by every project the search covered
 	if data, err := json.Marshal(output); err == nil {
-		if err := uc.cacheRepo.SaveSearchResults(ctx, cacheKey, data); err != nil {
+		if err := uc.cacheRepo.SaveSearchResults(ctx, cacheKey, data, projectIDs); err != nil {
 			uc.l.Warnf(ctx, "search.usecase.Search: Failed to save cache: %v", err)
 		}
 	}
 
-	uc.l.Infof(ctx, "search.usecase.Search: query=%q, enriched=%q, projects=%d, fetched=%d, deduped=%d, useful=%d, results=%d, no_context=%v, duration=%dms",
-		input.Query, enrichedQuery, len(projectIDs), preDedupeCount, len(pointResults), len(candidates), len(results), noRelevantContext, output.ProcessingTimeMs)
+	uc.l.Infof(ctx, "search.usecase.Search: query=%q, enriched=%q, projects=%d, offset=%d, fetched=%d, deduped=%d, useful=%d, suppressed=%d, macro=%d, results=%d, has_more=%v, no_context=%v, duration=%dms",
+		input.Query, enrichedQuery, len(projectIDs), offset, ordered.fetched, ordered.deduped, len(candidates), output.SuppressedRedundant, len(macroResults), len(results), hasMore, noRelevantContext, output.ProcessingTimeMs)
+
+	// Step 13: Record query analytics (async)
+	uc.recordQuery(ctx, sc, input, output)
 
 	return output, nil
 }
@@ -242,8 +290,13 @@ func maxFloat(values ...float64) float64 {
 	return max
 }
 
-// searchMultipleCollections searches across per-project Qdrant collections in parallel.
+// searchMultipleCollections searches the collections holding the projects in parallel
+// (one per project, or a single tenant-filtered query in the shared layout).
 // Non-existent collections are silently skipped (project may not have indexed data yet).
+// Recency decay (if any) is applied to every hit here so all collections are rescored
+// against the same clock before merging.
+// floor is the highest raw score at which a full (saturated) collection was cut off:
+// any hit not yet fetched scores at most floor, even after decay (factor <= 1).
 func (uc *implUseCase) searchMultipleCollections(
 	ctx context.Context,
 	projectIDs []string,
@@ -251,23 +304,23 @@ func (uc *implUseCase) searchMultipleCollections(
 	filter *point.Filter,
 	limit uint64,
 	scoreThreshold float32,
-) ([]point.SearchOutput, error) {
-	var (
-		allResults []point.SearchOutput
-		mu         sync.Mutex
-	)
+	decay *recencyDecay,
+	withVectors bool,
+) (allResults []point.SearchOutput, floor float32, saturated bool, err error) {
+	var mu sync.Mutex
 
 	g, gCtx := errgroup.WithContext(ctx)
 
-	for _, pid := range projectIDs {
-		collectionName := point.CollectionForProject(pid)
+	for _, target := range uc.pointUC.CollectionTargets(projectIDs) {
+		collectionName := target.Collection
 		g.Go(func() error {
 			results, err := uc.pointUC.Search(gCtx, point.SearchInput{
 				CollectionName: collectionName,
 				Vector:         vector,
-				Filter:         filter,
+				Filter:         target.Scope(filter),
 				Limit:          limit,
 				WithPayload:    true,
+				WithVectors:    withVectors,
 				ScoreThreshold: scoreThreshold,
 			})
 			if err != nil {
@@ -279,18 +332,171 @@ func (uc *implUseCase) searchMultipleCollections(
 				return fmt.Errorf("search collection %s: %w", collectionName, err)
 			}
 
+			full := len(results) > 0 && uint64(len(results)) >= limit
+			var cutoff float32
+			if full {
+				cutoff = results[len(results)-1].Score
+				for _, r := range results {
+					if r.Score < cutoff {
+						cutoff = r.Score
+					}
+				}
+			}
+			for i := range results {
+				decay.apply(&results[i])
+			}
+
 			mu.Lock()
 			allResults = append(allResults, results...)
+			if full && (!saturated || cutoff > floor) {
+				floor = cutoff
+				saturated = true
+			}
 			mu.Unlock()
 			return nil
 		})
 	}
 
 	if err := g.Wait(); err != nil {
-		return nil, err
+		return nil, 0, false, err
+	}
+
+	return allResults, floor, saturated, nil
+}
+
+// orderedCandidates is the stable prefix of a query's merged result ordering.
+type orderedCandidates struct {
+	candidates []search.SearchResult
+	vectors    map[string][]float32 // result ID → embedding, only when fetched withVectors
+	exhausted  bool
+	fetched    int
+	deduped    int
+}
+
+// fetchOrderedCandidates returns at least need useful candidates in stable order
+// (unless the collections run dry or MaxSearchDepth is reached).
+// Every collection is asked for the same depth D; merged hits scoring above the
+// fan-out floor can never be outranked by a deeper fetch, so keeping only those makes
+// each deeper fetch append to the prefix. Dedupe (first occurrence wins) and filtering
+// therefore stay stable across pages.
+func (uc *implUseCase) fetchOrderedCandidates(
+	ctx context.Context,
+	projectIDs []string,
+	vector []float32,
+	filter *point.Filter,
+	scoreThreshold float32,
+	decay *recencyDecay,
+	withVectors bool,
+	need int,
+) (orderedCandidates, error) {
+	depth := need * 2
+	for {
+		if depth > search.MaxSearchDepth {
+			depth = search.MaxSearchDepth
+		}
+
+		pointResults, floor, saturated, err := uc.searchMultipleCollections(ctx, projectIDs, vector, filter, uint64(depth), scoreThreshold, decay, withVectors)
+		if err != nil {
+			return orderedCandidates{}, err
+		}
+		sort.Slice(pointResults, func(i, j int) bool {
+			if pointResults[i].Score == pointResults[j].Score {
+				return pointResults[i].ID < pointResults[j].ID
+			}
+			return pointResults[i].Score > pointResults[j].Score
+		})
+		capped := depth >= search.MaxSearchDepth
+		if saturated && !capped {
+			cut := sort.Search(len(pointResults), func(i int) bool {
+				return pointResults[i].Score <= floor
+			})
+			pointResults = pointResults[:cut]
+		}
+
+		out := orderedCandidates{
+			exhausted: !saturated || capped,
+			fetched:   len(pointResults),
+		}
+		// Collapse repeated snapshots of the same logical post/UAP.
+		pointResults = uc.dedupePointResults(pointResults)
+		out.deduped = len(pointResults)
+		for _, r := range pointResults {
+			mapped := uc.mapQdrantResult(r)
+			if !isUsefulSearchResult(mapped) {
+				continue
+			}
+			mapped.RecencyFactor = decay.factor(mapped.ContentCreatedAt)
+			out.candidates = append(out.candidates, mapped)
+			if withVectors {
+				if out.vectors == nil {
+					out.vectors = make(map[string][]float32)
+				}
+				out.vectors[mapped.ID] = r.Vector
+			}
+		}
+
+		if len(out.candidates) >= need || out.exhausted {
+			return out, nil
+		}
+		depth *= 2
 	}
+}
 
-	return allResults, nil
+// rankedCandidates is the final post ordering of a query: windowed rerank, or
+// windowed MMR selection when diversity is enabled.
+type rankedCandidates struct {
+	results     []search.SearchResult
+	ordered     orderedCandidates
+	need        int
+	suppression []windowSuppression
+}
+
+// rankCandidates fetches the stable ordering and ranks it. MMR suppression can
+// leave fewer results than candidates, so with diversity on it keeps fetching one
+// window deeper until want results exist or the ordering runs out.
+func (uc *implUseCase) rankCandidates(
+	ctx context.Context,
+	projectIDs []string,
+	vector []float32,
+	filter *point.Filter,
+	scoreThreshold float32,
+	decay *recencyDecay,
+	mmr *mmrSelector,
+	window int,
+	need int,
+	want int,
+) (rankedCandidates, error) {
+	for {
+		ordered, err := uc.fetchOrderedCandidates(ctx, projectIDs, vector, filter, scoreThreshold, decay, mmr != nil, need)
+		if err != nil {
+			return rankedCandidates{}, err
+		}
+		if mmr == nil {
+			return rankedCandidates{results: rerankInWindows(ordered.candidates, window), ordered: ordered, need: need}, nil
+		}
+
+		results, suppression := mmr.selectInWindows(ordered.candidates, ordered.vectors, window)
+		if len(results) >= want || ordered.exhausted || need >= search.MaxSearchDepth {
+			return rankedCandidates{results: results, ordered: ordered, need: need, suppression: suppression}, nil
+		}
+		need += window
+	}
+}
+
+// rerankInWindows applies searchResultRankScore inside consecutive fixed-size
+// windows of the stable ordering so page boundaries never shift.
+func rerankInWindows(candidates []search.SearchResult, window int) []search.SearchResult {
+	for start := 0; start < len(candidates); start += window {
+		end := start + window
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		block := candidates[start:end]
+		sort.SliceStable(block, func(i, j int) bool {
+			return searchResultRankScore(block[i]) > searchResultRankScore(block[j])
+		})
+	}
+	return candidates
 }
 
 // isCollectionNotFoundError checks if the Qdrant error indicates a missing collection.
@@ -320,5 +526,13 @@ func (uc *implUseCase) validateInput(input search.SearchInput) error {
 	if len(input.Query) > search.MaxQueryLength {
 		return search.ErrQueryTooLong
 	}
+	if e := input.Filters.Entities; e != nil {
+		if e.Match != "" && e.Match != search.EntityMatchAny && e.Match != search.EntityMatchAll {
+			return search.ErrInvalidFilters
+		}
+	}
+	if input.Offset < 0 || input.Offset >= search.MaxSearchDepth {
+		return search.ErrInvalidCursor
+	}
 	return nil
 }
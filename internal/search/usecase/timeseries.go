@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/point"
+	"knowledge-srv/internal/search"
+
+	pb "github.com/qdrant/go-client/qdrant"
+	"golang.org/x/sync/errgroup"
+)
+
+// timeSeriesConcurrency bounds per-bucket count/facet calls in flight.
+const timeSeriesConcurrency = 8
+
+// bucketLocation - Day, week and month boundaries follow the users' timezone (UTC+7),
+// matching the date ranges chat resolves.
+var bucketLocation = time.FixedZone("ICT", 7*60*60)
+
+// TimeSeries - Document counts per interval with the sentiment mix of each bucket.
+// Only points with a content_created_at timestamp are counted.
+func (uc *implUseCase) TimeSeries(ctx context.Context, sc model.Scope, input search.TimeSeriesInput) (search.TimeSeriesOutput, error) {
+	if input.CampaignID == "" {
+		return search.TimeSeriesOutput{}, search.ErrCampaignNotFound
+	}
+	interval := input.Interval
+	if interval == "" {
+		interval = search.IntervalDay
+	}
+	buckets, err := timeSeriesBuckets(input.From, input.To, interval)
+	if err != nil {
+		return search.TimeSeriesOutput{}, err
+	}
+	// Key on the bucket boundary, so ranges ending "now" share an entry until it closes.
+	input.To = buckets[len(buckets)-1].End
+
+	// Step 0: Cache (shares the aggregate cache, evicted on ingestion)
+	cacheKey := generateTimeSeriesCacheKey(input, interval)
+	if cachedData, err := uc.cacheRepo.GetAggregateResults(ctx, cacheKey); err == nil && cachedData != nil {
+		var cached search.TimeSeriesOutput
+		if err := json.Unmarshal(cachedData, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	// Step 1: Resolve campaign -> projects
+	projectIDs, err := uc.resolveCampaignProjects(ctx, input.CampaignID)
+	if err != nil {
+		return search.TimeSeriesOutput{}, err
+	}
+
+	// Step 2: Count every bucket in every collection, merge per bucket
+	var mu sync.Mutex
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(timeSeriesConcurrency)
+	targets := uc.pointUC.CollectionTargets(projectIDs)
+	for i := range buckets {
+		filters := input.Filters
+		filters.DateFrom, filters.DateTo = &buckets[i].Start, &buckets[i].End
+		filter := uc.buildSearchFilter(projectIDs, filters)
+		for _, target := range targets {
+			g.Go(func() error {
+				count, sentiments, err := uc.countBucket(gCtx, target, filter)
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				buckets[i].Count += count
+				for label, n := range sentiments {
+					buckets[i].SentimentBreakdown[label] += n
+				}
+				return nil
+			})
+		}
+	}
+	if err := g.Wait(); err != nil {
+		uc.l.Errorf(ctx, "search.usecase.TimeSeries: bucket query failed: %v", err)
+		return search.TimeSeriesOutput{}, err
+	}
+
+	output := search.TimeSeriesOutput{Interval: interval, Buckets: buckets}
+	for _, b := range buckets {
+		output.TotalDocs += b.Count
+	}
+
+	// Step 3: Cache, tagged by every project counted
+	if data, err := json.Marshal(output); err == nil {
+		if err := uc.cacheRepo.SaveAggregateResults(ctx, cacheKey, data, projectIDs); err != nil {
+			uc.l.Warnf(ctx, "search.usecase.TimeSeries: Failed to save cache: %v", err)
+		}
+	}
+
+	return output, nil
+}
+
+// countBucket counts one bucket in one collection and facets its sentiment labels
+// (both payload formats). Non-existent collections count as empty.
+func (uc *implUseCase) countBucket(ctx context.Context, target point.CollectionTarget, filter *pb.Filter) (uint64, map[string]uint64, error) {
+	scoped := target.Scope(withConditions(filter))
+	count, err := uc.pointUC.Count(ctx, point.CountInput{
+		CollectionName: target.Collection,
+		Filter:         scoped,
+	})
+	if err != nil {
+		if isCollectionNotFoundError(err) {
+			return 0, nil, nil
+		}
+		return 0, nil, fmt.Errorf("failed to count %s: %w", target.Collection, err)
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+
+	sentiments := make(map[string]uint64)
+	for _, key := range []string{"overall_sentiment", "sentiment_label"} {
+		res, err := uc.pointUC.Facet(ctx, point.FacetInput{
+			CollectionName: target.Collection,
+			Key:            key,
+			Filter:         scoped,
+			Limit:          10,
+		})
+		if err != nil {
+			if isMissingFacetIndexError(err, key) {
+				continue
+			}
+			return 0, nil, fmt.Errorf("failed to facet %s in %s: %w", key, target.Collection, err)
+		}
+		for _, r := range res {
+			sentiments[r.Value] += r.Count
+		}
+	}
+	return count, sentiments, nil
+}
+
+// timeSeriesBuckets splits [from, to] into calendar-aligned intervals; the first
+// bucket is clipped to from, the last runs to the end of the interval holding to.
+func timeSeriesBuckets(from, to int64, interval string) ([]search.TimeSeriesBucket, error) {
+	if from <= 0 || to < from {
+		return nil, search.ErrInvalidTimeRange
+	}
+	var step func(time.Time) time.Time
+	var start time.Time
+	begin := time.Unix(from, 0).In(bucketLocation)
+	day := time.Date(begin.Year(), begin.Month(), begin.Day(), 0, 0, 0, 0, bucketLocation)
+	switch interval {
+	case search.IntervalDay:
+		start = day
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case search.IntervalWeek:
+		start = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case search.IntervalMonth:
+		start = time.Date(begin.Year(), begin.Month(), 1, 0, 0, 0, 0, bucketLocation)
+		step = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	default:
+		return nil, search.ErrInvalidTimeRange
+	}
+
+	var buckets []search.TimeSeriesBucket
+	for t := start; t.Unix() <= to; t = step(t) {
+		if len(buckets) == search.MaxTimeSeriesBuckets {
+			return nil, fmt.Errorf("%w: more than %d %s buckets", search.ErrInvalidTimeRange, search.MaxTimeSeriesBuckets, interval)
+		}
+		b := search.TimeSeriesBucket{
+			Start:              max(t.Unix(), from),
+			End:                step(t).Unix() - 1,
+			SentimentBreakdown: make(map[string]uint64),
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, nil
+}
+
+func generateTimeSeriesCacheKey(input search.TimeSeriesInput, interval string) string {
+	filterJSON, _ := json.Marshal(input.Filters)
+	hash := sha256.Sum256(filterJSON)
+	return fmt.Sprintf("aggregate:timeseries:%s:%s:%d:%d:%x", input.CampaignID, interval, input.From, input.To, hash[:8])
+}
@@ -0,0 +1,35 @@
+package share
+
+import "time"
+
+// Shareable resource types.
+const (
+	ResourceConversation = "CONVERSATION"
+	ResourceReport       = "REPORT"
+)
+
+// Grantee types.
+const (
+	GranteeUser     = "USER"     // named users
+	GranteeCampaign = "CAMPAIGN" // everyone with access to a project of the resource's campaign
+	GranteeLink     = "LINK"     // anyone signed in who holds the token
+)
+
+const (
+	// DefaultLinkTTL - Lifetime of a link share created without an expiry.
+	DefaultLinkTTL = 7 * 24 * time.Hour
+	// MaxLinkTTL - Link shares always expire, at the latest after this long.
+	MaxLinkTTL = 90 * 24 * time.Hour
+	// MaxUsersPerShare bounds the users granted in one request.
+	MaxUsersPerShare = 50
+)
+
+// IsValidResourceType reports whether t is a shareable resource type.
+func IsValidResourceType(t string) bool {
+	return t == ResourceConversation || t == ResourceReport
+}
+
+// IsValidGranteeType reports whether t is a grantee type.
+func IsValidGranteeType(t string) bool {
+	return t == GranteeUser || t == GranteeCampaign || t == GranteeLink
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"errors"
+	"knowledge-srv/internal/share"
+
+	pkgErrors "github.com/smap-hcmut/shared-libs/go/errors"
+)
+
+var (
+	errInvalidResourceType = pkgErrors.NewHTTPError(400, "Invalid resource type")
+	errInvalidGranteeType  = pkgErrors.NewHTTPError(400, "Invalid grantee type")
+	errUsersRequired       = pkgErrors.NewHTTPError(400, "At least one user is required")
+	errTooManyUsers        = pkgErrors.NewHTTPError(400, "Too many users in one share")
+	errInvalidExpiry       = pkgErrors.NewHTTPError(400, "Invalid share expiry")
+	errResourceNotFound    = pkgErrors.NewHTTPError(404, "Shared resource not found")
+	errForbidden           = pkgErrors.NewHTTPError(403, "Only the owner or an admin can manage shares")
+	errShareNotFound       = pkgErrors.NewHTTPError(404, "Share not found")
+	errInvalidToken        = pkgErrors.NewHTTPError(404, "Share link is invalid or has expired")
+	errCreateShare         = pkgErrors.NewHTTPError(500, "Failed to create share")
+	errListShares          = pkgErrors.NewHTTPError(500, "Failed to list shares")
+	errRevokeShare         = pkgErrors.NewHTTPError(500, "Failed to revoke share")
+)
+
+func (h *handler) mapError(err error) error {
+	switch {
+	case errors.Is(err, share.ErrInvalidResourceType):
+		return errInvalidResourceType
+	case errors.Is(err, share.ErrInvalidGranteeType):
+		return errInvalidGranteeType
+	case errors.Is(err, share.ErrUsersRequired):
+		return errUsersRequired
+	case errors.Is(err, share.ErrTooManyUsers):
+		return errTooManyUsers
+	case errors.Is(err, share.ErrInvalidExpiry):
+		return errInvalidExpiry
+	case errors.Is(err, share.ErrResourceNotFound):
+		return errResourceNotFound
+	case errors.Is(err, share.ErrForbidden):
+		return errForbidden
+	case errors.Is(err, share.ErrShareNotFound):
+		return errShareNotFound
+	case errors.Is(err, share.ErrInvalidToken):
+		return errInvalidToken
+	case errors.Is(err, share.ErrCreateShare):
+		return errCreateShare
+	case errors.Is(err, share.ErrListShares):
+		return errListShares
+	case errors.Is(err, share.ErrRevokeShare):
+		return errRevokeShare
+	default:
+		return pkgErrors.NewHTTPError(500, "Internal server error")
+	}
+}
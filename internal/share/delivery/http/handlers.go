@@ -0,0 +1,134 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/response"
+)
+
+// CreateShare - Handler cho POST /shares
+// @Summary Share a conversation or report
+// @Description Grant read access to a conversation or report: to named users (USER), to everyone who can access a project of its campaign (CAMPAIGN), or to whoever holds a new link token (LINK). Link shares expire after expires_in_hours (default 7 days, at most 90); their token is returned only in this response. Only the owner or an admin can share.
+// @Tags Share
+// @Accept json
+// @Produce json
+// @Param body body createShareReq true "Resource and grantees"
+// @Success 200 {object} createShareResp
+// @Failure 400 {object} response.Resp
+// @Failure 403 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /shares [post]
+func (h *handler) CreateShare(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processCreateShareRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "share.delivery.http.CreateShare: processCreateShareRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.CreateShare(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "share.delivery.http.CreateShare: usecase CreateShare failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newCreateShareResp(output))
+}
+
+// ListShares - Handler cho GET /shares
+// @Summary List shares
+// @Description With resource_id, list the shares of that conversation or report (owner or admin). Otherwise list the shares the caller created, or with direction=received the ones granted to the caller. Revoked and expired shares are left out unless include_inactive is set.
+// @Tags Share
+// @Produce json
+// @Param resource_type query string false "CONVERSATION | REPORT"
+// @Param resource_id query string false "Conversation or report ID"
+// @Param direction query string false "created (default) | received"
+// @Param include_inactive query bool false "Include revoked and expired shares"
+// @Param limit query int false "Max records (default 50, max 200)"
+// @Success 200 {object} listSharesResp
+// @Failure 400 {object} response.Resp
+// @Failure 403 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /shares [get]
+func (h *handler) ListShares(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processListSharesRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "share.delivery.http.ListShares: processListSharesRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.ListShares(ctx, sc, req.toInput())
+	if err != nil {
+		h.l.Errorf(ctx, "share.delivery.http.ListShares: usecase ListShares failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, h.newListSharesResp(output))
+}
+
+// RevokeShare - Handler cho DELETE /shares/:share_id
+// @Summary Revoke a share
+// @Description Withdraw a share; allowed to whoever created it, the owner of the resource and admins
+// @Tags Share
+// @Produce json
+// @Param share_id path string true "Share ID"
+// @Success 200 {object} revokeShareResp
+// @Failure 400 {object} response.Resp
+// @Failure 403 {object} response.Resp
+// @Failure 404 {object} response.Resp
+// @Failure 500 {object} response.Resp
+// @Router /shares/{share_id} [delete]
+func (h *handler) RevokeShare(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processShareIDRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "share.delivery.http.RevokeShare: processShareIDRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	if err := h.uc.RevokeShare(ctx, sc, req.toInput()); err != nil {
+		h.l.Errorf(ctx, "share.delivery.http.RevokeShare: usecase RevokeShare failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, revokeShareResp{ShareID: req.ShareID, Revoked: true})
+}
+
+// ResolveLink - Handler cho GET /shares/links/:token
+// @Summary Resolve a share link
+// @Description Return the conversation or report a link token opens. Read it with GET /conversations/{id} or the report endpoints, passing the token as share_token.
+// @Tags Share
+// @Produce json
+// @Param token path string true "Share link token"
+// @Success 200 {object} shareResp
+// @Failure 404 {object} response.Resp
+// @Router /shares/links/{token} [get]
+func (h *handler) ResolveLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req, sc, err := h.processResolveLinkRequest(c)
+	if err != nil {
+		h.l.Errorf(ctx, "share.delivery.http.ResolveLink: processResolveLinkRequest failed: %v", err)
+		response.Error(c, err, h.discord)
+		return
+	}
+
+	output, err := h.uc.ResolveToken(ctx, sc, req.Token)
+	if err != nil {
+		h.l.Warnf(ctx, "share.delivery.http.ResolveLink: usecase ResolveToken failed: %v", err)
+		response.Error(c, h.mapError(err), h.discord)
+		return
+	}
+
+	response.OK(c, newShareResp(output.Share))
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"time"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/share"
+)
+
+type createShareReq struct {
+	ResourceType   string   `json:"resource_type" binding:"required,oneof=CONVERSATION REPORT"`
+	ResourceID     string   `json:"resource_id" binding:"required,uuid"`
+	GranteeType    string   `json:"grantee_type" binding:"required,oneof=USER CAMPAIGN LINK"`
+	UserIDs        []string `json:"user_ids"`         // USER grants
+	ExpiresInHours int      `json:"expires_in_hours"` // 0 = no expiry (links: 7 days, at most 90)
+}
+
+func (r createShareReq) toInput() share.CreateShareInput {
+	return share.CreateShareInput{
+		ResourceType:   r.ResourceType,
+		ResourceID:     r.ResourceID,
+		GranteeType:    r.GranteeType,
+		UserIDs:        r.UserIDs,
+		ExpiresInHours: r.ExpiresInHours,
+	}
+}
+
+type listSharesReq struct {
+	ResourceType    string `form:"resource_type" binding:"omitempty,oneof=CONVERSATION REPORT"`
+	ResourceID      string `form:"resource_id" binding:"omitempty,uuid"`
+	Direction       string `form:"direction" binding:"omitempty,oneof=created received"`
+	IncludeInactive bool   `form:"include_inactive"`
+	Limit           int    `form:"limit"`
+}
+
+func (r listSharesReq) toInput() share.ListSharesInput {
+	return share.ListSharesInput{
+		ResourceType:    r.ResourceType,
+		ResourceID:      r.ResourceID,
+		Direction:       r.Direction,
+		IncludeInactive: r.IncludeInactive,
+		Limit:           r.Limit,
+	}
+}
+
+type shareIDReq struct {
+	ShareID string `uri:"share_id" binding:"required,uuid"`
+}
+
+func (r shareIDReq) toInput() share.RevokeShareInput {
+	return share.RevokeShareInput{ShareID: r.ShareID}
+}
+
+type resolveLinkReq struct {
+	Token string `uri:"token" binding:"required"`
+}
+
+type shareResp struct {
+	ID            string     `json:"id"`
+	ResourceType  string     `json:"resource_type"`
+	ResourceID    string     `json:"resource_id"`
+	CampaignID    string     `json:"campaign_id"`
+	GranteeType   string     `json:"grantee_type"`
+	GranteeUserID string     `json:"grantee_user_id,omitempty"`
+	CreatedBy     string     `json:"created_by"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type createShareResp struct {
+	Shares []shareResp `json:"shares"`
+	// Token opens a LINK share; it is shown only once.
+	Token string `json:"token,omitempty"`
+}
+
+type listSharesResp struct {
+	Shares []shareResp `json:"shares"`
+}
+
+type revokeShareResp struct {
+	ShareID string `json:"share_id"`
+	Revoked bool   `json:"revoked"`
+}
+
+func (h *handler) newCreateShareResp(o share.CreateShareOutput) createShareResp {
+	return createShareResp{Shares: newShareResps(o.Shares), Token: o.Token}
+}
+
+func (h *handler) newListSharesResp(o share.ListSharesOutput) listSharesResp {
+	return listSharesResp{Shares: newShareResps(o.Shares)}
+}
+
+func newShareResps(shares []model.Share) []shareResp {
+	resps := make([]shareResp, len(shares))
+	for i, s := range shares {
+		resps[i] = newShareResp(s)
+	}
+	return resps
+}
+
+func newShareResp(s model.Share) shareResp {
+	return shareResp{
+		ID:            s.ID,
+		ResourceType:  s.ResourceType,
+		ResourceID:    s.ResourceID,
+		CampaignID:    s.CampaignID,
+		GranteeType:   s.GranteeType,
+		GranteeUserID: s.GranteeUserID,
+		CreatedBy:     s.CreatedBy,
+		ExpiresAt:     s.ExpiresAt,
+		RevokedAt:     s.RevokedAt,
+		CreatedAt:     s.CreatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"knowledge-srv/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/auth"
+)
+
+func (h *handler) processCreateShareRequest(c *gin.Context) (createShareReq, model.Scope, error) {
+	var req createShareReq
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, model.Scope{}, err
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processListSharesRequest(c *gin.Context) (listSharesReq, model.Scope, error) {
+	var req listSharesReq
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return req, model.Scope{}, err
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processShareIDRequest(c *gin.Context) (shareIDReq, model.Scope, error) {
+	var req shareIDReq
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		return req, model.Scope{}, err
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
+
+func (h *handler) processResolveLinkRequest(c *gin.Context) (resolveLinkReq, model.Scope, error) {
+	var req resolveLinkReq
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		return req, model.Scope{}, err
+	}
+
+	sc := auth.GetScopeFromContext(c.Request.Context())
+	return req, model.ToScope(sc), nil
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smap-hcmut/shared-libs/go/middleware"
+)
+
+func (h *handler) RegisterRoutes(r *gin.RouterGroup, mw *middleware.Middleware) {
+	shares := r.Group("/shares")
+	shares.Use(mw.Auth())
+	{
+		shares.POST("", h.CreateShare)
+		shares.GET("", h.ListShares)
+		shares.DELETE("/:share_id", h.RevokeShare)
+		shares.GET("/links/:token", h.ResolveLink)
+	}
+}
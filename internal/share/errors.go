@@ -0,0 +1,18 @@
+package share
+
+import "errors"
+
+var (
+	ErrInvalidResourceType = errors.New("share: invalid resource type")
+	ErrInvalidGranteeType  = errors.New("share: invalid grantee type")
+	ErrUsersRequired       = errors.New("share: user grants need at least one user")
+	ErrTooManyUsers        = errors.New("share: too many users in one share")
+	ErrInvalidExpiry       = errors.New("share: invalid expiry")
+	ErrResourceNotFound    = errors.New("share: resource not found")
+	ErrForbidden           = errors.New("share: only the owner or an admin can manage shares")
+	ErrShareNotFound       = errors.New("share: share not found")
+	ErrInvalidToken        = errors.New("share: invalid or expired share token")
+	ErrCreateShare         = errors.New("share: failed to create share")
+	ErrListShares          = errors.New("share: failed to list shares")
+	ErrRevokeShare         = errors.New("share: failed to revoke share")
+)
@@ -0,0 +1,24 @@
+package share
+
+import (
+	"context"
+
+	"knowledge-srv/internal/model"
+)
+
+//go:generate mockery --name UseCase
+type UseCase interface {
+	// CreateShare grants read access to named users, to everyone on the resource's
+	// campaign, or to the holders of a new expiring link token. Only the owner of the
+	// resource or an admin can share it.
+	CreateShare(ctx context.Context, sc model.Scope, input CreateShareInput) (CreateShareOutput, error)
+	ListShares(ctx context.Context, sc model.Scope, input ListSharesInput) (ListSharesOutput, error)
+	// RevokeShare withdraws a share; allowed to its creator, the resource owner and admins.
+	RevokeShare(ctx context.Context, sc model.Scope, input RevokeShareInput) error
+	// ResolveToken returns the active link share a token belongs to.
+	ResolveToken(ctx context.Context, sc model.Scope, token string) (ResolveTokenOutput, error)
+	// CanRead reports whether an active share lets the caller read a resource it does
+	// not own: a grant to the caller, a campaign grant while the caller can access a
+	// project of the campaign, or a link share matching token (may be empty).
+	CanRead(ctx context.Context, sc model.Scope, res Resource, token string) bool
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"knowledge-srv/internal/model"
+)
+
+//go:generate mockery --name PostgresRepository
+type PostgresRepository interface {
+	ShareRepository
+	// GetResourceOwner returns the campaign and owner of a conversation or report
+	// (ErrNotFound when it does not exist).
+	GetResourceOwner(ctx context.Context, resourceType, resourceID string) (campaignID, ownerID string, err error)
+}
+
+// ShareRepository - Operations for the shares table
+type ShareRepository interface {
+	// CreateShare inserts a grant; an active grant of the same user and resource is
+	// updated in place instead.
+	CreateShare(ctx context.Context, opt CreateShareOptions) (model.Share, error)
+	GetShare(ctx context.Context, id string) (model.Share, error)
+	GetShareByTokenHash(ctx context.Context, tokenHash string) (model.Share, error)
+	ListShares(ctx context.Context, opt ListSharesOptions) ([]model.Share, error)
+	RevokeShare(ctx context.Context, id string) (model.Share, error)
+}
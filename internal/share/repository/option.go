@@ -0,0 +1,25 @@
+package repository
+
+import "time"
+
+// CreateShareOptions - Options for CreateShare
+type CreateShareOptions struct {
+	ResourceType  string
+	ResourceID    string
+	CampaignID    string
+	GranteeType   string
+	GranteeUserID string
+	TokenHash     string
+	CreatedBy     string
+	ExpiresAt     *time.Time
+}
+
+// ListSharesOptions - Filters for ListShares (empty = any)
+type ListSharesOptions struct {
+	ResourceType  string
+	ResourceID    string
+	GranteeUserID string
+	CreatedBy     string
+	ActiveOnly    bool // neither revoked nor expired
+	Limit         int  // 0 = no limit
+}
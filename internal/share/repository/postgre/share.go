@@ -0,0 +1,195 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/share"
+	repo "knowledge-srv/internal/share/repository"
+)
+
+const shareColumns = `id, resource_type, resource_id, campaign_id, grantee_type, grantee_user_id, token_hash, created_by, expires_at, revoked_at, created_at`
+
+// CreateShare - Insert a grant, or extend the active grant of the same user
+func (r *implPostgresRepository) CreateShare(ctx context.Context, opt repo.CreateShareOptions) (model.Share, error) {
+	query := `
+		INSERT INTO knowledge.shares (resource_type, resource_id, campaign_id, grantee_type, grantee_user_id, token_hash, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (resource_type, resource_id, grantee_user_id) WHERE grantee_type = 'USER' AND revoked_at IS NULL
+		DO UPDATE SET expires_at = EXCLUDED.expires_at
+		RETURNING ` + shareColumns
+
+	s, err := scanShare(r.db.QueryRowContext(ctx, query,
+		opt.ResourceType, opt.ResourceID, opt.CampaignID, opt.GranteeType,
+		nullString(opt.GranteeUserID), nullString(opt.TokenHash), opt.CreatedBy, opt.ExpiresAt,
+	))
+	if err != nil {
+		r.l.Errorf(ctx, "share.repository.postgre.CreateShare: Failed to insert share of %s %s: %v", opt.ResourceType, opt.ResourceID, err)
+		return model.Share{}, repo.ErrFailedToInsert
+	}
+	return s, nil
+}
+
+func (r *implPostgresRepository) GetShare(ctx context.Context, id string) (model.Share, error) {
+	query := `SELECT ` + shareColumns + ` FROM knowledge.shares WHERE id = $1`
+
+	s, err := scanShare(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Share{}, repo.ErrNotFound
+		}
+		r.l.Errorf(ctx, "share.repository.postgre.GetShare: Failed to get share %s: %v", id, err)
+		return model.Share{}, repo.ErrFailedToGet
+	}
+	return s, nil
+}
+
+func (r *implPostgresRepository) GetShareByTokenHash(ctx context.Context, tokenHash string) (model.Share, error) {
+	query := `SELECT ` + shareColumns + ` FROM knowledge.shares WHERE token_hash = $1`
+
+	s, err := scanShare(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Share{}, repo.ErrNotFound
+		}
+		r.l.Errorf(ctx, "share.repository.postgre.GetShareByTokenHash: Failed to get share: %v", err)
+		return model.Share{}, repo.ErrFailedToGet
+	}
+	return s, nil
+}
+
+// ListShares - Shares matching the filters, newest first
+func (r *implPostgresRepository) ListShares(ctx context.Context, opt repo.ListSharesOptions) ([]model.Share, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if opt.ResourceType != "" {
+		args = append(args, opt.ResourceType)
+		where = append(where, fmt.Sprintf("resource_type = $%d", len(args)))
+	}
+	if opt.ResourceID != "" {
+		args = append(args, opt.ResourceID)
+		where = append(where, fmt.Sprintf("resource_id = $%d", len(args)))
+	}
+	if opt.GranteeUserID != "" {
+		args = append(args, opt.GranteeUserID)
+		where = append(where, fmt.Sprintf("grantee_user_id = $%d", len(args)))
+	}
+	if opt.CreatedBy != "" {
+		args = append(args, opt.CreatedBy)
+		where = append(where, fmt.Sprintf("created_by = $%d", len(args)))
+	}
+	if opt.ActiveOnly {
+		where = append(where, "revoked_at IS NULL", "(expires_at IS NULL OR expires_at > NOW())")
+	}
+
+	query := `SELECT ` + shareColumns + ` FROM knowledge.shares`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if opt.Limit > 0 {
+		args = append(args, opt.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.l.Errorf(ctx, "share.repository.postgre.ListShares: Failed to query shares: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	defer rows.Close()
+
+	var shares []model.Share
+	for rows.Next() {
+		s, err := scanShare(rows)
+		if err != nil {
+			r.l.Errorf(ctx, "share.repository.postgre.ListShares: Failed to scan share: %v", err)
+			return nil, repo.ErrFailedToList
+		}
+		shares = append(shares, s)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Errorf(ctx, "share.repository.postgre.ListShares: Failed to iterate shares: %v", err)
+		return nil, repo.ErrFailedToList
+	}
+	return shares, nil
+}
+
+// RevokeShare - Mark a share revoked; revoking twice keeps the first time
+func (r *implPostgresRepository) RevokeShare(ctx context.Context, id string) (model.Share, error) {
+	query := `
+		UPDATE knowledge.shares
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING ` + shareColumns
+
+	s, err := scanShare(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Share{}, repo.ErrNotFound
+		}
+		r.l.Errorf(ctx, "share.repository.postgre.RevokeShare: Failed to revoke share %s: %v", id, err)
+		return model.Share{}, repo.ErrFailedToUpdate
+	}
+	return s, nil
+}
+
+// GetResourceOwner - Campaign and owner of the shared conversation or report
+func (r *implPostgresRepository) GetResourceOwner(ctx context.Context, resourceType, resourceID string) (string, string, error) {
+	var table string
+	switch resourceType {
+	case share.ResourceConversation:
+		table = "knowledge.conversations"
+	case share.ResourceReport:
+		table = "knowledge.reports"
+	default:
+		return "", "", repo.ErrNotFound
+	}
+
+	var campaignID, ownerID string
+	query := `SELECT campaign_id::text, user_id::text FROM ` + table + ` WHERE id = $1`
+	if err := r.db.QueryRowContext(ctx, query, resourceID).Scan(&campaignID, &ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", repo.ErrNotFound
+		}
+		r.l.Errorf(ctx, "share.repository.postgre.GetResourceOwner: Failed to get %s %s: %v", resourceType, resourceID, err)
+		return "", "", repo.ErrFailedToGet
+	}
+	return campaignID, ownerID, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanShare(s scanner) (model.Share, error) {
+	var (
+		sh                       model.Share
+		granteeUserID, tokenHash sql.NullString
+		expiresAt, revokedAt     sql.NullTime
+	)
+	err := s.Scan(&sh.ID, &sh.ResourceType, &sh.ResourceID, &sh.CampaignID, &sh.GranteeType,
+		&granteeUserID, &tokenHash, &sh.CreatedBy, &expiresAt, &revokedAt, &sh.CreatedAt)
+	if err != nil {
+		return model.Share{}, err
+	}
+	sh.GranteeUserID = granteeUserID.String
+	sh.TokenHash = tokenHash.String
+	if expiresAt.Valid {
+		sh.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		sh.RevokedAt = &revokedAt.Time
+	}
+	return sh, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,59 @@
+package share
+
+import "knowledge-srv/internal/model"
+
+// List directions.
+const (
+	DirectionCreated  = "created"  // shares the caller made (default)
+	DirectionReceived = "received" // user grants made to the caller
+)
+
+// Resource - A shareable conversation or report and who owns it
+type Resource struct {
+	Type       string
+	ID         string
+	CampaignID string
+	OwnerID    string
+}
+
+// CreateShareInput - Grant read access to a resource
+type CreateShareInput struct {
+	ResourceType string
+	ResourceID   string
+	GranteeType  string
+	UserIDs      []string // USER grants
+	// ExpiresInHours limits the grant; 0 = no expiry, except LINK shares which
+	// default to DefaultLinkTTL and never outlive MaxLinkTTL.
+	ExpiresInHours int
+}
+
+// CreateShareOutput - The grants created; Token is set for LINK shares and
+// returned only here.
+type CreateShareOutput struct {
+	Shares []model.Share
+	Token  string
+}
+
+// ListSharesInput - Shares of one resource (owner or admin), else the caller's
+// created or received shares
+type ListSharesInput struct {
+	ResourceType    string
+	ResourceID      string
+	Direction       string
+	IncludeInactive bool // also revoked and expired shares
+	Limit           int
+}
+
+type ListSharesOutput struct {
+	Shares []model.Share
+}
+
+// RevokeShareInput - Withdraw a share
+type RevokeShareInput struct {
+	ShareID string
+}
+
+// ResolveTokenOutput - What a link token opens
+type ResolveTokenOutput struct {
+	Share model.Share
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"knowledge-srv/internal/share"
+	"knowledge-srv/internal/share/repository"
+	"knowledge-srv/pkg/projectsrv"
+
+	"github.com/smap-hcmut/shared-libs/go/log"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+
+	// tokenBytes - Entropy of a link token before encoding.
+	tokenBytes = 32
+)
+
+type implUseCase struct {
+	repo       repository.PostgresRepository
+	projectSrv projectsrv.IProject // nil = campaign grants are never honoured
+	l          log.Logger
+}
+
+func New(repo repository.PostgresRepository, projectSrv projectsrv.IProject, l log.Logger) share.UseCase {
+	return &implUseCase{
+		repo:       repo,
+		projectSrv: projectSrv,
+		l:          l,
+	}
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"knowledge-srv/internal/model"
+	"knowledge-srv/internal/share"
+	"knowledge-srv/internal/share/repository"
+)
+
+func (uc *implUseCase) CreateShare(ctx context.Context, sc model.Scope, input share.CreateShareInput) (share.CreateShareOutput, error) {
+	if !share.IsValidGranteeType(input.GranteeType) {
+		return share.CreateShareOutput{}, share.ErrInvalidGranteeType
+	}
+	res, err := uc.loadResource(ctx, input.ResourceType, input.ResourceID)
+	if err != nil {
+		return share.CreateShareOutput{}, err
+	}
+	if !canManage(sc, res) {
+		return share.CreateShareOutput{}, share.ErrForbidden
+	}
+	expiresAt, err := shareExpiry(input.GranteeType, input.ExpiresInHours, time.Now())
+	if err != nil {
+		return share.CreateShareOutput{}, err
+	}
+
+	opt := repository.CreateShareOptions{
+		ResourceType: res.Type,
+		ResourceID:   res.ID,
+		CampaignID:   res.CampaignID,
+		GranteeType:  input.GranteeType,
+		CreatedBy:    sc.UserID,
+		ExpiresAt:    expiresAt,
+	}
+
+	var output share.CreateShareOutput
+	switch input.GranteeType {
+	case share.GranteeUser:
+		users, err := granteeUsers(input.UserIDs, res.OwnerID)
+		if err != nil {
+			return share.CreateShareOutput{}, err
+		}
+		for _, userID := range users {
+			opt.GranteeUserID = userID
+			s, err := uc.repo.CreateShare(ctx, opt)
+			if err != nil {
+				return share.CreateShareOutput{}, fmt.Errorf("%w: %v", share.ErrCreateShare, err)
+			}
+			output.Shares = append(output.Shares, s)
+		}
+	case share.GranteeLink:
+		token, err := newToken()
+		if err != nil {
+			return share.CreateShareOutput{}, fmt.Errorf("%w: %v", share.ErrCreateShare, err)
+		}
+		opt.TokenHash = hashToken(token)
+		s, err := uc.repo.CreateShare(ctx, opt)
+		if err != nil {
+			return share.CreateShareOutput{}, fmt.Errorf("%w: %v", share.ErrCreateShare, err)
+		}
+		output.Shares = []model.Share{s}
+		output.Token = token
+	default:
+		s, err := uc.repo.CreateShare(ctx, opt)
+		if err != nil {
+			return share.CreateShareOutput{}, fmt.Errorf("%w: %v", share.ErrCreateShare, err)
+		}
+		output.Shares = []model.Share{s}
+	}
+
+	uc.l.Infof(ctx, "share.usecase.CreateShare: %s %s shared by %s (grantee=%s, grants=%d)",
+		res.Type, res.ID, sc.UserID, input.GranteeType, len(output.Shares))
+	return output, nil
+}
+
+func (uc *implUseCase) ListShares(ctx context.Context, sc model.Scope, input share.ListSharesInput) (share.ListSharesOutput, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	opt := repository.ListSharesOptions{ActiveOnly: !input.IncludeInactive, Limit: limit}
+
+	switch {
+	case input.ResourceID != "":
+		res, err := uc.loadResource(ctx, input.ResourceType, input.ResourceID)
+		if err != nil {
+			return share.ListSharesOutput{}, err
+		}
+		if !canManage(sc, res) {
+			return share.ListSharesOutput{}, share.ErrForbidden
+		}
+		opt.ResourceType, opt.ResourceID = res.Type, res.ID
+	case input.Direction == share.DirectionReceived:
+		opt.ResourceType = input.ResourceType
+		opt.GranteeUserID = sc.UserID
+	default:
+		opt.ResourceType = input.ResourceType
+		opt.CreatedBy = sc.UserID
+	}
+	if opt.ResourceType != "" && !share.IsValidResourceType(opt.ResourceType) {
+		return share.ListSharesOutput{}, share.ErrInvalidResourceType
+	}
+
+	shares, err := uc.repo.ListShares(ctx, opt)
+	if err != nil {
+		return share.ListSharesOutput{}, fmt.Errorf("%w: %v", share.ErrListShares, err)
+	}
+	return share.ListSharesOutput{Shares: shares}, nil
+}
+
+func (uc *implUseCase) RevokeShare(ctx context.Context, sc model.Scope, input share.RevokeShareInput) error {
+	s, err := uc.repo.GetShare(ctx, input.ShareID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return share.ErrShareNotFound
+		}
+		return fmt.Errorf("%w: %v", share.ErrRevokeShare, err)
+	}
+
+	if !sc.IsAdmin() && (sc.UserID == "" || sc.UserID != s.CreatedBy) {
+		// The owner may revoke what others (e.g. admins) shared on their behalf
+		res, err := uc.loadResource(ctx, s.ResourceType, s.ResourceID)
+		if err != nil || !canManage(sc, res) {
+			return share.ErrForbidden
+		}
+	}
+
+	if _, err := uc.repo.RevokeShare(ctx, s.ID); err != nil {
+		return fmt.Errorf("%w: %v", share.ErrRevokeShare, err)
+	}
+	uc.l.Infof(ctx, "share.usecase.RevokeShare: share %s of %s %s revoked by %s", s.ID, s.ResourceType, s.ResourceID, sc.UserID)
+	return nil
+}
+
+func (uc *implUseCase) ResolveToken(ctx context.Context, sc model.Scope, token string) (share.ResolveTokenOutput, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return share.ResolveTokenOutput{}, share.ErrInvalidToken
+	}
+	s, err := uc.repo.GetShareByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			uc.l.Errorf(ctx, "share.usecase.ResolveToken: GetShareByTokenHash failed: %v", err)
+		}
+		return share.ResolveTokenOutput{}, share.ErrInvalidToken
+	}
+	if s.GranteeType != share.GranteeLink || !s.IsActive(time.Now()) {
+		return share.ResolveTokenOutput{}, share.ErrInvalidToken
+	}
+	return share.ResolveTokenOutput{Share: s}, nil
+}
+
+// loadResource looks up the campaign and owner of a shareable resource.
+func (uc *implUseCase) loadResource(ctx context.Context, resourceType, resourceID string) (share.Resource, error) {
+	if !share.IsValidResourceType(resourceType) {
+		return share.Resource{}, share.ErrInvalidResourceType
+	}
+	campaignID, ownerID, err := uc.repo.GetResourceOwner(ctx, resourceType, resourceID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			uc.l.Errorf(ctx, "share.usecase.loadResource: GetResourceOwner failed: %v", err)
+		}
+		return share.Resource{}, share.ErrResourceNotFound
+	}
+	return share.Resource{Type: resourceType, ID: resourceID, CampaignID: campaignID, OwnerID: ownerID}, nil
+}
+
+// canManage - Only the owner of a resource or an admin shares it.
+func canManage(sc model.Scope, res share.Resource) bool {
+	return sc.IsAdmin() || (sc.UserID != "" && sc.UserID == res.OwnerID)
+}
+
+// shareExpiry - No expiry unless asked for, except links which always expire.
+func shareExpiry(granteeType string, hours int, now time.Time) (*time.Time, error) {
+	if hours < 0 {
+		return nil, share.ErrInvalidExpiry
+	}
+	ttl := time.Duration(hours) * time.Hour
+	if granteeType == share.GranteeLink {
+		if ttl == 0 {
+			ttl = share.DefaultLinkTTL
+		}
+		if ttl > share.MaxLinkTTL {
+			return nil, share.ErrInvalidExpiry
+		}
+	}
+	if ttl == 0 {
+		return nil, nil
+	}
+	expiresAt := now.Add(ttl)
+	return &expiresAt, nil
+}
+
+// granteeUsers - Distinct users to grant, without the owner who already has access.
+func granteeUsers(userIDs []string, ownerID string) ([]string, error) {
+	users := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || id == ownerID || slices.Contains(users, id) {
+			continue
+		}
+		users = append(users, id)
+	}
+	if len(users) == 0 {
+		return nil, share.ErrUsersRequired
+	}
+	if len(users) > share.MaxUsersPerShare {
+		return nil, share.ErrTooManyUsers
+	}
+	return users, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/aarondl/sqlboiler/v4/queries"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
 	"github.com/aarondl/sqlboiler/v4/queries/qmhelper"
+	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/aarondl/strmangle"
 	"github.com/friendsofgo/errors"
 )
@@ -37,53 +38,92 @@ type Conversation struct {
 	LastMessageAt null.Time `boil:"last_message_at" json:"last_message_at,omitempty" toml:"last_message_at" yaml:"last_message_at,omitempty"`
 	CreatedAt     null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	UpdatedAt     null.Time `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	// Rolling summary of older turns, regenerated asynchronously every few turns; prompts use it plus the most recent messages
+	MemorySummary       null.String `boil:"memory_summary" json:"memory_summary,omitempty" toml:"memory_summary" yaml:"memory_summary,omitempty"`
+	MemoryKeyFacts      null.JSON   `boil:"memory_key_facts" json:"memory_key_facts,omitempty" toml:"memory_key_facts" yaml:"memory_key_facts,omitempty"`
+	MemoryActiveFilters null.JSON   `boil:"memory_active_filters" json:"memory_active_filters,omitempty" toml:"memory_active_filters" yaml:"memory_active_filters,omitempty"`
+	// Number of leading messages of the active branch covered by the summary; a memory reset sets it to the branch length
+	MemoryMessageCount int       `boil:"memory_message_count" json:"memory_message_count" toml:"memory_message_count" yaml:"memory_message_count"`
+	MemoryUpdatedAt    null.Time `boil:"memory_updated_at" json:"memory_updated_at,omitempty" toml:"memory_updated_at" yaml:"memory_updated_at,omitempty"`
+	// Last message of the active branch; history, prompts and new turns follow the path from it back to the root
+	ActiveLeafID null.String `boil:"active_leaf_id" json:"active_leaf_id,omitempty" toml:"active_leaf_id" yaml:"active_leaf_id,omitempty"`
+	// Campaigns involved in the conversation, campaign_id first; comparative turns add the campaigns they compare
+	CampaignIds types.StringArray `boil:"campaign_ids" json:"campaign_ids" toml:"campaign_ids" yaml:"campaign_ids"`
 
 	R *conversationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L conversationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ConversationColumns = struct {
-	ID            string
-	CampaignID    string
-	UserID        string
-	Title         string
-	Status        string
-	MessageCount  string
-	LastMessageAt string
-	CreatedAt     string
-	UpdatedAt     string
+	ID                  string
+	CampaignID          string
+	UserID              string
+	Title               string
+	Status              string
+	MessageCount        string
+	LastMessageAt       string
+	CreatedAt           string
+	UpdatedAt           string
+	MemorySummary       string
+	MemoryKeyFacts      string
+	MemoryActiveFilters string
+	MemoryMessageCount  string
+	MemoryUpdatedAt     string
+	ActiveLeafID        string
+	CampaignIds         string
 }{
-	ID:            "id",
-	CampaignID:    "campaign_id",
-	UserID:        "user_id",
-	Title:         "title",
-	Status:        "status",
-	MessageCount:  "message_count",
-	LastMessageAt: "last_message_at",
-	CreatedAt:     "created_at",
-	UpdatedAt:     "updated_at",
+	ID:                  "id",
+	CampaignID:          "campaign_id",
+	UserID:              "user_id",
+	Title:               "title",
+	Status:              "status",
+	MessageCount:        "message_count",
+	LastMessageAt:       "last_message_at",
+	CreatedAt:           "created_at",
+	UpdatedAt:           "updated_at",
+	MemorySummary:       "memory_summary",
+	MemoryKeyFacts:      "memory_key_facts",
+	MemoryActiveFilters: "memory_active_filters",
+	MemoryMessageCount:  "memory_message_count",
+	MemoryUpdatedAt:     "memory_updated_at",
+	ActiveLeafID:        "active_leaf_id",
+	CampaignIds:         "campaign_ids",
 }
 
 var ConversationTableColumns = struct {
-	ID            string
-	CampaignID    string
-	UserID        string
-	Title         string
-	Status        string
-	MessageCount  string
-	LastMessageAt string
-	CreatedAt     string
-	UpdatedAt     string
+	ID                  string
+	CampaignID          string
+	UserID              string
+	Title               string
+	Status              string
+	MessageCount        string
+	LastMessageAt       string
+	CreatedAt           string
+	UpdatedAt           string
+	MemorySummary       string
+	MemoryKeyFacts      string
+	MemoryActiveFilters string
+	MemoryMessageCount  string
+	MemoryUpdatedAt     string
+	ActiveLeafID        string
+	CampaignIds         string
 }{
-	ID:            "conversations.id",
-	CampaignID:    "conversations.campaign_id",
-	UserID:        "conversations.user_id",
-	Title:         "conversations.title",
-	Status:        "conversations.status",
-	MessageCount:  "conversations.message_count",
-	LastMessageAt: "conversations.last_message_at",
-	CreatedAt:     "conversations.created_at",
-	UpdatedAt:     "conversations.updated_at",
+	ID:                  "conversations.id",
+	CampaignID:          "conversations.campaign_id",
+	UserID:              "conversations.user_id",
+	Title:               "conversations.title",
+	Status:              "conversations.status",
+	MessageCount:        "conversations.message_count",
+	LastMessageAt:       "conversations.last_message_at",
+	CreatedAt:           "conversations.created_at",
+	UpdatedAt:           "conversations.updated_at",
+	MemorySummary:       "conversations.memory_summary",
+	MemoryKeyFacts:      "conversations.memory_key_facts",
+	MemoryActiveFilters: "conversations.memory_active_filters",
+	MemoryMessageCount:  "conversations.memory_message_count",
+	MemoryUpdatedAt:     "conversations.memory_updated_at",
+	ActiveLeafID:        "conversations.active_leaf_id",
+	CampaignIds:         "conversations.campaign_ids",
 }
 
 // Generated where
@@ -166,26 +206,61 @@ func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelpertypes_StringArray struct{ field string }
+
+func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
 var ConversationWhere = struct {
-	ID            whereHelperstring
-	CampaignID    whereHelperstring
-	UserID        whereHelperstring
-	Title         whereHelperstring
-	Status        whereHelperstring
-	MessageCount  whereHelperint
-	LastMessageAt whereHelpernull_Time
-	CreatedAt     whereHelpernull_Time
-	UpdatedAt     whereHelpernull_Time
+	ID                  whereHelperstring
+	CampaignID          whereHelperstring
+	UserID              whereHelperstring
+	Title               whereHelperstring
+	Status              whereHelperstring
+	MessageCount        whereHelperint
+	LastMessageAt       whereHelpernull_Time
+	CreatedAt           whereHelpernull_Time
+	UpdatedAt           whereHelpernull_Time
+	MemorySummary       whereHelpernull_String
+	MemoryKeyFacts      whereHelpernull_JSON
+	MemoryActiveFilters whereHelpernull_JSON
+	MemoryMessageCount  whereHelperint
+	MemoryUpdatedAt     whereHelpernull_Time
+	ActiveLeafID        whereHelpernull_String
+	CampaignIds         whereHelpertypes_StringArray
 }{
-	ID:            whereHelperstring{field: "\"knowledge\".\"conversations\".\"id\""},
-	CampaignID:    whereHelperstring{field: "\"knowledge\".\"conversations\".\"campaign_id\""},
-	UserID:        whereHelperstring{field: "\"knowledge\".\"conversations\".\"user_id\""},
-	Title:         whereHelperstring{field: "\"knowledge\".\"conversations\".\"title\""},
-	Status:        whereHelperstring{field: "\"knowledge\".\"conversations\".\"status\""},
-	MessageCount:  whereHelperint{field: "\"knowledge\".\"conversations\".\"message_count\""},
-	LastMessageAt: whereHelpernull_Time{field: "\"knowledge\".\"conversations\".\"last_message_at\""},
-	CreatedAt:     whereHelpernull_Time{field: "\"knowledge\".\"conversations\".\"created_at\""},
-	UpdatedAt:     whereHelpernull_Time{field: "\"knowledge\".\"conversations\".\"updated_at\""},
+	ID:                  whereHelperstring{field: "\"knowledge\".\"conversations\".\"id\""},
+	CampaignID:          whereHelperstring{field: "\"knowledge\".\"conversations\".\"campaign_id\""},
+	UserID:              whereHelperstring{field: "\"knowledge\".\"conversations\".\"user_id\""},
+	Title:               whereHelperstring{field: "\"knowledge\".\"conversations\".\"title\""},
+	Status:              whereHelperstring{field: "\"knowledge\".\"conversations\".\"status\""},
+	MessageCount:        whereHelperint{field: "\"knowledge\".\"conversations\".\"message_count\""},
+	LastMessageAt:       whereHelpernull_Time{field: "\"knowledge\".\"conversations\".\"last_message_at\""},
+	CreatedAt:           whereHelpernull_Time{field: "\"knowledge\".\"conversations\".\"created_at\""},
+	UpdatedAt:           whereHelpernull_Time{field: "\"knowledge\".\"conversations\".\"updated_at\""},
+	MemorySummary:       whereHelpernull_String{field: "\"knowledge\".\"conversations\".\"memory_summary\""},
+	MemoryKeyFacts:      whereHelpernull_JSON{field: "\"knowledge\".\"conversations\".\"memory_key_facts\""},
+	MemoryActiveFilters: whereHelpernull_JSON{field: "\"knowledge\".\"conversations\".\"memory_active_filters\""},
+	MemoryMessageCount:  whereHelperint{field: "\"knowledge\".\"conversations\".\"memory_message_count\""},
+	MemoryUpdatedAt:     whereHelpernull_Time{field: "\"knowledge\".\"conversations\".\"memory_updated_at\""},
+	ActiveLeafID:        whereHelpernull_String{field: "\"knowledge\".\"conversations\".\"active_leaf_id\""},
+	CampaignIds:         whereHelpertypes_StringArray{field: "\"knowledge\".\"conversations\".\"campaign_ids\""},
 }
 
 // ConversationRels is where relationship names are stored.
@@ -225,9 +300,9 @@ func (r *conversationR) GetMessages() MessageSlice {
 type conversationL struct{}
 
 var (
-	conversationAllColumns            = []string{"id", "campaign_id", "user_id", "title", "status", "message_count", "last_message_at", "created_at", "updated_at"}
+	conversationAllColumns            = []string{"id", "campaign_id", "user_id", "title", "status", "message_count", "last_message_at", "created_at", "updated_at", "memory_summary", "memory_key_facts", "memory_active_filters", "memory_message_count", "memory_updated_at", "active_leaf_id", "campaign_ids"}
 	conversationColumnsWithoutDefault = []string{"campaign_id", "user_id", "title"}
-	conversationColumnsWithDefault    = []string{"id", "status", "message_count", "last_message_at", "created_at", "updated_at"}
+	conversationColumnsWithDefault    = []string{"id", "status", "message_count", "last_message_at", "created_at", "updated_at", "memory_summary", "memory_key_facts", "memory_active_filters", "memory_message_count", "memory_updated_at", "active_leaf_id", "campaign_ids"}
 	conversationPrimaryKeyColumns     = []string{"id"}
 	conversationGeneratedColumns      = []string{}
 )
@@ -25,11 +25,11 @@ import (
 // IndexedDocument is an object representing the database table.
 type IndexedDocument struct {
 	ID string `boil:"id" json:"id" toml:"id" yaml:"id"`
-	// Foreign key to analytics.post_analytics.id - unique identifier for the analytics post
-	AnalyticsID string `boil:"analytics_id" json:"analytics_id" toml:"analytics_id" yaml:"analytics_id"`
-	ProjectID   string `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
-	SourceID    string `boil:"source_id" json:"source_id" toml:"source_id" yaml:"source_id"`
-	// The vector point ID in Qdrant (usually matches analytics_id)
+	// Foreign key to analytics.post_analytics.id; for Kafka insights, UUIDv5 of (project_id, uap_id)
+	AnalyticsID string      `boil:"analytics_id" json:"analytics_id" toml:"analytics_id" yaml:"analytics_id"`
+	ProjectID   string      `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
+	SourceID    null.String `boil:"source_id" json:"source_id,omitempty" toml:"source_id" yaml:"source_id,omitempty"`
+	// The vector point ID in Qdrant (analytics_id or uap_id, project-namespaced under the shared layout)
 	QdrantPointID  string `boil:"qdrant_point_id" json:"qdrant_point_id" toml:"qdrant_point_id" yaml:"qdrant_point_id"`
 	CollectionName string `boil:"collection_name" json:"collection_name" toml:"collection_name" yaml:"collection_name"`
 	// SHA-256 hash of the document content for duplicate detection across different sources
@@ -45,6 +45,11 @@ type IndexedDocument struct {
 	IndexedAt       null.Time   `boil:"indexed_at" json:"indexed_at,omitempty" toml:"indexed_at" yaml:"indexed_at,omitempty"`
 	CreatedAt       null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	UpdatedAt       null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	// 64-bit SimHash (hex) of normalized content, for near-duplicate detection
+	Simhash   null.String `boil:"simhash" json:"simhash,omitempty" toml:"simhash" yaml:"simhash,omitempty"`
+	ClusterID null.String `boil:"cluster_id" json:"cluster_id,omitempty" toml:"cluster_id" yaml:"cluster_id,omitempty"`
+	// Point ID of the near-duplicate cluster representative; NULL when this document is the representative
+	DuplicateOf null.String `boil:"duplicate_of" json:"duplicate_of,omitempty" toml:"duplicate_of" yaml:"duplicate_of,omitempty"`
 
 	R *indexedDocumentR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L indexedDocumentL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -68,6 +73,9 @@ var IndexedDocumentColumns = struct {
 	IndexedAt       string
 	CreatedAt       string
 	UpdatedAt       string
+	Simhash         string
+	ClusterID       string
+	DuplicateOf     string
 }{
 	ID:              "id",
 	AnalyticsID:     "analytics_id",
@@ -86,6 +94,9 @@ var IndexedDocumentColumns = struct {
 	IndexedAt:       "indexed_at",
 	CreatedAt:       "created_at",
 	UpdatedAt:       "updated_at",
+	Simhash:         "simhash",
+	ClusterID:       "cluster_id",
+	DuplicateOf:     "duplicate_of",
 }
 
 var IndexedDocumentTableColumns = struct {
@@ -106,6 +117,9 @@ var IndexedDocumentTableColumns = struct {
 	IndexedAt       string
 	CreatedAt       string
 	UpdatedAt       string
+	Simhash         string
+	ClusterID       string
+	DuplicateOf     string
 }{
 	ID:              "indexed_documents.id",
 	AnalyticsID:     "indexed_documents.analytics_id",
@@ -124,6 +138,9 @@ var IndexedDocumentTableColumns = struct {
 	IndexedAt:       "indexed_documents.indexed_at",
 	CreatedAt:       "indexed_documents.created_at",
 	UpdatedAt:       "indexed_documents.updated_at",
+	Simhash:         "indexed_documents.simhash",
+	ClusterID:       "indexed_documents.cluster_id",
+	DuplicateOf:     "indexed_documents.duplicate_of",
 }
 
 // Generated where
@@ -226,7 +243,7 @@ var IndexedDocumentWhere = struct {
 	ID              whereHelperstring
 	AnalyticsID     whereHelperstring
 	ProjectID       whereHelperstring
-	SourceID        whereHelperstring
+	SourceID        whereHelpernull_String
 	QdrantPointID   whereHelperstring
 	CollectionName  whereHelperstring
 	ContentHash     whereHelperstring
@@ -240,11 +257,14 @@ var IndexedDocumentWhere = struct {
 	IndexedAt       whereHelpernull_Time
 	CreatedAt       whereHelpernull_Time
 	UpdatedAt       whereHelpernull_Time
+	Simhash         whereHelpernull_String
+	ClusterID       whereHelpernull_String
+	DuplicateOf     whereHelpernull_String
 }{
 	ID:              whereHelperstring{field: "\"knowledge\".\"indexed_documents\".\"id\""},
 	AnalyticsID:     whereHelperstring{field: "\"knowledge\".\"indexed_documents\".\"analytics_id\""},
 	ProjectID:       whereHelperstring{field: "\"knowledge\".\"indexed_documents\".\"project_id\""},
-	SourceID:        whereHelperstring{field: "\"knowledge\".\"indexed_documents\".\"source_id\""},
+	SourceID:        whereHelpernull_String{field: "\"knowledge\".\"indexed_documents\".\"source_id\""},
 	QdrantPointID:   whereHelperstring{field: "\"knowledge\".\"indexed_documents\".\"qdrant_point_id\""},
 	CollectionName:  whereHelperstring{field: "\"knowledge\".\"indexed_documents\".\"collection_name\""},
 	ContentHash:     whereHelperstring{field: "\"knowledge\".\"indexed_documents\".\"content_hash\""},
@@ -258,6 +278,9 @@ var IndexedDocumentWhere = struct {
 	IndexedAt:       whereHelpernull_Time{field: "\"knowledge\".\"indexed_documents\".\"indexed_at\""},
 	CreatedAt:       whereHelpernull_Time{field: "\"knowledge\".\"indexed_documents\".\"created_at\""},
 	UpdatedAt:       whereHelpernull_Time{field: "\"knowledge\".\"indexed_documents\".\"updated_at\""},
+	Simhash:         whereHelpernull_String{field: "\"knowledge\".\"indexed_documents\".\"simhash\""},
+	ClusterID:       whereHelpernull_String{field: "\"knowledge\".\"indexed_documents\".\"cluster_id\""},
+	DuplicateOf:     whereHelpernull_String{field: "\"knowledge\".\"indexed_documents\".\"duplicate_of\""},
 }
 
 // IndexedDocumentRels is where relationship names are stored.
@@ -277,9 +300,9 @@ func (*indexedDocumentR) NewStruct() *indexedDocumentR {
 type indexedDocumentL struct{}
 
 var (
-	indexedDocumentAllColumns            = []string{"id", "analytics_id", "project_id", "source_id", "qdrant_point_id", "collection_name", "content_hash", "status", "error_message", "retry_count", "batch_id", "embedding_time_ms", "upsert_time_ms", "total_time_ms", "indexed_at", "created_at", "updated_at"}
-	indexedDocumentColumnsWithoutDefault = []string{"analytics_id", "project_id", "source_id", "qdrant_point_id", "collection_name", "content_hash"}
-	indexedDocumentColumnsWithDefault    = []string{"id", "status", "error_message", "retry_count", "batch_id", "embedding_time_ms", "upsert_time_ms", "total_time_ms", "indexed_at", "created_at", "updated_at"}
+	indexedDocumentAllColumns            = []string{"id", "analytics_id", "project_id", "source_id", "qdrant_point_id", "collection_name", "content_hash", "status", "error_message", "retry_count", "batch_id", "embedding_time_ms", "upsert_time_ms", "total_time_ms", "indexed_at", "created_at", "updated_at", "simhash", "cluster_id", "duplicate_of"}
+	indexedDocumentColumnsWithoutDefault = []string{"analytics_id", "project_id", "qdrant_point_id", "collection_name", "content_hash"}
+	indexedDocumentColumnsWithDefault    = []string{"id", "source_id", "status", "error_message", "retry_count", "batch_id", "embedding_time_ms", "upsert_time_ms", "total_time_ms", "indexed_at", "created_at", "updated_at", "simhash", "cluster_id", "duplicate_of"}
 	indexedDocumentPrimaryKeyColumns     = []string{"id"}
 	indexedDocumentGeneratedColumns      = []string{}
 )
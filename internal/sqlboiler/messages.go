@@ -38,6 +38,8 @@ type Message struct {
 	// JSONB object with the search filters that were applied (user messages only)
 	FiltersUsed null.JSON `boil:"filters_used" json:"filters_used,omitempty" toml:"filters_used" yaml:"filters_used,omitempty"`
 	CreatedAt   null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+	// Previous message on the branch; a regenerated answer or an edited question is a sibling of the message it replaces
+	ParentID null.String `boil:"parent_id" json:"parent_id,omitempty" toml:"parent_id" yaml:"parent_id,omitempty"`
 
 	R *messageR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L messageL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -53,6 +55,7 @@ var MessageColumns = struct {
 	Suggestions    string
 	FiltersUsed    string
 	CreatedAt      string
+	ParentID       string
 }{
 	ID:             "id",
 	ConversationID: "conversation_id",
@@ -63,6 +66,7 @@ var MessageColumns = struct {
 	Suggestions:    "suggestions",
 	FiltersUsed:    "filters_used",
 	CreatedAt:      "created_at",
+	ParentID:       "parent_id",
 }
 
 var MessageTableColumns = struct {
@@ -75,6 +79,7 @@ var MessageTableColumns = struct {
 	Suggestions    string
 	FiltersUsed    string
 	CreatedAt      string
+	ParentID       string
 }{
 	ID:             "messages.id",
 	ConversationID: "messages.conversation_id",
@@ -85,6 +90,7 @@ var MessageTableColumns = struct {
 	Suggestions:    "messages.suggestions",
 	FiltersUsed:    "messages.filters_used",
 	CreatedAt:      "messages.created_at",
+	ParentID:       "messages.parent_id",
 }
 
 // Generated where
@@ -123,6 +129,7 @@ var MessageWhere = struct {
 	Suggestions    whereHelpernull_JSON
 	FiltersUsed    whereHelpernull_JSON
 	CreatedAt      whereHelpernull_Time
+	ParentID       whereHelpernull_String
 }{
 	ID:             whereHelperstring{field: "\"knowledge\".\"messages\".\"id\""},
 	ConversationID: whereHelperstring{field: "\"knowledge\".\"messages\".\"conversation_id\""},
@@ -133,6 +140,7 @@ var MessageWhere = struct {
 	Suggestions:    whereHelpernull_JSON{field: "\"knowledge\".\"messages\".\"suggestions\""},
 	FiltersUsed:    whereHelpernull_JSON{field: "\"knowledge\".\"messages\".\"filters_used\""},
 	CreatedAt:      whereHelpernull_Time{field: "\"knowledge\".\"messages\".\"created_at\""},
+	ParentID:       whereHelpernull_String{field: "\"knowledge\".\"messages\".\"parent_id\""},
 }
 
 // MessageRels is where relationship names are stored.
@@ -172,9 +180,9 @@ func (r *messageR) GetConversation() *Conversation {
 type messageL struct{}
 
 var (
-	messageAllColumns            = []string{"id", "conversation_id", "role", "content", "citations", "search_metadata", "suggestions", "filters_used", "created_at"}
+	messageAllColumns            = []string{"id", "conversation_id", "role", "content", "citations", "search_metadata", "suggestions", "filters_used", "created_at", "parent_id"}
 	messageColumnsWithoutDefault = []string{"conversation_id", "role", "content"}
-	messageColumnsWithDefault    = []string{"id", "citations", "search_metadata", "suggestions", "filters_used", "created_at"}
+	messageColumnsWithDefault    = []string{"id", "citations", "search_metadata", "suggestions", "filters_used", "created_at", "parent_id"}
 	messagePrimaryKeyColumns     = []string{"id"}
 	messageGeneratedColumns      = []string{}
 )
@@ -42,6 +42,7 @@ type Report struct {
 	CompletedAt       null.Time   `boil:"completed_at" json:"completed_at,omitempty" toml:"completed_at" yaml:"completed_at,omitempty"`
 	CreatedAt         null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	UpdatedAt         null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	NumericCheck      null.JSON   `boil:"numeric_check" json:"numeric_check,omitempty" toml:"numeric_check" yaml:"numeric_check,omitempty"`
 
 	R *reportR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L reportL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -66,6 +67,7 @@ var ReportColumns = struct {
 	CompletedAt       string
 	CreatedAt         string
 	UpdatedAt         string
+	NumericCheck      string
 }{
 	ID:                "id",
 	CampaignID:        "campaign_id",
@@ -85,6 +87,7 @@ var ReportColumns = struct {
 	CompletedAt:       "completed_at",
 	CreatedAt:         "created_at",
 	UpdatedAt:         "updated_at",
+	NumericCheck:      "numeric_check",
 }
 
 var ReportTableColumns = struct {
@@ -106,6 +109,7 @@ var ReportTableColumns = struct {
 	CompletedAt       string
 	CreatedAt         string
 	UpdatedAt         string
+	NumericCheck      string
 }{
 	ID:                "reports.id",
 	CampaignID:        "reports.campaign_id",
@@ -125,6 +129,7 @@ var ReportTableColumns = struct {
 	CompletedAt:       "reports.completed_at",
 	CreatedAt:         "reports.created_at",
 	UpdatedAt:         "reports.updated_at",
+	NumericCheck:      "reports.numeric_check",
 }
 
 // Generated where
@@ -186,6 +191,7 @@ var ReportWhere = struct {
 	CompletedAt       whereHelpernull_Time
 	CreatedAt         whereHelpernull_Time
 	UpdatedAt         whereHelpernull_Time
+	NumericCheck      whereHelpernull_JSON
 }{
 	ID:                whereHelperstring{field: "\"knowledge\".\"reports\".\"id\""},
 	CampaignID:        whereHelperstring{field: "\"knowledge\".\"reports\".\"campaign_id\""},
@@ -205,6 +211,7 @@ var ReportWhere = struct {
 	CompletedAt:       whereHelpernull_Time{field: "\"knowledge\".\"reports\".\"completed_at\""},
 	CreatedAt:         whereHelpernull_Time{field: "\"knowledge\".\"reports\".\"created_at\""},
 	UpdatedAt:         whereHelpernull_Time{field: "\"knowledge\".\"reports\".\"updated_at\""},
+	NumericCheck:      whereHelpernull_JSON{field: "\"knowledge\".\"reports\".\"numeric_check\""},
 }
 
 // ReportRels is where relationship names are stored.
@@ -224,9 +231,9 @@ func (*reportR) NewStruct() *reportR {
 type reportL struct{}
 
 var (
-	reportAllColumns            = []string{"id", "campaign_id", "user_id", "title", "report_type", "params_hash", "filters", "status", "error_message", "file_url", "file_size_bytes", "file_format", "total_docs_analyzed", "sections_count", "generation_time_ms", "completed_at", "created_at", "updated_at"}
+	reportAllColumns            = []string{"id", "campaign_id", "user_id", "title", "report_type", "params_hash", "filters", "status", "error_message", "file_url", "file_size_bytes", "file_format", "total_docs_analyzed", "sections_count", "generation_time_ms", "completed_at", "created_at", "updated_at", "numeric_check"}
 	reportColumnsWithoutDefault = []string{"campaign_id", "user_id", "report_type", "params_hash"}
-	reportColumnsWithDefault    = []string{"id", "title", "filters", "status", "error_message", "file_url", "file_size_bytes", "file_format", "total_docs_analyzed", "sections_count", "generation_time_ms", "completed_at", "created_at", "updated_at"}
+	reportColumnsWithDefault    = []string{"id", "title", "filters", "status", "error_message", "file_url", "file_size_bytes", "file_format", "total_docs_analyzed", "sections_count", "generation_time_ms", "completed_at", "created_at", "updated_at", "numeric_check"}
 	reportPrimaryKeyColumns     = []string{"id"}
 	reportGeneratedColumns      = []string{}
 )
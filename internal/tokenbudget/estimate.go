@@ -0,0 +1,111 @@
+package tokenbudget
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Estimate approximates the tokens of text for BPE tokenizers without loading
+// one. English words cost about a token per four letters. Vietnamese syllables
+// with diacritics split into more pieces, about a token per two letters. Digits
+// go in threes, and every punctuation mark and line break is a token of its own.
+// The estimate leans high so a packed prompt stays under the real limit.
+func Estimate(text string) int {
+	tokens := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\n':
+			tokens++
+			i += size
+		case unicode.IsSpace(r):
+			i += size
+		case unicode.IsDigit(r):
+			n := 0
+			for i < len(text) {
+				r, size = utf8.DecodeRuneInString(text[i:])
+				if !unicode.IsDigit(r) {
+					break
+				}
+				n++
+				i += size
+			}
+			tokens += (n + 2) / 3
+		case unicode.IsLetter(r) || unicode.IsMark(r):
+			n, ascii := 0, true
+			for i < len(text) {
+				r, size = utf8.DecodeRuneInString(text[i:])
+				if !unicode.IsLetter(r) && !unicode.IsMark(r) {
+					break
+				}
+				if r >= utf8.RuneSelf {
+					ascii = false
+				}
+				n++
+				i += size
+			}
+			if ascii {
+				tokens += (n + 3) / 4
+			} else {
+				tokens += (n + 1) / 2
+			}
+		default:
+			tokens++
+			i += size
+		}
+	}
+	return tokens
+}
+
+// Truncate shortens text to about maxTokens, preferring to end on a sentence,
+// then on a word, and marks the cut with Ellipsis.
+func Truncate(text string, maxTokens int) string {
+	if Estimate(text) <= maxTokens {
+		return text
+	}
+	limit := maxTokens - Estimate(Ellipsis)
+	if limit <= 0 {
+		return ""
+	}
+
+	// Longest rune prefix within the limit
+	runes := []rune(text)
+	lo, hi := 0, len(runes)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if Estimate(string(runes[:mid])) <= limit {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	cut := string(runes[:lo])
+
+	// A sentence end in the last third of the cut, else the last word boundary
+	if i := lastSentenceEnd(cut); i > 0 && i >= len(cut)*2/3 {
+		return strings.TrimSpace(cut[:i]) + " " + Ellipsis
+	}
+	if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRightFunc(cut, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + Ellipsis
+}
+
+// lastSentenceEnd returns the offset just past the last ". ", "! ", "? " or line
+// break in text, 0 when there is none.
+func lastSentenceEnd(text string) int {
+	for i := len(text) - 1; i > 0; i-- {
+		switch text[i] {
+		case '\n':
+			return i + 1
+		case ' ':
+			if p := text[i-1]; p == '.' || p == '!' || p == '?' {
+				return i
+			}
+		}
+	}
+	return 0
+}
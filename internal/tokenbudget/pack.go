@@ -0,0 +1,114 @@
+package tokenbudget
+
+import (
+	"slices"
+	"strings"
+)
+
+// Pack fits sections into budget next to fixed, the text that is always sent.
+// Every section first gets its reserve, as much of it as its content needs; what
+// is left goes to the sections by priority. Room a section leaves unused passes
+// on to the next one in priority order.
+func Pack(budget int, fixed string, sections []Section) Packed {
+	fixedTokens := Estimate(fixed)
+	p := Packed{
+		sections: make(map[string]string, len(sections)),
+		Usage: Usage{
+			Budget:   budget,
+			Used:     fixedTokens,
+			Sections: map[string]int{SectionFixed: fixedTokens},
+		},
+	}
+	avail := max(budget-fixedTokens, 0)
+
+	order := make([]int, len(sections))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return sections[a].Priority - sections[b].Priority })
+
+	alloc := make([]int, len(sections))
+	need := make([]int, len(sections))
+	left := avail
+	for _, i := range order {
+		need[i] = sectionCost(sections[i])
+		alloc[i] = min(need[i], int(sections[i].Reserve*float64(avail)), left)
+		left -= alloc[i]
+	}
+	for _, i := range order {
+		extra := min(need[i]-alloc[i], left)
+		alloc[i] += extra
+		left -= extra
+	}
+
+	carry := 0
+	for _, i := range order {
+		text, used, truncated, dropped := packSection(sections[i], alloc[i]+carry)
+		carry = alloc[i] + carry - used
+		p.sections[sections[i].Name] = text
+		p.Usage.Sections[sections[i].Name] = used
+		p.Usage.Used += used
+		p.Usage.Truncated += truncated
+		p.Usage.Dropped += dropped
+	}
+	return p
+}
+
+func sectionCost(s Section) int {
+	if len(s.Items) == 0 {
+		return 0
+	}
+	cost := Estimate(s.Header) + Estimate(s.Footer)
+	for _, item := range s.Items {
+		cost += Estimate(item)
+	}
+	return cost
+}
+
+// packSection keeps the items of s that fit limit and renders them.
+func packSection(s Section, limit int) (text string, used, truncated, dropped int) {
+	if len(s.Items) == 0 {
+		return "", 0, 0, 0
+	}
+	frame := Estimate(s.Header) + Estimate(s.Footer)
+	if frame >= limit {
+		return "", 0, 0, len(s.Items)
+	}
+
+	kept := make([]string, 0, len(s.Items))
+	used = frame
+	for n := range s.Items {
+		i := n
+		if s.Newest {
+			i = len(s.Items) - 1 - n
+		}
+		item := s.Items[i]
+		cost := Estimate(item)
+		if used+cost <= limit {
+			kept = append(kept, item)
+			used += cost
+			continue
+		}
+		if room := limit - used; room >= minTruncatedTokens {
+			// Keep the line break an item ends with
+			cut := Truncate(strings.TrimRight(item, "\n"), room-1)
+			if strings.HasSuffix(item, "\n") {
+				cut += "\n"
+			}
+			kept = append(kept, cut)
+			used += Estimate(cut)
+			truncated++
+			dropped = len(s.Items) - n - 1
+		} else {
+			dropped = len(s.Items) - n
+		}
+		break
+	}
+	if len(kept) == 0 {
+		return "", 0, truncated, dropped
+	}
+	if s.Newest {
+		slices.Reverse(kept)
+	}
+	return s.Header + strings.Join(kept, "") + s.Footer, used, truncated, dropped
+}
@@ -0,0 +1,199 @@
+package tokenbudget
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// Token estimates for Vietnamese and English, budget allocation across sections
+// and truncation of the item that no longer fits.
+//
+//	go test ./internal/tokenbudget -v
+
+// words repeats word n times; a four-letter word is one estimated token.
+func words(word string, n int) string {
+	return strings.Repeat(word+" ", n)
+}
+
+func TestEstimate(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"empty", "", 0},
+		{"english word per four letters", "hello world", 4},
+		{"short english word", "cat", 1},
+		{"vietnamese syllable per two letters", "xin chào", 3},
+		{"vietnamese diacritics", "Việt Nam", 3},
+		{"digits in threes", "12345", 2},
+		{"punctuation and line breaks", "a, b.\n", 5},
+		{"spaces are free", "   \t  ", 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Estimate(tc.text); got != tc.want {
+				t.Errorf("Estimate(%q) = %d, want %d", tc.text, got, tc.want)
+			}
+		})
+	}
+
+	// Diacritics split into more pieces than the same letters without them
+	if vi, en := Estimate("thương hiệu"), Estimate("thuong hieu"); vi <= en {
+		t.Errorf("Estimate vi = %d, en = %d; Vietnamese should cost more", vi, en)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		name      string
+		text      string
+		maxTokens int
+		want      string
+	}{
+		{"fits unchanged", "hello world", 10, "hello world"},
+		{"no room beside the ellipsis", words("word", 10), 1, ""},
+		{"ends on a word", words("word", 20), 5, "word word word word" + Ellipsis},
+		{
+			"ends on a sentence",
+			"First sentence is here. Second sentence keeps going on and on",
+			10,
+			"First sentence is here. " + Ellipsis,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Truncate(tc.text, tc.maxTokens)
+			if got != tc.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tc.text, tc.maxTokens, got, tc.want)
+			}
+			if Estimate(got) > tc.maxTokens {
+				t.Errorf("Truncate(%q, %d) costs %d tokens", tc.text, tc.maxTokens, Estimate(got))
+			}
+		})
+	}
+
+	// Cuts land on rune boundaries and stay within the budget
+	text := strings.Repeat("Khách hàng phàn nàn về thời gian giao hàng chậm. ", 20)
+	for _, maxTokens := range []int{3, 10, 25, 60, 120} {
+		got := Truncate(text, maxTokens)
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(vi, %d) = %q is not valid UTF-8", maxTokens, got)
+		}
+		if Estimate(got) > maxTokens {
+			t.Errorf("Truncate(vi, %d) costs %d tokens", maxTokens, Estimate(got))
+		}
+	}
+}
+
+func TestPack(t *testing.T) {
+	cases := []struct {
+		name      string
+		budget    int
+		fixed     string
+		sections  []Section
+		kept      map[string]string // rendered text per section, "" = nothing kept
+		used      int
+		truncated int
+		dropped   int
+	}{
+		{
+			name:   "budget goes by priority",
+			budget: 100,
+			sections: []Section{
+				{Name: "c", Items: []string{words("cccc", 40)}, Priority: 3},
+				{Name: "a", Items: []string{words("aaaa", 40)}, Priority: 1},
+				{Name: "b", Items: []string{words("bbbb", 40)}, Priority: 2},
+			},
+			kept:    map[string]string{"a": words("aaaa", 40), "b": words("bbbb", 40), "c": ""},
+			used:    80,
+			dropped: 1,
+		},
+		{
+			name:   "reserve holds room for a low priority section",
+			budget: 100,
+			sections: []Section{
+				{Name: "a", Items: []string{words("aaaa", 40)}, Priority: 1},
+				{Name: "b", Items: []string{words("bbbb", 40)}, Priority: 2},
+				{Name: "c", Items: []string{words("cccc", 40)}, Priority: 3, Reserve: 0.3},
+			},
+			// b gets the 30 left after a and c's reserve, too little to keep or cut
+			// its item; the unused room passes on to c.
+			kept:    map[string]string{"a": words("aaaa", 40), "b": "", "c": words("cccc", 40)},
+			used:    80,
+			dropped: 1,
+		},
+		{
+			name:   "fixed text is paid first",
+			budget: 50,
+			fixed:  words("ffff", 30),
+			sections: []Section{
+				{Name: "a", Items: []string{words("aaaa", 40)}},
+			},
+			kept:    map[string]string{"a": ""},
+			used:    30,
+			dropped: 1,
+		},
+		{
+			name:   "newest items win and keep their order",
+			budget: 25,
+			sections: []Section{
+				{Name: "history", Items: []string{words("oldd", 10), words("midd", 10), words("neww", 10)}, Newest: true},
+			},
+			kept:    map[string]string{"history": words("midd", 10) + words("neww", 10)},
+			used:    20,
+			dropped: 1,
+		},
+		{
+			name:   "header and footer only around kept items",
+			budget: 100,
+			sections: []Section{
+				{Name: "a", Header: "Evidence:\n", Items: []string{words("aaaa", 5)}, Footer: "\n"},
+				{Name: "b", Header: "History:\n"},
+			},
+			kept: map[string]string{"a": "Evidence:\n" + words("aaaa", 5) + "\n", "b": ""},
+			used: 10,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := Pack(tc.budget, tc.fixed, tc.sections)
+			for name, want := range tc.kept {
+				if got := p.Text(name); got != want {
+					t.Errorf("section %s = %q, want %q", name, got, want)
+				}
+			}
+			if p.Usage.Used != tc.used || p.Usage.Truncated != tc.truncated || p.Usage.Dropped != tc.dropped {
+				t.Errorf("usage used=%d truncated=%d dropped=%d, want used=%d truncated=%d dropped=%d",
+					p.Usage.Used, p.Usage.Truncated, p.Usage.Dropped, tc.used, tc.truncated, tc.dropped)
+			}
+			if p.Usage.Sections[SectionFixed] != Estimate(tc.fixed) {
+				t.Errorf("fixed usage = %d, want %d", p.Usage.Sections[SectionFixed], Estimate(tc.fixed))
+			}
+		})
+	}
+}
+
+func TestPackTruncatesSnippet(t *testing.T) {
+	p := Pack(100, "", []Section{
+		{Name: "evidence", Items: []string{words("aaaa", 60) + "\n", words("bbbb", 60) + "\n", words("cccc", 60) + "\n"}},
+	})
+
+	text := p.Text("evidence")
+	if !strings.HasPrefix(text, words("aaaa", 60)+"\n") {
+		t.Errorf("first item should be kept whole, got %q", text)
+	}
+	if !strings.HasSuffix(text, Ellipsis+"\n") || !strings.Contains(text, "bbbb") {
+		t.Errorf("second item should be cut with its line break kept, got %q", text)
+	}
+	if p.Usage.Truncated != 1 || p.Usage.Dropped != 1 {
+		t.Errorf("truncated=%d dropped=%d, want 1 and 1", p.Usage.Truncated, p.Usage.Dropped)
+	}
+	if p.Usage.Used > 100 || p.Usage.Used != Estimate(text) {
+		t.Errorf("used = %d for %d estimated tokens within a budget of 100", p.Usage.Used, Estimate(text))
+	}
+}
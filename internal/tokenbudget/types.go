@@ -0,0 +1,56 @@
+package tokenbudget
+
+const (
+	// Ellipsis ends a truncated item.
+	Ellipsis = "…"
+
+	// minTruncatedTokens - An item is cut to fit only when at least this much room
+	// is left; a shorter stub carries no information.
+	minTruncatedTokens = 32
+)
+
+// Section - One block of a prompt, packed item by item. Items are kept whole while
+// they fit; the first one that does not is truncated when there is room, and the
+// rest are dropped.
+type Section struct {
+	Name string
+	// Header is written once before the items, and only when an item is kept.
+	Header string
+	Items  []string
+	// Footer is written after the items, and only when an item is kept.
+	Footer string
+	// Priority orders the sections for the budget left after reserves; lower first.
+	Priority int
+	// Reserve is the share of the budget (0-1) guaranteed to the section when its
+	// content needs it.
+	Reserve float64
+	// Newest packs from the last item backwards, e.g. history. Kept items stay in
+	// their original order.
+	Newest bool
+}
+
+// Packed - Rendered sections and what they cost.
+type Packed struct {
+	sections map[string]string
+	Usage    Usage
+}
+
+// Text returns the rendered section, empty when nothing of it was kept.
+func (p Packed) Text(name string) string {
+	return p.sections[name]
+}
+
+// Usage - Token accounting of a packed prompt.
+type Usage struct {
+	// Budget is the prompt budget, Used the estimate of what was packed.
+	Budget int `json:"budget"`
+	Used   int `json:"used"`
+	// Sections holds the tokens spent per section, the fixed text under "fixed".
+	Sections map[string]int `json:"sections"`
+	// Truncated and Dropped count items cut to fit and left out.
+	Truncated int `json:"truncated,omitempty"`
+	Dropped   int `json:"dropped,omitempty"`
+}
+
+// SectionFixed - Usage key of the text that is never cut (instructions, question).
+const SectionFixed = "fixed"
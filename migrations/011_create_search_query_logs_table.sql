@@ -0,0 +1,39 @@
+-- =====================================================
+-- Migration: 011 - Create search_query_logs table
+-- Purpose: Log mọi lượt retrieval (search/chat/report) để phân tích query,
+--          zero-result (content gap) và tuning relevance/latency
+-- Domain: Search (Query Analytics)
+-- Created: 2026-10-19
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS knowledge.search_query_logs (
+    -- Identity
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    campaign_id         UUID NOT NULL,              -- Campaign được search
+    user_id             UUID,                       -- NULL nếu là system retrieval (report background)
+
+    -- Query
+    query_text          TEXT NOT NULL,              -- Query gốc của user
+    normalized_query    TEXT NOT NULL,              -- lower + trim + collapse whitespace (group key)
+    intent              VARCHAR(30) NOT NULL,       -- SEARCH | NARRATIVE | STRUCTURED | REPORT
+    filters             JSONB,                      -- Search filters: {sentiments, aspects, platforms, ...}
+
+    -- Outcome
+    result_count        INT NOT NULL DEFAULT 0,     -- Số kết quả trả về
+    top_score           DOUBLE PRECISION,           -- Score cao nhất (NULL nếu không có kết quả)
+    no_relevant_context BOOLEAN NOT NULL DEFAULT FALSE,
+    cache_hit           BOOLEAN NOT NULL DEFAULT FALSE,
+    latency_ms          BIGINT NOT NULL DEFAULT 0,
+
+    -- Timestamps
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_search_query_logs_campaign_created ON knowledge.search_query_logs(campaign_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_search_query_logs_campaign_query ON knowledge.search_query_logs(campaign_id, normalized_query);
+CREATE INDEX IF NOT EXISTS idx_search_query_logs_zero_result ON knowledge.search_query_logs(campaign_id, created_at DESC)
+    WHERE result_count = 0;
+
+COMMENT ON TABLE knowledge.search_query_logs IS 'Query analytics: one row per search/chat/report retrieval, written asynchronously';
+COMMENT ON COLUMN knowledge.search_query_logs.normalized_query IS 'Lowercased, trimmed, whitespace-collapsed query used for grouping';
@@ -0,0 +1,65 @@
+-- =====================================================
+-- Migration: 012 - Near-duplicate fingerprints & per-project clusters
+-- Purpose: Lưu SimHash của nội dung lúc indexing, gom các bài gần trùng
+--          (repost, copy-paste seeding, bot campaign) thành cluster theo project
+-- Domain: Indexing (Vector Database Tracking)
+-- Created: 2026-10-19
+-- =====================================================
+
+-- =====================================================
+-- indexed_documents: fingerprint + cluster reference
+-- =====================================================
+ALTER TABLE knowledge.indexed_documents
+    ADD COLUMN IF NOT EXISTS simhash      VARCHAR(16),  -- 64-bit SimHash (hex) of normalized content
+    ADD COLUMN IF NOT EXISTS cluster_id   UUID,         -- near_duplicate_clusters.id (NULL if content too short to fingerprint)
+    ADD COLUMN IF NOT EXISTS duplicate_of VARCHAR(255); -- Qdrant point ID of the cluster representative (NULL for representatives)
+
+CREATE INDEX IF NOT EXISTS idx_indexed_docs_cluster
+    ON knowledge.indexed_documents(cluster_id)
+    WHERE cluster_id IS NOT NULL;
+
+-- =====================================================
+-- Table: near_duplicate_clusters
+-- Purpose: One row per group of near-identical content in a project.
+-- The first point seen becomes the representative; later points within the
+-- Hamming distance threshold of its SimHash join the cluster.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS knowledge.near_duplicate_clusters (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id              UUID NOT NULL,
+    representative_point_id VARCHAR(255) NOT NULL,  -- Qdrant point ID of the first member
+    simhash                 VARCHAR(16) NOT NULL,   -- Representative SimHash (hex)
+
+    -- 16-bit bands of the SimHash. Two fingerprints within Hamming distance 3
+    -- share at least one band, so candidates are found by exact band match.
+    band_0                  INT NOT NULL,
+    band_1                  INT NOT NULL,
+    band_2                  INT NOT NULL,
+    band_3                  INT NOT NULL,
+
+    member_count            INT NOT NULL DEFAULT 1,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_near_dup_clusters_band_0 ON knowledge.near_duplicate_clusters(project_id, band_0);
+CREATE INDEX IF NOT EXISTS idx_near_dup_clusters_band_1 ON knowledge.near_duplicate_clusters(project_id, band_1);
+CREATE INDEX IF NOT EXISTS idx_near_dup_clusters_band_2 ON knowledge.near_duplicate_clusters(project_id, band_2);
+CREATE INDEX IF NOT EXISTS idx_near_dup_clusters_band_3 ON knowledge.near_duplicate_clusters(project_id, band_3);
+CREATE INDEX IF NOT EXISTS idx_near_dup_clusters_size ON knowledge.near_duplicate_clusters(project_id, member_count DESC);
+
+-- =====================================================
+-- Table: near_duplicate_cluster_members
+-- Purpose: Membership log so re-indexing a point never inflates member_count.
+-- =====================================================
+CREATE TABLE IF NOT EXISTS knowledge.near_duplicate_cluster_members (
+    cluster_id  UUID NOT NULL REFERENCES knowledge.near_duplicate_clusters(id) ON DELETE CASCADE,
+    point_id    VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (cluster_id, point_id)
+);
+
+COMMENT ON TABLE knowledge.near_duplicate_clusters IS 'Per-project clusters of near-identical content (SimHash), used to flag coordinated seeding';
+COMMENT ON COLUMN knowledge.near_duplicate_clusters.member_count IS 'Distinct points assigned to the cluster, including the representative';
+COMMENT ON COLUMN knowledge.indexed_documents.simhash IS '64-bit SimHash (hex) of normalized content, for near-duplicate detection';
+COMMENT ON COLUMN knowledge.indexed_documents.duplicate_of IS 'Point ID of the near-duplicate cluster representative; NULL when this document is the representative';
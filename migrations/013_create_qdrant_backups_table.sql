@@ -0,0 +1,43 @@
+-- =====================================================
+-- Migration: 013 - Create qdrant_backups table
+-- Purpose: Ghi lại mỗi snapshot Qdrant (proj_*, macro_insights, knowledge_posts)
+--          đã upload lên MinIO để restore và áp dụng retention
+-- Domain: Backup (Vector Database Backup & Restore)
+-- Created: 2026-10-19
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS knowledge.qdrant_backups (
+    -- Identity
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id              UUID NOT NULL,              -- Một lần chạy backup (scheduled/manual) gồm nhiều collection
+    collection_name     VARCHAR(255) NOT NULL,      -- Qdrant collection (proj_{id}, macro_insights, knowledge_posts)
+    project_id          UUID,                       -- NULL cho collection không thuộc project
+
+    -- Snapshot
+    snapshot_name       VARCHAR(255),               -- Tên snapshot trên Qdrant node (xoá khỏi node sau khi upload)
+    object_name         VARCHAR(512),               -- Object key trong MinIO bucket
+    size_bytes          BIGINT NOT NULL DEFAULT 0,
+    checksum            VARCHAR(64),                -- SHA256 do Qdrant trả về
+    trigger             VARCHAR(20) NOT NULL,       -- SCHEDULED | MANUAL
+
+    -- Status
+    status              VARCHAR(20) NOT NULL,       -- RUNNING | SUCCEEDED | FAILED | EXPIRED
+    error_message       TEXT,
+
+    -- Timestamps
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at        TIMESTAMPTZ,
+    expired_at          TIMESTAMPTZ,                -- Thời điểm retention xoá object khỏi MinIO
+    restored_at         TIMESTAMPTZ                 -- Lần restore gần nhất từ backup này
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_qdrant_backups_run ON knowledge.qdrant_backups(run_id);
+CREATE INDEX IF NOT EXISTS idx_qdrant_backups_collection_created ON knowledge.qdrant_backups(collection_name, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_qdrant_backups_project_created ON knowledge.qdrant_backups(project_id, created_at DESC)
+    WHERE project_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_qdrant_backups_succeeded ON knowledge.qdrant_backups(collection_name, created_at DESC)
+    WHERE status = 'SUCCEEDED';
+
+COMMENT ON TABLE knowledge.qdrant_backups IS 'Qdrant collection snapshots uploaded to MinIO (one row per collection per backup run)';
+COMMENT ON COLUMN knowledge.qdrant_backups.status IS 'RUNNING | SUCCEEDED | FAILED | EXPIRED (object removed by retention)';
@@ -0,0 +1,47 @@
+-- =====================================================
+-- Migration: 014 - Create purge_requests table
+-- Purpose: Hàng đợi + audit cho việc xoá dữ liệu của project/campaign đã bị xoá ở project-srv
+--          (soft-delete grace period, retry, ghi lại những gì đã xoá)
+-- Domain: Lifecycle (Project/Campaign Purge)
+-- Created: 2026-10-19
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS knowledge.purge_requests (
+    -- Identity
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scope               VARCHAR(20) NOT NULL,       -- PROJECT | CAMPAIGN
+    scope_id            VARCHAR(100) NOT NULL,      -- project_id hoặc campaign_id
+    campaign_ids        TEXT[] NOT NULL DEFAULT '{}', -- PROJECT: campaign chứa project (reports của campaign bị xoá theo)
+    project_ids         TEXT[] NOT NULL DEFAULT '{}', -- CAMPAIGN: project thuộc campaign (để evict search cache)
+
+    -- Origin
+    source              VARCHAR(20) NOT NULL,       -- KAFKA | API
+    reason              TEXT,                       -- event_type hoặc lý do nhập tay
+
+    -- Workflow
+    status              VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING | RUNNING | COMPLETED | FAILED | CANCELLED
+    purge_after         TIMESTAMPTZ NOT NULL,       -- Hết grace period (hoặc thời điểm retry tiếp theo)
+    attempts            INT NOT NULL DEFAULT 0,
+    error_message       TEXT,
+
+    -- Audit
+    removed             JSONB NOT NULL DEFAULT '{}', -- Số lượng đã xoá theo từng store (cộng dồn qua các lần retry)
+
+    -- Timestamps
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at          TIMESTAMPTZ,
+    completed_at        TIMESTAMPTZ,
+    cancelled_at        TIMESTAMPTZ
+);
+
+-- Indexes
+-- Chỉ một request đang mở cho mỗi scope (request lặp lại trả về request cũ)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_purge_requests_open_scope ON knowledge.purge_requests(scope, scope_id)
+    WHERE status IN ('PENDING', 'RUNNING');
+CREATE INDEX IF NOT EXISTS idx_purge_requests_due ON knowledge.purge_requests(purge_after)
+    WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_purge_requests_scope_created ON knowledge.purge_requests(scope, scope_id, created_at DESC);
+
+COMMENT ON TABLE knowledge.purge_requests IS 'Purge workflow and audit trail for deleted projects/campaigns';
+COMMENT ON COLUMN knowledge.purge_requests.removed IS 'What was removed per store: qdrant, postgres, redis, minio (cumulative across attempts)';
@@ -0,0 +1,43 @@
+-- =====================================================
+-- Migration: 015 - Create erasure_requests & erasure_tombstones tables
+-- Purpose: Xoá theo yêu cầu (right to be forgotten) mọi bài viết/bình luận của
+--          một tác giả hoặc một URL nguồn; tombstone chặn ingest lại nội dung đã xoá
+-- Domain: Erasure (Data Subject Deletion)
+-- Created: 2026-10-19
+-- =====================================================
+
+-- Receipt of each deletion run (what was matched and removed in every store)
+CREATE TABLE IF NOT EXISTS knowledge.erasure_requests (
+    -- Identity
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind                VARCHAR(20) NOT NULL,       -- AUTHOR | USERNAME | URL
+    value               TEXT NOT NULL,              -- Giá trị như yêu cầu gửi lên
+    value_norm          TEXT NOT NULL,              -- Giá trị đã chuẩn hoá (so khớp tombstone)
+    project_ids         TEXT[] NOT NULL DEFAULT '{}', -- Giới hạn project (rỗng = mọi project)
+
+    -- Audit
+    reason              TEXT,
+    requested_by        VARCHAR(255),               -- Người/hệ thống gửi yêu cầu
+    removed             JSONB NOT NULL DEFAULT '{}'::jsonb, -- Số lượng đã xoá theo từng store
+
+    -- Timestamps
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at        TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_subject ON knowledge.erasure_requests(kind, value_norm, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_created ON knowledge.erasure_requests(created_at DESC);
+
+-- One tombstone per erased subject; indexing skips documents that match any tombstone
+CREATE TABLE IF NOT EXISTS knowledge.erasure_tombstones (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind                VARCHAR(20) NOT NULL,       -- AUTHOR | USERNAME | URL
+    value_norm          TEXT NOT NULL,
+    request_id          UUID REFERENCES knowledge.erasure_requests(id) ON DELETE SET NULL, -- Yêu cầu đầu tiên tạo tombstone
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, value_norm)
+);
+
+COMMENT ON TABLE knowledge.erasure_requests IS 'Deletion receipts for author/username/URL erasure requests';
+COMMENT ON TABLE knowledge.erasure_tombstones IS 'Erased subjects; matching documents are skipped at indexing time';
+COMMENT ON COLUMN knowledge.erasure_requests.removed IS 'Per-store counts: points, indexed documents, DLQ entries, evidence references, embeddings, search cache';
@@ -0,0 +1,12 @@
+-- =====================================================
+-- Migration: 016 - Numeric fact-check result on reports
+-- Purpose: Lưu kết quả kiểm tra số liệu (số, phần trăm) trong báo cáo so với
+--          aggregate, KPI và evidence đã đưa vào prompt
+-- Domain: Report Generation
+-- Created: 2026-10-19
+-- =====================================================
+
+ALTER TABLE knowledge.reports
+    ADD COLUMN IF NOT EXISTS numeric_check JSONB; -- factcheck result: checked/supported counts, unsupported figures, corrections
+
+COMMENT ON COLUMN knowledge.reports.numeric_check IS 'Verification of the figures in the generated report against its source data; NULL when the check was off or the report stated no figure';
@@ -0,0 +1,20 @@
+-- =====================================================
+-- Migration: 017 - Rolling conversation memory
+-- Purpose: Lưu bản tóm tắt hội thoại cuốn chiếu (summary, key facts, bộ lọc
+--          đang áp dụng) để chat dài không mất ngữ cảnh ngoài cửa sổ history
+-- Domain: Chat (Conversation Management)
+-- Created: 2026-10-19
+-- =====================================================
+
+ALTER TABLE knowledge.conversations
+    ADD COLUMN IF NOT EXISTS memory_summary        TEXT,                  -- Running summary of the messages it covers
+    ADD COLUMN IF NOT EXISTS memory_key_facts      JSONB,                 -- ["fact", ...] worth keeping verbatim
+    ADD COLUMN IF NOT EXISTS memory_active_filters JSONB,                 -- Filters in effect when the summary was written
+    ADD COLUMN IF NOT EXISTS memory_message_count  INT NOT NULL DEFAULT 0, -- Messages [0, n) are covered (or forgotten after a reset)
+    ADD COLUMN IF NOT EXISTS memory_updated_at     TIMESTAMPTZ;
+
+COMMENT ON COLUMN knowledge.conversations.memory_summary IS
+    'Rolling summary of older turns, regenerated asynchronously every few turns; prompts use it plus the most recent messages';
+
+COMMENT ON COLUMN knowledge.conversations.memory_message_count IS
+    'Number of leading messages covered by the summary; a memory reset sets it to message_count so earlier turns are no longer used';
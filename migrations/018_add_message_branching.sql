@@ -0,0 +1,49 @@
+-- =====================================================
+-- Migration: 018 - Message branching
+-- Purpose: Cho phép tạo lại câu trả lời và sửa câu hỏi mà không mất trao đổi cũ:
+--          mỗi message trỏ tới message cha, conversation ghi nhớ nhánh đang xem
+-- Domain: Chat (Conversation Management)
+-- Created: 2026-10-19
+-- =====================================================
+
+ALTER TABLE knowledge.messages
+    ADD COLUMN IF NOT EXISTS parent_id UUID                  -- Previous message on the branch, NULL for the first question
+        REFERENCES knowledge.messages(id) ON DELETE CASCADE;
+
+-- Children of a message (siblings share a parent)
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_parent
+    ON knowledge.messages(conversation_id, parent_id);
+
+ALTER TABLE knowledge.conversations
+    ADD COLUMN IF NOT EXISTS active_leaf_id UUID             -- Last message of the branch being shown and continued
+        REFERENCES knowledge.messages(id) ON DELETE SET NULL;
+
+-- Existing conversations are linear: chain every message to the one before it
+UPDATE knowledge.messages m
+SET parent_id = chain.prev_id
+FROM (
+    SELECT id, LAG(id) OVER (PARTITION BY conversation_id ORDER BY created_at, id) AS prev_id
+    FROM knowledge.messages
+) chain
+WHERE m.id = chain.id
+  AND m.parent_id IS NULL
+  AND chain.prev_id IS NOT NULL;
+
+UPDATE knowledge.conversations c
+SET active_leaf_id = last.id
+FROM (
+    SELECT DISTINCT ON (conversation_id) conversation_id, id
+    FROM knowledge.messages
+    ORDER BY conversation_id, created_at DESC, id DESC
+) last
+WHERE c.id = last.conversation_id
+  AND c.active_leaf_id IS NULL;
+
+COMMENT ON COLUMN knowledge.messages.parent_id IS
+    'Previous message on the branch; a regenerated answer or an edited question is a sibling of the message it replaces';
+
+COMMENT ON COLUMN knowledge.conversations.active_leaf_id IS
+    'Last message of the active branch; history, prompts and new turns follow the path from it back to the root';
+
+COMMENT ON COLUMN knowledge.conversations.memory_message_count IS
+    'Number of leading messages of the active branch covered by the summary; a memory reset sets it to the branch length';
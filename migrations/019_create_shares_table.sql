@@ -0,0 +1,52 @@
+-- =====================================================
+-- Migration: 019 - Create shares table
+-- Purpose: Chia sẻ conversation hoặc report cho đồng đội: cấp quyền đọc cho
+--          từng user, cho cả campaign, hoặc tạo link chỉ đọc có hạn dùng
+-- Domain: Share (Conversation & Report Access)
+-- Created: 2026-10-19
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS knowledge.shares (
+    -- Identity
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Shared resource
+    resource_type       VARCHAR(20) NOT NULL,       -- CONVERSATION | REPORT
+    resource_id         UUID NOT NULL,              -- Conversation hoặc report được chia sẻ
+    campaign_id         VARCHAR(100) NOT NULL,      -- Campaign của resource (grant CAMPAIGN)
+
+    -- Grantee
+    grantee_type        VARCHAR(20) NOT NULL,       -- USER | CAMPAIGN | LINK
+    grantee_user_id     VARCHAR(100),               -- Người được cấp quyền (USER)
+    token_hash          VARCHAR(64),                -- SHA-256 của link token (LINK), token gốc không lưu
+
+    -- Audit
+    created_by          VARCHAR(100) NOT NULL,      -- Người chia sẻ
+    expires_at          TIMESTAMPTZ,                -- NULL = không hết hạn
+    revoked_at          TIMESTAMPTZ,                -- NULL = còn hiệu lực
+
+    -- Timestamps
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_shares_resource_type CHECK (resource_type IN ('CONVERSATION', 'REPORT')),
+    CONSTRAINT chk_shares_grantee CHECK (
+        (grantee_type = 'USER' AND grantee_user_id IS NOT NULL) OR
+        (grantee_type = 'CAMPAIGN') OR
+        (grantee_type = 'LINK' AND token_hash IS NOT NULL AND expires_at IS NOT NULL)
+    )
+);
+
+-- Access checks: active grants of a resource
+CREATE INDEX IF NOT EXISTS idx_shares_resource ON knowledge.shares(resource_type, resource_id) WHERE revoked_at IS NULL;
+-- "Shared with me" and "shared by me"
+CREATE INDEX IF NOT EXISTS idx_shares_grantee_user ON knowledge.shares(grantee_user_id, created_at DESC) WHERE grantee_user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_shares_created_by ON knowledge.shares(created_by, created_at DESC);
+-- Link lookup
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shares_token_hash ON knowledge.shares(token_hash) WHERE token_hash IS NOT NULL;
+
+-- One active grant per user and resource
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shares_user_grant ON knowledge.shares(resource_type, resource_id, grantee_user_id)
+    WHERE grantee_type = 'USER' AND revoked_at IS NULL;
+
+COMMENT ON TABLE knowledge.shares IS 'Quyền đọc conversation/report được chia sẻ cho user, campaign hoặc link';
+COMMENT ON COLUMN knowledge.shares.token_hash IS 'SHA-256 hex của link token; token chỉ trả về một lần khi tạo';
@@ -0,0 +1,22 @@
+-- =====================================================
+-- Migration: 020 - Conversation campaigns
+-- Purpose: Cho phép một lượt chat so sánh nhiều campaign (thương hiệu vs đối thủ):
+--          conversation ghi lại mọi campaign đã tham gia, không chỉ campaign chính
+-- Domain: Chat (Conversation Management)
+-- Created: 2026-10-19
+-- =====================================================
+
+ALTER TABLE knowledge.conversations
+    ADD COLUMN IF NOT EXISTS campaign_ids TEXT[] NOT NULL DEFAULT '{}'; -- Every campaign compared in the conversation, campaign_id first
+
+-- Existing conversations involve their own campaign only
+UPDATE knowledge.conversations
+SET campaign_ids = ARRAY[campaign_id]
+WHERE campaign_ids = '{}';
+
+-- "Conversations of a campaign" includes the ones that compared it
+CREATE INDEX IF NOT EXISTS idx_conversations_campaign_ids
+    ON knowledge.conversations USING GIN (campaign_ids);
+
+COMMENT ON COLUMN knowledge.conversations.campaign_ids IS
+    'Campaigns involved in the conversation, campaign_id first; comparative turns add the campaigns they compare';
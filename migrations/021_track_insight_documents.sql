@@ -0,0 +1,19 @@
+-- =====================================================
+-- Migration: 021 - Track Kafka insight documents
+-- Purpose: Cho phép insight index qua Kafka (IndexBatch) ghi tracking record như
+--          luồng analytics: insight không có source_id, và point ID của layout
+--          per-project là uap_id (không phải UUID)
+-- Domain: Indexing (Vector Database Tracking)
+-- Created: 2026-10-19
+-- =====================================================
+
+ALTER TABLE knowledge.indexed_documents
+    ALTER COLUMN source_id DROP NOT NULL,                      -- Insights carry no data source
+    ALTER COLUMN qdrant_point_id TYPE VARCHAR(255)             -- uap_id under the per-project layout
+        USING qdrant_point_id::text;
+
+COMMENT ON COLUMN knowledge.indexed_documents.analytics_id IS
+    'Foreign key to analytics.post_analytics.id; for Kafka insights, UUIDv5 of (project_id, uap_id)';
+
+COMMENT ON COLUMN knowledge.indexed_documents.qdrant_point_id IS
+    'The vector point ID in Qdrant (analytics_id or uap_id, project-namespaced under the shared layout)';
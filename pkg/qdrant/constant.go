@@ -9,6 +9,9 @@ const (
 	// DefaultPingTimeout is the timeout for initial connection ping in New.
 	DefaultPingTimeout = 5 * time.Second
 
+	// DefaultRESTPort is the Qdrant HTTP API port (snapshot file transfer).
+	DefaultRESTPort = 6333
+
 	// DefaultSearchLimit is the default number of results returned when limit is 0.
 	DefaultSearchLimit = 10
 
@@ -17,4 +20,9 @@ const (
 	DistanceEuclidean = "euclidean"
 	DistanceDot       = "dot"
 	DistanceManhattan = "manhattan"
+
+	// Quantization modes for CollectionProfile.
+	QuantizationNone   = "none"
+	QuantizationScalar = "scalar"
+	QuantizationBinary = "binary"
 )
@@ -6,16 +6,20 @@ import (
 )
 
 var (
-	ErrInvalidConfig      = errors.New("invalid configuration")
-	ErrCollectionNotFound = errors.New("collection not found")
-	ErrPointNotFound      = errors.New("point not found")
-	ErrInvalidVector      = errors.New("invalid vector")
-	ErrInvalidPointID     = errors.New("invalid point ID")
-	ErrEmptyCollection    = errors.New("collection name cannot be empty")
-	ErrInvalidVectorSize  = errors.New("invalid vector size")
-	ErrConnectionFailed   = errors.New("connection failed")
-	ErrEmptyKey           = errors.New("facet key cannot be empty")
-	ErrMissingGroupField  = errors.New("groupBy field is required")
+	ErrInvalidConfig       = errors.New("invalid configuration")
+	ErrCollectionNotFound  = errors.New("collection not found")
+	ErrPointNotFound       = errors.New("point not found")
+	ErrInvalidVector       = errors.New("invalid vector")
+	ErrInvalidPointID      = errors.New("invalid point ID")
+	ErrEmptyCollection     = errors.New("collection name cannot be empty")
+	ErrInvalidVectorSize   = errors.New("invalid vector size")
+	ErrConnectionFailed    = errors.New("connection failed")
+	ErrEmptyKey            = errors.New("facet key cannot be empty")
+	ErrMissingGroupField   = errors.New("groupBy field is required")
+	ErrInvalidQuantization = errors.New("invalid quantization mode")
+	ErrEmptySnapshot       = errors.New("snapshot name cannot be empty")
+	ErrSnapshotNotFound    = errors.New("snapshot not found")
+	ErrEmptyFilter         = errors.New("filter cannot be empty")
 )
 
 // WrapError wraps an error with additional context.
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
+	"net/http"
 
 	pb "github.com/qdrant/go-client/qdrant"
 	"google.golang.org/grpc"
@@ -16,6 +18,7 @@ type IQdrant interface {
 	CollectionsOps
 	PointsOps
 	SearchOps
+	SnapshotOps
 	Close() error
 	Ping(ctx context.Context) error
 }
@@ -23,6 +26,10 @@ type IQdrant interface {
 // CollectionsOps defines interface for collection-related operations.
 type CollectionsOps interface {
 	CreateCollection(ctx context.Context, name string, vectorSize uint64, distance pb.Distance) error
+	// CreateCollectionWithProfile creates a collection with quantization, on-disk storage and HNSW settings.
+	CreateCollectionWithProfile(ctx context.Context, name string, vectorSize uint64, distance pb.Distance, profile CollectionProfile) error
+	// UpdateCollectionProfile applies a storage/index profile to an existing collection.
+	UpdateCollectionProfile(ctx context.Context, name string, profile CollectionProfile) error
 	DeleteCollection(ctx context.Context, name string) error
 	CollectionExists(ctx context.Context, name string) (bool, error)
 	GetCollectionInfo(ctx context.Context, name string) (*CollectionInfo, error)
@@ -34,24 +41,50 @@ type PointsOps interface {
 	UpsertPoint(ctx context.Context, colName string, point Point) error
 	UpsertPoints(ctx context.Context, colName string, points []Point) error
 	DeletePoint(ctx context.Context, colName string, pointID string) error
+	// DeletePointsWithFilter deletes every point matching filter and waits for the operation.
+	DeletePointsWithFilter(ctx context.Context, colName string, filter *pb.Filter) error
+	// SetPayload overwrites the given payload keys of one point (pointID as returned by ScrollPoints),
+	// leaving its vector and other keys untouched.
+	SetPayload(ctx context.Context, colName string, pointID string, payload map[string]interface{}) error
 	GetPoint(ctx context.Context, colName string, pointID string) (*Point, error)
 	CountPoints(ctx context.Context, colName string) (uint64, error)
+	// CountPointsWithFilter returns the exact number of points matching filter.
+	CountPointsWithFilter(ctx context.Context, colName string, filter *pb.Filter) (uint64, error)
+	// CopyPoints copies one page of points from src to dst (offset/next is the page cursor).
+	// Point IDs are kept unless mapID is set.
+	CopyPoints(ctx context.Context, src, dst string, limit uint32, offset *pb.PointId, setPayload map[string]interface{}, mapID PointIDMapper) (int, *pb.PointId, error)
 	// ScrollPoints iterates points matching filter (offset is next-page cursor from previous call).
 	ScrollPoints(ctx context.Context, colName string, filter *pb.Filter, limit uint32, withPayload bool, offset *pb.PointId) ([]Point, *pb.PointId, error)
 	// CreateFieldIndex creates a payload field index to enable faceting and filtering on the given field.
 	// Calling this on an already-indexed field is idempotent and safe.
 	CreateFieldIndex(ctx context.Context, colName string, fieldName string, fieldType pb.FieldType) error
+	// CreateFieldIndexWithParams creates a payload field index with explicit params (text tokenizer...).
+	CreateFieldIndexWithParams(ctx context.Context, colName string, fieldName string, fieldType pb.FieldType, params *pb.PayloadIndexParams) error
 }
 
 // SearchOps defines interface for search operations.
 type SearchOps interface {
 	Search(ctx context.Context, colName string, vector []float32, limit uint64) ([]SearchResult, error)
 	SearchWithFilter(ctx context.Context, colName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32) ([]SearchResult, error)
+	SearchWithVectors(ctx context.Context, colName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32) ([]SearchResult, error)
+	SearchWithParams(ctx context.Context, colName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32, withVectors bool, params SearchParams) ([]SearchResult, error)
 	SearchBatch(ctx context.Context, colName string, vectors [][]float32, limit uint64) ([][]SearchResult, error)
 	SearchGroups(ctx context.Context, colName string, vector []float32, limit uint64, groupBy string, groupLimit uint64, filter *pb.Filter) ([]GroupResult, error)
 	Facet(ctx context.Context, colName string, key string, limit uint64, filter *pb.Filter) ([]FacetResult, error)
 }
 
+// SnapshotOps defines interface for collection snapshot (backup/restore) operations.
+type SnapshotOps interface {
+	CreateSnapshot(ctx context.Context, colName string) (SnapshotInfo, error)
+	ListSnapshots(ctx context.Context, colName string) ([]SnapshotInfo, error)
+	DeleteSnapshot(ctx context.Context, colName string, snapshotName string) error
+	// DownloadSnapshot streams a snapshot file from the node. The caller must close the reader.
+	DownloadSnapshot(ctx context.Context, colName string, snapshotName string) (io.ReadCloser, error)
+	// RestoreSnapshot uploads a snapshot file and recovers colName from it, replacing
+	// its data (the collection is created if missing). checksum is optional.
+	RestoreSnapshot(ctx context.Context, colName string, snapshot io.Reader, checksum string) error
+}
+
 // New creates a new Qdrant client. Returns an implementation of IQdrant.
 func NewQdrant(cfg QdrantConfig) (IQdrant, error) {
 	// Validate config directly here
@@ -65,6 +98,9 @@ func NewQdrant(cfg QdrantConfig) (IQdrant, error) {
 	if cfg.Timeout == 0 {
 		cfg.Timeout = DefaultTimeout
 	}
+	if cfg.RESTPort == 0 {
+		cfg.RESTPort = DefaultRESTPort
+	}
 
 	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 
@@ -84,7 +120,11 @@ func NewQdrant(cfg QdrantConfig) (IQdrant, error) {
 		conn:              conn,
 		pointsClient:      pb.NewPointsClient(conn),
 		collectionsClient: pb.NewCollectionsClient(conn),
+		snapshotsClient:   pb.NewSnapshotsClient(conn),
 		defaultTimeout:    cfg.Timeout,
+		restURL:           restURL(cfg),
+		apiKey:            cfg.APIKey,
+		httpClient:        &http.Client{},
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), DefaultPingTimeout)
@@ -0,0 +1,788 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	pb "github.com/qdrant/go-client/qdrant"
+)
+
+// memoryImpl is a pure-Go, in-process IQdrant for tests and local runs without a
+// Qdrant server. Search is brute-force and exact (HNSW and quantization settings are
+// stored but have no effect). Point IDs, payload value types and error sentinels
+// follow the gRPC client so callers behave the same against both.
+type memoryImpl struct {
+	mu          sync.RWMutex
+	collections map[string]*memoryCollection
+	snapshots   map[string]map[string]memorySnapshot // collection → snapshot name → file
+}
+
+type memoryCollection struct {
+	vectorSize uint64
+	distance   pb.Distance
+	profile    CollectionProfile
+	indexes    map[string]PayloadIndexInfo
+	points     map[string]*memoryPoint // by PointIDString
+}
+
+type memoryPoint struct {
+	id      *pb.PointId
+	vector  []float32
+	payload map[string]interface{}
+}
+
+// NewMemory creates an empty in-memory IQdrant.
+func NewMemory() IQdrant {
+	return &memoryImpl{
+		collections: make(map[string]*memoryCollection),
+		snapshots:   make(map[string]map[string]memorySnapshot),
+	}
+}
+
+// Ping always succeeds.
+func (m *memoryImpl) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Close is a no-op.
+func (m *memoryImpl) Close() error {
+	return nil
+}
+
+// =====================================================
+// Collections
+// =====================================================
+
+func (m *memoryImpl) CreateCollection(ctx context.Context, name string, vectorSize uint64, distance pb.Distance) error {
+	return m.CreateCollectionWithProfile(ctx, name, vectorSize, distance, CollectionProfile{})
+}
+
+func (m *memoryImpl) CreateCollectionWithProfile(ctx context.Context, name string, vectorSize uint64, distance pb.Distance, profile CollectionProfile) error {
+	if name == "" {
+		return ErrEmptyCollection
+	}
+	if vectorSize == 0 {
+		return ErrInvalidVectorSize
+	}
+	if distance != pb.Distance_Cosine && distance != pb.Distance_Dot {
+		return fmt.Errorf("%w: in-memory store supports cosine and dot distance only", ErrInvalidConfig)
+	}
+	if _, err := quantizationConfig(profile); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.collections[name]; ok {
+		return WrapError(fmt.Errorf("collection `%s` already exists", name), "failed to create collection")
+	}
+	if profile.Quantization == "" {
+		profile.Quantization = QuantizationNone
+	}
+	m.collections[name] = &memoryCollection{
+		vectorSize: vectorSize,
+		distance:   distance,
+		profile:    profile,
+		indexes:    make(map[string]PayloadIndexInfo),
+		points:     make(map[string]*memoryPoint),
+	}
+	return nil
+}
+
+func (m *memoryImpl) UpdateCollectionProfile(ctx context.Context, name string, profile CollectionProfile) error {
+	if name == "" {
+		return ErrEmptyCollection
+	}
+	if _, err := quantizationConfig(profile); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	col, ok := m.collections[name]
+	if !ok {
+		return WrapError(ErrCollectionNotFound, "failed to update collection profile")
+	}
+	col.profile.OnDiskVectors = profile.OnDiskVectors
+	col.profile.OnDiskPayload = profile.OnDiskPayload
+	col.profile.Quantization = profile.Quantization
+	if col.profile.Quantization == "" {
+		col.profile.Quantization = QuantizationNone
+	}
+	col.profile.QuantizationAlwaysRAM = profile.QuantizationAlwaysRAM
+	if profile.HnswM > 0 {
+		col.profile.HnswM = profile.HnswM
+	}
+	if profile.HnswEfConstruct > 0 {
+		col.profile.HnswEfConstruct = profile.HnswEfConstruct
+	}
+	if profile.IndexingThreshold > 0 {
+		col.profile.IndexingThreshold = profile.IndexingThreshold
+	}
+	return nil
+}
+
+func (m *memoryImpl) DeleteCollection(ctx context.Context, name string) error {
+	if name == "" {
+		return ErrEmptyCollection
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.collections, name)
+	return nil
+}
+
+func (m *memoryImpl) CollectionExists(ctx context.Context, name string) (bool, error) {
+	if name == "" {
+		return false, ErrEmptyCollection
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.collections[name]
+	return ok, nil
+}
+
+func (m *memoryImpl) GetCollectionInfo(ctx context.Context, name string) (*CollectionInfo, error) {
+	if name == "" {
+		return nil, ErrEmptyCollection
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	col, err := m.collection(name, "failed to get collection info")
+	if err != nil {
+		return nil, err
+	}
+
+	info := &CollectionInfo{
+		Name:          name,
+		VectorSize:    col.vectorSize,
+		Distance:      col.distance.String(),
+		PointsCount:   uint64(len(col.points)),
+		Status:        pb.CollectionStatus_Green.String(),
+		PayloadSchema: make(map[string]PayloadIndexInfo, len(col.indexes)),
+		Profile:       col.profile,
+	}
+	for field, idx := range col.indexes {
+		idx.Points = col.countIndexed(field)
+		info.PayloadSchema[field] = idx
+	}
+	return info, nil
+}
+
+func (m *memoryImpl) ListCollections(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.collections))
+	for name := range m.collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// =====================================================
+// Points
+// =====================================================
+
+func (m *memoryImpl) UpsertPoint(ctx context.Context, colName string, point Point) error {
+	if point.ID == "" {
+		return ErrInvalidPointID
+	}
+	if len(point.Vector) == 0 {
+		return ErrInvalidVector
+	}
+	return m.UpsertPoints(ctx, colName, []Point{point})
+}
+
+func (m *memoryImpl) UpsertPoints(ctx context.Context, colName string, points []Point) error {
+	if colName == "" {
+		return ErrEmptyCollection
+	}
+	if len(points) == 0 {
+		return nil
+	}
+
+	converted := make([]*memoryPoint, 0, len(points))
+	for _, p := range points {
+		if p.ID == "" {
+			return ErrInvalidPointID
+		}
+		if len(p.Vector) == 0 {
+			return ErrInvalidVector
+		}
+		payload, err := normalizePayload(p.Payload)
+		if err != nil {
+			return WrapError(err, "failed to convert payload")
+		}
+		converted = append(converted, &memoryPoint{
+			id:      toPointID(p.ID),
+			vector:  append([]float32(nil), p.Vector...),
+			payload: payload,
+		})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	col, err := m.collection(colName, "failed to upsert points")
+	if err != nil {
+		return err
+	}
+	for _, p := range converted {
+		if err := ValidateVector(p.vector, col.vectorSize); err != nil {
+			return WrapError(err, "failed to upsert points")
+		}
+	}
+	for _, p := range converted {
+		col.points[PointIDString(p.id)] = p
+	}
+	return nil
+}
+
+func (m *memoryImpl) DeletePoint(ctx context.Context, colName string, pointID string) error {
+	if colName == "" {
+		return ErrEmptyCollection
+	}
+	if pointID == "" {
+		return ErrInvalidPointID
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	col, err := m.collection(colName, "failed to delete point")
+	if err != nil {
+		return err
+	}
+	delete(col.points, col.pointKey(pointID))
+	return nil
+}
+
+func (m *memoryImpl) DeletePointsWithFilter(ctx context.Context, colName string, filter *pb.Filter) error {
+	if colName == "" {
+		return ErrEmptyCollection
+	}
+	if filter == nil {
+		return ErrEmptyFilter
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	col, err := m.collection(colName, "failed to delete points")
+	if err != nil {
+		return err
+	}
+	for key, p := range col.points {
+		if matchFilter(filter, p.id, p.payload) {
+			delete(col.points, key)
+		}
+	}
+	return nil
+}
+
+func (m *memoryImpl) SetPayload(ctx context.Context, colName string, pointID string, payload map[string]interface{}) error {
+	if colName == "" {
+		return ErrEmptyCollection
+	}
+	if pointID == "" {
+		return ErrInvalidPointID
+	}
+	normalized, err := normalizePayload(payload)
+	if err != nil {
+		return WrapError(err, "failed to convert payload")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	col, err := m.collection(colName, "failed to set payload")
+	if err != nil {
+		return err
+	}
+	p, ok := col.points[PointIDString(ParsePointID(pointID))]
+	if !ok {
+		return ErrPointNotFound
+	}
+	if p.payload == nil {
+		p.payload = make(map[string]interface{}, len(normalized))
+	}
+	for key, value := range normalized {
+		p.payload[key] = value
+	}
+	return nil
+}
+
+func (m *memoryImpl) GetPoint(ctx context.Context, colName string, pointID string) (*Point, error) {
+	if colName == "" {
+		return nil, ErrEmptyCollection
+	}
+	if pointID == "" {
+		return nil, ErrInvalidPointID
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	col, err := m.collection(colName, "failed to get point")
+	if err != nil {
+		return nil, err
+	}
+	p, ok := col.points[col.pointKey(pointID)]
+	if !ok {
+		return nil, ErrPointNotFound
+	}
+	return &Point{ID: pointID, Vector: append([]float32(nil), p.vector...), Payload: clonePayload(p.payload)}, nil
+}
+
+func (m *memoryImpl) CountPoints(ctx context.Context, colName string) (uint64, error) {
+	return m.CountPointsWithFilter(ctx, colName, nil)
+}
+
+func (m *memoryImpl) CountPointsWithFilter(ctx context.Context, colName string, filter *pb.Filter) (uint64, error) {
+	if colName == "" {
+		return 0, ErrEmptyCollection
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	col, err := m.collection(colName, "failed to count points")
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	for _, p := range col.points {
+		if matchFilter(filter, p.id, p.payload) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *memoryImpl) CopyPoints(ctx context.Context, src, dst string, limit uint32, offset *pb.PointId, setPayload map[string]interface{}, mapID PointIDMapper) (int, *pb.PointId, error) {
+	if src == "" || dst == "" {
+		return 0, nil, ErrEmptyCollection
+	}
+	if limit == 0 {
+		limit = 100
+	}
+	extra, err := normalizePayload(setPayload)
+	if err != nil {
+		return 0, nil, WrapError(err, "failed to convert payload")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	from, err := m.collection(src, "failed to scroll points")
+	if err != nil {
+		return 0, nil, err
+	}
+	to, err := m.collection(dst, "failed to upsert copied points")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	page, next := scrollPage(from.sortedPoints(nil), offset, int(limit))
+	for _, p := range page {
+		if err := ValidateVector(p.vector, to.vectorSize); err != nil {
+			return 0, nil, WrapError(err, "failed to upsert copied points")
+		}
+	}
+	for _, p := range page {
+		id := p.id
+		if mapID != nil {
+			id = ParsePointID(mapID(PointIDString(p.id), clonePayload(p.payload)))
+		}
+		payload := clonePayload(p.payload)
+		for k, v := range extra {
+			payload[k] = v
+		}
+		to.points[PointIDString(id)] = &memoryPoint{id: id, vector: append([]float32(nil), p.vector...), payload: payload}
+	}
+	return len(page), next, nil
+}
+
+func (m *memoryImpl) ScrollPoints(ctx context.Context, colName string, filter *pb.Filter, limit uint32, withPayload bool, offset *pb.PointId) ([]Point, *pb.PointId, error) {
+	if colName == "" {
+		return nil, nil, ErrEmptyCollection
+	}
+	if limit == 0 {
+		limit = 100
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	col, err := m.collection(colName, "failed to scroll points")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page, next := scrollPage(col.sortedPoints(filter), offset, int(limit))
+	out := make([]Point, 0, len(page))
+	for _, p := range page {
+		payload := map[string]interface{}{}
+		if withPayload {
+			payload = clonePayload(p.payload)
+		}
+		out = append(out, Point{ID: PointIDString(p.id), Payload: payload})
+	}
+	return out, next, nil
+}
+
+func (m *memoryImpl) CreateFieldIndex(ctx context.Context, colName string, fieldName string, fieldType pb.FieldType) error {
+	return m.CreateFieldIndexWithParams(ctx, colName, fieldName, fieldType, nil)
+}
+
+func (m *memoryImpl) CreateFieldIndexWithParams(ctx context.Context, colName string, fieldName string, fieldType pb.FieldType, params *pb.PayloadIndexParams) error {
+	if colName == "" {
+		return ErrEmptyCollection
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	col, err := m.collection(colName, "failed to create field index")
+	if err != nil {
+		return err
+	}
+	col.indexes[fieldName] = PayloadIndexInfo{
+		DataType:  payloadSchemaType(fieldType),
+		Tokenizer: params.GetTextIndexParams().GetTokenizer(),
+		IsTenant:  params.GetKeywordIndexParams().GetIsTenant(),
+	}
+	return nil
+}
+
+// =====================================================
+// Search
+// =====================================================
+
+func (m *memoryImpl) Search(ctx context.Context, colName string, vector []float32, limit uint64) ([]SearchResult, error) {
+	return m.search(colName, vector, limit, nil, 0, false)
+}
+
+func (m *memoryImpl) SearchWithFilter(ctx context.Context, colName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32) ([]SearchResult, error) {
+	return m.search(colName, vector, limit, filter, scoreThreshold, false)
+}
+
+func (m *memoryImpl) SearchWithVectors(ctx context.Context, colName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32) ([]SearchResult, error) {
+	return m.search(colName, vector, limit, filter, scoreThreshold, true)
+}
+
+// SearchWithParams ignores params: brute-force search is already exact.
+func (m *memoryImpl) SearchWithParams(ctx context.Context, colName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32, withVectors bool, params SearchParams) ([]SearchResult, error) {
+	return m.search(colName, vector, limit, filter, scoreThreshold, withVectors)
+}
+
+func (m *memoryImpl) SearchBatch(ctx context.Context, colName string, vectors [][]float32, limit uint64) ([][]SearchResult, error) {
+	if colName == "" {
+		return nil, ErrEmptyCollection
+	}
+	if len(vectors) == 0 {
+		return nil, ErrInvalidVector
+	}
+	out := make([][]SearchResult, 0, len(vectors))
+	for _, vector := range vectors {
+		results, err := m.search(colName, vector, limit, nil, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, results)
+	}
+	return out, nil
+}
+
+// SearchGroups groups hits by the payload value at groupBy (a point with an array
+// value joins every group it names), keeping groups in order of their best hit.
+func (m *memoryImpl) SearchGroups(ctx context.Context, colName string, vector []float32, limit uint64, groupBy string, groupLimit uint64, filter *pb.Filter) ([]GroupResult, error) {
+	if groupBy == "" {
+		return nil, ErrMissingGroupField
+	}
+	if groupLimit == 0 {
+		groupLimit = 1
+	}
+	hits, err := m.search(colName, vector, math.MaxUint32, filter, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+
+	var groups []GroupResult
+	index := make(map[interface{}]int)
+	for _, hit := range hits {
+		for _, key := range groupKeys(payloadValues(hit.Payload, groupBy)) {
+			i, ok := index[key]
+			if !ok {
+				if uint64(len(groups)) >= limit {
+					continue
+				}
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, GroupResult{ID: key})
+			}
+			if uint64(len(groups[i].Hits)) < groupLimit {
+				groups[i].Hits = append(groups[i].Hits, hit)
+			}
+		}
+	}
+	return groups, nil
+}
+
+// Facet counts the distinct keyword/integer values at key per matching point. Like
+// Qdrant it requires a payload index on key.
+func (m *memoryImpl) Facet(ctx context.Context, colName string, key string, limit uint64, filter *pb.Filter) ([]FacetResult, error) {
+	if colName == "" {
+		return nil, ErrEmptyCollection
+	}
+	if key == "" {
+		return nil, ErrEmptyKey
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	col, ok := m.collections[colName]
+	if !ok {
+		return nil, ErrCollectionNotFound
+	}
+	if idx, ok := col.indexes[key]; !ok || (idx.DataType != pb.PayloadSchemaType_Keyword && idx.DataType != pb.PayloadSchemaType_Integer) {
+		return nil, WrapError(fmt.Errorf("Bad request: No appropriate index for faceting: %s", key), "failed to get facets")
+	}
+
+	counts := make(map[interface{}]uint64)
+	for _, p := range col.points {
+		if !matchFilter(filter, p.id, p.payload) {
+			continue
+		}
+		seen := make(map[interface{}]struct{})
+		for _, v := range payloadValues(p.payload, key) {
+			var fv interface{}
+			switch val := v.(type) {
+			case string:
+				fv = val
+			case int64:
+				fv = val
+			default:
+				continue
+			}
+			if _, dup := seen[fv]; dup {
+				continue
+			}
+			seen[fv] = struct{}{}
+			counts[fv]++
+		}
+	}
+
+	results := make([]FacetResult, 0, len(counts))
+	for v, c := range counts {
+		results = append(results, FacetResult{Value: v, Count: c})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		return fmt.Sprint(results[i].Value) < fmt.Sprint(results[j].Value)
+	})
+	if uint64(len(results)) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func (m *memoryImpl) search(colName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32, withVectors bool) ([]SearchResult, error) {
+	if colName == "" {
+		return nil, ErrEmptyCollection
+	}
+	if len(vector) == 0 {
+		return nil, ErrInvalidVector
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	col, err := m.collection(colName, "failed to search with filter")
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateVector(vector, col.vectorSize); err != nil {
+		return nil, WrapError(err, "failed to search with filter")
+	}
+
+	type scored struct {
+		p     *memoryPoint
+		score float32
+	}
+	hits := make([]scored, 0, len(col.points))
+	for _, p := range col.points {
+		if !matchFilter(filter, p.id, p.payload) {
+			continue
+		}
+		score := similarity(col.distance, vector, p.vector)
+		if scoreThreshold > 0 && score < scoreThreshold {
+			continue
+		}
+		hits = append(hits, scored{p: p, score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return pointIDLess(hits[i].p.id, hits[j].p.id)
+	})
+	if uint64(len(hits)) > limit {
+		hits = hits[:limit]
+	}
+
+	results := make([]SearchResult, 0, len(hits))
+	for _, h := range hits {
+		r := SearchResult{ID: PointIDString(h.p.id), Score: h.score, Payload: clonePayload(h.p.payload)}
+		if withVectors {
+			r.Vector = append([]float32(nil), h.p.vector...)
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// =====================================================
+// Helpers
+// =====================================================
+
+// collection returns the named collection or the wrapped not-found sentinel. Callers hold m.mu.
+func (m *memoryImpl) collection(name, msg string) (*memoryCollection, error) {
+	col, ok := m.collections[name]
+	if !ok {
+		return nil, WrapError(ErrCollectionNotFound, msg)
+	}
+	return col, nil
+}
+
+// pointKey resolves a caller-supplied ID: a raw UUID/source ID is mapped like
+// UpsertPoints does, while the numeric form returned by search/scroll is used as is.
+func (col *memoryCollection) pointKey(id string) string {
+	if _, ok := col.points[id]; ok {
+		return id
+	}
+	return PointIDString(toPointID(id))
+}
+
+func (col *memoryCollection) sortedPoints(filter *pb.Filter) []*memoryPoint {
+	points := make([]*memoryPoint, 0, len(col.points))
+	for _, p := range col.points {
+		if matchFilter(filter, p.id, p.payload) {
+			points = append(points, p)
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return pointIDLess(points[i].id, points[j].id) })
+	return points
+}
+
+func (col *memoryCollection) countIndexed(field string) uint64 {
+	var n uint64
+	for _, p := range col.points {
+		if len(payloadValues(p.payload, field)) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// scrollPage returns up to limit points starting at offset, plus the next offset.
+func scrollPage(points []*memoryPoint, offset *pb.PointId, limit int) ([]*memoryPoint, *pb.PointId) {
+	start := 0
+	if offset != nil {
+		start = sort.Search(len(points), func(i int) bool { return !pointIDLess(points[i].id, offset) })
+	}
+	end := start + limit
+	if end >= len(points) {
+		return points[start:], nil
+	}
+	return points[start:end], points[end].id
+}
+
+// toPointID maps a point ID string the same way UpsertPoints does on the gRPC client.
+func toPointID(id string) *pb.PointId {
+	if isValidUUID(id) {
+		return &pb.PointId{PointIdOptions: &pb.PointId_Uuid{Uuid: id}}
+	}
+	return &pb.PointId{PointIdOptions: &pb.PointId_Num{Num: generateHashNumber(id)}}
+}
+
+// pointIDLess orders IDs like Qdrant scroll: numeric IDs first, then UUIDs.
+func pointIDLess(a, b *pb.PointId) bool {
+	aUUID, bUUID := a.GetUuid(), b.GetUuid()
+	switch {
+	case aUUID == "" && bUUID == "":
+		return a.GetNum() < b.GetNum()
+	case aUUID == "" || bUUID == "":
+		return aUUID == ""
+	default:
+		return aUUID < bUUID
+	}
+}
+
+// normalizePayload round-trips a payload through Qdrant values so stored types
+// match what the gRPC client returns (integers as int64, other numbers as float64).
+func normalizePayload(payload map[string]interface{}) (map[string]interface{}, error) {
+	values, err := pb.TryValueMap(payload)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		out[k] = valueToInterface(v)
+	}
+	return out, nil
+}
+
+func clonePayload(payload map[string]interface{}) map[string]interface{} {
+	out, err := normalizePayload(payload)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	return out
+}
+
+func similarity(distance pb.Distance, a, b []float32) float32 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if distance == pb.Distance_Dot {
+		return float32(dot)
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
+
+func groupKeys(values []interface{}) []interface{} {
+	keys := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		switch val := v.(type) {
+		case string:
+			keys = append(keys, val)
+		case int64:
+			if val >= 0 {
+				keys = append(keys, uint64(val))
+			}
+		}
+	}
+	return keys
+}
+
+func payloadSchemaType(fieldType pb.FieldType) pb.PayloadSchemaType {
+	switch fieldType {
+	case pb.FieldType_FieldTypeInteger:
+		return pb.PayloadSchemaType_Integer
+	case pb.FieldType_FieldTypeFloat:
+		return pb.PayloadSchemaType_Float
+	case pb.FieldType_FieldTypeBool:
+		return pb.PayloadSchemaType_Bool
+	case pb.FieldType_FieldTypeDatetime:
+		return pb.PayloadSchemaType_Datetime
+	case pb.FieldType_FieldTypeText:
+		return pb.PayloadSchemaType_Text
+	case pb.FieldType_FieldTypeGeo:
+		return pb.PayloadSchemaType_Geo
+	default:
+		return pb.PayloadSchemaType_Keyword
+	}
+}
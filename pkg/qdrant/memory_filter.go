@@ -0,0 +1,377 @@
+package qdrant
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	pb "github.com/qdrant/go-client/qdrant"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// matchFilter evaluates a Qdrant filter against a stored point. id is nil inside
+// nested conditions, where HasId never matches.
+func matchFilter(f *pb.Filter, id *pb.PointId, payload map[string]interface{}) bool {
+	if f == nil {
+		return true
+	}
+	for _, c := range f.GetMust() {
+		if !matchCondition(c, id, payload) {
+			return false
+		}
+	}
+	for _, c := range f.GetMustNot() {
+		if matchCondition(c, id, payload) {
+			return false
+		}
+	}
+	if should := f.GetShould(); len(should) > 0 {
+		matched := false
+		for _, c := range should {
+			if matchCondition(c, id, payload) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if ms := f.GetMinShould(); ms != nil {
+		var n uint64
+		for _, c := range ms.GetConditions() {
+			if matchCondition(c, id, payload) {
+				n++
+			}
+		}
+		if n < ms.GetMinCount() {
+			return false
+		}
+	}
+	return true
+}
+
+func matchCondition(c *pb.Condition, id *pb.PointId, payload map[string]interface{}) bool {
+	switch cond := c.GetConditionOneOf().(type) {
+	case *pb.Condition_Field:
+		return matchField(cond.Field, payload)
+	case *pb.Condition_Filter:
+		return matchFilter(cond.Filter, id, payload)
+	case *pb.Condition_IsEmpty:
+		return isEmptyValue(payloadValues(payload, cond.IsEmpty.GetKey()))
+	case *pb.Condition_IsNull:
+		v, ok := lookupPath(payload, cond.IsNull.GetKey())
+		return ok && v == nil
+	case *pb.Condition_HasId:
+		if id == nil {
+			return false
+		}
+		for _, want := range cond.HasId.GetHasId() {
+			if PointIDString(want) == PointIDString(id) {
+				return true
+			}
+		}
+		return false
+	case *pb.Condition_Nested:
+		// Every condition of a nested filter must hold for the same array element.
+		for _, v := range payloadValues(payload, cond.Nested.GetKey()) {
+			if elem, ok := v.(map[string]interface{}); ok && matchFilter(cond.Nested.GetFilter(), nil, elem) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func matchField(fc *pb.FieldCondition, payload map[string]interface{}) bool {
+	values := payloadValues(payload, fc.GetKey())
+
+	switch {
+	case fc.GetMatch() != nil:
+		return matchValue(fc.GetMatch(), values)
+	case fc.GetRange() != nil:
+		for _, v := range values {
+			if f, ok := toFloat(v); ok && inRange(fc.GetRange(), f) {
+				return true
+			}
+		}
+		return false
+	case fc.GetDatetimeRange() != nil:
+		for _, v := range values {
+			if t, ok := toTime(v); ok && inDatetimeRange(fc.GetDatetimeRange(), t) {
+				return true
+			}
+		}
+		return false
+	case fc.GetValuesCount() != nil:
+		return inValuesCount(fc.GetValuesCount(), uint64(len(values)))
+	case fc.IsEmpty != nil:
+		return isEmptyValue(values) == fc.GetIsEmpty()
+	case fc.IsNull != nil:
+		v, ok := lookupPath(payload, fc.GetKey())
+		return (ok && v == nil) == fc.GetIsNull()
+	default:
+		// Geo conditions are not supported in memory.
+		return false
+	}
+}
+
+func matchValue(m *pb.Match, values []interface{}) bool {
+	switch mv := m.GetMatchValue().(type) {
+	case *pb.Match_ExceptKeywords:
+		return matchExcept(values, func(v interface{}) bool {
+			s, ok := v.(string)
+			return ok && containsString(mv.ExceptKeywords.GetStrings(), s)
+		})
+	case *pb.Match_ExceptIntegers:
+		return matchExcept(values, func(v interface{}) bool {
+			i, ok := toInt(v)
+			return ok && containsInt(mv.ExceptIntegers.GetIntegers(), i)
+		})
+	}
+
+	for _, v := range values {
+		switch mv := m.GetMatchValue().(type) {
+		case *pb.Match_Keyword:
+			if s, ok := v.(string); ok && s == mv.Keyword {
+				return true
+			}
+		case *pb.Match_Keywords:
+			if s, ok := v.(string); ok && containsString(mv.Keywords.GetStrings(), s) {
+				return true
+			}
+		case *pb.Match_Integer:
+			if i, ok := toInt(v); ok && i == mv.Integer {
+				return true
+			}
+		case *pb.Match_Integers:
+			if i, ok := toInt(v); ok && containsInt(mv.Integers.GetIntegers(), i) {
+				return true
+			}
+		case *pb.Match_Boolean:
+			if b, ok := v.(bool); ok && b == mv.Boolean {
+				return true
+			}
+		case *pb.Match_Text:
+			if s, ok := v.(string); ok && containsAllTokens(s, mv.Text) {
+				return true
+			}
+		case *pb.Match_TextAny:
+			if s, ok := v.(string); ok && containsAnyToken(s, mv.TextAny) {
+				return true
+			}
+		case *pb.Match_Phrase:
+			if s, ok := v.(string); ok && strings.Contains(strings.ToLower(s), strings.ToLower(mv.Phrase)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchExcept follows Qdrant: a missing field matches, an array matches when at
+// least one of its values is not excluded.
+func matchExcept(values []interface{}, excluded func(interface{}) bool) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if !excluded(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// payloadValues resolves a dotted path ("a.b", "a[].b") and flattens arrays along
+// the way, like Qdrant's payload key resolution.
+func payloadValues(payload map[string]interface{}, key string) []interface{} {
+	current := []interface{}{payload}
+	for _, part := range strings.Split(key, ".") {
+		part = strings.TrimSuffix(part, "[]")
+		var next []interface{}
+		for _, v := range current {
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			val, ok := obj[part]
+			if !ok {
+				continue
+			}
+			if arr, ok := val.([]interface{}); ok {
+				next = append(next, arr...)
+			} else {
+				next = append(next, val)
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+// lookupPath returns the raw value at a non-array dotted path.
+func lookupPath(payload map[string]interface{}, key string) (interface{}, bool) {
+	var current interface{} = payload
+	for _, part := range strings.Split(key, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func isEmptyValue(values []interface{}) bool {
+	for _, v := range values {
+		if v != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func inRange(r *pb.Range, v float64) bool {
+	if r.Lt != nil && !(v < r.GetLt()) {
+		return false
+	}
+	if r.Lte != nil && !(v <= r.GetLte()) {
+		return false
+	}
+	if r.Gt != nil && !(v > r.GetGt()) {
+		return false
+	}
+	if r.Gte != nil && !(v >= r.GetGte()) {
+		return false
+	}
+	return true
+}
+
+func inDatetimeRange(r *pb.DatetimeRange, t time.Time) bool {
+	bound := func(ts *timestamppb.Timestamp) time.Time { return ts.AsTime() }
+	if r.Lt != nil && !t.Before(bound(r.Lt)) {
+		return false
+	}
+	if r.Lte != nil && t.After(bound(r.Lte)) {
+		return false
+	}
+	if r.Gt != nil && !t.After(bound(r.Gt)) {
+		return false
+	}
+	if r.Gte != nil && t.Before(bound(r.Gte)) {
+		return false
+	}
+	return true
+}
+
+func inValuesCount(r *pb.ValuesCount, n uint64) bool {
+	if r.Lt != nil && !(n < r.GetLt()) {
+		return false
+	}
+	if r.Lte != nil && !(n <= r.GetLte()) {
+		return false
+	}
+	if r.Gt != nil && !(n > r.GetGt()) {
+		return false
+	}
+	if r.Gte != nil && !(n >= r.GetGte()) {
+		return false
+	}
+	return true
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func toInt(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case float64:
+		if n == float64(int64(n)) {
+			return int64(n), true
+		}
+	}
+	return 0, false
+}
+
+func toTime(v interface{}) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(list []int64, i int64) bool {
+	for _, item := range list {
+		if item == i {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenize approximates the word tokenizer: lowercase, split on non-letters/digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsAllTokens(text, query string) bool {
+	have := make(map[string]struct{})
+	for _, t := range tokenize(text) {
+		have[t] = struct{}{}
+	}
+	tokens := tokenize(query)
+	if len(tokens) == 0 {
+		return false
+	}
+	for _, t := range tokens {
+		if _, ok := have[t]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAnyToken(text, query string) bool {
+	have := make(map[string]struct{})
+	for _, t := range tokenize(text) {
+		have[t] = struct{}{}
+	}
+	for _, t := range tokenize(query) {
+		if _, ok := have[t]; ok {
+			return true
+		}
+	}
+	return false
+}
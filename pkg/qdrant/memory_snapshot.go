@@ -0,0 +1,184 @@
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	pb "github.com/qdrant/go-client/qdrant"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// memorySnapshot is a snapshot file kept by the in-memory store.
+type memorySnapshot struct {
+	info SnapshotInfo
+	data []byte
+}
+
+// memorySnapshotFile is the on-wire format of in-memory snapshots. Points are
+// protojson-encoded PointStructs so payload integers survive the round trip.
+type memorySnapshotFile struct {
+	VectorSize uint64                      `json:"vector_size"`
+	Distance   pb.Distance                 `json:"distance"`
+	Profile    CollectionProfile           `json:"profile"`
+	Indexes    map[string]PayloadIndexInfo `json:"indexes"`
+	Points     []json.RawMessage           `json:"points"`
+}
+
+func (m *memoryImpl) CreateSnapshot(ctx context.Context, colName string) (SnapshotInfo, error) {
+	if colName == "" {
+		return SnapshotInfo{}, ErrEmptyCollection
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	col, err := m.collection(colName, "failed to create snapshot")
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	file := memorySnapshotFile{
+		VectorSize: col.vectorSize,
+		Distance:   col.distance,
+		Profile:    col.profile,
+		Indexes:    col.indexes,
+	}
+	for _, p := range col.sortedPoints(nil) {
+		payload, err := pb.TryValueMap(p.payload)
+		if err != nil {
+			return SnapshotInfo{}, WrapError(err, "failed to create snapshot")
+		}
+		raw, err := protojson.Marshal(&pb.PointStruct{
+			Id:      p.id,
+			Vectors: &pb.Vectors{VectorsOptions: &pb.Vectors_Vector{Vector: &pb.Vector{Data: p.vector}}},
+			Payload: payload,
+		})
+		if err != nil {
+			return SnapshotInfo{}, WrapError(err, "failed to create snapshot")
+		}
+		file.Points = append(file.Points, raw)
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		return SnapshotInfo{}, WrapError(err, "failed to create snapshot")
+	}
+
+	now := time.Now().UTC()
+	sum := sha256.Sum256(data)
+	info := SnapshotInfo{
+		Name:      fmt.Sprintf("%s-%s.snapshot", colName, now.Format("2006-01-02-15-04-05.000000000")),
+		CreatedAt: now,
+		Size:      int64(len(data)),
+		Checksum:  hex.EncodeToString(sum[:]),
+	}
+	if m.snapshots[colName] == nil {
+		m.snapshots[colName] = make(map[string]memorySnapshot)
+	}
+	m.snapshots[colName][info.Name] = memorySnapshot{info: info, data: data}
+	return info, nil
+}
+
+func (m *memoryImpl) ListSnapshots(ctx context.Context, colName string) ([]SnapshotInfo, error) {
+	if colName == "" {
+		return nil, ErrEmptyCollection
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if _, err := m.collection(colName, "failed to list snapshots"); err != nil {
+		return nil, err
+	}
+	snapshots := make([]SnapshotInfo, 0, len(m.snapshots[colName]))
+	for _, s := range m.snapshots[colName] {
+		snapshots = append(snapshots, s.info)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+	return snapshots, nil
+}
+
+func (m *memoryImpl) DeleteSnapshot(ctx context.Context, colName string, snapshotName string) error {
+	if colName == "" {
+		return ErrEmptyCollection
+	}
+	if snapshotName == "" {
+		return ErrEmptySnapshot
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.snapshots[colName][snapshotName]; !ok {
+		return WrapError(ErrSnapshotNotFound, "failed to delete snapshot")
+	}
+	delete(m.snapshots[colName], snapshotName)
+	return nil
+}
+
+func (m *memoryImpl) DownloadSnapshot(ctx context.Context, colName string, snapshotName string) (io.ReadCloser, error) {
+	if colName == "" {
+		return nil, ErrEmptyCollection
+	}
+	if snapshotName == "" {
+		return nil, ErrEmptySnapshot
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.snapshots[colName][snapshotName]
+	if !ok {
+		return nil, WrapError(ErrSnapshotNotFound, "failed to download snapshot")
+	}
+	return io.NopCloser(bytes.NewReader(s.data)), nil
+}
+
+func (m *memoryImpl) RestoreSnapshot(ctx context.Context, colName string, snapshot io.Reader, checksum string) error {
+	if colName == "" {
+		return ErrEmptyCollection
+	}
+	data, err := io.ReadAll(snapshot)
+	if err != nil {
+		return WrapError(err, "failed to restore snapshot")
+	}
+	if checksum != "" {
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != checksum {
+			return WrapError(fmt.Errorf("checksum mismatch"), "failed to restore snapshot")
+		}
+	}
+
+	var file memorySnapshotFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return WrapError(err, "failed to restore snapshot")
+	}
+	col := &memoryCollection{
+		vectorSize: file.VectorSize,
+		distance:   file.Distance,
+		profile:    file.Profile,
+		indexes:    file.Indexes,
+		points:     make(map[string]*memoryPoint, len(file.Points)),
+	}
+	if col.indexes == nil {
+		col.indexes = make(map[string]PayloadIndexInfo)
+	}
+	for _, raw := range file.Points {
+		var ps pb.PointStruct
+		if err := protojson.Unmarshal(raw, &ps); err != nil {
+			return WrapError(err, "failed to restore snapshot")
+		}
+		payload := make(map[string]interface{}, len(ps.GetPayload()))
+		for k, v := range ps.GetPayload() {
+			payload[k] = valueToInterface(v)
+		}
+		col.points[PointIDString(ps.GetId())] = &memoryPoint{
+			id:      ps.GetId(),
+			vector:  ps.GetVectors().GetVector().GetData(),
+			payload: payload,
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.collections[colName] = col
+	return nil
+}
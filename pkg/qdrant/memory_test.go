@@ -0,0 +1,194 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "github.com/qdrant/go-client/qdrant"
+)
+
+func newMemoryFixture(t *testing.T) IQdrant {
+	t.Helper()
+	ctx := context.Background()
+	m := NewMemory()
+	if err := m.CreateCollection(ctx, "posts", 2, pb.Distance_Cosine); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	points := []Point{
+		{ID: "a", Vector: []float32{1, 0}, Payload: map[string]interface{}{"platform": "tiktok", "likes": 10, "aspects": []interface{}{map[string]interface{}{"aspect": "PRICE", "sentiment": "NEGATIVE"}}}},
+		{ID: "b", Vector: []float32{0.9, 0.1}, Payload: map[string]interface{}{"platform": "facebook", "likes": 50, "aspects": []interface{}{map[string]interface{}{"aspect": "PRICE", "sentiment": "POSITIVE"}}}},
+		{ID: "c", Vector: []float32{0, 1}, Payload: map[string]interface{}{"platform": "tiktok", "likes": 5}},
+	}
+	if err := m.UpsertPoints(ctx, "posts", points); err != nil {
+		t.Fatalf("UpsertPoints: %v", err)
+	}
+	return m
+}
+
+func keyword(key, value string) *pb.Condition {
+	return &pb.Condition{ConditionOneOf: &pb.Condition_Field{Field: &pb.FieldCondition{Key: key, Match: &pb.Match{MatchValue: &pb.Match_Keyword{Keyword: value}}}}}
+}
+
+func TestMemorySearchFilters(t *testing.T) {
+	m := newMemoryFixture(t)
+	ctx := context.Background()
+	gte := 8.0
+
+	tests := []struct {
+		name   string
+		filter *pb.Filter
+		want   int
+	}{
+		{name: "none", want: 3},
+		{name: "must", filter: &pb.Filter{Must: []*pb.Condition{keyword("platform", "tiktok")}}, want: 2},
+		{name: "must_not", filter: &pb.Filter{MustNot: []*pb.Condition{keyword("platform", "tiktok")}}, want: 1},
+		{name: "range", filter: &pb.Filter{Must: []*pb.Condition{{ConditionOneOf: &pb.Condition_Field{Field: &pb.FieldCondition{Key: "likes", Range: &pb.Range{Gte: &gte}}}}}}, want: 2},
+		{name: "array path", filter: &pb.Filter{Must: []*pb.Condition{keyword("aspects.aspect", "PRICE")}}, want: 2},
+		{name: "nested", filter: &pb.Filter{Must: []*pb.Condition{{ConditionOneOf: &pb.Condition_Nested{Nested: &pb.NestedCondition{
+			Key:    "aspects",
+			Filter: &pb.Filter{Must: []*pb.Condition{keyword("aspect", "PRICE"), keyword("sentiment", "NEGATIVE")}},
+		}}}}}, want: 1},
+		{name: "should", filter: &pb.Filter{Should: []*pb.Condition{keyword("platform", "facebook"), keyword("platform", "x")}}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := m.SearchWithFilter(ctx, "posts", []float32{1, 0}, 10, tt.filter, 0)
+			if err != nil {
+				t.Fatalf("SearchWithFilter: %v", err)
+			}
+			if len(results) != tt.want {
+				t.Fatalf("got %d results, want %d", len(results), tt.want)
+			}
+		})
+	}
+
+	results, err := m.SearchWithFilter(ctx, "posts", []float32{1, 0}, 10, nil, 0.5)
+	if err != nil {
+		t.Fatalf("SearchWithFilter: %v", err)
+	}
+	if len(results) != 2 || results[0].Score < results[1].Score {
+		t.Fatalf("score threshold/order: got %+v", results)
+	}
+	if _, ok := results[0].Payload["likes"].(int64); !ok {
+		t.Fatalf("integer payload decoded as %T, want int64", results[0].Payload["likes"])
+	}
+}
+
+func TestMemoryScrollOffsets(t *testing.T) {
+	m := NewMemory()
+	ctx := context.Background()
+	if err := m.CreateCollection(ctx, "posts", 1, pb.Distance_Cosine); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	var points []Point
+	for i := 0; i < 5; i++ {
+		points = append(points, Point{ID: fmt.Sprintf("p%d", i), Vector: []float32{1}})
+	}
+	if err := m.UpsertPoints(ctx, "posts", points); err != nil {
+		t.Fatalf("UpsertPoints: %v", err)
+	}
+
+	seen := map[string]bool{}
+	var offset *pb.PointId
+	for pages := 0; ; pages++ {
+		page, next, err := m.ScrollPoints(ctx, "posts", nil, 2, true, offset)
+		if err != nil {
+			t.Fatalf("ScrollPoints: %v", err)
+		}
+		for _, p := range page {
+			if seen[p.ID] {
+				t.Fatalf("point %s returned twice", p.ID)
+			}
+			seen[p.ID] = true
+		}
+		if next == nil {
+			break
+		}
+		if pages > 5 {
+			t.Fatalf("scroll did not terminate")
+		}
+		offset = next
+	}
+	if len(seen) != 5 {
+		t.Fatalf("scrolled %d points, want 5", len(seen))
+	}
+}
+
+func TestMemoryFacetAndGroups(t *testing.T) {
+	m := newMemoryFixture(t)
+	ctx := context.Background()
+
+	if _, err := m.Facet(ctx, "posts", "platform", 10, nil); err == nil || !strings.Contains(err.Error(), "No appropriate index for faceting") {
+		t.Fatalf("Facet without index: err = %v", err)
+	}
+	if err := m.CreateFieldIndex(ctx, "posts", "platform", pb.FieldType_FieldTypeKeyword); err != nil {
+		t.Fatalf("CreateFieldIndex: %v", err)
+	}
+	facets, err := m.Facet(ctx, "posts", "platform", 10, nil)
+	if err != nil {
+		t.Fatalf("Facet: %v", err)
+	}
+	if len(facets) != 2 || facets[0].Value != "tiktok" || facets[0].Count != 2 {
+		t.Fatalf("facets = %+v", facets)
+	}
+
+	groups, err := m.SearchGroups(ctx, "posts", []float32{1, 0}, 10, "platform", 1, nil)
+	if err != nil {
+		t.Fatalf("SearchGroups: %v", err)
+	}
+	if len(groups) != 2 || groups[0].ID != "tiktok" || len(groups[0].Hits) != 1 {
+		t.Fatalf("groups = %+v", groups)
+	}
+
+	if _, err := m.Search(ctx, "missing", []float32{1, 0}, 1); !errors.Is(err, ErrCollectionNotFound) {
+		t.Fatalf("Search on missing collection: err = %v", err)
+	}
+}
+
+func TestMemorySnapshotRestore(t *testing.T) {
+	m := newMemoryFixture(t)
+	ctx := context.Background()
+
+	info, err := m.CreateSnapshot(ctx, "posts")
+	if err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+	body, err := m.DownloadSnapshot(ctx, "posts", info.Name)
+	if err != nil {
+		t.Fatalf("DownloadSnapshot: %v", err)
+	}
+	defer body.Close()
+
+	for _, id := range []string{"a", "b"} {
+		if err := m.DeletePoint(ctx, "posts", id); err != nil {
+			t.Fatalf("DeletePoint: %v", err)
+		}
+	}
+	if err := m.RestoreSnapshot(ctx, "restored", strings.NewReader("{}"), info.Checksum); err == nil {
+		t.Fatalf("RestoreSnapshot with wrong checksum succeeded")
+	}
+	if err := m.RestoreSnapshot(ctx, "posts", body, info.Checksum); err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+
+	results, err := m.SearchWithFilter(ctx, "posts", []float32{1, 0}, 10, &pb.Filter{Must: []*pb.Condition{keyword("platform", "tiktok")}}, 0)
+	if err != nil {
+		t.Fatalf("SearchWithFilter: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("restored collection returned %d tiktok points, want 2", len(results))
+	}
+	if _, ok := results[0].Payload["likes"].(int64); !ok {
+		t.Fatalf("restored integer payload decoded as %T, want int64", results[0].Payload["likes"])
+	}
+
+	if err := m.DeleteSnapshot(ctx, "posts", info.Name); err != nil {
+		t.Fatalf("DeleteSnapshot: %v", err)
+	}
+	if _, err := m.DownloadSnapshot(ctx, "posts", info.Name); !errors.Is(err, ErrSnapshotNotFound) {
+		t.Fatalf("DownloadSnapshot after delete: err = %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package qdrant
+
+import (
+	"context"
+
+	pb "github.com/qdrant/go-client/qdrant"
+)
+
+// CreateCollectionWithProfile creates a new collection with the given storage/index profile.
+func (c *qdrantImpl) CreateCollectionWithProfile(ctx context.Context, name string, vectorSize uint64, distance pb.Distance, profile CollectionProfile) error {
+	if name == "" {
+		return ErrEmptyCollection
+	}
+	if vectorSize == 0 {
+		return ErrInvalidVectorSize
+	}
+	quantization, err := quantizationConfig(profile)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.CreateCollection{
+		CollectionName: name,
+		VectorsConfig: &pb.VectorsConfig{
+			Config: &pb.VectorsConfig_Params{
+				Params: &pb.VectorParams{
+					Size:     vectorSize,
+					Distance: distance,
+					OnDisk:   pb.PtrOf(profile.OnDiskVectors),
+				},
+			},
+		},
+		OnDiskPayload:      pb.PtrOf(profile.OnDiskPayload),
+		HnswConfig:         hnswConfigDiff(profile),
+		OptimizersConfig:   optimizersConfigDiff(profile),
+		QuantizationConfig: quantization,
+	}
+	if _, err := c.collectionsClient.Create(ctx, req); err != nil {
+		return WrapError(err, "failed to create collection")
+	}
+	return nil
+}
+
+// UpdateCollectionProfile applies a storage/index profile to an existing collection.
+// Qdrant rebuilds indexes and quantized data in the background after the update.
+func (c *qdrantImpl) UpdateCollectionProfile(ctx context.Context, name string, profile CollectionProfile) error {
+	if name == "" {
+		return ErrEmptyCollection
+	}
+
+	var quantization *pb.QuantizationConfigDiff
+	switch profile.Quantization {
+	case "", QuantizationNone:
+		quantization = &pb.QuantizationConfigDiff{
+			Quantization: &pb.QuantizationConfigDiff_Disabled{Disabled: &pb.Disabled{}},
+		}
+	case QuantizationScalar:
+		quantization = &pb.QuantizationConfigDiff{
+			Quantization: &pb.QuantizationConfigDiff_Scalar{Scalar: scalarQuantization(profile)},
+		}
+	case QuantizationBinary:
+		quantization = &pb.QuantizationConfigDiff{
+			Quantization: &pb.QuantizationConfigDiff_Binary{Binary: binaryQuantization(profile)},
+		}
+	default:
+		return ErrInvalidQuantization
+	}
+
+	req := &pb.UpdateCollection{
+		CollectionName: name,
+		Params:         &pb.CollectionParamsDiff{OnDiskPayload: pb.PtrOf(profile.OnDiskPayload)},
+		VectorsConfig: &pb.VectorsConfigDiff{
+			Config: &pb.VectorsConfigDiff_Params{
+				Params: &pb.VectorParamsDiff{OnDisk: pb.PtrOf(profile.OnDiskVectors)},
+			},
+		},
+		HnswConfig:         hnswConfigDiff(profile),
+		OptimizersConfig:   optimizersConfigDiff(profile),
+		QuantizationConfig: quantization,
+	}
+	if _, err := c.collectionsClient.Update(ctx, req); err != nil {
+		return wrapQdrantError(err, "failed to update collection profile")
+	}
+	return nil
+}
+
+// collectionProfileFromConfig reads the applied profile back from a collection config.
+func collectionProfileFromConfig(cfg *pb.CollectionConfig) CollectionProfile {
+	profile := CollectionProfile{
+		OnDiskPayload:     cfg.GetParams().GetOnDiskPayload(),
+		HnswM:             cfg.GetHnswConfig().GetM(),
+		HnswEfConstruct:   cfg.GetHnswConfig().GetEfConstruct(),
+		IndexingThreshold: cfg.GetOptimizerConfig().GetIndexingThreshold(),
+		Quantization:      QuantizationNone,
+	}
+	if params := cfg.GetParams().GetVectorsConfig().GetParams(); params != nil {
+		profile.OnDiskVectors = params.GetOnDisk()
+	}
+	quantization := cfg.GetQuantizationConfig()
+	switch {
+	case quantization.GetScalar() != nil:
+		profile.Quantization = QuantizationScalar
+		profile.QuantizationAlwaysRAM = quantization.GetScalar().GetAlwaysRam()
+	case quantization.GetBinary() != nil:
+		profile.Quantization = QuantizationBinary
+		profile.QuantizationAlwaysRAM = quantization.GetBinary().GetAlwaysRam()
+	}
+	return profile
+}
+
+func quantizationConfig(profile CollectionProfile) (*pb.QuantizationConfig, error) {
+	switch profile.Quantization {
+	case "", QuantizationNone:
+		return nil, nil
+	case QuantizationScalar:
+		return &pb.QuantizationConfig{
+			Quantization: &pb.QuantizationConfig_Scalar{Scalar: scalarQuantization(profile)},
+		}, nil
+	case QuantizationBinary:
+		return &pb.QuantizationConfig{
+			Quantization: &pb.QuantizationConfig_Binary{Binary: binaryQuantization(profile)},
+		}, nil
+	default:
+		return nil, ErrInvalidQuantization
+	}
+}
+
+func scalarQuantization(profile CollectionProfile) *pb.ScalarQuantization {
+	return &pb.ScalarQuantization{
+		Type:      pb.QuantizationType_Int8,
+		AlwaysRam: pb.PtrOf(profile.QuantizationAlwaysRAM),
+	}
+}
+
+func binaryQuantization(profile CollectionProfile) *pb.BinaryQuantization {
+	return &pb.BinaryQuantization{AlwaysRam: pb.PtrOf(profile.QuantizationAlwaysRAM)}
+}
+
+func hnswConfigDiff(profile CollectionProfile) *pb.HnswConfigDiff {
+	if profile.HnswM == 0 && profile.HnswEfConstruct == 0 {
+		return nil
+	}
+	diff := &pb.HnswConfigDiff{}
+	if profile.HnswM > 0 {
+		diff.M = pb.PtrOf(profile.HnswM)
+	}
+	if profile.HnswEfConstruct > 0 {
+		diff.EfConstruct = pb.PtrOf(profile.HnswEfConstruct)
+	}
+	return diff
+}
+
+func optimizersConfigDiff(profile CollectionProfile) *pb.OptimizersConfigDiff {
+	if profile.IndexingThreshold == 0 {
+		return nil
+	}
+	return &pb.OptimizersConfigDiff{IndexingThreshold: pb.PtrOf(profile.IndexingThreshold)}
+}
+
+// searchParams converts SearchParams into the request params; nil when nothing is set.
+func searchParams(params SearchParams) *pb.SearchParams {
+	if params.HnswEf == 0 && params.Oversampling <= 0 && !params.Rescore {
+		return nil
+	}
+	out := &pb.SearchParams{}
+	if params.HnswEf > 0 {
+		out.HnswEf = pb.PtrOf(params.HnswEf)
+	}
+	if params.Oversampling > 0 || params.Rescore {
+		out.Quantization = &pb.QuantizationSearchParams{Rescore: pb.PtrOf(params.Rescore)}
+		if params.Oversampling > 0 {
+			out.Quantization.Oversampling = pb.PtrOf(params.Oversampling)
+		}
+	}
+	return out
+}
@@ -41,29 +41,9 @@ func (c *qdrantImpl) GetDefaultTimeout() time.Duration {
 	return c.defaultTimeout
 }
 
-// CreateCollection creates a new collection in Qdrant.
+// CreateCollection creates a new collection in Qdrant with the default storage profile.
 func (c *qdrantImpl) CreateCollection(ctx context.Context, name string, vectorSize uint64, distance pb.Distance) error {
-	if name == "" {
-		return ErrEmptyCollection
-	}
-	if vectorSize == 0 {
-		return ErrInvalidVectorSize
-	}
-	_, err := c.collectionsClient.Create(ctx, &pb.CreateCollection{
-		CollectionName: name,
-		VectorsConfig: &pb.VectorsConfig{
-			Config: &pb.VectorsConfig_Params{
-				Params: &pb.VectorParams{
-					Size:     vectorSize,
-					Distance: distance,
-				},
-			},
-		},
-	})
-	if err != nil {
-		return WrapError(err, "failed to create collection")
-	}
-	return nil
+	return c.CreateCollectionWithProfile(ctx, name, vectorSize, distance, CollectionProfile{})
 }
 
 // DeleteCollection deletes a collection from Qdrant.
@@ -118,6 +98,19 @@ func (c *qdrantImpl) GetCollectionInfo(ctx context.Context, name string) (*Colle
 			}
 		}
 	}
+	info.Profile = collectionProfileFromConfig(resp.Result.Config)
+	info.PayloadSchema = make(map[string]PayloadIndexInfo, len(resp.Result.PayloadSchema))
+	for field, schema := range resp.Result.PayloadSchema {
+		idx := PayloadIndexInfo{
+			DataType: schema.GetDataType(),
+			Points:   schema.GetPoints(),
+		}
+		if text := schema.GetParams().GetTextIndexParams(); text != nil {
+			idx.Tokenizer = text.GetTokenizer()
+		}
+		idx.IsTenant = schema.GetParams().GetKeywordIndexParams().GetIsTenant()
+		info.PayloadSchema[field] = idx
+	}
 	return info, nil
 }
 
@@ -240,6 +233,57 @@ func (c *qdrantImpl) DeletePoint(ctx context.Context, collectionName string, poi
 	return nil
 }
 
+// DeletePointsWithFilter deletes every point matching filter and waits for the operation.
+func (c *qdrantImpl) DeletePointsWithFilter(ctx context.Context, collectionName string, filter *pb.Filter) error {
+	if collectionName == "" {
+		return ErrEmptyCollection
+	}
+	if filter == nil {
+		return ErrEmptyFilter
+	}
+	wait := true
+	_, err := c.pointsClient.Delete(ctx, &pb.DeletePoints{
+		CollectionName: collectionName,
+		Wait:           &wait,
+		Points: &pb.PointsSelector{
+			PointsSelectorOneOf: &pb.PointsSelector_Filter{Filter: filter},
+		},
+	})
+	if err != nil {
+		return wrapQdrantError(err, "failed to delete points")
+	}
+	return nil
+}
+
+// SetPayload overwrites the given payload keys of one point and waits for the operation.
+func (c *qdrantImpl) SetPayload(ctx context.Context, collectionName string, pointID string, payload map[string]interface{}) error {
+	if collectionName == "" {
+		return ErrEmptyCollection
+	}
+	if pointID == "" {
+		return ErrInvalidPointID
+	}
+	payloadMap, err := pb.TryValueMap(payload)
+	if err != nil {
+		return WrapError(err, "failed to convert payload")
+	}
+	wait := true
+	_, err = c.pointsClient.SetPayload(ctx, &pb.SetPayloadPoints{
+		CollectionName: collectionName,
+		Wait:           &wait,
+		Payload:        payloadMap,
+		PointsSelector: &pb.PointsSelector{
+			PointsSelectorOneOf: &pb.PointsSelector_Points{
+				Points: &pb.PointsIdsList{Ids: []*pb.PointId{ParsePointID(pointID)}},
+			},
+		},
+	})
+	if err != nil {
+		return wrapQdrantError(err, "failed to set payload")
+	}
+	return nil
+}
+
 // GetPoint retrieves a point by ID.
 func (c *qdrantImpl) GetPoint(ctx context.Context, collectionName string, pointID string) (*Point, error) {
 	if collectionName == "" {
@@ -300,6 +344,21 @@ func (c *qdrantImpl) Search(ctx context.Context, collectionName string, vector [
 // SearchWithFilter performs a vector similarity search with payload filter and optional score threshold.
 // If scoreThreshold > 0, Qdrant will only return results with score >= threshold (server-side filtering).
 func (c *qdrantImpl) SearchWithFilter(ctx context.Context, collectionName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32) ([]SearchResult, error) {
+	return c.searchWithFilter(ctx, collectionName, vector, limit, filter, scoreThreshold, false, SearchParams{})
+}
+
+// SearchWithVectors is SearchWithFilter that also returns each hit's stored vector
+// (needed for diversity-aware selection over results).
+func (c *qdrantImpl) SearchWithVectors(ctx context.Context, collectionName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32) ([]SearchResult, error) {
+	return c.searchWithFilter(ctx, collectionName, vector, limit, filter, scoreThreshold, true, SearchParams{})
+}
+
+// SearchWithParams is SearchWithFilter with HNSW ef and quantization oversampling/rescore tuning.
+func (c *qdrantImpl) SearchWithParams(ctx context.Context, collectionName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32, withVectors bool, params SearchParams) ([]SearchResult, error) {
+	return c.searchWithFilter(ctx, collectionName, vector, limit, filter, scoreThreshold, withVectors, params)
+}
+
+func (c *qdrantImpl) searchWithFilter(ctx context.Context, collectionName string, vector []float32, limit uint64, filter *pb.Filter, scoreThreshold float32, withVectors bool, params SearchParams) ([]SearchResult, error) {
 	if collectionName == "" {
 		return nil, ErrEmptyCollection
 	}
@@ -315,10 +374,14 @@ func (c *qdrantImpl) SearchWithFilter(ctx context.Context, collectionName string
 		Limit:          limit,
 		Filter:         filter,
 		WithPayload:    &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Enable{Enable: true}},
+		Params:         searchParams(params),
 	}
 	if scoreThreshold > 0 {
 		req.ScoreThreshold = &scoreThreshold
 	}
+	if withVectors {
+		req.WithVectors = &pb.WithVectorsSelector{SelectorOptions: &pb.WithVectorsSelector_Enable{Enable: true}}
+	}
 	resp, err := c.pointsClient.Search(ctx, req)
 	if err != nil {
 		return nil, wrapQdrantError(err, "failed to search with filter")
@@ -378,6 +441,92 @@ func (c *qdrantImpl) CountPoints(ctx context.Context, collectionName string) (ui
 	return resp.Result.Count, nil
 }
 
+// CountPointsWithFilter returns the exact number of points matching filter.
+func (c *qdrantImpl) CountPointsWithFilter(ctx context.Context, collectionName string, filter *pb.Filter) (uint64, error) {
+	if collectionName == "" {
+		return 0, ErrEmptyCollection
+	}
+	exact := true
+	resp, err := c.pointsClient.Count(ctx, &pb.CountPoints{
+		CollectionName: collectionName,
+		Filter:         filter,
+		Exact:          &exact,
+	})
+	if err != nil {
+		return 0, wrapQdrantError(err, "failed to count points")
+	}
+	if resp.Result == nil {
+		return 0, nil
+	}
+	return resp.Result.Count, nil
+}
+
+// CopyPoints copies one page of points (vectors and payload) from src to dst, keeping
+// the original point IDs. setPayload keys are written into every copied payload.
+// Returns the number copied and the cursor of the next page (nil when done).
+func (c *qdrantImpl) CopyPoints(ctx context.Context, src, dst string, limit uint32, offset *pb.PointId, setPayload map[string]interface{}, mapID PointIDMapper) (int, *pb.PointId, error) {
+	if src == "" || dst == "" {
+		return 0, nil, ErrEmptyCollection
+	}
+	if limit == 0 {
+		limit = 100
+	}
+	extra, err := pb.TryValueMap(setPayload)
+	if err != nil {
+		return 0, nil, WrapError(err, "failed to convert payload")
+	}
+	resp, err := c.pointsClient.Scroll(ctx, &pb.ScrollPoints{
+		CollectionName: src,
+		Limit:          &limit,
+		Offset:         offset,
+		WithPayload:    &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Enable{Enable: true}},
+		WithVectors:    &pb.WithVectorsSelector{SelectorOptions: &pb.WithVectorsSelector_Enable{Enable: true}},
+	})
+	if err != nil {
+		return 0, nil, wrapQdrantError(err, "failed to scroll points")
+	}
+	if len(resp.Result) == 0 {
+		return 0, nil, nil
+	}
+
+	points := make([]*pb.PointStruct, 0, len(resp.Result))
+	for _, rp := range resp.Result {
+		id := rp.Id
+		if mapID != nil {
+			source := make(map[string]interface{}, len(rp.Payload))
+			for k, v := range rp.Payload {
+				source[k] = valueToInterface(v)
+			}
+			id = ParsePointID(mapID(PointIDString(rp.Id), source))
+		}
+		payload := rp.Payload
+		if payload == nil {
+			payload = make(map[string]*pb.Value, len(extra))
+		}
+		for k, v := range extra {
+			payload[k] = v
+		}
+		vector := rp.GetVectors().GetVector()
+		if vector == nil {
+			return 0, nil, ErrInvalidVector
+		}
+		points = append(points, &pb.PointStruct{
+			Id:      id,
+			Vectors: &pb.Vectors{VectorsOptions: &pb.Vectors_Vector{Vector: &pb.Vector{Data: vector.GetData()}}},
+			Payload: payload,
+		})
+	}
+	wait := true
+	if _, err := c.pointsClient.Upsert(ctx, &pb.UpsertPoints{
+		CollectionName: dst,
+		Points:         points,
+		Wait:           &wait,
+	}); err != nil {
+		return 0, nil, wrapQdrantError(err, "failed to upsert copied points")
+	}
+	return len(points), resp.NextPageOffset, nil
+}
+
 // ScrollPoints scrolls points with an optional filter (offset is the next-page cursor from a previous response).
 func (c *qdrantImpl) ScrollPoints(ctx context.Context, collectionName string, filter *pb.Filter, limit uint32, withPayload bool, offset *pb.PointId) ([]Point, *pb.PointId, error) {
 	if collectionName == "" {
@@ -430,8 +579,14 @@ func (c *qdrantImpl) searchResultsFromHits(hits []*pb.ScoredPoint) []SearchResul
 		for key, value := range hit.Payload {
 			payload[key] = valueToInterface(value)
 		}
+		var vector []float32
+		if hit.Vectors != nil {
+			if v := hit.Vectors.GetVector(); v != nil {
+				vector = v.Data
+			}
+		}
 		id := PointIDString(hit.Id)
-		results = append(results, SearchResult{ID: id, Score: hit.Score, Payload: payload})
+		results = append(results, SearchResult{ID: id, Score: hit.Score, Payload: payload, Vector: vector})
 	}
 	return results
 }
@@ -479,6 +634,25 @@ func (c *qdrantImpl) CreateFieldIndex(ctx context.Context, colName string, field
 	return nil
 }
 
+// CreateFieldIndexWithParams creates a payload field index with explicit index params
+// (e.g. tokenizer settings for text fields). Re-creating an existing index with
+// different params replaces it.
+func (c *qdrantImpl) CreateFieldIndexWithParams(ctx context.Context, colName string, fieldName string, fieldType pb.FieldType, params *pb.PayloadIndexParams) error {
+	if colName == "" {
+		return ErrEmptyCollection
+	}
+	_, err := c.pointsClient.CreateFieldIndex(ctx, &pb.CreateFieldIndexCollection{
+		CollectionName:   colName,
+		FieldName:        fieldName,
+		FieldType:        fieldType.Enum(),
+		FieldIndexParams: params,
+	})
+	if err != nil {
+		return WrapError(err, "failed to create field index")
+	}
+	return nil
+}
+
 // Close closes the gRPC connection
 func (c *qdrantImpl) Close() error {
 	if c.conn != nil {
@@ -0,0 +1,175 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	pb "github.com/qdrant/go-client/qdrant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateSnapshot creates a snapshot of a collection on the Qdrant node.
+func (c *qdrantImpl) CreateSnapshot(ctx context.Context, collectionName string) (SnapshotInfo, error) {
+	if collectionName == "" {
+		return SnapshotInfo{}, ErrEmptyCollection
+	}
+	resp, err := c.snapshotsClient.Create(ctx, &pb.CreateSnapshotRequest{CollectionName: collectionName})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return SnapshotInfo{}, WrapError(ErrCollectionNotFound, "failed to create snapshot")
+		}
+		return SnapshotInfo{}, WrapError(err, "failed to create snapshot")
+	}
+	return snapshotInfo(resp.GetSnapshotDescription()), nil
+}
+
+// ListSnapshots lists the snapshots of a collection stored on the Qdrant node.
+func (c *qdrantImpl) ListSnapshots(ctx context.Context, collectionName string) ([]SnapshotInfo, error) {
+	if collectionName == "" {
+		return nil, ErrEmptyCollection
+	}
+	resp, err := c.snapshotsClient.List(ctx, &pb.ListSnapshotsRequest{CollectionName: collectionName})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, WrapError(ErrCollectionNotFound, "failed to list snapshots")
+		}
+		return nil, WrapError(err, "failed to list snapshots")
+	}
+	snapshots := make([]SnapshotInfo, 0, len(resp.GetSnapshotDescriptions()))
+	for _, d := range resp.GetSnapshotDescriptions() {
+		snapshots = append(snapshots, snapshotInfo(d))
+	}
+	return snapshots, nil
+}
+
+// DeleteSnapshot deletes a snapshot file from the Qdrant node.
+func (c *qdrantImpl) DeleteSnapshot(ctx context.Context, collectionName string, snapshotName string) error {
+	if collectionName == "" {
+		return ErrEmptyCollection
+	}
+	if snapshotName == "" {
+		return ErrEmptySnapshot
+	}
+	_, err := c.snapshotsClient.Delete(ctx, &pb.DeleteSnapshotRequest{CollectionName: collectionName, SnapshotName: snapshotName})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return WrapError(ErrSnapshotNotFound, "failed to delete snapshot")
+		}
+		return WrapError(err, "failed to delete snapshot")
+	}
+	return nil
+}
+
+// DownloadSnapshot streams a snapshot file over the HTTP API.
+func (c *qdrantImpl) DownloadSnapshot(ctx context.Context, collectionName string, snapshotName string) (io.ReadCloser, error) {
+	if collectionName == "" {
+		return nil, ErrEmptyCollection
+	}
+	if snapshotName == "" {
+		return nil, ErrEmptySnapshot
+	}
+	endpoint := fmt.Sprintf("%s/collections/%s/snapshots/%s", c.restURL, url.PathEscape(collectionName), url.PathEscape(snapshotName))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, WrapError(err, "failed to build snapshot download request")
+	}
+	c.setAPIKey(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, WrapError(err, "failed to download snapshot")
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, WrapError(ErrSnapshotNotFound, "failed to download snapshot")
+		}
+		return nil, WrapError(restError(resp), "failed to download snapshot")
+	}
+	return resp.Body, nil
+}
+
+// RestoreSnapshot uploads a snapshot over the HTTP API and waits for the recovery.
+// Snapshot data takes priority over whatever the collection currently holds.
+func (c *qdrantImpl) RestoreSnapshot(ctx context.Context, collectionName string, snapshot io.Reader, checksum string) error {
+	if collectionName == "" {
+		return ErrEmptyCollection
+	}
+
+	query := url.Values{"wait": {"true"}, "priority": {"snapshot"}}
+	if checksum != "" {
+		query.Set("checksum", checksum)
+	}
+	endpoint := fmt.Sprintf("%s/collections/%s/snapshots/upload?%s", c.restURL, url.PathEscape(collectionName), query.Encode())
+
+	// Stream the multipart body so large snapshots are never buffered in memory.
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile("snapshot", collectionName+".snapshot")
+		if err == nil {
+			_, err = io.Copy(part, snapshot)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		_ = writer.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		_ = body.Close()
+		return WrapError(err, "failed to build snapshot upload request")
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	c.setAPIKey(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		_ = body.Close()
+		return WrapError(err, "failed to restore snapshot")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return WrapError(restError(resp), "failed to restore snapshot")
+	}
+	return nil
+}
+
+func (c *qdrantImpl) setAPIKey(req *http.Request) {
+	if c.apiKey != "" {
+		req.Header.Set("api-key", c.apiKey)
+	}
+}
+
+// restURL builds the HTTP API base URL from the client config.
+func restURL(cfg QdrantConfig) string {
+	scheme := "http"
+	if cfg.UseTLS {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, cfg.Host, cfg.RESTPort)
+}
+
+// restError turns a non-200 HTTP API response into an error carrying its body.
+func restError(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("qdrant http %d: %s", resp.StatusCode, string(msg))
+}
+
+func snapshotInfo(d *pb.SnapshotDescription) SnapshotInfo {
+	info := SnapshotInfo{
+		Name:     d.GetName(),
+		Size:     d.GetSize(),
+		Checksum: d.GetChecksum(),
+	}
+	if ts := d.GetCreationTime(); ts != nil {
+		info.CreatedAt = ts.AsTime()
+	}
+	return info
+}
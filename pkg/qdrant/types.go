@@ -1,6 +1,7 @@
 package qdrant
 
 import (
+	"net/http"
 	"time"
 
 	pb "github.com/qdrant/go-client/qdrant"
@@ -12,11 +13,12 @@ type Config = QdrantConfig
 
 // QdrantConfig holds Qdrant configuration.
 type QdrantConfig struct {
-	Host    string
-	Port    int
-	UseTLS  bool
-	APIKey  string
-	Timeout time.Duration
+	Host     string
+	Port     int
+	RESTPort int // HTTP API port, used for snapshot download/upload (gRPC has no file transfer)
+	UseTLS   bool
+	APIKey   string
+	Timeout  time.Duration
 }
 
 // qdrantImpl implements IQdrant and wraps the Qdrant gRPC client.
@@ -24,7 +26,12 @@ type qdrantImpl struct {
 	conn              *grpc.ClientConn
 	pointsClient      pb.PointsClient
 	collectionsClient pb.CollectionsClient
+	snapshotsClient   pb.SnapshotsClient
 	defaultTimeout    time.Duration
+
+	restURL    string // e.g. http://host:6333
+	apiKey     string
+	httpClient *http.Client
 }
 
 // Point represents a vector point in Qdrant
@@ -34,11 +41,16 @@ type Point struct {
 	Payload map[string]interface{}
 }
 
+// PointIDMapper returns the ID a copied point gets in the target collection, from its
+// source ID (as returned by ScrollPoints) and payload.
+type PointIDMapper func(id string, payload map[string]interface{}) string
+
 // SearchResult represents a search result from Qdrant
 type SearchResult struct {
 	ID      string
 	Score   float32
 	Payload map[string]interface{}
+	Vector  []float32 // only set by SearchWithVectors
 }
 
 // CollectionInfo represents collection metadata
@@ -48,6 +60,37 @@ type CollectionInfo struct {
 	Distance    string
 	PointsCount uint64
 	Status      string
+	// PayloadSchema lists the payload indexes that exist on the collection, by field name.
+	PayloadSchema map[string]PayloadIndexInfo
+	// Profile is the storage/index configuration currently applied to the collection.
+	Profile CollectionProfile
+}
+
+// CollectionProfile describes the storage and index configuration of a collection.
+// Zero values leave the Qdrant defaults in place.
+type CollectionProfile struct {
+	OnDiskVectors         bool   // serve original vectors from disk (mmap)
+	OnDiskPayload         bool   // keep payload on disk instead of RAM
+	HnswM                 uint64 // edges per node in the HNSW graph
+	HnswEfConstruct       uint64 // neighbours considered while building the index
+	IndexingThreshold     uint64 // KB of vectors before a segment gets an HNSW index
+	Quantization          string // QuantizationNone | QuantizationScalar | QuantizationBinary
+	QuantizationAlwaysRAM bool   // keep quantized vectors in RAM even when originals are on disk
+}
+
+// SearchParams tunes a single vector search. Zero values use the Qdrant defaults.
+type SearchParams struct {
+	HnswEf       uint64  // beam size of the HNSW search
+	Oversampling float64 // quantized candidates fetched per requested result (>= 1)
+	Rescore      bool    // re-score quantized candidates with the original vectors
+}
+
+// PayloadIndexInfo describes one existing payload index
+type PayloadIndexInfo struct {
+	DataType  pb.PayloadSchemaType
+	Tokenizer pb.TokenizerType // text indexes only
+	IsTenant  bool             // keyword indexes only
+	Points    uint64
 }
 
 // GroupResult represents a group of search results
@@ -62,3 +105,11 @@ type FacetResult struct {
 	Value interface{}
 	Count uint64
 }
+
+// SnapshotInfo describes a collection snapshot stored on the Qdrant node.
+type SnapshotInfo struct {
+	Name      string
+	CreatedAt time.Time
+	Size      int64
+	Checksum  string // SHA256 hex digest, empty if Qdrant did not report one
+}
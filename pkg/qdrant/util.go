@@ -18,6 +18,18 @@ func PointIDString(id *pb.PointId) string {
 	return strconv.FormatUint(id.GetNum(), 10)
 }
 
+// ParsePointID maps a point ID as returned by Scroll/Search (a UUID or the decimal form
+// of a numeric ID) back to a PointId. Other strings are hashed the way UpsertPoints does.
+func ParsePointID(id string) *pb.PointId {
+	if isValidUUID(id) {
+		return &pb.PointId{PointIdOptions: &pb.PointId_Uuid{Uuid: id}}
+	}
+	if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return &pb.PointId{PointIdOptions: &pb.PointId_Num{Num: n}}
+	}
+	return &pb.PointId{PointIdOptions: &pb.PointId_Num{Num: generateHashNumber(id)}}
+}
+
 // valueToInterface converts a qdrant Value to a Go interface{} (for payload extraction).
 func valueToInterface(v *pb.Value) interface{} {
 	if v == nil {